@@ -0,0 +1,168 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/IBM/eventstreams-go-sdk/internal/cli"
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/apply"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+)
+
+// Exit codes.
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// environment : The process environment used by a run, which tests replace.
+type environment struct {
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(key string) string
+
+	// True when stdout is a terminal, so the diff is colored by default.
+	terminal bool
+}
+
+// newEnvironment returns the environment of the process.
+func newEnvironment() *environment {
+	env := &environment{
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		getenv: os.Getenv,
+	}
+	if info, err := os.Stdout.Stat(); err == nil {
+		env.terminal = info.Mode()&os.ModeCharDevice != 0
+	}
+	return env
+}
+
+// settings : The command-line flags.
+type settings struct {
+	cli.Connection
+	timeout time.Duration
+	prune   bool
+	dryRun  bool
+	noColor bool
+}
+
+// register adds the flags to a flag set, with defaults taken from the environment variables used by esctl and the
+// examples.
+func (s *settings) register(flags *flag.FlagSet, env *environment) {
+	flags.StringVar(&s.URL, "url", env.getenv("KAFKA_ADMIN_URL"), "the URL of the Admin REST API (default $KAFKA_ADMIN_URL)")
+	flags.StringVar(&s.SchemaURL, "schema-url", env.getenv("SCHEMA_REGISTRY_URL"),
+		"the URL of the schema registry, if not the Admin REST API URL (default $SCHEMA_REGISTRY_URL)")
+	flags.StringVar(&s.APIKey, "api-key", env.getenv("API_KEY"), "the API key (default $API_KEY)")
+	flags.StringVar(&s.BearerToken, "bearer-token", env.getenv("BEARER_TOKEN"), "a bearer token to use instead of an API key (default $BEARER_TOKEN)")
+	flags.DurationVar(&s.timeout, "timeout", 0, "the time allowed for the whole run, such as 2m; 0 means no limit")
+	flags.BoolVar(&s.prune, "prune", false, "delete resources of the kinds in the manifests that are not in the manifests")
+	flags.BoolVar(&s.dryRun, "dry-run", false, "print the changes without making them")
+	flags.BoolVar(&s.noColor, "no-color", env.getenv("NO_COLOR") != "", "do not color the diff (default true when $NO_COLOR is set)")
+}
+
+// run runs the command line, returning the exit code.
+func run(args []string, env *environment) int {
+	s := &settings{}
+	flags := flag.NewFlagSet("es-apply", flag.ContinueOnError)
+	flags.SetOutput(env.stderr)
+	s.register(flags, env)
+	flags.Usage = func() {
+		fmt.Fprintf(env.stderr, "Usage: es-apply [flags] [path ...]\n\n")
+		fmt.Fprintf(env.stderr, "Applies the manifests in the files and directories given, or in the current directory.\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	if err := s.apply(flags.Args(), env); err != nil {
+		fmt.Fprintf(env.stderr, "es-apply: %s\n", err.Error())
+		return exitError
+	}
+	return exitOK
+}
+
+// apply loads the manifests, prints the plan and applies it unless this is a dry run.
+func (s *settings) apply(paths []string, env *environment) error {
+	manifests, err := load(paths)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	var admin *adminrestv1.AdminrestV1
+	if len(manifests.Topics) > 0 || len(manifests.Quotas) > 0 || manifests.Mirroring != nil {
+		if admin, err = s.AdminClient(); err != nil {
+			return err
+		}
+	}
+	var schemas *schemaregistryv1.SchemaregistryV1
+	if len(manifests.Schemas) > 0 || len(manifests.SchemaRules) > 0 {
+		if schemas, err = s.SchemaClient(); err != nil {
+			return err
+		}
+	}
+
+	applier := apply.New(admin, schemas, &apply.Options{Prune: s.prune})
+	plan, err := applier.Plan(ctx, manifests)
+	if err != nil {
+		return err
+	}
+	if err := plan.WriteDiff(env.stdout, env.terminal && !s.noColor); err != nil {
+		return err
+	}
+	if plan.IsEmpty() {
+		return nil
+	}
+	fmt.Fprintf(env.stdout, "\n%s\n", plan.Summary())
+	if s.dryRun {
+		return nil
+	}
+	if err := applier.Apply(ctx, plan); err != nil {
+		return err
+	}
+	fmt.Fprintf(env.stdout, "Applied %d changes.\n", len(plan.Changes))
+	return nil
+}
+
+// load reads the manifests in the paths, which are files or directories, or the current directory when there are
+// none.
+func load(paths []string) (*apply.Manifests, error) {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	return apply.LoadFiles(paths...)
+}
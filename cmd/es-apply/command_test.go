@@ -0,0 +1,155 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	adminfake "github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1/fake"
+	schemafake "github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// result : The outcome of running es-apply in a test.
+type result struct {
+	code   int
+	stdout string
+	stderr string
+}
+
+// runWith runs es-apply with the environment variables, on a terminal or not.
+func runWith(vars map[string]string, terminal bool, args ...string) result {
+	var stdout, stderr bytes.Buffer
+	env := &environment{
+		stdout:   &stdout,
+		stderr:   &stderr,
+		getenv:   func(key string) string { return vars[key] },
+		terminal: terminal,
+	}
+	code := run(args, env)
+	return result{code: code, stdout: stdout.String(), stderr: stderr.String()}
+}
+
+var _ = Describe(`Command line`, func() {
+	var adminServer *adminfake.Server
+	var schemaServer *schemafake.Server
+	var vars map[string]string
+	var dir string
+
+	writeManifest := func(name string, text string) {
+		Expect(ioutil.WriteFile(filepath.Join(dir, name), []byte(text), 0644)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		adminServer = adminfake.NewServer(&adminfake.ServerOptions{APIKey: "secret"})
+		adminServer.AddTopic(adminfake.Topic{Name: "orders", Partitions: 3})
+		adminServer.AddTopic(adminfake.Topic{Name: "legacy"})
+		schemaServer = schemafake.NewServer(nil)
+		vars = map[string]string{
+			"KAFKA_ADMIN_URL":     adminServer.URL,
+			"SCHEMA_REGISTRY_URL": schemaServer.URL,
+			"API_KEY":             "secret",
+		}
+
+		var err error
+		dir, err = ioutil.TempDir("", "es-apply")
+		Expect(err).To(BeNil())
+		writeManifest("topics.yaml", "kind: Topic\nname: orders\npartitions: 6\n---\nkind: Topic\nname: audit\n")
+		writeManifest("rules.yml", "kind: SchemaRule\nconfig: backward\n")
+		writeManifest("README.md", "not a manifest")
+	})
+	AfterEach(func() {
+		adminServer.Close()
+		schemaServer.Close()
+		os.RemoveAll(dir)
+	})
+
+	It(`Print the plan of a dry run`, func() {
+		r := runWith(vars, false, "-dry-run", dir)
+		Expect(r.stderr).To(Equal(""))
+		Expect(r.code).To(Equal(exitOK))
+		Expect(r.stdout).To(Equal(`+ Topic audit
+~ Topic orders
+-     partitions: 3
++     partitions: 6
+~ SchemaRule (global)
+-     config: NONE
++     config: BACKWARD
+
+Plan: 1 to create, 2 to update, 0 to delete.
+`))
+		Expect(adminServer.TopicNames()).To(Equal([]string{"legacy", "orders"}))
+		Expect(schemaServer.GlobalRule()).To(Equal("NONE"))
+	})
+	It(`Color the diff on a terminal`, func() {
+		r := runWith(vars, true, "-dry-run", filepath.Join(dir, "rules.yml"))
+		Expect(r.code).To(Equal(exitOK))
+		Expect(r.stdout).To(HavePrefix("\x1b[1m\x1b[33m~ SchemaRule (global)\x1b[0m\n\x1b[31m-     config: NONE\x1b[0m\n"))
+
+		r = runWith(vars, true, "-dry-run", "-no-color", filepath.Join(dir, "rules.yml"))
+		Expect(r.stdout).To(HavePrefix("~ SchemaRule (global)\n"))
+
+		vars["NO_COLOR"] = "1"
+		r = runWith(vars, true, "-dry-run", filepath.Join(dir, "rules.yml"))
+		Expect(r.stdout).To(HavePrefix("~ SchemaRule (global)\n"))
+	})
+	It(`Apply and prune the manifests`, func() {
+		r := runWith(vars, false, "--prune", dir)
+		Expect(r.stderr).To(Equal(""))
+		Expect(r.code).To(Equal(exitOK))
+		Expect(r.stdout).To(HaveSuffix("Plan: 1 to create, 2 to update, 1 to delete.\nApplied 4 changes.\n"))
+		Expect(adminServer.TopicNames()).To(Equal([]string{"audit", "orders"}))
+		Expect(schemaServer.GlobalRule()).To(Equal("BACKWARD"))
+
+		r = runWith(vars, false, "-prune", dir)
+		Expect(r.code).To(Equal(exitOK))
+		Expect(r.stdout).To(Equal("No changes.\n"))
+	})
+	It(`Create only the clients the manifests need`, func() {
+		delete(vars, "SCHEMA_REGISTRY_URL")
+		delete(vars, "KAFKA_ADMIN_URL")
+		r := runWith(vars, false, "-dry-run", "-schema-url", schemaServer.URL, filepath.Join(dir, "rules.yml"))
+		Expect(r.code).To(Equal(exitOK))
+
+		r = runWith(vars, false, "-dry-run", filepath.Join(dir, "topics.yaml"))
+		Expect(r.code).To(Equal(exitError))
+		Expect(r.stderr).To(Equal("es-apply: no Admin REST API URL: set -url, KAFKA_ADMIN_URL or ADMINREST_URL\n"))
+	})
+	It(`Report invalid manifests and failed changes`, func() {
+		writeManifest("quotas.yaml", "kind: Quota\nname: default\n")
+		r := runWith(vars, false, dir)
+		Expect(r.code).To(Equal(exitError))
+		Expect(r.stderr).To(Equal("es-apply: invalid manifests: " + filepath.Join(dir, "quotas.yaml") + "#1: quota default sets neither producer_byte_rate nor consumer_byte_rate\n"))
+
+		r = runWith(vars, false, "-api-key", "wrong", filepath.Join(dir, "topics.yaml"))
+		Expect(r.code).To(Equal(exitError))
+		Expect(r.stderr).To(HavePrefix("es-apply: "))
+		Expect(r.stdout).To(Equal(""))
+
+		r = runWith(vars, false, "-dry-run", "-bogus")
+		Expect(r.code).To(Equal(exitUsage))
+		Expect(r.stderr).To(ContainSubstring("Usage: es-apply [flags] [path ...]"))
+
+		r = runWith(vars, false, filepath.Join(dir, "missing.yaml"))
+		Expect(r.code).To(Equal(exitError))
+		Expect(r.stderr).To(ContainSubstring("missing.yaml: no such file or directory"))
+	})
+})
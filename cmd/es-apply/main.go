@@ -0,0 +1,34 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Command es-apply : Manage an Event Streams instance from a directory of YAML manifests
+//
+// es-apply reads the Topic, Quota, MirroringSelection, Schema and SchemaRule manifests in the given files and
+// directories, prints the changes that bring the instance to the state they describe as a diff, and makes them:
+//
+//	es-apply [flags] [path ...]
+//
+// With -dry-run it only prints the diff, and with -prune it also deletes the resources of the manifest kinds that are
+// not in the manifests. See the apply package for the manifest format.
+package main
+
+import (
+	"os"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], newEnvironment()))
+}
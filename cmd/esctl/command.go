@@ -0,0 +1,281 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/IBM/eventstreams-go-sdk/internal/cli"
+)
+
+// Exit codes.
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// environment : The process state used by commands, which tests replace.
+type environment struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(key string) string
+}
+
+// newEnvironment returns the environment of the process.
+func newEnvironment() *environment {
+	return &environment{
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		getenv: os.Getenv,
+	}
+}
+
+// globals : The flags accepted by every command.
+type globals struct {
+	cli.Connection
+	output  string
+	timeout time.Duration
+}
+
+// newGlobals returns the globals with their defaults, which are taken from the environment variables used by the
+// examples.
+func newGlobals(env *environment) *globals {
+	return &globals{
+		Connection: cli.Connection{
+			URL:         env.getenv("KAFKA_ADMIN_URL"),
+			SchemaURL:   env.getenv("SCHEMA_REGISTRY_URL"),
+			APIKey:      env.getenv("API_KEY"),
+			BearerToken: env.getenv("BEARER_TOKEN"),
+		},
+		output: formatTable,
+	}
+}
+
+// register adds the global flags to a flag set. The current values are the defaults, so registering them again on
+// the flag set of a command keeps the values given before the command name.
+func (g *globals) register(flags *flag.FlagSet) {
+	flags.StringVar(&g.URL, "url", g.URL, "the URL of the Admin REST API (default $KAFKA_ADMIN_URL)")
+	flags.StringVar(&g.SchemaURL, "schema-url", g.SchemaURL, "the URL of the schema registry (default $SCHEMA_REGISTRY_URL, or -url)")
+	flags.StringVar(&g.APIKey, "api-key", g.APIKey, "authenticate with an API key (default $API_KEY)")
+	flags.StringVar(&g.BearerToken, "bearer-token", g.BearerToken, "authenticate with a bearer token (default $BEARER_TOKEN)")
+	flags.StringVar(&g.output, "o", g.output, "the output format: table, json or yaml")
+	flags.DurationVar(&g.timeout, "timeout", g.timeout, "the time allowed for the command, or 0 for no limit")
+}
+
+// command : A command, or a group of subcommands when run is nil.
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(inv *invocation) error
+
+	subcommands []*command
+}
+
+// find returns the subcommand with the name, or nil if there is none.
+func (cmd *command) find(name string) *command {
+	for _, subcommand := range cmd.subcommands {
+		if subcommand.name == name {
+			return subcommand
+		}
+	}
+	return nil
+}
+
+// printUsage writes the subcommands of a group.
+func (cmd *command) printUsage(w io.Writer, path string) {
+	fmt.Fprintf(w, "Usage: %s <command> [flags] [arguments]\n\nCommands:\n", path)
+	for _, subcommand := range cmd.subcommands {
+		fmt.Fprintf(w, "  %-10s %s\n", subcommand.name, subcommand.summary)
+	}
+	fmt.Fprintf(w, "\nRun '%s <command> -h' for the flags of a command.\n", path)
+}
+
+// usageError : An error in the command line rather than in the request.
+type usageError struct {
+	message string
+}
+
+func (err *usageError) Error() string {
+	return err.message
+}
+
+// usagef returns a usageError.
+func usagef(format string, args ...interface{}) error {
+	return &usageError{message: fmt.Sprintf(format, args...)}
+}
+
+// errFlagReported is returned when the flag package has already reported a problem with the flags.
+var errFlagReported = errors.New("invalid flags")
+
+// invocation : A command being run, with its flags and arguments.
+type invocation struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	env     *environment
+	globals *globals
+	path    string
+	flags   *flag.FlagSet
+	args    []string
+	rawArgs []string
+}
+
+// parse parses the flags and arguments of the command, which must have between minArgs and maxArgs arguments, or
+// any number from minArgs when maxArgs is negative. Flags may appear before, between and after the arguments.
+func (inv *invocation) parse(usage string, minArgs int, maxArgs int) error {
+	inv.globals.register(inv.flags)
+	inv.flags.Usage = func() {
+		fmt.Fprintf(inv.flags.Output(), "Usage: %s [flags] %s\n\nFlags:\n", inv.path, usage)
+		inv.flags.PrintDefaults()
+	}
+
+	args := inv.rawArgs
+	for len(args) > 0 {
+		if err := inv.flags.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return err
+			}
+			return errFlagReported
+		}
+		rest := inv.flags.Args()
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			inv.args = append(inv.args, rest...)
+			break
+		}
+		if len(rest) == 0 {
+			break
+		}
+		inv.args = append(inv.args, rest[0])
+		args = rest[1:]
+	}
+
+	switch inv.globals.output {
+	case formatTable, formatJSON, formatYAML:
+	default:
+		return usagef("unknown output format %q, use table, json or yaml", inv.globals.output)
+	}
+	if len(inv.args) < minArgs || (maxArgs >= 0 && len(inv.args) > maxArgs) {
+		return usagef("usage: %s [flags] %s", inv.path, usage)
+	}
+	if inv.globals.timeout > 0 {
+		inv.ctx, inv.cancel = context.WithTimeout(inv.ctx, inv.globals.timeout)
+	}
+	return nil
+}
+
+// stringList : A flag that may be repeated, collecting each value.
+type stringList []string
+
+func (list *stringList) String() string {
+	return strings.Join(*list, ",")
+}
+
+func (list *stringList) Set(value string) error {
+	*list = append(*list, value)
+	return nil
+}
+
+// run runs the command line, returning the exit code.
+func run(args []string, env *environment) int {
+	g := newGlobals(env)
+	root := rootCommand()
+
+	flags := flag.NewFlagSet("esctl", flag.ContinueOnError)
+	flags.SetOutput(env.stderr)
+	g.register(flags)
+	flags.Usage = func() {
+		root.printUsage(env.stderr, "esctl")
+		fmt.Fprintf(env.stderr, "\nGlobal flags:\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	args = flags.Args()
+
+	cmd, path := root, "esctl"
+	for cmd.run == nil {
+		if len(args) == 0 {
+			cmd.printUsage(env.stderr, path)
+			return exitUsage
+		}
+		if args[0] == "help" {
+			cmd.printUsage(env.stdout, path)
+			return exitOK
+		}
+		subcommand := cmd.find(args[0])
+		if subcommand == nil {
+			fmt.Fprintf(env.stderr, "%s: unknown command %q\n", path, args[0])
+			cmd.printUsage(env.stderr, path)
+			return exitUsage
+		}
+		cmd, path, args = subcommand, path+" "+subcommand.name, args[1:]
+	}
+
+	inv := &invocation{
+		ctx:     context.Background(),
+		env:     env,
+		globals: g,
+		path:    path,
+		flags:   flag.NewFlagSet(path, flag.ContinueOnError),
+		rawArgs: args,
+	}
+	inv.flags.SetOutput(env.stderr)
+
+	err := cmd.run(inv)
+	if inv.cancel != nil {
+		inv.cancel()
+	}
+	var usage *usageError
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.Is(err, errFlagReported):
+		return exitUsage
+	case errors.As(err, &usage):
+		fmt.Fprintf(env.stderr, "%s: %s\n", path, usage.message)
+		return exitUsage
+	}
+	fmt.Fprintf(env.stderr, "%s: %s\n", path, err.Error())
+	return exitError
+}
+
+// rootCommand returns the command tree.
+func rootCommand() *command {
+	return &command{
+		name: "esctl",
+		subcommands: []*command{
+			topicsCommand(),
+			mirroringCommand(),
+			quotasCommand(),
+			schemasCommand(),
+		},
+	}
+}
@@ -0,0 +1,162 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1/fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// result : The outcome of running esctl in a test.
+type result struct {
+	code   int
+	stdout string
+	stderr string
+}
+
+// runWith runs esctl with the environment variables and standard input.
+func runWith(vars map[string]string, stdin string, args ...string) result {
+	var stdout, stderr bytes.Buffer
+	env := &environment{
+		stdin:  strings.NewReader(stdin),
+		stdout: &stdout,
+		stderr: &stderr,
+		getenv: func(key string) string { return vars[key] },
+	}
+	code := run(args, env)
+	return result{code: code, stdout: stdout.String(), stderr: stderr.String()}
+}
+
+var _ = Describe(`Command line`, func() {
+	var server *fake.Server
+	var vars map[string]string
+
+	BeforeEach(func() {
+		server = fake.NewServer(&fake.ServerOptions{APIKey: "secret"})
+		server.AddTopic(fake.Topic{Name: "orders", Partitions: 3})
+		vars = map[string]string{"KAFKA_ADMIN_URL": server.URL, "API_KEY": "secret"}
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Print the commands of a group`, func() {
+		r := runWith(vars, "", "help")
+		Expect(r.code).To(Equal(exitOK))
+		Expect(r.stdout).To(ContainSubstring("topics     list, get, create, update and delete Kafka topics"))
+
+		r = runWith(vars, "", "schemas", "versions")
+		Expect(r.code).To(Equal(exitUsage))
+		Expect(r.stderr).To(ContainSubstring("Usage: esctl schemas versions <command>"))
+
+		r = runWith(vars, "", "topic", "list")
+		Expect(r.code).To(Equal(exitUsage))
+		Expect(r.stderr).To(HavePrefix(`esctl: unknown command "topic"`))
+	})
+	It(`Report usage errors`, func() {
+		r := runWith(vars, "", "topics", "get")
+		Expect(r.code).To(Equal(exitUsage))
+		Expect(r.stderr).To(Equal("esctl topics get: usage: esctl topics get [flags] <name>\n"))
+
+		r = runWith(vars, "", "topics", "list", "-partitions", "3")
+		Expect(r.code).To(Equal(exitUsage))
+		Expect(r.stderr).To(HavePrefix("flag provided but not defined: -partitions"))
+
+		r = runWith(vars, "", "-o", "xml", "topics", "list")
+		Expect(r.code).To(Equal(exitUsage))
+		Expect(r.stderr).To(Equal("esctl topics list: unknown output format \"xml\", use table, json or yaml\n"))
+
+		r = runWith(vars, "", "topics", "create", "-h")
+		Expect(r.code).To(Equal(exitOK))
+		Expect(r.stderr).To(ContainSubstring("Usage: esctl topics create [flags] <name>"))
+		Expect(r.stderr).To(ContainSubstring("-partitions"))
+		Expect(r.stderr).To(ContainSubstring("-api-key"))
+	})
+	It(`Accept global flags before and after the command`, func() {
+		vars = map[string]string{}
+		r := runWith(vars, "", "-url", server.URL, "topics", "get", "orders", "-api-key", "secret", "-o", "json")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(ContainSubstring(`"name": "orders"`))
+	})
+	It(`Report API errors`, func() {
+		r := runWith(vars, "", "topics", "get", "missing")
+		Expect(r.code).To(Equal(exitError))
+		Expect(r.stderr).To(HavePrefix("esctl topics get: "))
+		Expect(r.stderr).To(ContainSubstring("status 404"))
+
+		vars["API_KEY"] = "wrong"
+		r = runWith(vars, "", "topics", "list")
+		Expect(r.code).To(Equal(exitError))
+		Expect(r.stderr).To(ContainSubstring("status 401"))
+	})
+	It(`Authenticate with a bearer token or the external configuration`, func() {
+		vars["BEARER_TOKEN"] = "token"
+		r := runWith(vars, "", "topics", "list")
+		Expect(r.code).To(Equal(exitError))
+		Expect(r.stderr).To(Equal("esctl topics list: set either an API key or a bearer token, not both\n"))
+
+		delete(vars, "API_KEY")
+		r = runWith(vars, "", "topics", "list")
+		Expect(r.code).To(Equal(exitError))
+		Expect(r.stderr).To(ContainSubstring("status 401"))
+
+		os.Setenv("ADMINREST_AUTH_TYPE", "basic")
+		os.Setenv("ADMINREST_USERNAME", "token")
+		os.Setenv("ADMINREST_PASSWORD", "secret")
+		os.Setenv("ADMINREST_URL", server.URL)
+		defer func() {
+			for _, key := range []string{"ADMINREST_AUTH_TYPE", "ADMINREST_USERNAME", "ADMINREST_PASSWORD", "ADMINREST_URL"} {
+				os.Unsetenv(key)
+			}
+		}()
+		r = runWith(map[string]string{}, "", "topics", "list")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(ContainSubstring("orders"))
+	})
+	It(`Require a URL`, func() {
+		r := runWith(map[string]string{"API_KEY": "secret"}, "", "topics", "list")
+		Expect(r.code).To(Equal(exitError))
+		Expect(r.stderr).To(Equal("esctl topics list: no Admin REST API URL: set -url, KAFKA_ADMIN_URL or ADMINREST_URL\n"))
+	})
+	It(`Print results as a table, JSON or YAML`, func() {
+		r := runWith(vars, "", "topics", "list")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(Equal(
+			"NAME    PARTITIONS  REPLICATION FACTOR  CLEANUP POLICY  RETENTION\n" +
+				"orders  3           3                   delete          24h0m0s\n"))
+
+		r = runWith(vars, "", "-o", "json", "topics", "list")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(HavePrefix("[\n  {\n    \"name\": \"orders\",\n    \"partitions\": 3,\n"))
+
+		r = runWith(vars, "", "-o", "yaml", "topics", "list")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(ContainSubstring("- cleanupPolicy: delete\n"))
+		Expect(r.stdout).To(ContainSubstring("  configs:\n    cleanup.policy: delete\n"))
+		Expect(r.stdout).To(ContainSubstring("  name: orders\n  partitions: 3\n"))
+	})
+	It(`Print nothing for commands without a result in JSON and YAML`, func() {
+		r := runWith(vars, "", "-o", "json", "topics", "delete", "orders")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(BeEmpty())
+	})
+})
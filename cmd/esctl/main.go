@@ -0,0 +1,38 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Command esctl : A command-line tool for the Event Streams Admin REST API and schema registry
+//
+// esctl maps its subcommands onto the AdminrestV1 and SchemaregistryV1 clients:
+//
+//	esctl topics list|get|create|update|delete
+//	esctl mirroring get|set|add|remove|active
+//	esctl quotas list|get|create|update|set|delete
+//	esctl schemas list|get|create|update|delete
+//	esctl schemas versions list|create|delete
+//	esctl schemas rules get|set|delete
+//
+// A version of a schema is fetched with esctl schemas get -version. Run esctl help, or any command with -h, for the
+// flags of each command.
+package main
+
+import (
+	"os"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], newEnvironment()))
+}
@@ -0,0 +1,128 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+)
+
+// mirroringCommand returns the mirroring commands.
+func mirroringCommand() *command {
+	return &command{
+		name:    "mirroring",
+		summary: "manage the topics mirrored to this instance",
+		subcommands: []*command{
+			{name: "get", summary: "get the patterns of the mirroring topic selection", run: getMirroringSelection},
+			{name: "set", summary: "replace the patterns of the mirroring topic selection", run: setMirroringSelection},
+			{name: "add", summary: "add patterns to the mirroring topic selection", run: addMirroringPatterns},
+			{name: "remove", summary: "remove patterns from the mirroring topic selection", run: removeMirroringPatterns},
+			{name: "active", summary: "list the topics that are being mirrored", run: getMirroringActiveTopics},
+		},
+	}
+}
+
+// printSelection prints a mirroring topic selection, one pattern to a row in the table format.
+func printSelection(inv *invocation, selection *adminrestv1.MirroringTopicSelection) error {
+	t := newTable("PATTERN")
+	for _, pattern := range selection.Includes {
+		t.add(pattern)
+	}
+	return inv.print(selection, t)
+}
+
+func getMirroringSelection(inv *invocation) error {
+	if err := inv.parse("", 0, 0); err != nil {
+		return err
+	}
+	client, err := inv.globals.AdminClient()
+	if err != nil {
+		return err
+	}
+	selection, _, err := client.GetMirroringTopicSelectionWithContext(inv.ctx, client.NewGetMirroringTopicSelectionOptions())
+	if err != nil {
+		return err
+	}
+	return printSelection(inv, selection)
+}
+
+func setMirroringSelection(inv *invocation) error {
+	if err := inv.parse("<pattern>...", 1, -1); err != nil {
+		return err
+	}
+	if err := adminrestv1.ValidateMirroringPatterns(inv.args); err != nil {
+		return err
+	}
+	client, err := inv.globals.AdminClient()
+	if err != nil {
+		return err
+	}
+	options := client.NewReplaceMirroringTopicSelectionOptions().SetIncludes(append([]string{}, inv.args...))
+	selection, _, err := client.ReplaceMirroringTopicSelectionWithContext(inv.ctx, options)
+	if err != nil {
+		return err
+	}
+	return printSelection(inv, selection)
+}
+
+func addMirroringPatterns(inv *invocation) error {
+	if err := inv.parse("<pattern>...", 1, -1); err != nil {
+		return err
+	}
+	client, err := inv.globals.AdminClient()
+	if err != nil {
+		return err
+	}
+	selection, err := client.AddMirroringPatternsWithContext(inv.ctx, client.NewAddMirroringPatternsOptions(inv.args))
+	if err != nil {
+		return err
+	}
+	return printSelection(inv, selection)
+}
+
+func removeMirroringPatterns(inv *invocation) error {
+	if err := inv.parse("<pattern>...", 1, -1); err != nil {
+		return err
+	}
+	client, err := inv.globals.AdminClient()
+	if err != nil {
+		return err
+	}
+	selection, err := client.RemoveMirroringPatternsWithContext(inv.ctx, client.NewRemoveMirroringPatternsOptions(inv.args))
+	if err != nil {
+		return err
+	}
+	return printSelection(inv, selection)
+}
+
+func getMirroringActiveTopics(inv *invocation) error {
+	if err := inv.parse("", 0, 0); err != nil {
+		return err
+	}
+	client, err := inv.globals.AdminClient()
+	if err != nil {
+		return err
+	}
+	active, _, err := client.GetMirroringActiveTopicsWithContext(inv.ctx, client.NewGetMirroringActiveTopicsOptions())
+	if err != nil {
+		return err
+	}
+	t := newTable("TOPIC")
+	for _, topic := range active.ActiveTopics {
+		t.add(topic)
+	}
+	return inv.print(active, t)
+}
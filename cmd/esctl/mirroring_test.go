@@ -0,0 +1,70 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1/fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Mirroring commands`, func() {
+	var server *fake.Server
+	var vars map[string]string
+
+	BeforeEach(func() {
+		server = fake.NewServer(&fake.ServerOptions{MirroringEnabled: true})
+		server.SetMirroringTopicSelection([]string{"orders.*"})
+		server.SetMirroringSourceTopics([]string{"orders.eu", "payments"})
+		vars = map[string]string{"KAFKA_ADMIN_URL": server.URL, "BEARER_TOKEN": "token"}
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Get and replace the topic selection`, func() {
+		r := runWith(vars, "", "mirroring", "get")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(Equal("PATTERN\norders.*\n"))
+
+		r = runWith(vars, "", "mirroring", "set", "payments", "audit.*", "-o", "json")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(MatchJSON(`{"includes": ["payments", "audit.*"]}`))
+		Expect(server.MirroringTopicSelection()).To(Equal([]string{"payments", "audit.*"}))
+
+		r = runWith(vars, "", "mirroring", "set")
+		Expect(r.code).To(Equal(exitUsage))
+
+		r = runWith(vars, "", "mirroring", "set", "orders[")
+		Expect(r.code).To(Equal(exitError))
+		Expect(server.RequestCount(fake.OperationReplaceMirroringTopicSelection)).To(Equal(1))
+	})
+	It(`Add and remove patterns`, func() {
+		r := runWith(vars, "", "mirroring", "add", "payments")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(server.MirroringTopicSelection()).To(Equal([]string{"orders.*", "payments"}))
+
+		r = runWith(vars, "", "mirroring", "remove", "orders.*")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(Equal("PATTERN\npayments\n"))
+	})
+	It(`List the active topics`, func() {
+		r := runWith(vars, "", "mirroring", "active", "-o", "yaml")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(Equal("active_topics:\n- orders.eu\n"))
+	})
+})
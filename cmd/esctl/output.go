@@ -0,0 +1,132 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"gopkg.in/yaml.v2"
+)
+
+// Output formats.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+// table : A result in the table format.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// newTable returns a table with the column headings.
+func newTable(header ...string) *table {
+	return &table{header: header}
+}
+
+// add appends a row.
+func (t *table) add(cells ...string) *table {
+	t.rows = append(t.rows, cells)
+	return t
+}
+
+// print writes a result in the output format. The table format prints t, or value as indented JSON when t is nil.
+// The JSON and YAML formats print value, with the field names of its JSON encoding.
+func (inv *invocation) print(value interface{}, t *table) error {
+	switch {
+	case inv.globals.output == formatYAML:
+		buf, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		// JSON is YAML, so decoding it keeps the JSON field names and the types of the values.
+		var document interface{}
+		err = yaml.Unmarshal(buf, &document)
+		if err != nil {
+			return err
+		}
+		buf, err = yaml.Marshal(document)
+		if err != nil {
+			return err
+		}
+		_, err = inv.env.stdout.Write(buf)
+		return err
+	case inv.globals.output == formatJSON || t == nil:
+		encoder := json.NewEncoder(inv.env.stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}
+
+	w := tabwriter.NewWriter(inv.env.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(t.header, "\t"))
+	for _, row := range t.rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// done reports the outcome of a command that has no result. It is printed in the table format only, so that the JSON
+// and YAML formats stay empty.
+func (inv *invocation) done(format string, args ...interface{}) {
+	if inv.globals.output == formatTable {
+		fmt.Fprintf(inv.env.stdout, format+"\n", args...)
+	}
+}
+
+// formatInt returns a number for a table cell, or "-" when it is not set.
+func formatInt(value *int64) string {
+	if value == nil {
+		return "-"
+	}
+	return strconv.FormatInt(*value, 10)
+}
+
+// formatString returns a string for a table cell, or "-" when it is not set.
+func formatString(value *string) string {
+	if value == nil || *value == "" {
+		return "-"
+	}
+	return *value
+}
+
+// formatMs returns a number of milliseconds as a duration for a table cell. Negative values, which mean unlimited,
+// are returned as they are.
+func formatMs(value *int64) string {
+	if value == nil {
+		return "-"
+	}
+	if *value < 0 {
+		return strconv.FormatInt(*value, 10)
+	}
+	return (time.Duration(*value) * time.Millisecond).String()
+}
+
+// formatByteRate returns a rate in bytes per second for a table cell, or "-" when it is not set.
+func formatByteRate(value *int64) string {
+	if value == nil {
+		return "-"
+	}
+	return adminrestv1.ByteRate(*value).String()
+}
@@ -0,0 +1,205 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+)
+
+// quotasCommand returns the quotas commands.
+func quotasCommand() *command {
+	return &command{
+		name:    "quotas",
+		summary: "list, get, create, update and delete the quotas of users and the default quota",
+		subcommands: []*command{
+			{name: "list", summary: "list the quotas", run: listQuotas},
+			{name: "get", summary: "get the quotas of an entity", run: getQuota},
+			{name: "create", summary: "create the quotas of an entity", run: createQuota},
+			{name: "update", summary: "change the quotas of an entity", run: updateQuota},
+			{name: "set", summary: "create or change the quotas of an entity", run: setQuota},
+			{name: "delete", summary: "delete the quotas of an entity", run: deleteQuota},
+		},
+	}
+}
+
+// quotasTable returns the table of a list of quotas.
+func quotasTable(quotas []adminrestv1.EntityQuotaDetail) *table {
+	t := newTable("ENTITY", "PRODUCER BYTE RATE", "CONSUMER BYTE RATE")
+	for _, quota := range quotas {
+		t.add(formatString(quota.EntityName), formatByteRate(quota.ProducerByteRate), formatByteRate(quota.ConsumerByteRate))
+	}
+	return t
+}
+
+// rateFlags : The flags that set the rates of a quota.
+type rateFlags struct {
+	producer *string
+	consumer *string
+}
+
+// addRateFlags adds the flags that set the rates of a quota.
+func addRateFlags(inv *invocation) *rateFlags {
+	return &rateFlags{
+		producer: inv.flags.String("producer-byte-rate", "", "the producer rate, such as 1048576 or 1MiB/s"),
+		consumer: inv.flags.String("consumer-byte-rate", "", "the consumer rate, such as 1048576 or 1MiB/s"),
+	}
+}
+
+// parse returns the rates that were given, at least one of which must be.
+func (flags *rateFlags) parse() (producer *int64, consumer *int64, err error) {
+	if *flags.producer == "" && *flags.consumer == "" {
+		return nil, nil, usagef("set -producer-byte-rate, -consumer-byte-rate or both")
+	}
+	if *flags.producer != "" {
+		rate, err := adminrestv1.ParseByteRate(*flags.producer)
+		if err != nil {
+			return nil, nil, err
+		}
+		producer = new(int64)
+		*producer = int64(rate)
+	}
+	if *flags.consumer != "" {
+		rate, err := adminrestv1.ParseByteRate(*flags.consumer)
+		if err != nil {
+			return nil, nil, err
+		}
+		consumer = new(int64)
+		*consumer = int64(rate)
+	}
+	return producer, consumer, nil
+}
+
+func listQuotas(inv *invocation) error {
+	if err := inv.parse("", 0, 0); err != nil {
+		return err
+	}
+	client, err := inv.globals.AdminClient()
+	if err != nil {
+		return err
+	}
+	result, _, err := client.ListQuotasWithContext(inv.ctx, client.NewListQuotasOptions())
+	if err != nil {
+		return err
+	}
+	return inv.print(result.Data, quotasTable(result.Data))
+}
+
+func getQuota(inv *invocation) error {
+	if err := inv.parse("<entity>", 1, 1); err != nil {
+		return err
+	}
+	client, err := inv.globals.AdminClient()
+	if err != nil {
+		return err
+	}
+	quota, _, err := client.GetQuotaWithContext(inv.ctx, client.NewGetQuotaOptions(inv.args[0]))
+	if err != nil {
+		return err
+	}
+	entityQuota := adminrestv1.EntityQuotaDetail{
+		EntityName:       &inv.args[0],
+		ProducerByteRate: quota.ProducerByteRate,
+		ConsumerByteRate: quota.ConsumerByteRate,
+	}
+	return inv.print(quota, quotasTable([]adminrestv1.EntityQuotaDetail{entityQuota}))
+}
+
+func createQuota(inv *invocation) error {
+	rates := addRateFlags(inv)
+	if err := inv.parse("<entity>", 1, 1); err != nil {
+		return err
+	}
+	producer, consumer, err := rates.parse()
+	if err != nil {
+		return err
+	}
+	client, err := inv.globals.AdminClient()
+	if err != nil {
+		return err
+	}
+	options := client.NewCreateQuotaOptions(inv.args[0])
+	options.ProducerByteRate, options.ConsumerByteRate = producer, consumer
+	if _, err = client.CreateQuotaWithContext(inv.ctx, options); err != nil {
+		return err
+	}
+	inv.done("Quota of %s created", inv.args[0])
+	return nil
+}
+
+func updateQuota(inv *invocation) error {
+	rates := addRateFlags(inv)
+	if err := inv.parse("<entity>", 1, 1); err != nil {
+		return err
+	}
+	producer, consumer, err := rates.parse()
+	if err != nil {
+		return err
+	}
+	client, err := inv.globals.AdminClient()
+	if err != nil {
+		return err
+	}
+	options := client.NewUpdateQuotaOptions(inv.args[0])
+	options.ProducerByteRate, options.ConsumerByteRate = producer, consumer
+	if _, err = client.UpdateQuotaWithContext(inv.ctx, options); err != nil {
+		return err
+	}
+	inv.done("Quota of %s updated", inv.args[0])
+	return nil
+}
+
+func setQuota(inv *invocation) error {
+	rates := addRateFlags(inv)
+	if err := inv.parse("<entity>", 1, 1); err != nil {
+		return err
+	}
+	producer, consumer, err := rates.parse()
+	if err != nil {
+		return err
+	}
+	client, err := inv.globals.AdminClient()
+	if err != nil {
+		return err
+	}
+	options := client.NewUpsertQuotaOptions(inv.args[0])
+	options.ProducerByteRate, options.ConsumerByteRate = producer, consumer
+	created, _, err := client.UpsertQuotaWithContext(inv.ctx, options)
+	if err != nil {
+		return err
+	}
+	if created {
+		inv.done("Quota of %s created", inv.args[0])
+	} else {
+		inv.done("Quota of %s updated", inv.args[0])
+	}
+	return nil
+}
+
+func deleteQuota(inv *invocation) error {
+	if err := inv.parse("<entity>", 1, 1); err != nil {
+		return err
+	}
+	client, err := inv.globals.AdminClient()
+	if err != nil {
+		return err
+	}
+	if _, err = client.DeleteQuotaWithContext(inv.ctx, client.NewDeleteQuotaOptions(inv.args[0])); err != nil {
+		return err
+	}
+	inv.done("Quota of %s deleted", inv.args[0])
+	return nil
+}
@@ -0,0 +1,92 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1/fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Quotas commands`, func() {
+	var server *fake.Server
+	var vars map[string]string
+
+	BeforeEach(func() {
+		server = fake.NewServer(nil)
+		server.SetQuota("default", fake.Quota{ProducerByteRate: 1048576, ConsumerByteRate: 1048576})
+		server.SetQuota("iam-ServiceId-orders", fake.Quota{ProducerByteRate: 500000})
+		vars = map[string]string{"KAFKA_ADMIN_URL": server.URL, "BEARER_TOKEN": "token"}
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	// quota returns the quota of an entity held by the server.
+	quota := func(entityName string) fake.Quota {
+		quota, ok := server.Quota(entityName)
+		Expect(ok).To(BeTrue())
+		return quota
+	}
+
+	It(`List and get quotas with readable rates`, func() {
+		r := runWith(vars, "", "quotas", "list")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(Equal(
+			"ENTITY                PRODUCER BYTE RATE  CONSUMER BYTE RATE\n" +
+				"default               1MiB/s              1MiB/s\n" +
+				"iam-ServiceId-orders  500KB/s             -\n"))
+
+		r = runWith(vars, "", "quotas", "get", "iam-ServiceId-orders", "-o", "json")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(MatchJSON(`{"producer_byte_rate": 500000}`))
+	})
+	It(`Create, update and delete quotas`, func() {
+		r := runWith(vars, "", "quotas", "create", "iam-ServiceId-payments", "-producer-byte-rate", "10MiB/s")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(Equal("Quota of iam-ServiceId-payments created\n"))
+		Expect(quota("iam-ServiceId-payments")).To(Equal(fake.Quota{ProducerByteRate: 10485760}))
+
+		r = runWith(vars, "", "quotas", "update", "iam-ServiceId-payments", "-consumer-byte-rate", "2MB")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(quota("iam-ServiceId-payments")).To(Equal(fake.Quota{ProducerByteRate: 10485760, ConsumerByteRate: 2000000}))
+
+		r = runWith(vars, "", "quotas", "delete", "iam-ServiceId-payments")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		_, ok := server.Quota("iam-ServiceId-payments")
+		Expect(ok).To(BeFalse())
+	})
+	It(`Set quotas whether or not they exist`, func() {
+		r := runWith(vars, "", "quotas", "set", "iam-ServiceId-orders", "-consumer-byte-rate", "1MiB/s")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(Equal("Quota of iam-ServiceId-orders updated\n"))
+
+		r = runWith(vars, "", "quotas", "set", "iam-ServiceId-audit", "-consumer-byte-rate", "1MiB/s")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(Equal("Quota of iam-ServiceId-audit created\n"))
+	})
+	It(`Check the rates before sending them`, func() {
+		r := runWith(vars, "", "quotas", "create", "iam-ServiceId-payments")
+		Expect(r.code).To(Equal(exitUsage))
+		Expect(r.stderr).To(Equal("esctl quotas create: set -producer-byte-rate, -consumer-byte-rate or both\n"))
+
+		r = runWith(vars, "", "quotas", "create", "iam-ServiceId-payments", "-producer-byte-rate", "10M")
+		Expect(r.code).To(Equal(exitError))
+		Expect(r.stderr).To(ContainSubstring(`invalid byte rate "10M"`))
+		Expect(server.RequestCount(fake.OperationCreateQuota)).To(Equal(0))
+	})
+})
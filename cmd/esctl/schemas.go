@@ -0,0 +1,343 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/compat"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// schemasCommand returns the schemas commands.
+func schemasCommand() *command {
+	return &command{
+		name:    "schemas",
+		summary: "manage the schemas, versions and rules of the schema registry",
+		subcommands: []*command{
+			{name: "list", summary: "list the IDs of the schemas", run: listSchemas},
+			{name: "get", summary: "get the latest or a given version of a schema", run: getSchema},
+			{name: "create", summary: "create a schema from a file", run: createSchema},
+			{name: "update", summary: "replace a schema with the contents of a file", run: updateSchema},
+			{name: "delete", summary: "delete a schema and all of its versions", run: deleteSchema},
+			{
+				name:    "versions",
+				summary: "list, create and delete the versions of a schema",
+				subcommands: []*command{
+					{name: "list", summary: "list the versions of a schema", run: listVersions},
+					{name: "create", summary: "create a version of a schema from a file", run: createVersion},
+					{name: "delete", summary: "delete a version of a schema", run: deleteVersion},
+				},
+			},
+			{
+				name:    "rules",
+				summary: "get, set and delete the global or a schema's COMPATIBILITY rule",
+				subcommands: []*command{
+					{name: "get", summary: "get the global rule, or the rule of a schema", run: getRule},
+					{name: "set", summary: "set the global rule, or the rule of a schema", run: setRule},
+					{name: "delete", summary: "delete the rule of a schema, so that the global rule applies", run: deleteRule},
+				},
+			},
+		},
+	}
+}
+
+// readSchemaFile reads a JSON schema document from a file, or from standard input when the path is "-".
+func readSchemaFile(inv *invocation, path string) (map[string]interface{}, error) {
+	var r io.Reader = inv.env.stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		r = file
+	}
+	var schema map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&schema); err != nil {
+		return nil, fmt.Errorf("%s is not a JSON schema document: %w", path, err)
+	}
+	return schema, nil
+}
+
+// parseVersion converts a version number argument.
+func parseVersion(text string) (int64, error) {
+	version, err := strconv.ParseInt(text, 10, 64)
+	if err != nil || version < 1 {
+		return 0, usagef("invalid version %q, expected a version number", text)
+	}
+	return version, nil
+}
+
+// printMetadata prints the metadata of a schema version.
+func printMetadata(inv *invocation, metadata *schemaregistryv1.SchemaMetadata) error {
+	t := newTable("ID", "VERSION", "GLOBAL ID", "TYPE")
+	t.add(formatString(metadata.ID), formatInt(metadata.Version), formatInt(metadata.GlobalID), formatString(metadata.Type))
+	return inv.print(metadata, t)
+}
+
+// notFound returns true if a request failed because the resource does not exist.
+func notFound(response *core.DetailedResponse) bool {
+	return response != nil && response.StatusCode == http.StatusNotFound
+}
+
+func listSchemas(inv *invocation) error {
+	if err := inv.parse("", 0, 0); err != nil {
+		return err
+	}
+	client, err := inv.globals.SchemaClient()
+	if err != nil {
+		return err
+	}
+	ids, _, err := client.ListSchemasWithContext(inv.ctx, client.NewListSchemasOptions())
+	if err != nil {
+		return err
+	}
+	t := newTable("ID")
+	for _, id := range ids {
+		t.add(id)
+	}
+	return inv.print(ids, t)
+}
+
+func getSchema(inv *invocation) error {
+	version := inv.flags.String("version", "", "the version to get, instead of the latest")
+	if err := inv.parse("<id>", 1, 1); err != nil {
+		return err
+	}
+	client, err := inv.globals.SchemaClient()
+	if err != nil {
+		return err
+	}
+	var schema map[string]interface{}
+	if *version == "" {
+		schema, _, err = client.GetLatestSchemaWithContext(inv.ctx, client.NewGetLatestSchemaOptions(inv.args[0]))
+	} else {
+		number, parseErr := parseVersion(*version)
+		if parseErr != nil {
+			return parseErr
+		}
+		schema, _, err = client.GetVersionWithContext(inv.ctx, client.NewGetVersionOptions(inv.args[0], number))
+	}
+	if err != nil {
+		return err
+	}
+	return inv.print(schema, nil)
+}
+
+func createSchema(inv *invocation) error {
+	id := inv.flags.String("id", "", "the ID of the schema, instead of one chosen by the registry")
+	if err := inv.parse("<file>", 1, 1); err != nil {
+		return err
+	}
+	schema, err := readSchemaFile(inv, inv.args[0])
+	if err != nil {
+		return err
+	}
+	client, err := inv.globals.SchemaClient()
+	if err != nil {
+		return err
+	}
+	options := client.NewCreateSchemaOptions().SetSchema(schema)
+	if *id != "" {
+		options.SetID(*id)
+	}
+	metadata, _, err := client.CreateSchemaWithContext(inv.ctx, options)
+	if err != nil {
+		return err
+	}
+	return printMetadata(inv, metadata)
+}
+
+func updateSchema(inv *invocation) error {
+	if err := inv.parse("<id> <file>", 2, 2); err != nil {
+		return err
+	}
+	schema, err := readSchemaFile(inv, inv.args[1])
+	if err != nil {
+		return err
+	}
+	client, err := inv.globals.SchemaClient()
+	if err != nil {
+		return err
+	}
+	metadata, _, err := client.UpdateSchemaWithContext(inv.ctx, client.NewUpdateSchemaOptions(inv.args[0]).SetSchema(schema))
+	if err != nil {
+		return err
+	}
+	return printMetadata(inv, metadata)
+}
+
+func deleteSchema(inv *invocation) error {
+	if err := inv.parse("<id>", 1, 1); err != nil {
+		return err
+	}
+	client, err := inv.globals.SchemaClient()
+	if err != nil {
+		return err
+	}
+	if _, err = client.DeleteSchemaWithContext(inv.ctx, client.NewDeleteSchemaOptions(inv.args[0])); err != nil {
+		return err
+	}
+	inv.done("Schema %s deleted", inv.args[0])
+	return nil
+}
+
+func listVersions(inv *invocation) error {
+	if err := inv.parse("<id>", 1, 1); err != nil {
+		return err
+	}
+	client, err := inv.globals.SchemaClient()
+	if err != nil {
+		return err
+	}
+	versions, _, err := client.ListVersionsWithContext(inv.ctx, client.NewListVersionsOptions(inv.args[0]))
+	if err != nil {
+		return err
+	}
+	t := newTable("VERSION")
+	for _, version := range versions {
+		t.add(strconv.FormatInt(version, 10))
+	}
+	return inv.print(versions, t)
+}
+
+func createVersion(inv *invocation) error {
+	if err := inv.parse("<id> <file>", 2, 2); err != nil {
+		return err
+	}
+	schema, err := readSchemaFile(inv, inv.args[1])
+	if err != nil {
+		return err
+	}
+	client, err := inv.globals.SchemaClient()
+	if err != nil {
+		return err
+	}
+	metadata, _, err := client.CreateVersionWithContext(inv.ctx, client.NewCreateVersionOptions(inv.args[0]).SetSchema(schema))
+	if err != nil {
+		return err
+	}
+	return printMetadata(inv, metadata)
+}
+
+func deleteVersion(inv *invocation) error {
+	if err := inv.parse("<id> <version>", 2, 2); err != nil {
+		return err
+	}
+	version, err := parseVersion(inv.args[1])
+	if err != nil {
+		return err
+	}
+	client, err := inv.globals.SchemaClient()
+	if err != nil {
+		return err
+	}
+	if _, err = client.DeleteVersionWithContext(inv.ctx, client.NewDeleteVersionOptions(inv.args[0], version)); err != nil {
+		return err
+	}
+	inv.done("Version %d of schema %s deleted", version, inv.args[0])
+	return nil
+}
+
+// printRule prints a COMPATIBILITY rule, with the schema it applies to in the table format.
+func printRule(inv *invocation, scope string, rule *schemaregistryv1.Rule) error {
+	t := newTable("SCOPE", "RULE", "CONFIG")
+	t.add(scope, formatString(rule.Type), formatString(rule.Config))
+	return inv.print(rule, t)
+}
+
+func getRule(inv *invocation) error {
+	if err := inv.parse("[<id>]", 0, 1); err != nil {
+		return err
+	}
+	client, err := inv.globals.SchemaClient()
+	if err != nil {
+		return err
+	}
+	const ruleType = schemaregistryv1.RuleTypeCompatibilityConst
+	if len(inv.args) == 0 {
+		rule, _, err := client.GetGlobalRuleWithContext(inv.ctx, client.NewGetGlobalRuleOptions(ruleType))
+		if err != nil {
+			return err
+		}
+		return printRule(inv, "global", rule)
+	}
+	rule, _, err := client.GetSchemaRuleWithContext(inv.ctx, client.NewGetSchemaRuleOptions(inv.args[0], ruleType))
+	if err != nil {
+		return err
+	}
+	return printRule(inv, inv.args[0], rule)
+}
+
+func setRule(inv *invocation) error {
+	if err := inv.parse("[<id>] <config>", 1, 2); err != nil {
+		return err
+	}
+	config := compat.Mode(strings.ToUpper(inv.args[len(inv.args)-1]))
+	if !config.Valid() {
+		return usagef("invalid config %q, use BACKWARD, BACKWARD_TRANSITIVE, FORWARD, FORWARD_TRANSITIVE, FULL, FULL_TRANSITIVE or NONE",
+			inv.args[len(inv.args)-1])
+	}
+	client, err := inv.globals.SchemaClient()
+	if err != nil {
+		return err
+	}
+	const ruleType = schemaregistryv1.RuleTypeCompatibilityConst
+	if len(inv.args) == 1 {
+		options := client.NewUpdateGlobalRuleOptions(ruleType, ruleType, string(config))
+		rule, _, err := client.UpdateGlobalRuleWithContext(inv.ctx, options)
+		if err != nil {
+			return err
+		}
+		return printRule(inv, "global", rule)
+	}
+
+	// A schema has no rule of its own until one is created, so create the rule if there is none to update.
+	id := inv.args[0]
+	rule, response, err := client.UpdateSchemaRuleWithContext(inv.ctx, client.NewUpdateSchemaRuleOptions(id, ruleType, ruleType, string(config)))
+	if notFound(response) {
+		rule, _, err = client.CreateSchemaRuleWithContext(inv.ctx, client.NewCreateSchemaRuleOptions(id, ruleType, string(config)))
+	}
+	if err != nil {
+		return err
+	}
+	return printRule(inv, id, rule)
+}
+
+func deleteRule(inv *invocation) error {
+	if err := inv.parse("<id>", 1, 1); err != nil {
+		return err
+	}
+	client, err := inv.globals.SchemaClient()
+	if err != nil {
+		return err
+	}
+	options := client.NewDeleteSchemaRuleOptions(inv.args[0], schemaregistryv1.RuleTypeCompatibilityConst)
+	if _, err = client.DeleteSchemaRuleWithContext(inv.ctx, options); err != nil {
+		return err
+	}
+	inv.done("Rule of schema %s deleted", inv.args[0])
+	return nil
+}
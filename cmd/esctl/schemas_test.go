@@ -0,0 +1,139 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Schemas commands`, func() {
+	const orderV1 = `{"type": "record", "name": "Order", "fields": [{"name": "id", "type": "string"}]}`
+	const orderV2 = `{"type": "record", "name": "Order", "fields": [{"name": "id", "type": "string"}, {"name": "total", "type": "double"}]}`
+
+	var server *fake.Server
+	var vars map[string]string
+	var dir string
+
+	BeforeEach(func() {
+		server = fake.NewServer(nil)
+		vars = map[string]string{"KAFKA_ADMIN_URL": "https://admin.example.com", "SCHEMA_REGISTRY_URL": server.URL, "API_KEY": "key"}
+		var err error
+		dir, err = ioutil.TempDir("", "esctl")
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(dir)
+	})
+
+	// writeFile writes a schema file and returns its path.
+	writeFile := func(name string, contents string) string {
+		path := filepath.Join(dir, name)
+		Expect(ioutil.WriteFile(path, []byte(contents), 0600)).To(Succeed())
+		return path
+	}
+
+	It(`Create schemas and versions, and get them back`, func() {
+		r := runWith(vars, "", "schemas", "create", "-id", "orders-value", writeFile("order.avsc", orderV1))
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(Equal("ID            VERSION  GLOBAL ID  TYPE\norders-value  1        1          AVRO\n"))
+
+		r = runWith(vars, orderV2, "schemas", "versions", "create", "orders-value", "-")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(ContainSubstring("orders-value  2        2"))
+
+		r = runWith(vars, "", "schemas", "list", "-o", "json")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(MatchJSON(`["orders-value"]`))
+
+		r = runWith(vars, "", "schemas", "versions", "list", "orders-value")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(Equal("VERSION\n1\n2\n"))
+
+		r = runWith(vars, "", "schemas", "get", "orders-value")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(MatchJSON(orderV2))
+
+		r = runWith(vars, "", "schemas", "get", "orders-value", "-version", "1")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(MatchJSON(orderV1))
+	})
+	It(`Delete versions and schemas`, func() {
+		Expect(runWith(vars, orderV1, "schemas", "create", "-id", "orders-value", "-").code).To(Equal(exitOK))
+		Expect(runWith(vars, orderV2, "schemas", "update", "orders-value", "-").code).To(Equal(exitOK))
+
+		r := runWith(vars, "", "schemas", "versions", "delete", "orders-value", "1")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(Equal("Version 1 of schema orders-value deleted\n"))
+		Expect(server.Versions("orders-value")).To(HaveLen(1))
+
+		r = runWith(vars, "", "schemas", "versions", "delete", "orders-value", "first")
+		Expect(r.code).To(Equal(exitUsage))
+
+		r = runWith(vars, "", "schemas", "delete", "orders-value")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(server.SchemaIDs()).To(BeEmpty())
+	})
+	It(`Reject files that are not JSON`, func() {
+		r := runWith(vars, "", "schemas", "create", writeFile("order.avsc", "record Order"))
+		Expect(r.code).To(Equal(exitError))
+		Expect(r.stderr).To(ContainSubstring("order.avsc is not a JSON schema document"))
+		Expect(server.RequestCount(fake.OperationCreateSchema)).To(Equal(0))
+	})
+	It(`Get and set the global and schema rules`, func() {
+		Expect(runWith(vars, orderV1, "schemas", "create", "-id", "orders-value", "-").code).To(Equal(exitOK))
+
+		r := runWith(vars, "", "schemas", "rules", "set", "backward")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(Equal("SCOPE   RULE           CONFIG\nglobal  COMPATIBILITY  BACKWARD\n"))
+		Expect(server.GlobalRule()).To(Equal("BACKWARD"))
+
+		r = runWith(vars, "", "schemas", "rules", "set", "orders-value", "FULL")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		r = runWith(vars, "", "schemas", "rules", "set", "orders-value", "FORWARD")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		rule, ok := server.SchemaRule("orders-value")
+		Expect(ok).To(BeTrue())
+		Expect(rule).To(Equal("FORWARD"))
+
+		r = runWith(vars, "", "schemas", "rules", "get", "orders-value", "-o", "yaml")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(Equal("config: FORWARD\ntype: COMPATIBILITY\n"))
+
+		r = runWith(vars, "", "schemas", "rules", "delete", "orders-value")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		_, ok = server.SchemaRule("orders-value")
+		Expect(ok).To(BeFalse())
+
+		r = runWith(vars, "", "schemas", "rules", "set", "orders-value", "LOOSE")
+		Expect(r.code).To(Equal(exitUsage))
+		Expect(r.stderr).To(ContainSubstring(`invalid config "LOOSE"`))
+	})
+	It(`Use the Admin REST API URL when there is no schema registry URL`, func() {
+		vars["KAFKA_ADMIN_URL"] = server.URL
+		delete(vars, "SCHEMA_REGISTRY_URL")
+		r := runWith(vars, "", "schemas", "list")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(Equal("ID\n"))
+	})
+})
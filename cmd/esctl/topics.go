@@ -0,0 +1,214 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"strings"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+)
+
+// topicsCommand returns the topics commands.
+func topicsCommand() *command {
+	return &command{
+		name:    "topics",
+		summary: "list, get, create, update and delete Kafka topics",
+		subcommands: []*command{
+			{name: "list", summary: "list the topics", run: listTopics},
+			{name: "get", summary: "get the details of a topic", run: getTopic},
+			{name: "create", summary: "create a topic", run: createTopic},
+			{name: "update", summary: "change the partition count or configs of a topic", run: updateTopic},
+			{name: "delete", summary: "delete a topic", run: deleteTopic},
+		},
+	}
+}
+
+// topicsTable returns the table of a list of topics.
+func topicsTable(topics []adminrestv1.TopicDetail) *table {
+	t := newTable("NAME", "PARTITIONS", "REPLICATION FACTOR", "CLEANUP POLICY", "RETENTION")
+	for _, topic := range topics {
+		t.add(formatString(topic.Name), formatInt(topic.Partitions), formatInt(topic.ReplicationFactor),
+			formatString(topic.CleanupPolicy), formatMs(topic.RetentionMs))
+	}
+	return t
+}
+
+// printTopic prints the details of a topic, with its configs in the table format.
+func printTopic(inv *invocation, topic *adminrestv1.TopicDetail) error {
+	t := topicsTable([]adminrestv1.TopicDetail{*topic})
+	t.header = append(t.header, "CONFIGS")
+	configs := "-"
+	if topic.Configs != nil {
+		configs = topic.Configs.String()
+	}
+	t.rows[0] = append(t.rows[0], configs)
+	return inv.print(topic, t)
+}
+
+// parseConfigFlags converts name=value pairs into topic configs, checking the names and values. Byte sizes may have
+// units, such as 1GiB.
+func parseConfigFlags(pairs []string) (*adminrestv1.TypedTopicConfigs, error) {
+	values := make(map[string]string)
+	for _, pair := range pairs {
+		i := strings.Index(pair, "=")
+		if i <= 0 {
+			return nil, usagef("invalid config %q, use name=value", pair)
+		}
+		values[pair[:i]] = pair[i+1:]
+	}
+	return adminrestv1.ParseTopicConfigs(values)
+}
+
+// waitForTopic waits for an operation to complete and prints the topic, if it still exists.
+func waitForTopic(inv *invocation, operation *adminrestv1.Operation) error {
+	topic, err := operation.Wait(inv.ctx)
+	if err != nil {
+		return err
+	}
+	if topic == nil {
+		inv.done("Topic %s deleted", operation.TopicName())
+		return nil
+	}
+	return printTopic(inv, topic)
+}
+
+func listTopics(inv *invocation) error {
+	filter := inv.flags.String("filter", "", "list the topics whose names match a filter, such as orders-* or /orders-.*/")
+	if err := inv.parse("", 0, 0); err != nil {
+		return err
+	}
+	client, err := inv.globals.AdminClient()
+	if err != nil {
+		return err
+	}
+	options := client.NewListAllTopicsOptions()
+	if *filter != "" {
+		options.SetTopicFilter(*filter)
+	}
+	result, err := client.ListAllTopicsWithContext(inv.ctx, options)
+	if err != nil {
+		return err
+	}
+	return inv.print(result.Topics, topicsTable(result.Topics))
+}
+
+func getTopic(inv *invocation) error {
+	if err := inv.parse("<name>", 1, 1); err != nil {
+		return err
+	}
+	client, err := inv.globals.AdminClient()
+	if err != nil {
+		return err
+	}
+	topic, _, err := client.GetTopicWithContext(inv.ctx, client.NewGetTopicOptions(inv.args[0]))
+	if err != nil {
+		return err
+	}
+	return printTopic(inv, topic)
+}
+
+func createTopic(inv *invocation) error {
+	partitions := inv.flags.Int64("partitions", 1, "the number of partitions")
+	var configs stringList
+	inv.flags.Var(&configs, "config", "set a config, as name=value; may be repeated")
+	wait := inv.flags.Bool("wait", false, "wait for the topic to be created, and print it")
+	if err := inv.parse("<name>", 1, 1); err != nil {
+		return err
+	}
+	typed, err := parseConfigFlags(configs)
+	if err != nil {
+		return err
+	}
+	client, err := inv.globals.AdminClient()
+	if err != nil {
+		return err
+	}
+	options, err := client.NewCreateTopicOptions().SetName(inv.args[0]).SetPartitions(*partitions).SetTypedConfigs(typed)
+	if err != nil {
+		return err
+	}
+	if err = options.Validate(nil); err != nil {
+		return err
+	}
+	operation, _, err := client.CreateTopicAsyncWithContext(inv.ctx, options)
+	if err != nil {
+		return err
+	}
+	if *wait {
+		return waitForTopic(inv, operation)
+	}
+	inv.done("Topic %s created", inv.args[0])
+	return nil
+}
+
+func updateTopic(inv *invocation) error {
+	partitions := inv.flags.Int64("partitions", 0, "the new total number of partitions, which must be more than the current number")
+	var configs, resets stringList
+	inv.flags.Var(&configs, "config", "set a config, as name=value; may be repeated")
+	inv.flags.Var(&resets, "reset", "reset a config to its default; may be repeated")
+	wait := inv.flags.Bool("wait", false, "wait for the changes to be applied, and print the topic")
+	if err := inv.parse("<name>", 1, 1); err != nil {
+		return err
+	}
+	typed, err := parseConfigFlags(configs)
+	if err != nil {
+		return err
+	}
+	client, err := inv.globals.AdminClient()
+	if err != nil {
+		return err
+	}
+	options, err := client.NewUpdateTopicOptions(inv.args[0]).SetTypedConfigs(typed, resets...)
+	if err != nil {
+		return err
+	}
+	if *partitions != 0 {
+		options.SetNewTotalPartitionCount(*partitions)
+	}
+	if err = options.Validate(nil); err != nil {
+		return err
+	}
+	operation, _, err := client.UpdateTopicAsyncWithContext(inv.ctx, options)
+	if err != nil {
+		return err
+	}
+	if *wait {
+		return waitForTopic(inv, operation)
+	}
+	inv.done("Topic %s updated", inv.args[0])
+	return nil
+}
+
+func deleteTopic(inv *invocation) error {
+	wait := inv.flags.Bool("wait", false, "wait for the topic to be deleted")
+	if err := inv.parse("<name>", 1, 1); err != nil {
+		return err
+	}
+	client, err := inv.globals.AdminClient()
+	if err != nil {
+		return err
+	}
+	operation, _, err := client.DeleteTopicAsyncWithContext(inv.ctx, client.NewDeleteTopicOptions(inv.args[0]))
+	if err != nil {
+		return err
+	}
+	if *wait {
+		return waitForTopic(inv, operation)
+	}
+	inv.done("Topic %s deleted", inv.args[0])
+	return nil
+}
@@ -0,0 +1,96 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1/fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Topics commands`, func() {
+	var server *fake.Server
+	var vars map[string]string
+
+	BeforeEach(func() {
+		server = fake.NewServer(nil)
+		server.AddTopic(fake.Topic{Name: "orders", Partitions: 3, Configs: map[string]string{"retention.ms": "-1"}})
+		server.AddTopic(fake.Topic{Name: "payments"})
+		vars = map[string]string{"KAFKA_ADMIN_URL": server.URL, "BEARER_TOKEN": "token"}
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`List topics matching a filter`, func() {
+		r := runWith(vars, "", "topics", "list", "-filter", "pay*")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(ContainSubstring("payments"))
+		Expect(r.stdout).ToNot(ContainSubstring("orders"))
+	})
+	It(`Get a topic with its configs`, func() {
+		r := runWith(vars, "", "topics", "get", "orders")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(ContainSubstring("orders  3           3                   delete          -1         cleanup.policy=delete"))
+		Expect(r.stdout).To(ContainSubstring("retention.bytes=1GiB"))
+	})
+	It(`Create a topic with configs and wait for it`, func() {
+		r := runWith(vars, "", "topics", "create", "audit", "-partitions", "6",
+			"-config", "retention.bytes=512MiB", "-config", "cleanup.policy=compact", "-wait", "-o", "json")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(ContainSubstring(`"partitions": 6`))
+
+		topic, ok := server.Topic("audit")
+		Expect(ok).To(BeTrue())
+		Expect(topic.Partitions).To(Equal(int64(6)))
+		Expect(topic.Configs).To(Equal(map[string]string{"cleanup.policy": "compact", "retention.bytes": "536870912"}))
+	})
+	It(`Check a topic before creating it`, func() {
+		r := runWith(vars, "", "topics", "create", "bad..name", "-config", "retention.bytes=10M")
+		Expect(r.code).To(Equal(exitError))
+		Expect(r.stderr).To(ContainSubstring("retention.bytes"))
+		Expect(server.RequestCount(fake.OperationCreateTopic)).To(Equal(0))
+
+		r = runWith(vars, "", "topics", "create", "-partitions", "0", "audit")
+		Expect(r.code).To(Equal(exitError))
+		Expect(r.stderr).To(ContainSubstring("partitions: must be at least 1, not 0"))
+		Expect(server.RequestCount(fake.OperationCreateTopic)).To(Equal(0))
+
+		r = runWith(vars, "", "topics", "create", "audit", "-config", "retention")
+		Expect(r.code).To(Equal(exitUsage))
+		Expect(r.stderr).To(Equal("esctl topics create: invalid config \"retention\", use name=value\n"))
+	})
+	It(`Update and reset topic configs`, func() {
+		r := runWith(vars, "", "topics", "update", "orders", "-partitions", "4", "-config", "segment.bytes=256MiB", "-reset", "retention.ms")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(Equal("Topic orders updated\n"))
+
+		topic, _ := server.Topic("orders")
+		Expect(topic.Partitions).To(Equal(int64(4)))
+		Expect(topic.Configs).To(Equal(map[string]string{"segment.bytes": "268435456"}))
+
+		r = runWith(vars, "", "topics", "update", "orders")
+		Expect(r.code).To(Equal(exitError))
+		Expect(server.RequestCount(fake.OperationUpdateTopic)).To(Equal(1))
+	})
+	It(`Delete a topic and wait for it to go`, func() {
+		r := runWith(vars, "", "topics", "delete", "payments", "-wait")
+		Expect(r.code).To(Equal(exitOK), r.stderr)
+		Expect(r.stdout).To(Equal("Topic payments deleted\n"))
+		Expect(server.TopicNames()).To(Equal([]string{"orders"}))
+	})
+})
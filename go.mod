@@ -5,7 +5,10 @@ go 1.13
 require (
 	github.com/IBM/go-sdk-core/v5 v5.6.3
 	github.com/go-openapi/strfmt v0.20.1
+	github.com/hashicorp/go-retryablehttp v0.6.6
 	github.com/onsi/ginkgo v1.16.4
 	github.com/onsi/gomega v1.13.0
+	github.com/stretchr/objx v0.3.0 // indirect
 	github.com/stretchr/testify v1.7.0
+	gopkg.in/yaml.v2 v2.4.0
 )
@@ -0,0 +1,113 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package cli : Code shared by the command-line tools in the cmd directory.
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Connection : The -url, -schema-url, -api-key and -bearer-token flags of a command, from which the clients are
+// created.
+type Connection struct {
+	URL         string
+	SchemaURL   string
+	APIKey      string
+	BearerToken string
+}
+
+// Authenticator : Return the authenticator for -api-key or -bearer-token, or nil when neither is set and the
+// authenticator should come from the external configuration of the service.
+func (connection *Connection) Authenticator() (core.Authenticator, error) {
+	switch {
+	case connection.APIKey != "" && connection.BearerToken != "":
+		return nil, errors.New("set either an API key or a bearer token, not both")
+	case connection.APIKey != "":
+		return core.NewBasicAuthenticator("token", connection.APIKey)
+	case connection.BearerToken != "":
+		return core.NewBearerTokenAuthenticator(connection.BearerToken)
+	}
+	return nil, nil
+}
+
+// AdminClient : Return a client for the Admin REST API. Without an API key or bearer token, the authenticator and
+// URL are read from the external configuration of the adminrest service, such as the ADMINREST_AUTH_TYPE and
+// ADMINREST_URL environment variables.
+func (connection *Connection) AdminClient() (*adminrestv1.AdminrestV1, error) {
+	authenticator, err := connection.Authenticator()
+	if err != nil {
+		return nil, err
+	}
+	options := &adminrestv1.AdminrestV1Options{
+		URL:           connection.URL,
+		Authenticator: authenticator,
+	}
+	var client *adminrestv1.AdminrestV1
+	if authenticator != nil {
+		client, err = adminrestv1.NewAdminrestV1(options)
+	} else {
+		client, err = adminrestv1.NewAdminrestV1UsingExternalConfig(options)
+	}
+	if err != nil {
+		return nil, credentialsError(adminrestv1.DefaultServiceName, err)
+	}
+	if client.GetServiceURL() == adminrestv1.DefaultServiceURL && connection.URL == "" {
+		return nil, errors.New("no Admin REST API URL: set -url, KAFKA_ADMIN_URL or ADMINREST_URL")
+	}
+	return client, nil
+}
+
+// SchemaClient : Return a client for the schema registry, which uses -schema-url, or -url when it is not set.
+// Without an API key or bearer token, the authenticator and URL are read from the external configuration of the
+// schemaregistry service.
+func (connection *Connection) SchemaClient() (*schemaregistryv1.SchemaregistryV1, error) {
+	authenticator, err := connection.Authenticator()
+	if err != nil {
+		return nil, err
+	}
+	options := &schemaregistryv1.SchemaregistryV1Options{
+		URL:           connection.SchemaURL,
+		Authenticator: authenticator,
+	}
+	if options.URL == "" {
+		options.URL = connection.URL
+	}
+	var client *schemaregistryv1.SchemaregistryV1
+	if authenticator != nil {
+		client, err = schemaregistryv1.NewSchemaregistryV1(options)
+	} else {
+		client, err = schemaregistryv1.NewSchemaregistryV1UsingExternalConfig(options)
+	}
+	if err != nil {
+		return nil, credentialsError(schemaregistryv1.DefaultServiceName, err)
+	}
+	if client.GetServiceURL() == "" {
+		return nil, errors.New("no schema registry URL: set -schema-url, -url, SCHEMA_REGISTRY_URL or SCHEMAREGISTRY_URL")
+	}
+	return client, nil
+}
+
+// credentialsError explains how to supply credentials when a client cannot be created.
+func credentialsError(serviceName string, err error) error {
+	return fmt.Errorf("cannot create the %s client, set -api-key, -bearer-token or the external configuration of the service: %w",
+		serviceName, err)
+}
@@ -0,0 +1,67 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cli
+
+import (
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Connection`, func() {
+	It(`Authenticate with an API key or a bearer token`, func() {
+		authenticator, err := (&Connection{APIKey: "secret"}).Authenticator()
+		Expect(err).To(BeNil())
+		Expect(authenticator.AuthenticationType()).To(Equal(core.AUTHTYPE_BASIC))
+
+		authenticator, err = (&Connection{BearerToken: "token"}).Authenticator()
+		Expect(err).To(BeNil())
+		Expect(authenticator.AuthenticationType()).To(Equal(core.AUTHTYPE_BEARER_TOKEN))
+
+		authenticator, err = (&Connection{}).Authenticator()
+		Expect(err).To(BeNil())
+		Expect(authenticator).To(BeNil())
+
+		_, err = (&Connection{APIKey: "secret", BearerToken: "token"}).Authenticator()
+		Expect(err).To(MatchError("set either an API key or a bearer token, not both"))
+	})
+	It(`Create the Admin REST API client`, func() {
+		client, err := (&Connection{URL: "https://admin.example.com", APIKey: "secret"}).AdminClient()
+		Expect(err).To(BeNil())
+		Expect(client.GetServiceURL()).To(Equal("https://admin.example.com"))
+
+		_, err = (&Connection{APIKey: "secret"}).AdminClient()
+		Expect(err).To(MatchError("no Admin REST API URL: set -url, KAFKA_ADMIN_URL or ADMINREST_URL"))
+	})
+	It(`Create the schema registry client from -schema-url or -url`, func() {
+		client, err := (&Connection{URL: "https://admin.example.com", SchemaURL: "https://registry.example.com", APIKey: "secret"}).SchemaClient()
+		Expect(err).To(BeNil())
+		Expect(client.GetServiceURL()).To(Equal("https://registry.example.com"))
+
+		client, err = (&Connection{URL: "https://admin.example.com", APIKey: "secret"}).SchemaClient()
+		Expect(err).To(BeNil())
+		Expect(client.GetServiceURL()).To(Equal("https://admin.example.com"))
+
+		_, err = (&Connection{APIKey: "secret"}).SchemaClient()
+		Expect(err).To(MatchError("no schema registry URL: set -schema-url, -url, SCHEMA_REGISTRY_URL or SCHEMAREGISTRY_URL"))
+	})
+	It(`Explain how to supply credentials`, func() {
+		_, err := (&Connection{URL: "https://admin.example.com"}).AdminClient()
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(HavePrefix("cannot create the adminrest client, set -api-key, -bearer-token or the external configuration of the service: "))
+	})
+})
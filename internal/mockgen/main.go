@@ -0,0 +1,305 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Command mockgen : Generates the testify mocks of the service API interfaces
+//
+// mockgen reads an interface from a Go source file and writes a mock of it, in the form that mockery writes, to the
+// output file. It is run by go generate in the mocks packages:
+//
+//	go run github.com/IBM/eventstreams-go-sdk/internal/mockgen -source ../api.go -interface API \
+//		-import github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1 -output api.go
+//
+// It is part of the module, so go generate needs no tool to be installed, and it only needs the standard library.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// header is the license header of the generated files.
+const header = `/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+`
+
+func main() {
+	source := flag.String("source", "", "the Go source file that declares the interface")
+	name := flag.String("interface", "API", "the name of the interface")
+	importPath := flag.String("import", "", "the import path of the package of the source file")
+	output := flag.String("output", "", "the file to write the mock to")
+	flag.Parse()
+	if *source == "" || *importPath == "" || *output == "" {
+		fmt.Fprintln(os.Stderr, "mockgen: -source, -import and -output are required")
+		os.Exit(2)
+	}
+
+	mock, err := generate(*source, *name, *importPath)
+	if err == nil {
+		err = ioutil.WriteFile(*output, mock, 0644)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "mockgen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// generate returns the formatted source of a mock of the interface declared in the source file.
+func generate(source, name, importPath string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, source, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	iface := findInterface(file, name)
+	if iface == nil {
+		return nil, fmt.Errorf("%s does not declare the interface %s", source, name)
+	}
+
+	g := &generator{
+		pkg:      file.Name.Name,
+		imports:  fileImports(file),
+		used:     map[string]string{file.Name.Name: importPath},
+		mockType: name,
+	}
+	var methods bytes.Buffer
+	for _, method := range iface.Methods.List {
+		funcType, ok := method.Type.(*ast.FuncType)
+		if !ok || len(method.Names) != 1 {
+			return nil, fmt.Errorf("%s: the interface %s embeds another interface", fset.Position(method.Pos()), name)
+		}
+		if err := g.method(&methods, method.Names[0].Name, funcType); err != nil {
+			return nil, fmt.Errorf("%s: %w", fset.Position(method.Pos()), err)
+		}
+	}
+
+	var out bytes.Buffer
+	out.WriteString(header)
+	out.WriteString("\n// Code generated by internal/mockgen. DO NOT EDIT.\n\npackage mocks\n\nimport (\n\t\"context\"\n\n")
+	paths := []string{"github.com/stretchr/testify/mock"}
+	for _, importPath := range g.used {
+		if importPath != "context" {
+			paths = append(paths, importPath)
+		}
+	}
+	sort.Strings(paths)
+	for _, importPath := range paths {
+		fmt.Fprintf(&out, "\t%q\n", importPath)
+	}
+	out.WriteString(")\n\n")
+	fmt.Fprintf(&out, "// %s : A mock implementation of %s.%s.\n", name, g.pkg, name)
+	fmt.Fprintf(&out, "type %s struct {\n\tmock.Mock\n}\n\n", name)
+	fmt.Fprintf(&out, "// %s must implement every method of %s.%s.\n", name, g.pkg, name)
+	fmt.Fprintf(&out, "var _ %s.%s = (*%s)(nil)\n\n", g.pkg, name, name)
+	fmt.Fprintf(&out, `// New%[1]s : constructs a mock %[1]s that fails the test on unexpected calls and asserts the expected calls were made
+// when the test finishes.
+func New%[1]s(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *%[1]s {
+	api := &%[1]s{}
+	api.Mock.Test(t)
+	t.Cleanup(func() { api.AssertExpectations(t) })
+	return api
+}
+`, name)
+	out.Write(methods.Bytes())
+	return format.Source(out.Bytes())
+}
+
+// findInterface returns the interface type declared with the name in the file, or nil.
+func findInterface(file *ast.File, name string) *ast.InterfaceType {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if iface, ok := typeSpec.Type.(*ast.InterfaceType); ok && typeSpec.Name.Name == name {
+				return iface
+			}
+		}
+	}
+	return nil
+}
+
+// fileImports returns the import paths of the file by the name they are used with.
+func fileImports(file *ast.File) map[string]string {
+	imports := map[string]string{}
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		name := path.Base(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = importPath
+	}
+	return imports
+}
+
+// generator : The state of the generation of a mock.
+type generator struct {
+	// pkg is the name of the package of the interface, which qualifies the types it declares.
+	pkg string
+
+	// imports are the imports of the source file, and used are those that the mock needs, by name.
+	imports map[string]string
+	used    map[string]string
+
+	mockType string
+}
+
+// param : A parameter or result of a method.
+type param struct {
+	name     string
+	typeName string
+}
+
+// method writes the mock method.
+func (g *generator) method(out *bytes.Buffer, name string, funcType *ast.FuncType) error {
+	params, err := g.fields(funcType.Params)
+	if err != nil {
+		return err
+	}
+	results, err := g.fields(funcType.Results)
+	if err != nil {
+		return err
+	}
+
+	var names, paramTypes, resultTypes, declared []string
+	for _, p := range params {
+		names = append(names, p.name)
+		paramTypes = append(paramTypes, p.typeName)
+		declared = append(declared, p.name+" "+p.typeName)
+	}
+	for _, r := range results {
+		resultTypes = append(resultTypes, r.typeName)
+	}
+	resultList := strings.Join(resultTypes, ", ")
+	if len(resultTypes) > 1 {
+		resultList = "(" + resultList + ")"
+	}
+
+	fmt.Fprintf(out, "\n// %s provides a mock function with given fields: %s\n", name, strings.Join(names, ", "))
+	fmt.Fprintf(out, "func (_m *%s) %s(%s) %s {\n", g.mockType, name, strings.Join(declared, ", "), resultList)
+	if len(results) == 0 {
+		fmt.Fprintf(out, "\t_m.Called(%s)\n}\n", strings.Join(names, ", "))
+		return nil
+	}
+	fmt.Fprintf(out, "\tret := _m.Called(%s)\n", strings.Join(names, ", "))
+
+	var returned []string
+	for i, r := range results {
+		variable := fmt.Sprintf("r%d", i)
+		returned = append(returned, variable)
+		fmt.Fprintf(out, "\n\tvar %s %s\n", variable, r.typeName)
+		fmt.Fprintf(out, "\tif rf, ok := ret.Get(%d).(func(%s) %s); ok {\n", i, strings.Join(paramTypes, ", "), r.typeName)
+		fmt.Fprintf(out, "\t\t%s = rf(%s)\n", variable, strings.Join(names, ", "))
+		fmt.Fprintf(out, "\t} else if ret.Get(%d) != nil {\n", i)
+		fmt.Fprintf(out, "\t\t%s = ret.Get(%d).(%s)\n\t}\n", variable, i, r.typeName)
+	}
+	fmt.Fprintf(out, "\n\treturn %s\n}\n", strings.Join(returned, ", "))
+	return nil
+}
+
+// fields returns the parameters or results in the field list, naming unnamed parameters after their position.
+func (g *generator) fields(list *ast.FieldList) ([]param, error) {
+	var params []param
+	if list == nil {
+		return params, nil
+	}
+	for _, field := range list.List {
+		if _, ok := field.Type.(*ast.Ellipsis); ok {
+			return nil, errors.New("variadic methods are not supported")
+		}
+		typeName, err := g.typeName(field.Type)
+		if err != nil {
+			return nil, err
+		}
+		if len(field.Names) == 0 {
+			params = append(params, param{name: fmt.Sprintf("_a%d", len(params)), typeName: typeName})
+		}
+		for _, name := range field.Names {
+			params = append(params, param{name: name.Name, typeName: typeName})
+		}
+	}
+	return params, nil
+}
+
+// typeName returns a type as written in the mocks package, in which the types of the source package are qualified.
+func (g *generator) typeName(expr ast.Expr) (string, error) {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		if types.Universe.Lookup(expr.Name) != nil {
+			return expr.Name, nil
+		}
+		return g.pkg + "." + expr.Name, nil
+	case *ast.SelectorExpr:
+		pkg, ok := expr.X.(*ast.Ident)
+		if !ok || g.imports[pkg.Name] == "" {
+			return "", fmt.Errorf("unknown package in %s", types.ExprString(expr))
+		}
+		g.used[pkg.Name] = g.imports[pkg.Name]
+		return pkg.Name + "." + expr.Sel.Name, nil
+	case *ast.StarExpr:
+		elem, err := g.typeName(expr.X)
+		return "*" + elem, err
+	case *ast.ArrayType:
+		if expr.Len != nil {
+			return "", fmt.Errorf("arrays such as %s are not supported", types.ExprString(expr))
+		}
+		elem, err := g.typeName(expr.Elt)
+		return "[]" + elem, err
+	case *ast.MapType:
+		key, err := g.typeName(expr.Key)
+		if err != nil {
+			return "", err
+		}
+		value, err := g.typeName(expr.Value)
+		return "map[" + key + "]" + value, err
+	case *ast.InterfaceType:
+		if len(expr.Methods.List) == 0 {
+			return "interface{}", nil
+		}
+	}
+	return "", fmt.Errorf("the type %s is not supported", types.ExprString(expr))
+}
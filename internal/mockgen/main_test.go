@@ -0,0 +1,42 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`mockgen`, func() {
+	It(`Generate the mocks that are committed`, func() {
+		for _, pkg := range []string{"adminrestv1", "schemaregistryv1"} {
+			dir := filepath.Join("..", "..", "pkg", pkg)
+			mock, err := generate(filepath.Join(dir, "api.go"), "API", "github.com/IBM/eventstreams-go-sdk/pkg/"+pkg)
+			Expect(err).To(BeNil())
+			committed, err := ioutil.ReadFile(filepath.Join(dir, "mocks", "api.go"))
+			Expect(err).To(BeNil())
+			Expect(string(mock)).To(Equal(string(committed)), "run go generate in pkg/%s/mocks", pkg)
+		}
+	})
+	It(`Report an interface that is not declared`, func() {
+		_, err := generate(filepath.Join("..", "..", "pkg", "adminrestv1", "api.go"), "Missing", "example.com/adminrestv1")
+		Expect(err).To(MatchError(`../../pkg/adminrestv1/api.go does not declare the interface Missing`))
+	})
+})
@@ -30,6 +30,7 @@ import (
 	"time"
 
 	"github.com/IBM/eventstreams-go-sdk/pkg/common"
+	"github.com/IBM/eventstreams-go-sdk/pkg/middleware"
 	"github.com/IBM/go-sdk-core/v5/core"
 )
 
@@ -38,6 +39,8 @@ import (
 // Version: 1.1.1
 type AdminrestV1 struct {
 	Service *core.BaseService
+
+	middlewares []middleware.Middleware
 }
 
 // DefaultServiceURL is the default URL to make service requests to.
@@ -51,6 +54,9 @@ type AdminrestV1Options struct {
 	ServiceName   string
 	URL           string
 	Authenticator core.Authenticator
+
+	// The middlewares that every request runs through, in order.
+	Middlewares []middleware.Middleware
 }
 
 // NewAdminrestV1UsingExternalConfig : constructs an instance of AdminrestV1 with passed in options and external configuration.
@@ -102,7 +108,8 @@ func NewAdminrestV1(options *AdminrestV1Options) (service *AdminrestV1, err erro
 	}
 
 	service = &AdminrestV1{
-		Service: baseService,
+		Service:     baseService,
+		middlewares: middleware.Append(nil, options.Middlewares...),
 	}
 
 	return
@@ -150,8 +157,10 @@ func (adminrest *AdminrestV1) GetEnableGzipCompression() bool {
 
 // EnableRetries enables automatic retries for requests invoked for this service instance.
 // If either parameter is specified as 0, then a default value is used instead.
+// The attempts made for each request are reported to middlewares in Call.Attempts.
 func (adminrest *AdminrestV1) EnableRetries(maxRetries int, maxRetryInterval time.Duration) {
 	adminrest.Service.EnableRetries(maxRetries, maxRetryInterval)
+	middleware.CountAttempts(adminrest.Service.Client)
 }
 
 // DisableRetries disables automatic retries for requests invoked for this service instance.
@@ -218,7 +227,7 @@ func (adminrest *AdminrestV1) CreateTopicWithContext(ctx context.Context, create
 		return
 	}
 
-	response, err = adminrest.Service.Request(request, nil)
+	response, err = adminrest.request("CreateTopic", request, nil)
 
 	return
 }
@@ -271,9 +280,8 @@ func (adminrest *AdminrestV1) ListTopicsWithContext(ctx context.Context, listTop
 	}
 
 	var rawResponse []json.RawMessage
-	response, err = adminrest.Service.Request(request, &rawResponse)
+	response, err = adminrest.request("ListTopics", request, &rawResponse)
 	if err != nil {
-		err = fmt.Errorf("error: %v, %v", err.Error(), response)
 		return
 	}
 	if rawResponse != nil {
@@ -332,7 +340,7 @@ func (adminrest *AdminrestV1) GetTopicWithContext(ctx context.Context, getTopicO
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = adminrest.Service.Request(request, &rawResponse)
+	response, err = adminrest.request("GetTopic", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -391,7 +399,7 @@ func (adminrest *AdminrestV1) DeleteTopicWithContext(ctx context.Context, delete
 		return
 	}
 
-	response, err = adminrest.Service.Request(request, nil)
+	response, err = adminrest.request("DeleteTopic", request, nil)
 
 	return
 }
@@ -453,7 +461,7 @@ func (adminrest *AdminrestV1) UpdateTopicWithContext(ctx context.Context, update
 		return
 	}
 
-	response, err = adminrest.Service.Request(request, nil)
+	response, err = adminrest.request("UpdateTopic", request, nil)
 
 	return
 }
@@ -495,7 +503,7 @@ func (adminrest *AdminrestV1) GetMirroringTopicSelectionWithContext(ctx context.
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = adminrest.Service.Request(request, &rawResponse)
+	response, err = adminrest.request("GetMirroringTopicSelection", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -561,7 +569,7 @@ func (adminrest *AdminrestV1) ReplaceMirroringTopicSelectionWithContext(ctx cont
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = adminrest.Service.Request(request, &rawResponse)
+	response, err = adminrest.request("ReplaceMirroringTopicSelection", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -613,7 +621,7 @@ func (adminrest *AdminrestV1) GetMirroringActiveTopicsWithContext(ctx context.Co
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = adminrest.Service.Request(request, &rawResponse)
+	response, err = adminrest.request("GetMirroringActiveTopics", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -1191,7 +1199,7 @@ func (adminrest *AdminrestV1) CreateQuotaWithContext(ctx context.Context, create
 		return
 	}
 
-	response, err = adminrest.Service.Request(request, nil)
+	response, err = adminrest.request("CreateQuota", request, nil)
 
 	return
 }
@@ -1252,7 +1260,7 @@ func (adminrest *AdminrestV1) UpdateQuotaWithContext(ctx context.Context, update
 		return
 	}
 
-	response, err = adminrest.Service.Request(request, nil)
+	response, err = adminrest.request("UpdateQuota", request, nil)
 
 	return
 }
@@ -1300,7 +1308,7 @@ func (adminrest *AdminrestV1) DeleteQuotaWithContext(ctx context.Context, delete
 		return
 	}
 
-	response, err = adminrest.Service.Request(request, nil)
+	response, err = adminrest.request("DeleteQuota", request, nil)
 
 	return
 }
@@ -1350,7 +1358,7 @@ func (adminrest *AdminrestV1) GetQuotaWithContext(ctx context.Context, getQuotaO
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = adminrest.Service.Request(request, &rawResponse)
+	response, err = adminrest.request("GetQuota", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -1402,7 +1410,7 @@ func (adminrest *AdminrestV1) ListQuotasWithContext(ctx context.Context, listQuo
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = adminrest.Service.Request(request, &rawResponse)
+	response, err = adminrest.request("ListQuotas", request, &rawResponse)
 	if err != nil {
 		return
 	}
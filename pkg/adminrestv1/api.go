@@ -0,0 +1,57 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminrestv1
+
+import (
+	"context"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// API : The operations of the Admin REST API, in the form that takes a Context.
+// AdminrestV1 implements API. Code that depends on API rather than on *AdminrestV1 can be tested with the mocks
+// package, or with any other implementation.
+type API interface {
+	// Topics
+	CreateTopicWithContext(ctx context.Context, createTopicOptions *CreateTopicOptions) (response *core.DetailedResponse, err error)
+	ListTopicsWithContext(ctx context.Context, listTopicsOptions *ListTopicsOptions) (result []TopicDetail, response *core.DetailedResponse, err error)
+	ListAllTopicsWithContext(ctx context.Context, listAllTopicsOptions *ListAllTopicsOptions) (result *TopicList, err error)
+	GetTopicWithContext(ctx context.Context, getTopicOptions *GetTopicOptions) (result *TopicDetail, response *core.DetailedResponse, err error)
+	UpdateTopicWithContext(ctx context.Context, updateTopicOptions *UpdateTopicOptions) (response *core.DetailedResponse, err error)
+	DeleteTopicWithContext(ctx context.Context, deleteTopicOptions *DeleteTopicOptions) (response *core.DetailedResponse, err error)
+	CreateTopicAsyncWithContext(ctx context.Context, createTopicOptions *CreateTopicOptions) (operation *Operation, response *core.DetailedResponse, err error)
+	UpdateTopicAsyncWithContext(ctx context.Context, updateTopicOptions *UpdateTopicOptions) (operation *Operation, response *core.DetailedResponse, err error)
+	DeleteTopicAsyncWithContext(ctx context.Context, deleteTopicOptions *DeleteTopicOptions) (operation *Operation, response *core.DetailedResponse, err error)
+
+	// Mirroring
+	GetMirroringTopicSelectionWithContext(ctx context.Context, getMirroringTopicSelectionOptions *GetMirroringTopicSelectionOptions) (result *MirroringTopicSelection, response *core.DetailedResponse, err error)
+	ReplaceMirroringTopicSelectionWithContext(ctx context.Context, replaceMirroringTopicSelectionOptions *ReplaceMirroringTopicSelectionOptions) (result *MirroringTopicSelection, response *core.DetailedResponse, err error)
+	AddMirroringPatternsWithContext(ctx context.Context, addMirroringPatternsOptions *AddMirroringPatternsOptions) (result *MirroringTopicSelection, err error)
+	RemoveMirroringPatternsWithContext(ctx context.Context, removeMirroringPatternsOptions *RemoveMirroringPatternsOptions) (result *MirroringTopicSelection, err error)
+	GetMirroringActiveTopicsWithContext(ctx context.Context, getMirroringActiveTopicsOptions *GetMirroringActiveTopicsOptions) (result *MirroringActiveTopics, response *core.DetailedResponse, err error)
+
+	// Quotas
+	CreateQuotaWithContext(ctx context.Context, createQuotaOptions *CreateQuotaOptions) (response *core.DetailedResponse, err error)
+	UpdateQuotaWithContext(ctx context.Context, updateQuotaOptions *UpdateQuotaOptions) (response *core.DetailedResponse, err error)
+	UpsertQuotaWithContext(ctx context.Context, upsertQuotaOptions *UpsertQuotaOptions) (created bool, response *core.DetailedResponse, err error)
+	DeleteQuotaWithContext(ctx context.Context, deleteQuotaOptions *DeleteQuotaOptions) (response *core.DetailedResponse, err error)
+	GetQuotaWithContext(ctx context.Context, getQuotaOptions *GetQuotaOptions) (result *QuotaDetail, response *core.DetailedResponse, err error)
+	ListQuotasWithContext(ctx context.Context, listQuotasOptions *ListQuotasOptions) (result *EntityQuotasList, response *core.DetailedResponse, err error)
+}
+
+// AdminrestV1 must implement every method of API.
+var _ API = (*AdminrestV1)(nil)
@@ -0,0 +1,271 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminrestv1
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// ByteSize : A number of bytes.
+//
+// ParseByteSize reads sizes such as "512KB" and "1GiB", and String formats them the same way. Units ending in "B" are
+// powers of 1000 and units ending in "iB" are powers of 1024, so "1MB" is 1000000 bytes and "1MiB" is 1048576 bytes.
+type ByteSize int64
+
+// Units of ByteSize.
+const (
+	Byte ByteSize = 1
+
+	Kilobyte ByteSize = 1000 * Byte
+	Megabyte ByteSize = 1000 * Kilobyte
+	Gigabyte ByteSize = 1000 * Megabyte
+	Terabyte ByteSize = 1000 * Gigabyte
+
+	Kibibyte ByteSize = 1024 * Byte
+	Mebibyte ByteSize = 1024 * Kibibyte
+	Gibibyte ByteSize = 1024 * Mebibyte
+	Tebibyte ByteSize = 1024 * Gibibyte
+)
+
+// byteUnit associates a unit symbol with its size.
+type byteUnit struct {
+	symbol string
+	size   ByteSize
+}
+
+// binaryByteUnits and decimalByteUnits list the units used by String, largest first.
+var (
+	binaryByteUnits  = []byteUnit{{"TiB", Tebibyte}, {"GiB", Gibibyte}, {"MiB", Mebibyte}, {"KiB", Kibibyte}}
+	decimalByteUnits = []byteUnit{{"TB", Terabyte}, {"GB", Gigabyte}, {"MB", Megabyte}, {"KB", Kilobyte}}
+)
+
+// byteUnits maps each unit symbol accepted by ParseByteSize to its size.
+var byteUnits = map[string]ByteSize{
+	"":    Byte,
+	"B":   Byte,
+	"kB":  Kilobyte,
+	"KB":  Kilobyte,
+	"MB":  Megabyte,
+	"GB":  Gigabyte,
+	"TB":  Terabyte,
+	"KiB": Kibibyte,
+	"MiB": Mebibyte,
+	"GiB": Gibibyte,
+	"TiB": Tebibyte,
+}
+
+// ParseByteSize : parses a size such as "512", "512B", "512KB", "1.5MiB" or "1GiB". A number without a unit is a
+// number of bytes. Units without a "B", such as "M", are refused because they do not say whether they are powers of
+// 1000 or of 1024.
+func ParseByteSize(text string) (ByteSize, error) {
+	size, reason := parseByteSize(text)
+	if reason != "" {
+		return 0, fmt.Errorf("invalid byte size %q: %s", text, reason)
+	}
+	return size, nil
+}
+
+// parseByteSize parses a size, returning the reason if it is not valid.
+func parseByteSize(text string) (ByteSize, string) {
+	trimmed := strings.TrimSpace(text)
+	end := 0
+	for end < len(trimmed) && (trimmed[end] == '-' || trimmed[end] == '+' || trimmed[end] == '.' ||
+		(trimmed[end] >= '0' && trimmed[end] <= '9')) {
+		end++
+	}
+	number, symbol := trimmed[:end], strings.TrimSpace(trimmed[end:])
+	if number == "" {
+		return 0, "expected a number"
+	}
+	unit, ok := byteUnits[symbol]
+	if !ok {
+		return 0, fmt.Sprintf("unknown unit %q, use KB, MB, GB or TB for powers of 1000 "+
+			"or KiB, MiB, GiB or TiB for powers of 1024", symbol)
+	}
+
+	if whole, err := strconv.ParseInt(number, 10, 64); err == nil {
+		if whole > math.MaxInt64/int64(unit) || whole < math.MinInt64/int64(unit) {
+			return 0, "too large"
+		}
+		return ByteSize(whole) * unit, ""
+	}
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, "expected a number"
+	}
+	bytes := value * float64(unit)
+	if bytes >= math.MaxInt64 || bytes <= math.MinInt64 {
+		return 0, "too large"
+	}
+	if bytes != math.Trunc(bytes) {
+		return 0, "not a whole number of bytes"
+	}
+	return ByteSize(bytes), ""
+}
+
+// String formats the size with the largest unit that divides it exactly, preferring units that are powers of 1024,
+// for example "512KiB", "1MB" or "1500B".
+func (size ByteSize) String() string {
+	if size != 0 {
+		for _, units := range [][]byteUnit{binaryByteUnits, decimalByteUnits} {
+			for _, unit := range units {
+				if size%unit.size == 0 {
+					return strconv.FormatInt(int64(size/unit.size), 10) + unit.symbol
+				}
+			}
+		}
+	}
+	return strconv.FormatInt(int64(size), 10) + "B"
+}
+
+// MarshalText implements encoding.TextMarshaler, so sizes are written to JSON and YAML documents in the form of
+// String.
+func (size ByteSize) MarshalText() ([]byte, error) {
+	return []byte(size.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting any form read by ParseByteSize.
+func (size *ByteSize) UnmarshalText(text []byte) error {
+	parsed, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+	*size = parsed
+	return nil
+}
+
+// ByteRate : A number of bytes per second, as used by the producer and consumer byte rate quotas.
+type ByteRate int64
+
+// byteRateSuffix ends the text form of a ByteRate.
+const byteRateSuffix = "/s"
+
+// ParseByteRate : parses a rate such as "10MiB/s" or "512KB/s". The "/s" suffix is optional, and the size is read as
+// ParseByteSize reads it.
+func ParseByteRate(text string) (ByteRate, error) {
+	size, reason := parseByteSize(strings.TrimSuffix(strings.TrimSpace(text), byteRateSuffix))
+	if reason != "" {
+		return 0, fmt.Errorf("invalid byte rate %q: %s", text, reason)
+	}
+	return ByteRate(size), nil
+}
+
+// String formats the rate as a ByteSize followed by "/s", for example "10MiB/s".
+func (rate ByteRate) String() string {
+	return ByteSize(rate).String() + byteRateSuffix
+}
+
+// MarshalText implements encoding.TextMarshaler, so rates are written to JSON and YAML documents in the form of
+// String.
+func (rate ByteRate) MarshalText() ([]byte, error) {
+	return []byte(rate.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting any form read by ParseByteRate.
+func (rate *ByteRate) UnmarshalText(text []byte) error {
+	parsed, err := ParseByteRate(string(text))
+	if err != nil {
+		return err
+	}
+	*rate = parsed
+	return nil
+}
+
+// SetProducerByteRateString : Allow user to set ProducerByteRate from a rate such as "10MiB/s", as read by ParseByteRate
+func (_options *CreateQuotaOptions) SetProducerByteRateString(producerByteRate string) (*CreateQuotaOptions, error) {
+	rate, err := ParseByteRate(producerByteRate)
+	if err != nil {
+		return _options, err
+	}
+	_options.ProducerByteRate = core.Int64Ptr(int64(rate))
+	return _options, nil
+}
+
+// SetConsumerByteRateString : Allow user to set ConsumerByteRate from a rate such as "10MiB/s", as read by ParseByteRate
+func (_options *CreateQuotaOptions) SetConsumerByteRateString(consumerByteRate string) (*CreateQuotaOptions, error) {
+	rate, err := ParseByteRate(consumerByteRate)
+	if err != nil {
+		return _options, err
+	}
+	_options.ConsumerByteRate = core.Int64Ptr(int64(rate))
+	return _options, nil
+}
+
+// SetProducerByteRateString : Allow user to set ProducerByteRate from a rate such as "10MiB/s", as read by ParseByteRate
+func (_options *UpdateQuotaOptions) SetProducerByteRateString(producerByteRate string) (*UpdateQuotaOptions, error) {
+	rate, err := ParseByteRate(producerByteRate)
+	if err != nil {
+		return _options, err
+	}
+	_options.ProducerByteRate = core.Int64Ptr(int64(rate))
+	return _options, nil
+}
+
+// SetConsumerByteRateString : Allow user to set ConsumerByteRate from a rate such as "10MiB/s", as read by ParseByteRate
+func (_options *UpdateQuotaOptions) SetConsumerByteRateString(consumerByteRate string) (*UpdateQuotaOptions, error) {
+	rate, err := ParseByteRate(consumerByteRate)
+	if err != nil {
+		return _options, err
+	}
+	_options.ConsumerByteRate = core.Int64Ptr(int64(rate))
+	return _options, nil
+}
+
+// SetProducerByteRateString : Allow user to set ProducerByteRate from a rate such as "10MiB/s", as read by ParseByteRate
+func (_options *UpsertQuotaOptions) SetProducerByteRateString(producerByteRate string) (*UpsertQuotaOptions, error) {
+	rate, err := ParseByteRate(producerByteRate)
+	if err != nil {
+		return _options, err
+	}
+	_options.ProducerByteRate = core.Int64Ptr(int64(rate))
+	return _options, nil
+}
+
+// SetConsumerByteRateString : Allow user to set ConsumerByteRate from a rate such as "10MiB/s", as read by ParseByteRate
+func (_options *UpsertQuotaOptions) SetConsumerByteRateString(consumerByteRate string) (*UpsertQuotaOptions, error) {
+	rate, err := ParseByteRate(consumerByteRate)
+	if err != nil {
+		return _options, err
+	}
+	_options.ConsumerByteRate = core.Int64Ptr(int64(rate))
+	return _options, nil
+}
+
+// formatRate returns the rate of a quota for display, or "unset".
+func formatRate(rate *int64) string {
+	if rate == nil {
+		return "unset"
+	}
+	return ByteRate(*rate).String()
+}
+
+// String returns the rates of the quota, for example "producer 10MiB/s, consumer unset".
+func (quotaDetail *QuotaDetail) String() string {
+	return fmt.Sprintf("producer %s, consumer %s", formatRate(quotaDetail.ProducerByteRate), formatRate(quotaDetail.ConsumerByteRate))
+}
+
+// String returns the entity name and rates of the quota, for example
+// "iam-ServiceId-1234: producer 10MiB/s, consumer 1MiB/s".
+func (entityQuotaDetail *EntityQuotaDetail) String() string {
+	return fmt.Sprintf("%s: producer %s, consumer %s", core.StringNilMapper(entityQuotaDetail.EntityName),
+		formatRate(entityQuotaDetail.ProducerByteRate), formatRate(entityQuotaDetail.ConsumerByteRate))
+}
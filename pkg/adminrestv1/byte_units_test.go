@@ -0,0 +1,156 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminrestv1
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+)
+
+var _ = Describe(`Byte units`, func() {
+	Describe(`ParseByteSize`, func() {
+		It(`Parse sizes with decimal and binary units`, func() {
+			for text, size := range map[string]ByteSize{
+				"0":        0,
+				"512":      512,
+				"512B":     512,
+				"512KB":    512000,
+				"512 kB":   512000,
+				"1MB":      1000000,
+				"1MiB":     1048576,
+				"1.5KiB":   1536,
+				"1GiB":     1073741824,
+				" 2TB ":    2000000000000,
+				"1TiB":     1099511627776,
+				"-1":       -1,
+				"0.5MB":    500000,
+				"8388608":  8 * Mebibyte,
+				"10 MiB":   10 * Mebibyte,
+				"1.25 GiB": 1342177280,
+			} {
+				parsed, err := ParseByteSize(text)
+				Expect(err).To(BeNil(), text)
+				Expect(parsed).To(Equal(size), text)
+			}
+		})
+		It(`Reject ambiguous units and fractions of a byte`, func() {
+			_, err := ParseByteSize("10M")
+			Expect(err).To(MatchError(`invalid byte size "10M": unknown unit "M", use KB, MB, GB or TB for powers of 1000 or KiB, MiB, GiB or TiB for powers of 1024`))
+			_, err = ParseByteSize("1.1B")
+			Expect(err).To(MatchError(`invalid byte size "1.1B": not a whole number of bytes`))
+			_, err = ParseByteSize("MiB")
+			Expect(err).To(MatchError(`invalid byte size "MiB": expected a number`))
+			_, err = ParseByteSize("1..5MiB")
+			Expect(err).To(MatchError(`invalid byte size "1..5MiB": expected a number`))
+			_, err = ParseByteSize("9000000TiB")
+			Expect(err).To(MatchError(`invalid byte size "9000000TiB": too large`))
+		})
+	})
+	It(`Format sizes with the largest exact unit`, func() {
+		Expect(ByteSize(0).String()).To(Equal("0B"))
+		Expect(ByteSize(1500).String()).To(Equal("1500B"))
+		Expect(ByteSize(512 * Kibibyte).String()).To(Equal("512KiB"))
+		Expect(ByteSize(1000000).String()).To(Equal("1MB"))
+		Expect(ByteSize(1536 * Mebibyte).String()).To(Equal("1536MiB"))
+		Expect(ByteSize(2 * Tebibyte).String()).To(Equal("2TiB"))
+		Expect(ByteSize(-1).String()).To(Equal("-1B"))
+	})
+	It(`Parse and format byte rates`, func() {
+		rate, err := ParseByteRate("10MiB/s")
+		Expect(err).To(BeNil())
+		Expect(rate).To(Equal(ByteRate(10 * Mebibyte)))
+		Expect(rate.String()).To(Equal("10MiB/s"))
+
+		rate, err = ParseByteRate("512KB")
+		Expect(err).To(BeNil())
+		Expect(rate).To(Equal(ByteRate(512000)))
+
+		_, err = ParseByteRate("10Mbps")
+		Expect(err).To(MatchError(`invalid byte rate "10Mbps": unknown unit "Mbps", use KB, MB, GB or TB for powers of 1000 or KiB, MiB, GiB or TiB for powers of 1024`))
+	})
+	It(`Read and write JSON and YAML documents`, func() {
+		type limits struct {
+			Segment ByteSize `json:"segment" yaml:"segment"`
+			Rate    ByteRate `json:"rate" yaml:"rate"`
+		}
+		var fromYAML limits
+		Expect(yaml.Unmarshal([]byte("segment: 1GiB\nrate: 10MiB/s\n"), &fromYAML)).To(Succeed())
+		Expect(fromYAML).To(Equal(limits{Segment: Gibibyte, Rate: ByteRate(10 * Mebibyte)}))
+
+		buf, err := json.Marshal(fromYAML)
+		Expect(err).To(BeNil())
+		Expect(string(buf)).To(Equal(`{"segment":"1GiB","rate":"10MiB/s"}`))
+
+		var fromJSON limits
+		Expect(json.Unmarshal(buf, &fromJSON)).To(Succeed())
+		Expect(fromJSON).To(Equal(fromYAML))
+
+		Expect(json.Unmarshal([]byte(`{"segment":"1G"}`), &fromJSON)).ToNot(Succeed())
+	})
+	It(`Set quota rates from strings`, func() {
+		service := new(AdminrestV1)
+		createOptions, err := service.NewCreateQuotaOptions("iam-ServiceId-a").SetProducerByteRateString("10MiB/s")
+		Expect(err).To(BeNil())
+		Expect(*createOptions.ProducerByteRate).To(Equal(int64(10485760)))
+
+		updateOptions, err := service.NewUpdateQuotaOptions("iam-ServiceId-a").SetConsumerByteRateString("512KB/s")
+		Expect(err).To(BeNil())
+		Expect(*updateOptions.ConsumerByteRate).To(Equal(int64(512000)))
+
+		upsertOptions, err := service.NewUpsertQuotaOptions("iam-ServiceId-a").SetConsumerByteRateString("fast")
+		Expect(err).ToNot(BeNil())
+		Expect(upsertOptions.ConsumerByteRate).To(BeNil())
+	})
+	It(`Format quotas and topic configs`, func() {
+		quota := &QuotaDetail{ProducerByteRate: core.Int64Ptr(10485760)}
+		Expect(quota.String()).To(Equal("producer 10MiB/s, consumer unset"))
+
+		entityQuota := &EntityQuotaDetail{EntityName: core.StringPtr("iam-ServiceId-a"), ProducerByteRate: core.Int64Ptr(1000000), ConsumerByteRate: core.Int64Ptr(1048576)}
+		Expect(entityQuota.String()).To(Equal("iam-ServiceId-a: producer 1MB/s, consumer 1MiB/s"))
+
+		topicConfigs := &TopicConfigs{
+			CleanupPolicy:  core.StringPtr("delete"),
+			RetentionBytes: core.StringPtr("1073741824"),
+			RetentionMs:    core.StringPtr("604800000"),
+			SegmentBytes:   core.StringPtr("-1"),
+		}
+		Expect(topicConfigs.String()).To(Equal("cleanup.policy=delete, retention.bytes=1GiB, retention.ms=168h0m0s, segment.bytes=-1"))
+
+		unlimited := UnlimitedRetention
+		typed := &TypedTopicConfigs{Retention: &unlimited, Other: map[string]string{"message.format.version": "3.0"}}
+		Expect(typed.String()).To(Equal("retention.ms=-1, message.format.version=3.0"))
+	})
+	It(`Accept byte sizes with units in topic configs`, func() {
+		typed, err := ParseTopicConfigs(map[string]string{"segment.bytes": "512MiB"})
+		Expect(err).To(BeNil())
+		Expect(*typed.SegmentBytes).To(Equal(512 * Mebibyte))
+		values, err := typed.Map()
+		Expect(err).To(BeNil())
+		Expect(values).To(Equal(map[string]string{"segment.bytes": "536870912"}))
+	})
+	It(`Only accept numbers of bytes in the configs sent to the service`, func() {
+		_, err := ParseConfigCreates([]ConfigCreate{{Name: core.StringPtr("retention.bytes"), Value: core.StringPtr("1GiB")}})
+		Expect(err).To(MatchError(`topic config retention.bytes="1GiB": expected a whole number of bytes`))
+		_, _, err = ParseConfigUpdates([]ConfigUpdate{{Name: core.StringPtr("segment.bytes"), Value: core.StringPtr("512MiB")}})
+		Expect(errors.Is(err, ErrInvalidConfig)).To(BeTrue())
+	})
+})
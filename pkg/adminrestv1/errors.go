@@ -0,0 +1,268 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminrestv1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/middleware"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// KafkaErrorCode : A Kafka protocol error code, as carried in the last two digits of the 'error_code' field of an
+// error response.
+type KafkaErrorCode int
+
+// Kafka protocol error codes that can be reported by the Admin REST API.
+const (
+	KafkaErrorNone                       KafkaErrorCode = 0
+	KafkaErrorOffsetOutOfRange           KafkaErrorCode = 1
+	KafkaErrorCorruptMessage             KafkaErrorCode = 2
+	KafkaErrorUnknownTopicOrPartition    KafkaErrorCode = 3
+	KafkaErrorInvalidFetchSize           KafkaErrorCode = 4
+	KafkaErrorLeaderNotAvailable         KafkaErrorCode = 5
+	KafkaErrorNotLeaderOrFollower        KafkaErrorCode = 6
+	KafkaErrorRequestTimedOut            KafkaErrorCode = 7
+	KafkaErrorBrokerNotAvailable         KafkaErrorCode = 8
+	KafkaErrorReplicaNotAvailable        KafkaErrorCode = 9
+	KafkaErrorMessageTooLarge            KafkaErrorCode = 10
+	KafkaErrorNetworkException           KafkaErrorCode = 13
+	KafkaErrorInvalidTopicException      KafkaErrorCode = 17
+	KafkaErrorNotEnoughReplicas          KafkaErrorCode = 19
+	KafkaErrorTopicAuthorizationFailed   KafkaErrorCode = 29
+	KafkaErrorClusterAuthorizationFailed KafkaErrorCode = 31
+	KafkaErrorUnsupportedVersion         KafkaErrorCode = 35
+	KafkaErrorTopicAlreadyExists         KafkaErrorCode = 36
+	KafkaErrorInvalidPartitions          KafkaErrorCode = 37
+	KafkaErrorInvalidReplicationFactor   KafkaErrorCode = 38
+	KafkaErrorInvalidReplicaAssignment   KafkaErrorCode = 39
+	KafkaErrorInvalidConfig              KafkaErrorCode = 40
+	KafkaErrorNotController              KafkaErrorCode = 41
+	KafkaErrorInvalidRequest             KafkaErrorCode = 42
+	KafkaErrorPolicyViolation            KafkaErrorCode = 44
+	KafkaErrorTopicDeletionDisabled      KafkaErrorCode = 73
+	KafkaErrorThrottlingQuotaExceeded    KafkaErrorCode = 89
+)
+
+// kafkaErrorCodeDigits separates the HTTP status from the Kafka error code in the HHHKK 'error_code' format.
+const kafkaErrorCodeDigits = 100
+
+// kafkaErrorNames maps each known Kafka error code to the name used by Kafka.
+var kafkaErrorNames = map[KafkaErrorCode]string{
+	KafkaErrorNone:                       "NONE",
+	KafkaErrorOffsetOutOfRange:           "OFFSET_OUT_OF_RANGE",
+	KafkaErrorCorruptMessage:             "CORRUPT_MESSAGE",
+	KafkaErrorUnknownTopicOrPartition:    "UNKNOWN_TOPIC_OR_PARTITION",
+	KafkaErrorInvalidFetchSize:           "INVALID_FETCH_SIZE",
+	KafkaErrorLeaderNotAvailable:         "LEADER_NOT_AVAILABLE",
+	KafkaErrorNotLeaderOrFollower:        "NOT_LEADER_OR_FOLLOWER",
+	KafkaErrorRequestTimedOut:            "REQUEST_TIMED_OUT",
+	KafkaErrorBrokerNotAvailable:         "BROKER_NOT_AVAILABLE",
+	KafkaErrorReplicaNotAvailable:        "REPLICA_NOT_AVAILABLE",
+	KafkaErrorMessageTooLarge:            "MESSAGE_TOO_LARGE",
+	KafkaErrorNetworkException:           "NETWORK_EXCEPTION",
+	KafkaErrorInvalidTopicException:      "INVALID_TOPIC_EXCEPTION",
+	KafkaErrorNotEnoughReplicas:          "NOT_ENOUGH_REPLICAS",
+	KafkaErrorTopicAuthorizationFailed:   "TOPIC_AUTHORIZATION_FAILED",
+	KafkaErrorClusterAuthorizationFailed: "CLUSTER_AUTHORIZATION_FAILED",
+	KafkaErrorUnsupportedVersion:         "UNSUPPORTED_VERSION",
+	KafkaErrorTopicAlreadyExists:         "TOPIC_ALREADY_EXISTS",
+	KafkaErrorInvalidPartitions:          "INVALID_PARTITIONS",
+	KafkaErrorInvalidReplicationFactor:   "INVALID_REPLICATION_FACTOR",
+	KafkaErrorInvalidReplicaAssignment:   "INVALID_REPLICA_ASSIGNMENT",
+	KafkaErrorInvalidConfig:              "INVALID_CONFIG",
+	KafkaErrorNotController:              "NOT_CONTROLLER",
+	KafkaErrorInvalidRequest:             "INVALID_REQUEST",
+	KafkaErrorPolicyViolation:            "POLICY_VIOLATION",
+	KafkaErrorTopicDeletionDisabled:      "TOPIC_DELETION_DISABLED",
+	KafkaErrorThrottlingQuotaExceeded:    "THROTTLING_QUOTA_EXCEEDED",
+}
+
+// String returns the Kafka name of the error code, or "UNKNOWN_<code>" for codes this package does not know.
+func (code KafkaErrorCode) String() string {
+	if name, ok := kafkaErrorNames[code]; ok {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN_%d", int(code))
+}
+
+// Sentinel errors that can be matched against the errors returned by AdminrestV1 operations with errors.Is.
+var (
+	// ErrTopicNotFound : The topic does not exist.
+	ErrTopicNotFound = errors.New("topic not found")
+
+	// ErrTopicAlreadyExists : A topic with the same name already exists.
+	ErrTopicAlreadyExists = errors.New("topic already exists")
+
+	// ErrInvalidPartitions : The requested number of partitions is not valid, for example because it is lower
+	// than the current number of partitions.
+	ErrInvalidPartitions = errors.New("invalid partitions")
+
+	// ErrInvalidConfig : A topic config name or value is not valid.
+	ErrInvalidConfig = errors.New("invalid config")
+
+	// ErrQuotaNotFound : The quota does not exist.
+	ErrQuotaNotFound = errors.New("quota not found")
+
+	// ErrUnauthorized : The request was not authenticated.
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrForbidden : The caller is not authorized to perform the operation.
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrInvalidPattern : A mirroring topic selection pattern is not a valid regular expression.
+	ErrInvalidPattern = errors.New("invalid pattern")
+
+	// ErrMirroringConflict : The mirroring topic selection kept changing while it was being edited.
+	ErrMirroringConflict = errors.New("mirroring topic selection changed concurrently")
+)
+
+// APIError : The error returned by AdminrestV1 operations when the service responds with an unsuccessful
+// status code.
+type APIError struct {
+	// The name of the operation that failed, for example "GetTopic".
+	Operation string
+
+	// The HTTP status code of the response.
+	HTTPStatus int
+
+	// The Kafka error code reported in the 'error_code' field, or KafkaErrorNone if there was none.
+	KafkaErrorCode KafkaErrorCode
+
+	// The full 'error_code' value of the response, or zero if the response did not include one.
+	ErrorCode int64
+
+	// The error message.
+	Message string
+
+	// The incident ID of the error, which identifies the request in the service logs.
+	IncidentID string
+
+	// The full response, which may be nil.
+	Response *core.DetailedResponse
+}
+
+// Error implements the error interface.
+func (apiError *APIError) Error() string {
+	var details []string
+	details = append(details, fmt.Sprintf("status %d", apiError.HTTPStatus))
+	if apiError.KafkaErrorCode != KafkaErrorNone {
+		details = append(details, fmt.Sprintf("kafka error %s", apiError.KafkaErrorCode))
+	}
+	if apiError.IncidentID != "" {
+		details = append(details, fmt.Sprintf("incident %s", apiError.IncidentID))
+	}
+	message := apiError.Message
+	if apiError.Operation != "" {
+		message = apiError.Operation + ": " + message
+	}
+	return fmt.Sprintf("%s (%s)", message, strings.Join(details, ", "))
+}
+
+// Is reports whether the error matches one of the sentinel errors of this package.
+func (apiError *APIError) Is(target error) bool {
+	switch target {
+	case ErrTopicNotFound:
+		return apiError.KafkaErrorCode == KafkaErrorUnknownTopicOrPartition ||
+			(apiError.HTTPStatus == http.StatusNotFound && isTopicOperation(apiError.Operation))
+	case ErrTopicAlreadyExists:
+		return apiError.KafkaErrorCode == KafkaErrorTopicAlreadyExists
+	case ErrInvalidPartitions:
+		return apiError.KafkaErrorCode == KafkaErrorInvalidPartitions
+	case ErrInvalidConfig:
+		return apiError.KafkaErrorCode == KafkaErrorInvalidConfig
+	case ErrQuotaNotFound:
+		return apiError.HTTPStatus == http.StatusNotFound && isQuotaOperation(apiError.Operation)
+	case ErrUnauthorized:
+		return apiError.HTTPStatus == http.StatusUnauthorized
+	case ErrForbidden:
+		return apiError.HTTPStatus == http.StatusForbidden
+	}
+	return false
+}
+
+// isTopicOperation returns true for operations that address a single topic.
+func isTopicOperation(operation string) bool {
+	switch operation {
+	case "GetTopic", "UpdateTopic", "DeleteTopic":
+		return true
+	}
+	return false
+}
+
+// isQuotaOperation returns true for operations that address a single quota.
+func isQuotaOperation(operation string) bool {
+	switch operation {
+	case "GetQuota", "UpdateQuota", "DeleteQuota":
+		return true
+	}
+	return false
+}
+
+// newAPIError builds an APIError from an unsuccessful response. If the response is nil or the request was successful
+// (for example when the response body could not be decoded) the original error is returned unchanged.
+func newAPIError(operation string, response *core.DetailedResponse, err error) error {
+	if response == nil || (response.StatusCode >= 200 && response.StatusCode < 300) {
+		return err
+	}
+
+	apiError := &APIError{
+		Operation:  operation,
+		HTTPStatus: response.StatusCode,
+		Message:    err.Error(),
+		Response:   response,
+	}
+
+	if body, ok := response.Result.(map[string]interface{}); ok {
+		if code, ok := body["error_code"]; ok {
+			var errorCode int64
+			if buf, marshalErr := json.Marshal(code); marshalErr == nil && json.Unmarshal(buf, &errorCode) == nil {
+				apiError.ErrorCode = errorCode
+				apiError.KafkaErrorCode = KafkaErrorCode(errorCode % kafkaErrorCodeDigits)
+			}
+		}
+		if message, ok := body["message"].(string); ok && message != "" {
+			apiError.Message = message
+		}
+		if incidentID, ok := body["incident_id"].(string); ok {
+			apiError.IncidentID = incidentID
+		} else if incidentID, ok := body["incident"].(string); ok {
+			apiError.IncidentID = incidentID
+		}
+	}
+	return apiError
+}
+
+// request sends the request through the middlewares of the client and decodes the response body into result. An
+// unsuccessful response is returned as an *APIError.
+func (adminrest *AdminrestV1) request(operation string, request *http.Request, result interface{}) (response *core.DetailedResponse, err error) {
+	send := func(call *middleware.Call) (response *core.DetailedResponse, err error) {
+		response, err = middleware.Send(call, func(request *http.Request) (*core.DetailedResponse, error) {
+			return adminrest.Service.Request(request, result)
+		})
+		if err != nil {
+			err = newAPIError(call.Operation, response, err)
+		}
+		return
+	}
+	call := &middleware.Call{Service: DefaultServiceName, Operation: operation, Request: request}
+	return middleware.Chain(send, adminrest.middlewares...)(call)
+}
@@ -0,0 +1,151 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminrestv1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`APIError`, func() {
+	var testServer *httptest.Server
+
+	// newErrorServer responds to every request with the specified status code and body.
+	newErrorServer := func(statusCode int, contentType string, body string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			if contentType != "" {
+				res.Header().Set("Content-type", contentType)
+			}
+			res.WriteHeader(statusCode)
+			fmt.Fprintf(res, "%s", body)
+		}))
+	}
+
+	newService := func() *AdminrestV1 {
+		adminrestService, serviceErr := NewAdminrestV1(&AdminrestV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(serviceErr).To(BeNil())
+		return adminrestService
+	}
+
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	It(`Decode a topic not found error from GetTopic`, func() {
+		testServer = newErrorServer(404, "application/json", `{"error_code": 40403, "message": "Topic not found", "incident_id": "abc-123"}`)
+		adminrestService := newService()
+
+		result, response, err := adminrestService.GetTopic(adminrestService.NewGetTopicOptions("missing"))
+		Expect(result).To(BeNil())
+		Expect(response).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(404))
+
+		var apiError *APIError
+		Expect(errors.As(err, &apiError)).To(BeTrue())
+		Expect(apiError.Operation).To(Equal("GetTopic"))
+		Expect(apiError.HTTPStatus).To(Equal(404))
+		Expect(apiError.ErrorCode).To(Equal(int64(40403)))
+		Expect(apiError.KafkaErrorCode).To(Equal(KafkaErrorUnknownTopicOrPartition))
+		Expect(apiError.KafkaErrorCode.String()).To(Equal("UNKNOWN_TOPIC_OR_PARTITION"))
+		Expect(apiError.Message).To(Equal("Topic not found"))
+		Expect(apiError.IncidentID).To(Equal("abc-123"))
+		Expect(apiError.Error()).To(Equal("GetTopic: Topic not found (status 404, kafka error UNKNOWN_TOPIC_OR_PARTITION, incident abc-123)"))
+
+		Expect(errors.Is(err, ErrTopicNotFound)).To(BeTrue())
+		Expect(errors.Is(err, ErrTopicAlreadyExists)).To(BeFalse())
+		Expect(errors.Is(err, ErrQuotaNotFound)).To(BeFalse())
+	})
+	It(`Decode a topic already exists error from CreateTopic`, func() {
+		testServer = newErrorServer(422, "application/json", `{"error_code": 42236, "message": "Topic 'orders' already exists.", "incident": "def-456"}`)
+		adminrestService := newService()
+
+		_, err := adminrestService.CreateTopic(adminrestService.NewCreateTopicOptions().SetName("orders"))
+		Expect(errors.Is(err, ErrTopicAlreadyExists)).To(BeTrue())
+		Expect(errors.Is(err, ErrTopicNotFound)).To(BeFalse())
+
+		var apiError *APIError
+		Expect(errors.As(err, &apiError)).To(BeTrue())
+		Expect(apiError.IncidentID).To(Equal("def-456"))
+
+		wrapped := fmt.Errorf("creating topic: %w", err)
+		Expect(errors.Is(wrapped, ErrTopicAlreadyExists)).To(BeTrue())
+	})
+	It(`Decode an invalid partitions error from UpdateTopic`, func() {
+		testServer = newErrorServer(422, "application/json", `{"error_code": 42237, "message": "Number of partitions must be larger than 3."}`)
+		adminrestService := newService()
+
+		_, err := adminrestService.UpdateTopic(adminrestService.NewUpdateTopicOptions("orders").SetNewTotalPartitionCount(1))
+		Expect(errors.Is(err, ErrInvalidPartitions)).To(BeTrue())
+	})
+	It(`Decode an error from ListTopics`, func() {
+		testServer = newErrorServer(503, "application/json", `{"error_code": 50301, "message": "Unknown Kafka Error", "incident_id": "ghi-789"}`)
+		adminrestService := newService()
+
+		_, _, err := adminrestService.ListTopics(adminrestService.NewListTopicsOptions())
+		var apiError *APIError
+		Expect(errors.As(err, &apiError)).To(BeTrue())
+		Expect(apiError.HTTPStatus).To(Equal(503))
+		Expect(apiError.KafkaErrorCode).To(Equal(KafkaErrorOffsetOutOfRange))
+	})
+	It(`Match a quota not found error`, func() {
+		testServer = newErrorServer(404, "application/json", `{"error_code": 40400, "message": "Not found"}`)
+		adminrestService := newService()
+
+		_, _, err := adminrestService.GetQuota(adminrestService.NewGetQuotaOptions("default"))
+		Expect(errors.Is(err, ErrQuotaNotFound)).To(BeTrue())
+		Expect(errors.Is(err, ErrTopicNotFound)).To(BeFalse())
+
+		var apiError *APIError
+		Expect(errors.As(err, &apiError)).To(BeTrue())
+		Expect(apiError.KafkaErrorCode).To(Equal(KafkaErrorNone))
+		Expect(apiError.Error()).To(Equal("GetQuota: Not found (status 404)"))
+	})
+	It(`Decode an error without a JSON body`, func() {
+		testServer = newErrorServer(401, "text/plain", `go away`)
+		adminrestService := newService()
+
+		_, err := adminrestService.DeleteTopic(adminrestService.NewDeleteTopicOptions("orders"))
+		Expect(errors.Is(err, ErrUnauthorized)).To(BeTrue())
+
+		var apiError *APIError
+		Expect(errors.As(err, &apiError)).To(BeTrue())
+		Expect(apiError.Message).To(Equal("Unauthorized"))
+		Expect(string(apiError.Response.RawResult)).To(Equal("go away"))
+	})
+	It(`Leave errors from successful responses unchanged`, func() {
+		testServer = newErrorServer(200, "application/json", `this is not JSON`)
+		adminrestService := newService()
+
+		_, _, err := adminrestService.GetTopic(adminrestService.NewGetTopicOptions("orders"))
+		Expect(err).ToNot(BeNil())
+		var apiError *APIError
+		Expect(errors.As(err, &apiError)).To(BeFalse())
+	})
+	It(`Name unknown Kafka error codes`, func() {
+		Expect(KafkaErrorCode(99).String()).To(Equal("UNKNOWN_99"))
+		Expect(KafkaErrorInvalidPartitions.String()).To(Equal("INVALID_PARTITIONS"))
+	})
+})
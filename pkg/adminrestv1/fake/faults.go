@@ -0,0 +1,72 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fake
+
+import (
+	"time"
+)
+
+// Fault : A failure injected into the responses of a Server.
+type Fault struct {
+	// The operation affected by the fault, for example OperationCreateTopic. An empty value affects every operation.
+	Operation string
+
+	// A delay added before the request is handled.
+	Latency time.Duration
+
+	// When non-zero, the request fails with this status code instead of being handled, for example
+	// http.StatusServiceUnavailable or http.StatusTooManyRequests.
+	StatusCode int
+
+	// When non-zero, the value sent in the Retry-After header of the failed response.
+	RetryAfter time.Duration
+
+	// The number of requests affected by the fault. Zero means every request until ClearFaults is called.
+	Times int
+}
+
+// InjectFault adds a fault to the server. When several faults match a request, the one injected first is used.
+func (server *Server) InjectFault(fault Fault) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.faults = append(server.faults, &fault)
+}
+
+// ClearFaults removes every fault from the server.
+func (server *Server) ClearFaults() {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.faults = nil
+}
+
+// takeFault returns the fault that applies to the next request for the operation, if any, and uses up one of its
+// remaining requests. The caller must hold the server mutex.
+func (server *Server) takeFault(operation string) *Fault {
+	for i, fault := range server.faults {
+		if fault.Operation != "" && fault.Operation != operation {
+			continue
+		}
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				server.faults = append(server.faults[:i:i], server.faults[i+1:]...)
+			}
+		}
+		return fault
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+)
+
+// SetMirroringTopicSelection replaces the mirroring topic selection patterns held by the server.
+func (server *Server) SetMirroringTopicSelection(includes []string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.selection = append([]string{}, includes...)
+}
+
+// MirroringTopicSelection returns the mirroring topic selection patterns held by the server.
+func (server *Server) MirroringTopicSelection() []string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return append([]string{}, server.selection...)
+}
+
+// SetMirroringSourceTopics sets the names of the topics in the source cluster of the mirroring relationship. The
+// active topics reported by GetMirroringActiveTopics are the source topics matched by the topic selection.
+func (server *Server) SetMirroringSourceTopics(names []string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.sourceTopics = append([]string{}, names...)
+}
+
+// activeTopics returns the source topics that match the topic selection, in sorted order. The caller must hold the
+// server mutex.
+func (server *Server) activeTopics() []string {
+	unverifiable := make(map[string]bool)
+	for _, include := range adminrestv1.UnverifiableMirroringPatterns(server.selection) {
+		unverifiable[include] = true
+	}
+	var patterns []*regexp.Regexp
+	for _, include := range server.selection {
+		if unverifiable[include] {
+			continue
+		}
+		pattern, err := regexp.Compile("^(?:" + include + ")$")
+		if err == nil {
+			patterns = append(patterns, pattern)
+		}
+	}
+	active := []string{}
+	for _, name := range server.sourceTopics {
+		for _, pattern := range patterns {
+			if pattern.MatchString(name) {
+				active = append(active, name)
+				break
+			}
+		}
+	}
+	sort.Strings(active)
+	return active
+}
+
+// mirroringTopicSelection is the body of the mirroring topic selection requests and responses.
+type mirroringTopicSelection struct {
+	Includes []string `json:"includes"`
+}
+
+// getMirroringTopicSelection handles GetMirroringTopicSelection.
+func (server *Server) getMirroringTopicSelection(res http.ResponseWriter, req *http.Request, _ string) {
+	if !server.mirroringEnabled(res) {
+		return
+	}
+	writeJSON(res, http.StatusOK, mirroringTopicSelection{Includes: append([]string{}, server.selection...)})
+}
+
+// replaceMirroringTopicSelection handles ReplaceMirroringTopicSelection.
+func (server *Server) replaceMirroringTopicSelection(res http.ResponseWriter, req *http.Request, _ string) {
+	if !server.mirroringEnabled(res) {
+		return
+	}
+	var body mirroringTopicSelection
+	if !decodeBody(res, req, &body) {
+		return
+	}
+	// Patterns in Java syntax that RE2 does not support are accepted, but never match a topic.
+	for _, include := range body.Includes {
+		if err := adminrestv1.ValidateMirroringPatterns([]string{include}); err != nil {
+			writeError(res, http.StatusBadRequest, 0, fmt.Sprintf("Invalid topic selection pattern '%s': %s", include, err.Error()))
+			return
+		}
+	}
+	server.selection = append([]string{}, body.Includes...)
+	writeJSON(res, http.StatusOK, mirroringTopicSelection{Includes: append([]string{}, server.selection...)})
+}
+
+// getMirroringActiveTopics handles GetMirroringActiveTopics.
+func (server *Server) getMirroringActiveTopics(res http.ResponseWriter, req *http.Request, _ string) {
+	if !server.mirroringEnabled(res) {
+		return
+	}
+	writeJSON(res, http.StatusOK, map[string][]string{"active_topics": server.activeTopics()})
+}
+
+// mirroringEnabled returns true if mirroring is enabled, and otherwise writes a 404 response.
+func (server *Server) mirroringEnabled(res http.ResponseWriter) bool {
+	if !server.options.MirroringEnabled {
+		writeError(res, http.StatusNotFound, 0, "Mirroring is not enabled for this instance.")
+		return false
+	}
+	return true
+}
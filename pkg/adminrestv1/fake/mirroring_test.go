@@ -0,0 +1,82 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fake
+
+import (
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Mirroring`, func() {
+	var server *Server
+	var client *adminrestv1.AdminrestV1
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context(`With mirroring enabled`, func() {
+		BeforeEach(func() {
+			server = NewServer(&ServerOptions{MirroringEnabled: true})
+			client, _ = server.NewClient()
+			server.SetMirroringSourceTopics([]string{"orders", "orders-eu", "payments", "audit"})
+		})
+		It(`Replace the topic selection and report active topics`, func() {
+			selection, _, err := client.GetMirroringTopicSelection(client.NewGetMirroringTopicSelectionOptions())
+			Expect(err).To(BeNil())
+			Expect(selection.Includes).To(BeEmpty())
+
+			selection, response, err := client.ReplaceMirroringTopicSelection(
+				client.NewReplaceMirroringTopicSelectionOptions().SetIncludes([]string{"orders.*", "audit"}))
+			Expect(err).To(BeNil())
+			Expect(response.StatusCode).To(Equal(200))
+			Expect(selection.Includes).To(Equal([]string{"orders.*", "audit"}))
+			Expect(server.MirroringTopicSelection()).To(Equal([]string{"orders.*", "audit"}))
+
+			active, _, err := client.GetMirroringActiveTopics(client.NewGetMirroringActiveTopicsOptions())
+			Expect(err).To(BeNil())
+			Expect(active.ActiveTopics).To(Equal([]string{"audit", "orders", "orders-eu"}))
+		})
+		It(`Accept Java patterns that RE2 does not support`, func() {
+			_, _, err := client.ReplaceMirroringTopicSelection(
+				client.NewReplaceMirroringTopicSelectionOptions().SetIncludes([]string{`orders(?!\.test).*`}))
+			Expect(err).To(BeNil())
+			Expect(server.MirroringTopicSelection()).To(Equal([]string{`orders(?!\.test).*`}))
+		})
+		It(`Reject an invalid pattern`, func() {
+			server.SetMirroringTopicSelection([]string{"audit"})
+			_, response, err := client.ReplaceMirroringTopicSelection(
+				client.NewReplaceMirroringTopicSelectionOptions().SetIncludes([]string{"orders[", "payments"}))
+			Expect(err).ToNot(BeNil())
+			Expect(response.StatusCode).To(Equal(400))
+			Expect(server.MirroringTopicSelection()).To(Equal([]string{"audit"}))
+		})
+	})
+
+	Context(`With mirroring disabled`, func() {
+		BeforeEach(func() {
+			server = NewServer(nil)
+			client, _ = server.NewClient()
+		})
+		It(`Respond with not found`, func() {
+			_, response, err := client.GetMirroringActiveTopics(client.NewGetMirroringActiveTopicsOptions())
+			Expect(err).ToNot(BeNil())
+			Expect(response.StatusCode).To(Equal(404))
+		})
+	})
+})
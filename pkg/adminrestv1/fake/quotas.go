@@ -0,0 +1,183 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Quota : The quotas of an entity held by a Server. A rate of zero means the quota is not set.
+type Quota struct {
+	// The producer byte rate quota value.
+	ProducerByteRate int64
+
+	// The consumer byte rate quota value.
+	ConsumerByteRate int64
+}
+
+// quotaBody is the body of the quota requests and responses.
+type quotaBody struct {
+	EntityName       string `json:"entity_name,omitempty"`
+	ProducerByteRate *int64 `json:"producer_byte_rate,omitempty"`
+	ConsumerByteRate *int64 `json:"consumer_byte_rate,omitempty"`
+}
+
+// toBody returns the JSON representation of the quota.
+func (quota *Quota) toBody(entityName string) quotaBody {
+	body := quotaBody{EntityName: entityName}
+	if quota.ProducerByteRate != 0 {
+		producerByteRate := quota.ProducerByteRate
+		body.ProducerByteRate = &producerByteRate
+	}
+	if quota.ConsumerByteRate != 0 {
+		consumerByteRate := quota.ConsumerByteRate
+		body.ConsumerByteRate = &consumerByteRate
+	}
+	return body
+}
+
+// SetQuota sets the quotas of an entity held by the server.
+func (server *Server) SetQuota(entityName string, quota Quota) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.quotas[entityName] = &quota
+}
+
+// Quota returns the quotas of an entity held by the server.
+func (server *Server) Quota(entityName string) (quota Quota, ok bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	stored, ok := server.quotas[entityName]
+	if ok {
+		quota = *stored
+	}
+	return
+}
+
+// validEntityName returns true for the entity names accepted by the quota operations.
+func validEntityName(entityName string) bool {
+	return entityName == "default" || strings.HasPrefix(entityName, "iam-ServiceId")
+}
+
+// validateQuotaBody writes an error response and returns false if the body does not contain a valid rate.
+func validateQuotaBody(res http.ResponseWriter, body *quotaBody) bool {
+	if body.ProducerByteRate == nil && body.ConsumerByteRate == nil {
+		writeError(res, http.StatusBadRequest, 0, "At least one of producer_byte_rate and consumer_byte_rate must be set.")
+		return false
+	}
+	if (body.ProducerByteRate != nil && *body.ProducerByteRate <= 0) || (body.ConsumerByteRate != nil && *body.ConsumerByteRate <= 0) {
+		writeError(res, http.StatusUnprocessableEntity, 0, "Quota values must be greater than zero.")
+		return false
+	}
+	return true
+}
+
+// createQuota handles CreateQuota.
+func (server *Server) createQuota(res http.ResponseWriter, req *http.Request, entityName string) {
+	var body quotaBody
+	if !decodeBody(res, req, &body) {
+		return
+	}
+	if !validEntityName(entityName) {
+		writeError(res, http.StatusUnprocessableEntity, 0,
+			fmt.Sprintf("Entity name '%s' must be 'default' or start with 'iam-ServiceId'.", entityName))
+		return
+	}
+	if !validateQuotaBody(res, &body) {
+		return
+	}
+	if _, exists := server.quotas[entityName]; exists {
+		writeError(res, http.StatusUnprocessableEntity, 0, fmt.Sprintf("Quota for entity '%s' already exists.", entityName))
+		return
+	}
+
+	quota := &Quota{}
+	if body.ProducerByteRate != nil {
+		quota.ProducerByteRate = *body.ProducerByteRate
+	}
+	if body.ConsumerByteRate != nil {
+		quota.ConsumerByteRate = *body.ConsumerByteRate
+	}
+	server.quotas[entityName] = quota
+	writeEmpty(res, http.StatusCreated)
+}
+
+// updateQuota handles UpdateQuota.
+func (server *Server) updateQuota(res http.ResponseWriter, req *http.Request, entityName string) {
+	var body quotaBody
+	if !decodeBody(res, req, &body) {
+		return
+	}
+	quota, exists := server.quotas[entityName]
+	if !exists {
+		writeQuotaNotFound(res, entityName)
+		return
+	}
+	if !validateQuotaBody(res, &body) {
+		return
+	}
+	if body.ProducerByteRate != nil {
+		quota.ProducerByteRate = *body.ProducerByteRate
+	}
+	if body.ConsumerByteRate != nil {
+		quota.ConsumerByteRate = *body.ConsumerByteRate
+	}
+	writeEmpty(res, http.StatusAccepted)
+}
+
+// deleteQuota handles DeleteQuota.
+func (server *Server) deleteQuota(res http.ResponseWriter, req *http.Request, entityName string) {
+	if _, exists := server.quotas[entityName]; !exists {
+		writeQuotaNotFound(res, entityName)
+		return
+	}
+	delete(server.quotas, entityName)
+	writeEmpty(res, http.StatusAccepted)
+}
+
+// getQuota handles GetQuota.
+func (server *Server) getQuota(res http.ResponseWriter, req *http.Request, entityName string) {
+	quota, exists := server.quotas[entityName]
+	if !exists {
+		writeQuotaNotFound(res, entityName)
+		return
+	}
+	writeJSON(res, http.StatusOK, quota.toBody(""))
+}
+
+// listQuotas handles ListQuotas.
+func (server *Server) listQuotas(res http.ResponseWriter, req *http.Request, _ string) {
+	names := make([]string, 0, len(server.quotas))
+	for name := range server.quotas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	data := []quotaBody{}
+	for _, name := range names {
+		data = append(data, server.quotas[name].toBody(name))
+	}
+	writeJSON(res, http.StatusOK, map[string][]quotaBody{"data": data})
+}
+
+// writeQuotaNotFound writes the response for a quota that does not exist.
+func writeQuotaNotFound(res http.ResponseWriter, entityName string) {
+	writeError(res, http.StatusNotFound, 0, fmt.Sprintf("Quota for entity '%s' does not exist.", entityName))
+}
@@ -0,0 +1,91 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fake
+
+import (
+	"errors"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Quotas`, func() {
+	var server *Server
+	var client *adminrestv1.AdminrestV1
+
+	BeforeEach(func() {
+		server = NewServer(nil)
+		client, _ = server.NewClient()
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Create, update, get, list and delete quotas`, func() {
+		response, err := client.CreateQuota(client.NewCreateQuotaOptions("default").SetProducerByteRate(1024))
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(201))
+
+		response, err = client.CreateQuota(client.NewCreateQuotaOptions("iam-ServiceId-123").SetConsumerByteRate(2048))
+		Expect(err).To(BeNil())
+
+		response, err = client.UpdateQuota(client.NewUpdateQuotaOptions("default").SetConsumerByteRate(4096))
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(202))
+		quota, ok := server.Quota("default")
+		Expect(ok).To(BeTrue())
+		Expect(quota).To(Equal(Quota{ProducerByteRate: 1024, ConsumerByteRate: 4096}))
+
+		detail, _, err := client.GetQuota(client.NewGetQuotaOptions("iam-ServiceId-123"))
+		Expect(err).To(BeNil())
+		Expect(detail.ProducerByteRate).To(BeNil())
+		Expect(*detail.ConsumerByteRate).To(Equal(int64(2048)))
+
+		list, _, err := client.ListQuotas(client.NewListQuotasOptions())
+		Expect(err).To(BeNil())
+		Expect(list.Data).To(HaveLen(2))
+		Expect(*list.Data[0].EntityName).To(Equal("default"))
+
+		response, err = client.DeleteQuota(client.NewDeleteQuotaOptions("default"))
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(202))
+		_, ok = server.Quota("default")
+		Expect(ok).To(BeFalse())
+	})
+	It(`Reject invalid quota requests`, func() {
+		_, err := client.CreateQuota(client.NewCreateQuotaOptions("someone").SetProducerByteRate(1024))
+		var apiError *adminrestv1.APIError
+		Expect(errors.As(err, &apiError)).To(BeTrue())
+		Expect(apiError.HTTPStatus).To(Equal(422))
+
+		response, err := client.CreateQuota(client.NewCreateQuotaOptions("default"))
+		Expect(err).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(400))
+
+		server.SetQuota("default", Quota{ProducerByteRate: 1})
+		response, err = client.CreateQuota(client.NewCreateQuotaOptions("default").SetProducerByteRate(1024))
+		Expect(err).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(422))
+
+		_, err = client.UpdateQuota(client.NewUpdateQuotaOptions("iam-ServiceId-404").SetProducerByteRate(1024))
+		Expect(errors.Is(err, adminrestv1.ErrQuotaNotFound)).To(BeTrue())
+
+		_, _, err = client.GetQuota(client.NewGetQuotaOptions("iam-ServiceId-404"))
+		Expect(errors.Is(err, adminrestv1.ErrQuotaNotFound)).To(BeTrue())
+	})
+})
@@ -0,0 +1,307 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fake : An in-memory Event Streams Admin REST API server for tests
+//
+// A Server implements every path of admin-rest-api.yaml on top of an httptest.Server. It keeps the topics, mirroring
+// topic selection and quotas it is given in memory, validates requests the way the service does, and responds with
+// the same status codes and HHHKK error codes. Faults such as latency, 5xx responses and 429 responses can be
+// injected per operation.
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Operation names, matching the operation names used by the adminrestv1 package.
+const (
+	OperationCreateTopic                    = "CreateTopic"
+	OperationListTopics                     = "ListTopics"
+	OperationGetTopic                       = "GetTopic"
+	OperationDeleteTopic                    = "DeleteTopic"
+	OperationUpdateTopic                    = "UpdateTopic"
+	OperationGetMirroringTopicSelection     = "GetMirroringTopicSelection"
+	OperationReplaceMirroringTopicSelection = "ReplaceMirroringTopicSelection"
+	OperationGetMirroringActiveTopics       = "GetMirroringActiveTopics"
+	OperationCreateQuota                    = "CreateQuota"
+	OperationUpdateQuota                    = "UpdateQuota"
+	OperationDeleteQuota                    = "DeleteQuota"
+	OperationGetQuota                       = "GetQuota"
+	OperationListQuotas                     = "ListQuotas"
+)
+
+// headerNameTransactionID is the header that carries the transaction ID of a request.
+const headerNameTransactionID = "X-Global-Transaction-Id"
+
+// ServerOptions : The options of a fake Server.
+type ServerOptions struct {
+	// When set, requests must authenticate with this API key, either as the password of basic authentication with the
+	// user name 'token', as a bearer token or in the X-Auth-Token header. When empty, requests are not authenticated.
+	APIKey string
+
+	// When true, the mirroring endpoints are available. When false, they respond with 404 as they do for an instance
+	// that is not the target of a mirroring relationship.
+	MirroringEnabled bool
+
+	// The largest page size served by ListTopics. Zero means no limit.
+	MaxPerPage int64
+
+	// The replication factor reported for topics. Defaults to DefaultReplicationFactor.
+	ReplicationFactor int64
+}
+
+// Server : An in-memory implementation of the Event Streams Admin REST API.
+type Server struct {
+	// The base URL of the server, suitable for AdminrestV1Options.URL.
+	URL string
+
+	httpServer    *httptest.Server
+	options       ServerOptions
+	mutex         sync.Mutex
+	topics        map[string]*Topic
+	selection     []string
+	sourceTopics  []string
+	quotas        map[string]*Quota
+	faults        []*Fault
+	requestCounts map[string]int
+	nextIncident  int64
+}
+
+// NewServer : starts a new fake Server. The caller should call Close when finished.
+func NewServer(options *ServerOptions) *Server {
+	server := &Server{
+		topics:        make(map[string]*Topic),
+		quotas:        make(map[string]*Quota),
+		requestCounts: make(map[string]int),
+	}
+	if options != nil {
+		server.options = *options
+	}
+	if server.options.ReplicationFactor == 0 {
+		server.options.ReplicationFactor = DefaultReplicationFactor
+	}
+	server.httpServer = httptest.NewServer(server)
+	server.URL = server.httpServer.URL
+	return server
+}
+
+// Close shuts down the server.
+func (server *Server) Close() {
+	server.httpServer.Close()
+}
+
+// NewClient returns an AdminrestV1 client configured to use the server.
+func (server *Server) NewClient() (*adminrestv1.AdminrestV1, error) {
+	var authenticator core.Authenticator = &core.NoAuthAuthenticator{}
+	if server.options.APIKey != "" {
+		basicAuthenticator, err := core.NewBasicAuthenticator("token", server.options.APIKey)
+		if err != nil {
+			return nil, err
+		}
+		authenticator = basicAuthenticator
+	}
+	return adminrestv1.NewAdminrestV1(&adminrestv1.AdminrestV1Options{
+		URL:           server.URL,
+		Authenticator: authenticator,
+	})
+}
+
+// RequestCount returns the number of requests received for the operation, including requests that failed.
+func (server *Server) RequestCount(operation string) int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return server.requestCounts[operation]
+}
+
+// route identifies the operation addressed by a request.
+type route struct {
+	operation string
+	name      string
+	handler   func(server *Server, res http.ResponseWriter, req *http.Request, name string)
+}
+
+// findRoute returns the route for the request, or nil if the path and method are not part of the API.
+func findRoute(req *http.Request) *route {
+	path := req.URL.Path
+	switch {
+	case path == "/admin/topics":
+		switch req.Method {
+		case http.MethodPost:
+			return &route{operation: OperationCreateTopic, handler: (*Server).createTopic}
+		case http.MethodGet:
+			return &route{operation: OperationListTopics, handler: (*Server).listTopics}
+		}
+	case strings.HasPrefix(path, "/admin/topics/") && len(path) > len("/admin/topics/"):
+		name := strings.TrimPrefix(path, "/admin/topics/")
+		switch req.Method {
+		case http.MethodGet:
+			return &route{operation: OperationGetTopic, name: name, handler: (*Server).getTopic}
+		case http.MethodDelete:
+			return &route{operation: OperationDeleteTopic, name: name, handler: (*Server).deleteTopic}
+		case http.MethodPatch:
+			return &route{operation: OperationUpdateTopic, name: name, handler: (*Server).updateTopic}
+		}
+	case path == "/admin/mirroring/topic-selection":
+		switch req.Method {
+		case http.MethodGet:
+			return &route{operation: OperationGetMirroringTopicSelection, handler: (*Server).getMirroringTopicSelection}
+		case http.MethodPost:
+			return &route{operation: OperationReplaceMirroringTopicSelection, handler: (*Server).replaceMirroringTopicSelection}
+		}
+	case path == "/admin/mirroring/active-topics":
+		if req.Method == http.MethodGet {
+			return &route{operation: OperationGetMirroringActiveTopics, handler: (*Server).getMirroringActiveTopics}
+		}
+	case path == "/admin/quotas":
+		if req.Method == http.MethodGet {
+			return &route{operation: OperationListQuotas, handler: (*Server).listQuotas}
+		}
+	case strings.HasPrefix(path, "/admin/quotas/") && len(path) > len("/admin/quotas/"):
+		name := strings.TrimPrefix(path, "/admin/quotas/")
+		switch req.Method {
+		case http.MethodPost:
+			return &route{operation: OperationCreateQuota, name: name, handler: (*Server).createQuota}
+		case http.MethodGet:
+			return &route{operation: OperationGetQuota, name: name, handler: (*Server).getQuota}
+		case http.MethodPatch:
+			return &route{operation: OperationUpdateQuota, name: name, handler: (*Server).updateQuota}
+		case http.MethodDelete:
+			return &route{operation: OperationDeleteQuota, name: name, handler: (*Server).deleteQuota}
+		}
+	}
+	return nil
+}
+
+// ServeHTTP implements http.Handler, so the server can also be mounted in another http.Server.
+func (server *Server) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	transactionID := req.Header.Get(headerNameTransactionID)
+	if transactionID == "" {
+		server.mutex.Lock()
+		server.nextIncident++
+		transactionID = fmt.Sprintf("fake-%08d", server.nextIncident)
+		server.mutex.Unlock()
+	}
+	res.Header().Set(headerNameTransactionID, transactionID)
+	res.Header().Set("Cache-Control", "no-cache, no-store")
+	res.Header().Set("Pragma", "no-cache")
+
+	matched := findRoute(req)
+	if matched == nil {
+		writeError(res, http.StatusNotFound, 0, "The requested resource was not found.")
+		return
+	}
+
+	server.mutex.Lock()
+	server.requestCounts[matched.operation]++
+	fault := server.takeFault(matched.operation)
+	server.mutex.Unlock()
+
+	if fault != nil {
+		if fault.Latency > 0 {
+			select {
+			case <-time.After(fault.Latency):
+			case <-req.Context().Done():
+				return
+			}
+		}
+		if fault.StatusCode != 0 {
+			if fault.RetryAfter > 0 {
+				res.Header().Set("Retry-After", fmt.Sprintf("%d", int64(fault.RetryAfter/time.Second)))
+			}
+			writeError(res, fault.StatusCode, 0, http.StatusText(fault.StatusCode))
+			return
+		}
+	}
+
+	if !server.authenticated(req) {
+		writeError(res, http.StatusUnauthorized, 0, "Authentication information was missing or bad.")
+		return
+	}
+
+	if req.Method == http.MethodPost || req.Method == http.MethodPatch {
+		mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+		if err != nil || mediaType != "application/json" {
+			writeError(res, http.StatusUnsupportedMediaType, 0, "Unsupported Content-Type provided.")
+			return
+		}
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	matched.handler(server, res, req, matched.name)
+}
+
+// authenticated returns true if the request carries the API key, or if the server does not require one.
+func (server *Server) authenticated(req *http.Request) bool {
+	if server.options.APIKey == "" {
+		return true
+	}
+	if user, password, ok := req.BasicAuth(); ok {
+		return user == "token" && password == server.options.APIKey
+	}
+	return req.Header.Get("Authorization") == "Bearer "+server.options.APIKey ||
+		req.Header.Get("X-Auth-Token") == server.options.APIKey
+}
+
+// errorBody is the JSON body of an error response.
+type errorBody struct {
+	ErrorCode  int    `json:"error_code"`
+	Message    string `json:"message"`
+	IncidentID string `json:"incident_id"`
+}
+
+// writeError writes an error response whose error_code combines the HTTP status and the Kafka error code.
+func writeError(res http.ResponseWriter, statusCode int, kafkaErrorCode adminrestv1.KafkaErrorCode, message string) {
+	writeJSON(res, statusCode, errorBody{
+		ErrorCode:  statusCode*100 + int(kafkaErrorCode),
+		Message:    message,
+		IncidentID: res.Header().Get(headerNameTransactionID),
+	})
+}
+
+// writeJSON writes a JSON response.
+func writeJSON(res http.ResponseWriter, statusCode int, body interface{}) {
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(statusCode)
+	if body != nil {
+		_ = json.NewEncoder(res).Encode(body)
+	}
+}
+
+// writeEmpty writes a response with an empty JSON object as its body.
+func writeEmpty(res http.ResponseWriter, statusCode int) {
+	writeJSON(res, statusCode, struct{}{})
+}
+
+// decodeBody decodes the JSON request body into body, writing a 400 response and returning false if it is not valid.
+func decodeBody(res http.ResponseWriter, req *http.Request, body interface{}) bool {
+	err := json.NewDecoder(req.Body).Decode(body)
+	if err != nil {
+		writeError(res, http.StatusBadRequest, 0, "The request body was invalid JSON.")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,160 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Server`, func() {
+	var server *Server
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe(`Authentication`, func() {
+		BeforeEach(func() {
+			server = NewServer(&ServerOptions{APIKey: "secret"})
+		})
+		It(`Accept requests from NewClient`, func() {
+			client, err := server.NewClient()
+			Expect(err).To(BeNil())
+			_, response, err := client.ListTopics(client.NewListTopicsOptions())
+			Expect(err).To(BeNil())
+			Expect(response.StatusCode).To(Equal(200))
+		})
+		It(`Accept a bearer token`, func() {
+			client, err := adminrestv1.NewAdminrestV1(&adminrestv1.AdminrestV1Options{
+				URL:           server.URL,
+				Authenticator: &core.BearerTokenAuthenticator{BearerToken: "secret"},
+			})
+			Expect(err).To(BeNil())
+			_, _, err = client.ListTopics(client.NewListTopicsOptions())
+			Expect(err).To(BeNil())
+		})
+		It(`Reject a request with the wrong key`, func() {
+			client, err := adminrestv1.NewAdminrestV1(&adminrestv1.AdminrestV1Options{
+				URL:           server.URL,
+				Authenticator: &core.BasicAuthenticator{Username: "token", Password: "wrong"},
+			})
+			Expect(err).To(BeNil())
+			_, _, err = client.ListTopics(client.NewListTopicsOptions())
+			Expect(errors.Is(err, adminrestv1.ErrUnauthorized)).To(BeTrue())
+		})
+	})
+
+	Describe(`Requests`, func() {
+		BeforeEach(func() {
+			server = NewServer(nil)
+		})
+		It(`Honor the transaction ID of the request`, func() {
+			client, _ := server.NewClient()
+			_, _, err := client.GetTopic(client.NewGetTopicOptions("missing").SetHeaders(map[string]string{
+				"X-Global-Transaction-Id": "my-transaction",
+			}))
+			var apiError *adminrestv1.APIError
+			Expect(errors.As(err, &apiError)).To(BeTrue())
+			Expect(apiError.IncidentID).To(Equal("my-transaction"))
+			Expect(apiError.Response.Headers.Get("X-Global-Transaction-Id")).To(Equal("my-transaction"))
+		})
+		It(`Reject an unknown path`, func() {
+			response, err := http.Get(server.URL + "/admin/unknown")
+			Expect(err).To(BeNil())
+			Expect(response.StatusCode).To(Equal(404))
+		})
+		It(`Reject a body that is not JSON`, func() {
+			response, err := http.Post(server.URL+"/admin/topics", "application/json", strings.NewReader("{"))
+			Expect(err).To(BeNil())
+			Expect(response.StatusCode).To(Equal(400))
+
+			response, err = http.Post(server.URL+"/admin/topics", "text/plain", strings.NewReader("{}"))
+			Expect(err).To(BeNil())
+			Expect(response.StatusCode).To(Equal(415))
+		})
+		It(`Count requests by operation`, func() {
+			client, _ := server.NewClient()
+			_, _, _ = client.ListTopics(client.NewListTopicsOptions())
+			_, _, _ = client.ListTopics(client.NewListTopicsOptions())
+			_, _, _ = client.GetTopic(client.NewGetTopicOptions("missing"))
+			Expect(server.RequestCount(OperationListTopics)).To(Equal(2))
+			Expect(server.RequestCount(OperationGetTopic)).To(Equal(1))
+			Expect(server.RequestCount(OperationCreateTopic)).To(Equal(0))
+		})
+	})
+
+	Describe(`InjectFault(fault Fault)`, func() {
+		BeforeEach(func() {
+			server = NewServer(nil)
+		})
+		It(`Fail a limited number of requests for one operation`, func() {
+			client, _ := server.NewClient()
+			server.InjectFault(Fault{Operation: OperationListTopics, StatusCode: http.StatusServiceUnavailable, Times: 2})
+
+			for i := 0; i < 2; i++ {
+				_, response, err := client.ListTopics(client.NewListTopicsOptions())
+				Expect(err).ToNot(BeNil())
+				Expect(response.StatusCode).To(Equal(503))
+			}
+			_, _, err := client.GetTopic(client.NewGetTopicOptions("missing"))
+			Expect(errors.Is(err, adminrestv1.ErrTopicNotFound)).To(BeTrue())
+
+			_, response, err := client.ListTopics(client.NewListTopicsOptions())
+			Expect(err).To(BeNil())
+			Expect(response.StatusCode).To(Equal(200))
+		})
+		It(`Throttle every request until the faults are cleared`, func() {
+			client, _ := server.NewClient()
+			server.InjectFault(Fault{StatusCode: http.StatusTooManyRequests, RetryAfter: 2 * time.Second})
+
+			_, response, err := client.ListQuotas(client.NewListQuotasOptions())
+			Expect(err).ToNot(BeNil())
+			Expect(response.StatusCode).To(Equal(429))
+			Expect(response.Headers.Get("Retry-After")).To(Equal("2"))
+			var apiError *adminrestv1.APIError
+			Expect(errors.As(err, &apiError)).To(BeTrue())
+			Expect(apiError.ErrorCode).To(Equal(int64(42900)))
+
+			server.ClearFaults()
+			_, _, err = client.ListQuotas(client.NewListQuotasOptions())
+			Expect(err).To(BeNil())
+		})
+		It(`Delay a request`, func() {
+			client, _ := server.NewClient()
+			server.InjectFault(Fault{Latency: 200 * time.Millisecond, Times: 1})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			_, _, err := client.ListTopicsWithContext(ctx, client.NewListTopicsOptions())
+			Expect(err).ToNot(BeNil())
+
+			start := time.Now()
+			_, _, err = client.ListTopics(client.NewListTopicsOptions())
+			Expect(err).To(BeNil())
+			Expect(time.Since(start)).To(BeNumerically("<", 200*time.Millisecond))
+		})
+	})
+})
@@ -0,0 +1,436 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+)
+
+// DefaultReplicationFactor is the replication factor reported for topics when ServerOptions.ReplicationFactor is not
+// set.
+const DefaultReplicationFactor int64 = 3
+
+// MaxPartitions is the largest partition count accepted for a topic.
+const MaxPartitions int64 = 1000
+
+// maxTopicNameLength is the longest topic name accepted by Kafka.
+const maxTopicNameLength = 249
+
+// legalTopicName matches the characters Kafka accepts in a topic name.
+var legalTopicName = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// DefaultTopicConfigs are the config values reported for a topic when they have not been set.
+var DefaultTopicConfigs = map[string]string{
+	"cleanup.policy":      "delete",
+	"min.insync.replicas": "2",
+	"retention.bytes":     "1073741824",
+	"retention.ms":        "86400000",
+	"segment.bytes":       "536870912",
+	"segment.index.bytes": "10485760",
+	"segment.ms":          "604800000",
+}
+
+// updatableConfigs are the config names accepted by UpdateTopic.
+var updatableConfigs = map[string]bool{
+	"cleanup.policy":      true,
+	"retention.bytes":     true,
+	"retention.ms":        true,
+	"segment.bytes":       true,
+	"segment.index.bytes": true,
+	"segment.ms":          true,
+}
+
+// kafkaTopicConfigs are the config names accepted by CreateTopic.
+var kafkaTopicConfigs = func() map[string]bool {
+	names := make(map[string]bool)
+	for _, name := range adminrestv1.TypedTopicConfigNames() {
+		names[name] = true
+	}
+	return names
+}()
+
+// Topic : A topic held by a Server.
+type Topic struct {
+	// The name of the topic.
+	Name string
+
+	// The number of partitions.
+	Partitions int64
+
+	// The configs that have been set on the topic, keyed by config name. Configs that are not set report the value in
+	// DefaultTopicConfigs. Like the service, GetTopic and ListTopics only report the configs in DefaultTopicConfigs.
+	Configs map[string]string
+}
+
+// copyTopic returns a deep copy of the topic.
+func copyTopic(topic *Topic) Topic {
+	result := Topic{Name: topic.Name, Partitions: topic.Partitions, Configs: make(map[string]string)}
+	for name, value := range topic.Configs {
+		result.Configs[name] = value
+	}
+	return result
+}
+
+// AddTopic adds a topic to the server, replacing any topic with the same name. A partition count of zero is
+// stored as one.
+func (server *Server) AddTopic(topic Topic) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	if topic.Partitions == 0 {
+		topic.Partitions = 1
+	}
+	stored := copyTopic(&topic)
+	server.topics[topic.Name] = &stored
+}
+
+// Topic returns a copy of the topic with the specified name.
+func (server *Server) Topic(name string) (topic Topic, ok bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	stored, ok := server.topics[name]
+	if ok {
+		topic = copyTopic(stored)
+	}
+	return
+}
+
+// TopicNames returns the names of the topics held by the server, in sorted order.
+func (server *Server) TopicNames() []string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return server.sortedTopicNames()
+}
+
+// sortedTopicNames returns the topic names in sorted order. The caller must hold the server mutex.
+func (server *Server) sortedTopicNames() []string {
+	names := make([]string, 0, len(server.topics))
+	for name := range server.topics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// topicDetail returns the JSON representation of a topic, as returned by ListTopics and GetTopic.
+func (server *Server) topicDetail(topic *Topic) map[string]interface{} {
+	configs := make(map[string]string)
+	for name, value := range DefaultTopicConfigs {
+		configs[name] = value
+	}
+	for name, value := range topic.Configs {
+		if _, reported := DefaultTopicConfigs[name]; reported {
+			configs[name] = value
+		}
+	}
+	retentionMs, _ := strconv.ParseInt(configs["retention.ms"], 10, 64)
+
+	var assignments []map[string]interface{}
+	for partition := int64(0); partition < topic.Partitions; partition++ {
+		var replicas []int64
+		for replica := int64(0); replica < server.options.ReplicationFactor; replica++ {
+			replicas = append(replicas, (partition+replica)%server.options.ReplicationFactor)
+		}
+		assignments = append(assignments, map[string]interface{}{
+			"id":      partition,
+			"brokers": map[string]interface{}{"replicas": replicas},
+		})
+	}
+
+	return map[string]interface{}{
+		"name":               topic.Name,
+		"partitions":         topic.Partitions,
+		"replicationFactor":  server.options.ReplicationFactor,
+		"retentionMs":        retentionMs,
+		"cleanupPolicy":      configs["cleanup.policy"],
+		"configs":            configs,
+		"replicaAssignments": assignments,
+	}
+}
+
+// configEntry is a config in a CreateTopic or UpdateTopic request body.
+type configEntry struct {
+	Name           string  `json:"name"`
+	Value          *string `json:"value"`
+	ResetToDefault bool    `json:"reset_to_default"`
+}
+
+// validateConfig returns a description of the problem with a config value, or an empty string if it is valid. Like
+// the service, it accepts the Kafka topic configs that adminrestv1.TypedTopicConfigs has a field for; UpdateTopic
+// only accepts those in updatableConfigs.
+func validateConfig(name string, value string) string {
+	switch name {
+	case "cleanup.policy":
+		switch value {
+		case "delete", "compact", "compact,delete", "delete,compact":
+			return ""
+		}
+		return fmt.Sprintf("Invalid value %s for configuration cleanup.policy", value)
+	case "retention.ms", "retention.bytes":
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || number < -1 {
+			return fmt.Sprintf("Invalid value %s for configuration %s", value, name)
+		}
+	case "segment.bytes", "segment.index.bytes", "segment.ms", "min.insync.replicas":
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || number < 1 {
+			return fmt.Sprintf("Invalid value %s for configuration %s", value, name)
+		}
+	default:
+		if !kafkaTopicConfigs[name] {
+			return fmt.Sprintf("Unknown topic config name: %s", name)
+		}
+		_, err := adminrestv1.ParseConfigCreates([]adminrestv1.ConfigCreate{{Name: &name, Value: &value}})
+		if err != nil {
+			return fmt.Sprintf("Invalid value %s for configuration %s", value, name)
+		}
+	}
+	return ""
+}
+
+// createTopic handles CreateTopic.
+func (server *Server) createTopic(res http.ResponseWriter, req *http.Request, _ string) {
+	var body struct {
+		Name           string        `json:"name"`
+		Partitions     *int64        `json:"partitions"`
+		PartitionCount *int64        `json:"partition_count"`
+		Configs        []configEntry `json:"configs"`
+	}
+	if !decodeBody(res, req, &body) {
+		return
+	}
+
+	if body.Name == "" || len(body.Name) > maxTopicNameLength || !legalTopicName.MatchString(body.Name) || body.Name == "." || body.Name == ".." {
+		writeError(res, http.StatusUnprocessableEntity, adminrestv1.KafkaErrorInvalidTopicException,
+			fmt.Sprintf("Topic name '%s' is illegal", body.Name))
+		return
+	}
+	if _, exists := server.topics[body.Name]; exists {
+		writeError(res, http.StatusUnprocessableEntity, adminrestv1.KafkaErrorTopicAlreadyExists,
+			fmt.Sprintf("Topic '%s' already exists.", body.Name))
+		return
+	}
+
+	partitions := int64(1)
+	if body.PartitionCount != nil {
+		partitions = *body.PartitionCount
+	} else if body.Partitions != nil {
+		partitions = *body.Partitions
+	}
+	if partitions < 1 || partitions > MaxPartitions {
+		writeError(res, http.StatusUnprocessableEntity, adminrestv1.KafkaErrorInvalidPartitions,
+			fmt.Sprintf("Number of partitions must be between 1 and %d.", MaxPartitions))
+		return
+	}
+
+	topic := &Topic{Name: body.Name, Partitions: partitions, Configs: make(map[string]string)}
+	for _, config := range body.Configs {
+		if config.Value == nil {
+			writeError(res, http.StatusUnprocessableEntity, adminrestv1.KafkaErrorInvalidConfig,
+				fmt.Sprintf("Missing value for configuration %s", config.Name))
+			return
+		}
+		if problem := validateConfig(config.Name, *config.Value); problem != "" {
+			writeError(res, http.StatusUnprocessableEntity, adminrestv1.KafkaErrorInvalidConfig, problem)
+			return
+		}
+		topic.Configs[config.Name] = *config.Value
+	}
+
+	server.topics[topic.Name] = topic
+	writeEmpty(res, http.StatusAccepted)
+}
+
+// queryInt returns the value of an integer query parameter, or def if it is not set.
+func queryInt(query url.Values, name string, def int64) (int64, error) {
+	value := query.Get(name)
+	if value == "" {
+		return def, nil
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+// listTopics handles ListTopics.
+func (server *Server) listTopics(res http.ResponseWriter, req *http.Request, _ string) {
+	query := req.URL.Query()
+	matches, err := adminrestv1.TopicFilterMatcher(query.Get("topic_filter"))
+	if err != nil {
+		writeError(res, http.StatusBadRequest, 0, fmt.Sprintf("Invalid topic filter: %s", err.Error()))
+		return
+	}
+	perPage, err := queryInt(query, "per_page", 0)
+	if err != nil || perPage < 0 {
+		writeError(res, http.StatusBadRequest, 0, "Invalid value for per_page")
+		return
+	}
+	page, err := queryInt(query, "page", 1)
+	if err != nil || page < 1 {
+		writeError(res, http.StatusBadRequest, 0, "Invalid value for page")
+		return
+	}
+	if server.options.MaxPerPage > 0 && (perPage == 0 || perPage > server.options.MaxPerPage) {
+		perPage = server.options.MaxPerPage
+	}
+
+	var names []string
+	for _, name := range server.sortedTopicNames() {
+		if matches(name) {
+			names = append(names, name)
+		}
+	}
+	total := int64(len(names))
+
+	if perPage > 0 {
+		start := (page - 1) * perPage
+		end := start + perPage
+		if start > total {
+			start = total
+		}
+		if end > total {
+			end = total
+		}
+		names = names[start:end]
+		res.Header().Set("Link", linkHeader(req, page, perPage, total))
+	}
+
+	topics := []map[string]interface{}{}
+	for _, name := range names {
+		topics = append(topics, server.topicDetail(server.topics[name]))
+	}
+	res.Header().Set("X-Total-Count", strconv.FormatInt(total, 10))
+	writeJSON(res, http.StatusOK, topics)
+}
+
+// linkHeader returns the Link header for a page of ListTopics results.
+func linkHeader(req *http.Request, page int64, perPage int64, total int64) string {
+	lastPage := (total + perPage - 1) / perPage
+	if lastPage < 1 {
+		lastPage = 1
+	}
+	link := func(target int64, rel string) string {
+		query := req.URL.Query()
+		query.Set("page", strconv.FormatInt(target, 10))
+		query.Set("per_page", strconv.FormatInt(perPage, 10))
+		return fmt.Sprintf(`<%s?%s>; rel="%s"`, req.URL.Path, query.Encode(), rel)
+	}
+
+	links := []string{}
+	if page < lastPage {
+		links = append(links, link(page+1, "next"))
+	}
+	if page > 1 {
+		links = append(links, link(page-1, "prev"))
+	}
+	links = append(links, link(1, "first"), link(lastPage, "last"))
+	return strings.Join(links, ", ")
+}
+
+// getTopic handles GetTopic.
+func (server *Server) getTopic(res http.ResponseWriter, req *http.Request, name string) {
+	topic, exists := server.topics[name]
+	if !exists {
+		writeTopicNotFound(res, name)
+		return
+	}
+	writeJSON(res, http.StatusOK, server.topicDetail(topic))
+}
+
+// deleteTopic handles DeleteTopic.
+func (server *Server) deleteTopic(res http.ResponseWriter, req *http.Request, name string) {
+	if _, exists := server.topics[name]; !exists {
+		writeTopicNotFound(res, name)
+		return
+	}
+	delete(server.topics, name)
+	writeEmpty(res, http.StatusAccepted)
+}
+
+// updateTopic handles UpdateTopic.
+func (server *Server) updateTopic(res http.ResponseWriter, req *http.Request, name string) {
+	var body struct {
+		NewTotalPartitionCount *int64        `json:"new_total_partition_count"`
+		Configs                []configEntry `json:"configs"`
+	}
+	if !decodeBody(res, req, &body) {
+		return
+	}
+
+	topic, exists := server.topics[name]
+	if !exists {
+		writeTopicNotFound(res, name)
+		return
+	}
+
+	if body.NewTotalPartitionCount != nil {
+		count := *body.NewTotalPartitionCount
+		if count <= topic.Partitions {
+			writeError(res, http.StatusUnprocessableEntity, adminrestv1.KafkaErrorInvalidPartitions,
+				fmt.Sprintf("Topic currently has %d partitions, which is higher than the requested %d.", topic.Partitions, count))
+			return
+		}
+		if count > MaxPartitions {
+			writeError(res, http.StatusUnprocessableEntity, adminrestv1.KafkaErrorInvalidPartitions,
+				fmt.Sprintf("Number of partitions must not be larger than %d.", MaxPartitions))
+			return
+		}
+	}
+
+	for _, config := range body.Configs {
+		if !updatableConfigs[config.Name] {
+			writeError(res, http.StatusUnprocessableEntity, adminrestv1.KafkaErrorInvalidConfig,
+				fmt.Sprintf("Config %s cannot be updated", config.Name))
+			return
+		}
+		if config.ResetToDefault {
+			continue
+		}
+		if config.Value == nil {
+			writeError(res, http.StatusUnprocessableEntity, adminrestv1.KafkaErrorInvalidConfig,
+				fmt.Sprintf("Missing value for configuration %s", config.Name))
+			return
+		}
+		if problem := validateConfig(config.Name, *config.Value); problem != "" {
+			writeError(res, http.StatusUnprocessableEntity, adminrestv1.KafkaErrorInvalidConfig, problem)
+			return
+		}
+	}
+
+	if body.NewTotalPartitionCount != nil {
+		topic.Partitions = *body.NewTotalPartitionCount
+	}
+	for _, config := range body.Configs {
+		if config.ResetToDefault {
+			delete(topic.Configs, config.Name)
+		} else {
+			topic.Configs[config.Name] = *config.Value
+		}
+	}
+	writeEmpty(res, http.StatusAccepted)
+}
+
+// writeTopicNotFound writes the response for a topic that does not exist.
+func writeTopicNotFound(res http.ResponseWriter, name string) {
+	writeError(res, http.StatusNotFound, adminrestv1.KafkaErrorUnknownTopicOrPartition,
+		fmt.Sprintf("Topic '%s' does not exist.", name))
+}
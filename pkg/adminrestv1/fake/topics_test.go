@@ -0,0 +1,226 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fake
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Topics`, func() {
+	var server *Server
+	var client *adminrestv1.AdminrestV1
+
+	BeforeEach(func() {
+		server = NewServer(nil)
+		var err error
+		client, err = server.NewClient()
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe(`CreateTopic`, func() {
+		It(`Create a topic with configs`, func() {
+			options := client.NewCreateTopicOptions().
+				SetName("orders").
+				SetPartitionCount(3).
+				SetConfigs([]adminrestv1.ConfigCreate{{Name: core.StringPtr("retention.ms"), Value: core.StringPtr("3600000")}})
+			response, err := client.CreateTopic(options)
+			Expect(err).To(BeNil())
+			Expect(response.StatusCode).To(Equal(202))
+
+			topic, ok := server.Topic("orders")
+			Expect(ok).To(BeTrue())
+			Expect(topic.Partitions).To(Equal(int64(3)))
+			Expect(topic.Configs).To(Equal(map[string]string{"retention.ms": "3600000"}))
+
+			detail, _, err := client.GetTopic(client.NewGetTopicOptions("orders"))
+			Expect(err).To(BeNil())
+			Expect(*detail.Partitions).To(Equal(int64(3)))
+			Expect(*detail.ReplicationFactor).To(Equal(DefaultReplicationFactor))
+			Expect(*detail.RetentionMs).To(Equal(int64(3600000)))
+			Expect(*detail.CleanupPolicy).To(Equal("delete"))
+			Expect(*detail.Configs.RetentionMs).To(Equal("3600000"))
+			Expect(*detail.Configs.SegmentMs).To(Equal(DefaultTopicConfigs["segment.ms"]))
+			Expect(detail.ReplicaAssignments).To(HaveLen(3))
+			Expect(detail.ReplicaAssignments[1].Brokers.Replicas).To(Equal([]int64{1, 2, 0}))
+		})
+		It(`Accept Kafka topic configs that GetTopic does not report`, func() {
+			_, err := client.CreateTopic(client.NewCreateTopicOptions().SetName("orders").SetConfigs([]adminrestv1.ConfigCreate{
+				{Name: core.StringPtr("max.message.bytes"), Value: core.StringPtr("2097152")},
+				{Name: core.StringPtr("min.insync.replicas"), Value: core.StringPtr("1")},
+			}))
+			Expect(err).To(BeNil())
+			topic, _ := server.Topic("orders")
+			Expect(topic.Configs).To(Equal(map[string]string{"max.message.bytes": "2097152", "min.insync.replicas": "1"}))
+
+			detail, _, err := client.GetTopic(client.NewGetTopicOptions("orders"))
+			Expect(err).To(BeNil())
+			Expect(*detail.Configs.MinInsyncReplicas).To(Equal("1"))
+
+			_, err = client.CreateTopic(client.NewCreateTopicOptions().SetName("payments").
+				SetConfigs([]adminrestv1.ConfigCreate{{Name: core.StringPtr("max.message.bytes"), Value: core.StringPtr("large")}}))
+			Expect(errors.Is(err, adminrestv1.ErrInvalidConfig)).To(BeTrue())
+
+			_, err = client.UpdateTopic(client.NewUpdateTopicOptions("orders").
+				SetConfigs([]adminrestv1.ConfigUpdate{{Name: core.StringPtr("max.message.bytes"), Value: core.StringPtr("1048576")}}))
+			Expect(errors.Is(err, adminrestv1.ErrInvalidConfig)).To(BeTrue())
+		})
+		It(`Default to one partition`, func() {
+			_, err := client.CreateTopic(client.NewCreateTopicOptions().SetName("orders"))
+			Expect(err).To(BeNil())
+			topic, _ := server.Topic("orders")
+			Expect(topic.Partitions).To(Equal(int64(1)))
+		})
+		It(`Reject a topic that already exists`, func() {
+			server.AddTopic(Topic{Name: "orders"})
+			_, err := client.CreateTopic(client.NewCreateTopicOptions().SetName("orders"))
+			Expect(errors.Is(err, adminrestv1.ErrTopicAlreadyExists)).To(BeTrue())
+		})
+		It(`Reject invalid requests`, func() {
+			_, err := client.CreateTopic(client.NewCreateTopicOptions().SetName("bad name"))
+			var apiError *adminrestv1.APIError
+			Expect(errors.As(err, &apiError)).To(BeTrue())
+			Expect(apiError.ErrorCode).To(Equal(int64(42217)))
+
+			_, err = client.CreateTopic(client.NewCreateTopicOptions().SetName("orders").SetPartitionCount(1001))
+			Expect(errors.Is(err, adminrestv1.ErrInvalidPartitions)).To(BeTrue())
+
+			_, err = client.CreateTopic(client.NewCreateTopicOptions().SetName("orders").
+				SetConfigs([]adminrestv1.ConfigCreate{{Name: core.StringPtr("cleanup.policy"), Value: core.StringPtr("forever")}}))
+			Expect(errors.Is(err, adminrestv1.ErrInvalidConfig)).To(BeTrue())
+
+			_, err = client.CreateTopic(client.NewCreateTopicOptions().SetName("orders").
+				SetConfigs([]adminrestv1.ConfigCreate{{Name: core.StringPtr("unknown.config"), Value: core.StringPtr("1")}}))
+			Expect(errors.Is(err, adminrestv1.ErrInvalidConfig)).To(BeTrue())
+
+			Expect(server.TopicNames()).To(BeEmpty())
+		})
+	})
+
+	Describe(`ListTopics`, func() {
+		BeforeEach(func() {
+			for i := 1; i <= 12; i++ {
+				server.AddTopic(Topic{Name: fmt.Sprintf("topic-%02d", i)})
+			}
+			server.AddTopic(Topic{Name: "other"})
+		})
+		It(`List every topic`, func() {
+			topics, response, err := client.ListTopics(client.NewListTopicsOptions())
+			Expect(err).To(BeNil())
+			Expect(topics).To(HaveLen(13))
+			Expect(response.Headers.Get("X-Total-Count")).To(Equal("13"))
+		})
+		It(`Filter and page the topics`, func() {
+			topics, response, err := client.ListTopics(client.NewListTopicsOptions().SetTopicFilter("topic-*").SetPerPage(5).SetPage(3))
+			Expect(err).To(BeNil())
+			Expect(topics).To(HaveLen(2))
+			Expect(*topics[0].Name).To(Equal("topic-11"))
+			Expect(response.Headers.Get("X-Total-Count")).To(Equal("12"))
+			Expect(response.Headers.Get("Link")).To(ContainSubstring(`page=2&per_page=5&topic_filter=topic-%2A>; rel="prev"`))
+			Expect(response.Headers.Get("Link")).To(ContainSubstring(`page=3&per_page=5&topic_filter=topic-%2A>; rel="last"`))
+			Expect(response.Headers.Get("Link")).ToNot(ContainSubstring(`rel="next"`))
+
+			topics, _, err = client.ListTopics(client.NewListTopicsOptions().SetTopicFilter("/.*-0[1-3]/"))
+			Expect(err).To(BeNil())
+			Expect(topics).To(HaveLen(3))
+		})
+		It(`Work with ListAllTopics`, func() {
+			result, err := client.ListAllTopics(client.NewListAllTopicsOptions().SetPerPage(4))
+			Expect(err).To(BeNil())
+			Expect(result.Topics).To(HaveLen(13))
+			Expect(server.RequestCount(OperationListTopics)).To(Equal(4))
+		})
+		It(`Reject an invalid filter`, func() {
+			_, response, err := client.ListTopics(client.NewListTopicsOptions().SetTopicFilter("/(/"))
+			Expect(err).ToNot(BeNil())
+			Expect(response.StatusCode).To(Equal(400))
+		})
+	})
+
+	Describe(`ListTopics with a page size limit`, func() {
+		BeforeEach(func() {
+			server.Close()
+			server = NewServer(&ServerOptions{MaxPerPage: 3})
+			client, _ = server.NewClient()
+			for i := 1; i <= 7; i++ {
+				server.AddTopic(Topic{Name: fmt.Sprintf("topic-%02d", i)})
+			}
+		})
+		It(`Cap the page size`, func() {
+			topics, _, err := client.ListTopics(client.NewListTopicsOptions().SetPerPage(10))
+			Expect(err).To(BeNil())
+			Expect(topics).To(HaveLen(3))
+
+			result, err := client.ListAllTopics(client.NewListAllTopicsOptions().SetPerPage(10))
+			Expect(err).To(BeNil())
+			Expect(result.Topics).To(HaveLen(7))
+		})
+	})
+
+	Describe(`UpdateTopic and DeleteTopic`, func() {
+		BeforeEach(func() {
+			server.AddTopic(Topic{Name: "orders", Partitions: 2, Configs: map[string]string{"cleanup.policy": "compact"}})
+		})
+		It(`Update partitions and configs`, func() {
+			options := client.NewUpdateTopicOptions("orders").
+				SetNewTotalPartitionCount(4).
+				SetConfigs([]adminrestv1.ConfigUpdate{
+					{Name: core.StringPtr("retention.ms"), Value: core.StringPtr("1000")},
+					{Name: core.StringPtr("cleanup.policy"), ResetToDefault: core.BoolPtr(true)},
+				})
+			response, err := client.UpdateTopic(options)
+			Expect(err).To(BeNil())
+			Expect(response.StatusCode).To(Equal(202))
+
+			topic, _ := server.Topic("orders")
+			Expect(topic.Partitions).To(Equal(int64(4)))
+			Expect(topic.Configs).To(Equal(map[string]string{"retention.ms": "1000"}))
+		})
+		It(`Reject invalid updates without changing the topic`, func() {
+			_, err := client.UpdateTopic(client.NewUpdateTopicOptions("orders").SetNewTotalPartitionCount(1))
+			Expect(errors.Is(err, adminrestv1.ErrInvalidPartitions)).To(BeTrue())
+
+			_, err = client.UpdateTopic(client.NewUpdateTopicOptions("orders").
+				SetNewTotalPartitionCount(5).
+				SetConfigs([]adminrestv1.ConfigUpdate{{Name: core.StringPtr("min.insync.replicas"), Value: core.StringPtr("1")}}))
+			Expect(errors.Is(err, adminrestv1.ErrInvalidConfig)).To(BeTrue())
+
+			_, err = client.UpdateTopic(client.NewUpdateTopicOptions("missing").SetNewTotalPartitionCount(5))
+			Expect(errors.Is(err, adminrestv1.ErrTopicNotFound)).To(BeTrue())
+
+			topic, _ := server.Topic("orders")
+			Expect(topic.Partitions).To(Equal(int64(2)))
+		})
+		It(`Delete a topic`, func() {
+			response, err := client.DeleteTopic(client.NewDeleteTopicOptions("orders"))
+			Expect(err).To(BeNil())
+			Expect(response.StatusCode).To(Equal(202))
+			Expect(server.TopicNames()).To(BeEmpty())
+
+			_, err = client.DeleteTopic(client.NewDeleteTopicOptions("orders"))
+			Expect(errors.Is(err, adminrestv1.ErrTopicNotFound)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,27 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminrestv1
+
+import (
+	"github.com/IBM/eventstreams-go-sdk/pkg/middleware"
+)
+
+// Use adds middlewares to the end of the chain that every request of the client runs through. Clones made before the
+// call are not affected.
+func (adminrest *AdminrestV1) Use(middlewares ...middleware.Middleware) {
+	adminrest.middlewares = middleware.Append(adminrest.middlewares, middlewares...)
+}
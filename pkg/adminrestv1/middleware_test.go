@@ -0,0 +1,155 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminrestv1
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/middleware"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`AdminrestV1 middlewares`, func() {
+	var testServer *httptest.Server
+	var calls []string
+	var correlationIDs []string
+
+	// recording returns a middleware that records the operation and outcome of every call.
+	recording := func(next middleware.Handler) middleware.Handler {
+		return func(call *middleware.Call) (*core.DetailedResponse, error) {
+			response, err := next(call)
+			var apiError *APIError
+			switch {
+			case errors.As(err, &apiError):
+				calls = append(calls, call.Service+" "+call.Operation+" "+apiError.Message)
+			case err != nil:
+				calls = append(calls, call.Service+" "+call.Operation+" "+err.Error())
+			default:
+				calls = append(calls, call.Service+" "+call.Operation+" "+http.StatusText(response.StatusCode))
+			}
+			return response, err
+		}
+	}
+	correlationID := middleware.RequestHeader("X-Correlation-Id", func(call *middleware.Call) string { return "abc-123" })
+
+	BeforeEach(func() {
+		calls = nil
+		correlationIDs = nil
+		testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			correlationIDs = append(correlationIDs, req.Header.Get("X-Correlation-Id"))
+			res.Header().Set("Content-Type", "application/json")
+			if req.URL.Path == "/admin/topics/missing" {
+				res.WriteHeader(http.StatusNotFound)
+				_, _ = res.Write([]byte(`{"error_code":40403,"message":"Topic not found."}`))
+				return
+			}
+			res.WriteHeader(http.StatusOK)
+			_, _ = res.Write([]byte(`[{"name":"orders"}]`))
+		}))
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	It(`Run every request through the middlewares in the options`, func() {
+		service, err := NewAdminrestV1(&AdminrestV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+			Middlewares:   []middleware.Middleware{recording, correlationID},
+		})
+		Expect(err).To(BeNil())
+
+		topics, _, err := service.ListTopics(service.NewListTopicsOptions())
+		Expect(err).To(BeNil())
+		Expect(topics).To(HaveLen(1))
+		_, _, err = service.GetTopic(service.NewGetTopicOptions("missing"))
+		Expect(errors.Is(err, ErrTopicNotFound)).To(BeTrue())
+
+		Expect(calls).To(Equal([]string{"adminrest ListTopics OK", "adminrest GetTopic Topic not found."}))
+		Expect(correlationIDs).To(Equal([]string{"abc-123", "abc-123"}))
+	})
+	It(`Add middlewares with Use without changing earlier clones`, func() {
+		service, err := NewAdminrestV1(&AdminrestV1Options{URL: testServer.URL, Authenticator: &core.NoAuthAuthenticator{}})
+		Expect(err).To(BeNil())
+		clone := service.Clone()
+		service.Use(recording)
+
+		_, _, err = clone.ListTopics(clone.NewListTopicsOptions())
+		Expect(err).To(BeNil())
+		Expect(calls).To(BeEmpty())
+
+		_, _, err = service.ListTopics(service.NewListTopicsOptions())
+		Expect(err).To(BeNil())
+		Expect(calls).To(Equal([]string{"adminrest ListTopics OK"}))
+	})
+	It(`Return the error of a middleware that does not send the request`, func() {
+		service, err := NewAdminrestV1(&AdminrestV1Options{URL: testServer.URL, Authenticator: &core.NoAuthAuthenticator{}})
+		Expect(err).To(BeNil())
+		service.Use(recording, func(next middleware.Handler) middleware.Handler {
+			return func(call *middleware.Call) (*core.DetailedResponse, error) {
+				return nil, errors.New("request not signed")
+			}
+		})
+
+		_, err = service.DeleteTopic(service.NewDeleteTopicOptions("orders"))
+		Expect(err).To(MatchError("request not signed"))
+		Expect(calls).To(Equal([]string{"adminrest DeleteTopic request not signed"}))
+		Expect(correlationIDs).To(BeEmpty())
+	})
+	It(`Report the attempts made when retries are enabled`, func() {
+		failures := 2
+		retryServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.Header().Set("Content-Type", "application/json")
+			if failures > 0 {
+				failures--
+				res.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = res.Write([]byte(`{"error_code":50300,"message":"Service unavailable."}`))
+				return
+			}
+			_, _ = res.Write([]byte(`[]`))
+		}))
+		defer retryServer.Close()
+
+		var attempts []int
+		service, err := NewAdminrestV1(&AdminrestV1Options{
+			URL:           retryServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+			Middlewares: []middleware.Middleware{func(next middleware.Handler) middleware.Handler {
+				return func(call *middleware.Call) (*core.DetailedResponse, error) {
+					response, err := next(call)
+					attempts = append(attempts, call.Attempts)
+					return response, err
+				}
+			}},
+		})
+		Expect(err).To(BeNil())
+
+		_, _, err = service.ListTopics(service.NewListTopicsOptions())
+		Expect(err).ToNot(BeNil())
+		service.EnableRetries(3, 10*time.Millisecond)
+		_, _, err = service.ListTopics(service.NewListTopicsOptions())
+		Expect(err).To(BeNil())
+		_, _, err = service.ListTopics(service.NewListTopicsOptions())
+		Expect(err).To(BeNil())
+		Expect(attempts).To(Equal([]int{1, 2, 1}))
+	})
+})
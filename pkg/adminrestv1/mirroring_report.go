@@ -0,0 +1,163 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminrestv1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MirroringCoverage : The result of MirroringReport, comparing the mirroring topic selection of a target instance with
+// the topics of its source instance and the topics being actively mirrored.
+type MirroringCoverage struct {
+	// The patterns of the topic selection of the target instance.
+	Includes []string `json:"includes"`
+
+	// The source topics matched by at least one pattern, in order of name.
+	Selected []string `json:"selected"`
+
+	// The source topics matched by no pattern, in order of name.
+	Unmatched []string `json:"unmatched"`
+
+	// The selected topics that are not being actively mirrored, in order of name.
+	SelectedNotActive []string `json:"selected_not_active"`
+
+	// The actively mirrored topics that no pattern matches any more, or that no longer exist in the source, in order of
+	// name.
+	ActiveNotSelected []string `json:"active_not_selected"`
+
+	// The patterns that match no source topic, in the order of the selection.
+	UnusedPatterns []string `json:"unused_patterns"`
+
+	// The patterns that use Java syntax outside the RE2 subset of the regexp package, in the order of the selection.
+	// They are not matched against the topics, so Unmatched and ActiveNotSelected may list topics that they select.
+	UnverifiablePatterns []string `json:"unverifiable_patterns"`
+}
+
+// Covered returns true if exactly the selected topics are being mirrored. It returns false when the selection has
+// unverifiable patterns, because the topics they select are not known.
+func (coverage *MirroringCoverage) Covered() bool {
+	return len(coverage.SelectedNotActive) == 0 && len(coverage.ActiveNotSelected) == 0 &&
+		len(coverage.UnverifiablePatterns) == 0
+}
+
+// String returns a summary of the coverage, followed by a line for each topic or pattern that needs attention.
+func (coverage *MirroringCoverage) String() string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%d patterns select %d source topics, %d unmatched\n",
+		len(coverage.Includes), len(coverage.Selected), len(coverage.Unmatched))
+	for _, name := range coverage.SelectedNotActive {
+		fmt.Fprintf(&buf, "  selected but not active: %s\n", name)
+	}
+	for _, name := range coverage.ActiveNotSelected {
+		fmt.Fprintf(&buf, "  active but not selected: %s\n", name)
+	}
+	for _, pattern := range coverage.UnusedPatterns {
+		fmt.Fprintf(&buf, "  pattern matches no topic: %s\n", pattern)
+	}
+	for _, pattern := range coverage.UnverifiablePatterns {
+		fmt.Fprintf(&buf, "  pattern cannot be evaluated: %s\n", pattern)
+	}
+	return buf.String()
+}
+
+// MirroringReport : compares the mirroring topic selection of the target instance with the topics of the source
+// instance, as listed by ListTopics, and with the topics the target reports as actively mirrored. A topic is selected
+// when one of the patterns of the selection matches its whole name. The patterns are evaluated with the regexp
+// package; those in Java syntax that it does not support are reported as unverifiable instead.
+func MirroringReport(ctx context.Context, source *AdminrestV1, target *AdminrestV1) (*MirroringCoverage, error) {
+	selection, _, err := target.GetMirroringTopicSelectionWithContext(ctx, target.NewGetMirroringTopicSelectionOptions())
+	if err != nil {
+		return nil, err
+	}
+	active, _, err := target.GetMirroringActiveTopicsWithContext(ctx, target.NewGetMirroringActiveTopicsOptions())
+	if err != nil {
+		return nil, err
+	}
+	topics, err := source.ListAllTopicsWithContext(ctx, source.NewListAllTopicsOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	// A nil pattern cannot be evaluated.
+	patterns := make([]*regexp.Regexp, len(selection.Includes))
+	for i, include := range selection.Includes {
+		patterns[i], err = compileMirroringPattern(include)
+		if err != nil {
+			return nil, fmt.Errorf("MirroringReport: %w: %q", ErrInvalidPattern, include)
+		}
+	}
+
+	coverage := &MirroringCoverage{
+		Includes:             append([]string{}, selection.Includes...),
+		Selected:             []string{},
+		Unmatched:            []string{},
+		SelectedNotActive:    []string{},
+		ActiveNotSelected:    []string{},
+		UnusedPatterns:       []string{},
+		UnverifiablePatterns: []string{},
+	}
+	used := make([]bool, len(patterns))
+	selected := make(map[string]bool)
+	for _, topic := range topics.Topics {
+		if topic.Name == nil {
+			continue
+		}
+		name := *topic.Name
+		for i, pattern := range patterns {
+			if pattern != nil && pattern.MatchString(name) {
+				used[i] = true
+				selected[name] = true
+			}
+		}
+		if selected[name] {
+			coverage.Selected = append(coverage.Selected, name)
+		} else {
+			coverage.Unmatched = append(coverage.Unmatched, name)
+		}
+	}
+	for i, include := range selection.Includes {
+		switch {
+		case patterns[i] == nil:
+			coverage.UnverifiablePatterns = append(coverage.UnverifiablePatterns, include)
+		case !used[i]:
+			coverage.UnusedPatterns = append(coverage.UnusedPatterns, include)
+		}
+	}
+
+	isActive := make(map[string]bool)
+	for _, name := range active.ActiveTopics {
+		isActive[name] = true
+		if !selected[name] {
+			coverage.ActiveNotSelected = append(coverage.ActiveNotSelected, name)
+		}
+	}
+	for _, name := range coverage.Selected {
+		if !isActive[name] {
+			coverage.SelectedNotActive = append(coverage.SelectedNotActive, name)
+		}
+	}
+
+	sort.Strings(coverage.Selected)
+	sort.Strings(coverage.Unmatched)
+	sort.Strings(coverage.SelectedNotActive)
+	sort.Strings(coverage.ActiveNotSelected)
+	return coverage, nil
+}
@@ -0,0 +1,135 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminrestv1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`MirroringReport`, func() {
+	var sourceServer, targetServer *httptest.Server
+	var sourceTopics, includes, activeTopics []string
+
+	newClient := func(server *httptest.Server) *AdminrestV1 {
+		client, err := NewAdminrestV1(&AdminrestV1Options{URL: server.URL, Authenticator: &core.NoAuthAuthenticator{}})
+		Expect(err).To(BeNil())
+		return client
+	}
+
+	BeforeEach(func() {
+		sourceTopics = []string{"orders.eu", "orders.us", "payments", "audit", "scratch"}
+		includes = []string{`orders\..*`, "payments", "billing"}
+		activeTopics = []string{"orders.eu", "payments", "legacy"}
+
+		sourceServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/admin/topics" {
+				res.Header().Set("Content-Type", "application/json")
+				res.WriteHeader(http.StatusNotFound)
+				_, _ = res.Write([]byte(`{"error_code":40400,"message":"Mirroring is not enabled for this instance."}`))
+				return
+			}
+			topics := []map[string]interface{}{}
+			if req.URL.Query().Get("page") == "1" {
+				for _, name := range sourceTopics {
+					topics = append(topics, map[string]interface{}{"name": name, "partitions": 1})
+				}
+			}
+			res.Header().Set("Content-Type", "application/json")
+			res.Header().Set("X-Total-Count", strconv.Itoa(len(sourceTopics)))
+			_ = json.NewEncoder(res).Encode(topics)
+		}))
+		targetServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.Header().Set("Content-Type", "application/json")
+			switch req.URL.Path {
+			case "/admin/mirroring/topic-selection":
+				_ = json.NewEncoder(res).Encode(map[string][]string{"includes": includes})
+			case "/admin/mirroring/active-topics":
+				_ = json.NewEncoder(res).Encode(map[string][]string{"active_topics": activeTopics})
+			default:
+				res.WriteHeader(http.StatusNotFound)
+				_, _ = res.Write([]byte(`{"error_code":40400,"message":"Mirroring is not enabled for this instance."}`))
+			}
+		}))
+	})
+	AfterEach(func() {
+		sourceServer.Close()
+		targetServer.Close()
+	})
+
+	It(`Compare the selection with the source and active topics`, func() {
+		coverage, err := MirroringReport(context.Background(), newClient(sourceServer), newClient(targetServer))
+		Expect(err).To(BeNil())
+		Expect(coverage.Includes).To(Equal(includes))
+		Expect(coverage.Selected).To(Equal([]string{"orders.eu", "orders.us", "payments"}))
+		Expect(coverage.Unmatched).To(Equal([]string{"audit", "scratch"}))
+		Expect(coverage.SelectedNotActive).To(Equal([]string{"orders.us"}))
+		Expect(coverage.ActiveNotSelected).To(Equal([]string{"legacy"}))
+		Expect(coverage.UnusedPatterns).To(Equal([]string{"billing"}))
+		Expect(coverage.Covered()).To(BeFalse())
+		Expect(coverage.String()).To(Equal("3 patterns select 3 source topics, 2 unmatched\n" +
+			"  selected but not active: orders.us\n" +
+			"  active but not selected: legacy\n" +
+			"  pattern matches no topic: billing\n"))
+	})
+	It(`Match patterns against whole topic names`, func() {
+		includes = []string{"orders"}
+		activeTopics = []string{}
+		coverage, err := MirroringReport(context.Background(), newClient(sourceServer), newClient(targetServer))
+		Expect(err).To(BeNil())
+		Expect(coverage.Selected).To(BeEmpty())
+		Expect(coverage.Covered()).To(BeTrue())
+	})
+	It(`Report a complete coverage`, func() {
+		activeTopics = []string{"orders.us", "payments", "orders.eu"}
+		coverage, err := MirroringReport(context.Background(), newClient(sourceServer), newClient(targetServer))
+		Expect(err).To(BeNil())
+		Expect(coverage.Covered()).To(BeTrue())
+
+		buf, err := json.Marshal(coverage)
+		Expect(err).To(BeNil())
+		Expect(string(buf)).To(ContainSubstring(`"selected_not_active":[]`))
+	})
+	It(`Report the patterns that cannot be evaluated`, func() {
+		includes = []string{`orders\..*`, `pay(?=ments)\w+`, "payments"}
+		activeTopics = []string{"orders.eu", "orders.us", "payments"}
+		coverage, err := MirroringReport(context.Background(), newClient(sourceServer), newClient(targetServer))
+		Expect(err).To(BeNil())
+		Expect(coverage.Selected).To(Equal([]string{"orders.eu", "orders.us", "payments"}))
+		Expect(coverage.UnusedPatterns).To(BeEmpty())
+		Expect(coverage.UnverifiablePatterns).To(Equal([]string{`pay(?=ments)\w+`}))
+		Expect(coverage.Covered()).To(BeFalse())
+		Expect(coverage.String()).To(Equal("3 patterns select 3 source topics, 2 unmatched\n" +
+			"  pattern cannot be evaluated: pay(?=ments)\\w+\n"))
+	})
+	It(`Return the errors of the target`, func() {
+		_, err := MirroringReport(context.Background(), newClient(sourceServer), newClient(sourceServer))
+		Expect(err).ToNot(BeNil())
+
+		includes = []string{"orders("}
+		_, err = MirroringReport(context.Background(), newClient(sourceServer), newClient(targetServer))
+		Expect(errors.Is(err, ErrInvalidPattern)).To(BeTrue())
+	})
+})
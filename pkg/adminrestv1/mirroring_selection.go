@@ -0,0 +1,325 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminrestv1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// DefaultMirroringEditAttempts is the number of times AddMirroringPatterns and RemoveMirroringPatterns try to edit
+// the selection when no limit is set.
+const DefaultMirroringEditAttempts = 5
+
+// mirroringRetryDelay is the base delay before another attempt to edit the selection. Each attempt waits a random
+// time of up to the base delay times the number of attempts made.
+var mirroringRetryDelay = 200 * time.Millisecond
+
+// AddMirroringPatterns : Add patterns to the topic selection for mirroring
+// Reads the current selection, appends the patterns it does not already include, and writes it back. The selection is
+// read again before and after the write; if it was changed by another client, the edit is applied again to the
+// selection as it now is. The service has no conditional update, so this is best-effort: a change made by another
+// client between the last read and the write is not detected. Every pattern is checked with
+// ValidateMirroringPatterns before anything is sent.
+func (adminrest *AdminrestV1) AddMirroringPatterns(addMirroringPatternsOptions *AddMirroringPatternsOptions) (result *MirroringTopicSelection, err error) {
+	return adminrest.AddMirroringPatternsWithContext(context.Background(), addMirroringPatternsOptions)
+}
+
+// AddMirroringPatternsWithContext is an alternate form of the AddMirroringPatterns method which supports a Context parameter
+func (adminrest *AdminrestV1) AddMirroringPatternsWithContext(ctx context.Context, addMirroringPatternsOptions *AddMirroringPatternsOptions) (result *MirroringTopicSelection, err error) {
+	err = core.ValidateNotNil(addMirroringPatternsOptions, "addMirroringPatternsOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(addMirroringPatternsOptions, "addMirroringPatternsOptions")
+	if err != nil {
+		return
+	}
+	err = ValidateMirroringPatterns(addMirroringPatternsOptions.Patterns)
+	if err != nil {
+		return nil, fmt.Errorf("AddMirroringPatterns: %w", err)
+	}
+	return adminrest.editMirroringSelection(ctx, "AddMirroringPatterns", addMirroringPatternsOptions.Patterns,
+		addMirroringPatternsOptions.MaxAttempts, addMirroringPatternsOptions.Headers, addPatterns)
+}
+
+// RemoveMirroringPatterns : Remove patterns from the topic selection for mirroring
+// Reads the current selection, removes the patterns, and writes it back. The selection is read again before and after
+// the write; if it was changed by another client, the edit is applied again to the selection as it now is. As with
+// AddMirroringPatterns, a change made by another client between the last read and the write is not detected. The
+// patterns are not checked, so that invalid patterns can be removed.
+func (adminrest *AdminrestV1) RemoveMirroringPatterns(removeMirroringPatternsOptions *RemoveMirroringPatternsOptions) (result *MirroringTopicSelection, err error) {
+	return adminrest.RemoveMirroringPatternsWithContext(context.Background(), removeMirroringPatternsOptions)
+}
+
+// RemoveMirroringPatternsWithContext is an alternate form of the RemoveMirroringPatterns method which supports a Context parameter
+func (adminrest *AdminrestV1) RemoveMirroringPatternsWithContext(ctx context.Context, removeMirroringPatternsOptions *RemoveMirroringPatternsOptions) (result *MirroringTopicSelection, err error) {
+	err = core.ValidateNotNil(removeMirroringPatternsOptions, "removeMirroringPatternsOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(removeMirroringPatternsOptions, "removeMirroringPatternsOptions")
+	if err != nil {
+		return
+	}
+	return adminrest.editMirroringSelection(ctx, "RemoveMirroringPatterns", removeMirroringPatternsOptions.Patterns,
+		removeMirroringPatternsOptions.MaxAttempts, removeMirroringPatternsOptions.Headers, removePatterns)
+}
+
+// editMirroringSelection applies an edit to the mirroring topic selection, retrying when the selection is seen to
+// change between the reads and the write. It is best-effort: a change made after the last read and before the write
+// is overwritten without being detected.
+func (adminrest *AdminrestV1) editMirroringSelection(ctx context.Context, operation string, patterns []string,
+	maxAttempts *int64, headers map[string]string, edit func(current []string, patterns []string) []string) (result *MirroringTopicSelection, err error) {
+	attempts := DefaultMirroringEditAttempts
+	if maxAttempts != nil && *maxAttempts > 0 {
+		attempts = int(*maxAttempts)
+	}
+	getOptions := &GetMirroringTopicSelectionOptions{Headers: headers}
+
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			delay := time.Duration(rand.Int63n(int64(mirroringRetryDelay)*int64(attempt-1)) + 1)
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		current, _, err := adminrest.GetMirroringTopicSelectionWithContext(ctx, getOptions)
+		if err != nil {
+			return nil, err
+		}
+		edited := edit(current.Includes, patterns)
+		if equalPatterns(edited, current.Includes) {
+			return current, nil
+		}
+
+		// Read the selection again just before writing it, to narrow the window in which another client's change
+		// could be overwritten.
+		before, _, err := adminrest.GetMirroringTopicSelectionWithContext(ctx, getOptions)
+		if err != nil {
+			return nil, err
+		}
+		if !equalPatterns(before.Includes, current.Includes) {
+			continue
+		}
+
+		replaceOptions := &ReplaceMirroringTopicSelectionOptions{Includes: edited, Headers: headers}
+		_, _, err = adminrest.ReplaceMirroringTopicSelectionWithContext(ctx, replaceOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		after, _, err := adminrest.GetMirroringTopicSelectionWithContext(ctx, getOptions)
+		if err != nil {
+			return nil, err
+		}
+		if equalPatterns(after.Includes, edited) {
+			return after, nil
+		}
+	}
+	return nil, fmt.Errorf("%s: gave up after %d attempts: %w", operation, attempts, ErrMirroringConflict)
+}
+
+// ValidateMirroringPatterns : checks that every pattern is a valid regular expression, returning an error that
+// matches ErrInvalidPattern and names each pattern that is not.
+// The service takes Java regular expressions, and the patterns are checked with the regexp package, which implements
+// the RE2 subset of them. Patterns that use Java syntax outside that subset, such as lookarounds, possessive
+// quantifiers, atomic groups, back references, \p{java...} classes and class intersections, are not rejected: they are
+// listed by UnverifiableMirroringPatterns and left for the service to check.
+func ValidateMirroringPatterns(patterns []string) error {
+	var problems []string
+	for _, pattern := range patterns {
+		if pattern == "" {
+			problems = append(problems, "an empty pattern")
+			continue
+		}
+		if _, err := compileMirroringPattern(pattern); err != nil {
+			problems = append(problems, fmt.Sprintf("%q (%s)", pattern, strings.TrimPrefix(err.Error(), "error parsing regexp: ")))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidPattern, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// UnverifiableMirroringPatterns : returns the patterns that use Java regular expression syntax outside the RE2
+// subset of the regexp package, in their order. They can be neither checked nor matched against topic names in Go.
+func UnverifiableMirroringPatterns(patterns []string) []string {
+	var unverifiable []string
+	for _, pattern := range patterns {
+		if compiled, err := compileMirroringPattern(pattern); compiled == nil && err == nil {
+			unverifiable = append(unverifiable, pattern)
+		}
+	}
+	return unverifiable
+}
+
+// compileMirroringPattern compiles a pattern to match whole topic names. It returns a nil Regexp and no error for a
+// pattern that uses Java syntax outside the RE2 subset, and an error for a pattern that is not valid in either.
+func compileMirroringPattern(pattern string) (*regexp.Regexp, error) {
+	if _, err := regexp.Compile(pattern); err != nil {
+		var syntaxError *syntax.Error
+		if errors.As(err, &syntaxError) && javaOnlySyntax(syntaxError) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if strings.Contains(pattern, "&&") {
+		// A class intersection in Java, such as [a-z&&[^q]], but literal characters of the class in RE2.
+		return nil, nil
+	}
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
+// javaOnlySyntax returns true if the RE2 syntax error is for syntax that Java regular expressions accept.
+func javaOnlySyntax(syntaxError *syntax.Error) bool {
+	switch syntaxError.Code {
+	case syntax.ErrInvalidPerlOp, syntax.ErrInvalidNamedCapture, syntax.ErrInvalidEscape:
+		// Lookarounds, atomic groups, and escapes such as back references and \Z.
+		return true
+	case syntax.ErrInvalidRepeatOp:
+		// Possessive quantifiers, and repeat counts larger than RE2 allows.
+		return strings.HasSuffix(syntaxError.Expr, "+") || strings.HasPrefix(syntaxError.Expr, "{")
+	case syntax.ErrInvalidCharRange:
+		// Classes such as \p{javaLowerCase}.
+		return strings.HasPrefix(syntaxError.Expr, `\p`) || strings.HasPrefix(syntaxError.Expr, `\P`)
+	}
+	return false
+}
+
+// addPatterns returns the selection with the patterns it does not already include appended.
+func addPatterns(current []string, patterns []string) []string {
+	edited := append([]string{}, current...)
+	for _, pattern := range patterns {
+		if !containsString(edited, pattern) {
+			edited = append(edited, pattern)
+		}
+	}
+	return edited
+}
+
+// removePatterns returns the selection without the patterns.
+func removePatterns(current []string, patterns []string) []string {
+	edited := []string{}
+	for _, include := range current {
+		if !containsString(patterns, include) {
+			edited = append(edited, include)
+		}
+	}
+	return edited
+}
+
+// equalPatterns returns true if two selections have the same patterns in the same order.
+func equalPatterns(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// AddMirroringPatternsOptions : The AddMirroringPatterns options.
+type AddMirroringPatternsOptions struct {
+	// The patterns to add, as regular expressions matching topic names.
+	Patterns []string `validate:"required"`
+
+	// The number of times the edit is tried when the selection changes concurrently. Defaults to
+	// DefaultMirroringEditAttempts.
+	MaxAttempts *int64
+
+	// Allows users to set headers on API requests
+	Headers map[string]string
+}
+
+// NewAddMirroringPatternsOptions : Instantiate AddMirroringPatternsOptions
+func (*AdminrestV1) NewAddMirroringPatternsOptions(patterns []string) *AddMirroringPatternsOptions {
+	return &AddMirroringPatternsOptions{
+		Patterns: patterns,
+	}
+}
+
+// SetPatterns : Allow user to set Patterns
+func (_options *AddMirroringPatternsOptions) SetPatterns(patterns []string) *AddMirroringPatternsOptions {
+	_options.Patterns = patterns
+	return _options
+}
+
+// SetMaxAttempts : Allow user to set MaxAttempts
+func (_options *AddMirroringPatternsOptions) SetMaxAttempts(maxAttempts int64) *AddMirroringPatternsOptions {
+	_options.MaxAttempts = core.Int64Ptr(maxAttempts)
+	return _options
+}
+
+// SetHeaders : Allow user to set Headers
+func (options *AddMirroringPatternsOptions) SetHeaders(param map[string]string) *AddMirroringPatternsOptions {
+	options.Headers = param
+	return options
+}
+
+// RemoveMirroringPatternsOptions : The RemoveMirroringPatterns options.
+type RemoveMirroringPatternsOptions struct {
+	// The patterns to remove, exactly as they appear in the selection.
+	Patterns []string `validate:"required"`
+
+	// The number of times the edit is tried when the selection changes concurrently. Defaults to
+	// DefaultMirroringEditAttempts.
+	MaxAttempts *int64
+
+	// Allows users to set headers on API requests
+	Headers map[string]string
+}
+
+// NewRemoveMirroringPatternsOptions : Instantiate RemoveMirroringPatternsOptions
+func (*AdminrestV1) NewRemoveMirroringPatternsOptions(patterns []string) *RemoveMirroringPatternsOptions {
+	return &RemoveMirroringPatternsOptions{
+		Patterns: patterns,
+	}
+}
+
+// SetPatterns : Allow user to set Patterns
+func (_options *RemoveMirroringPatternsOptions) SetPatterns(patterns []string) *RemoveMirroringPatternsOptions {
+	_options.Patterns = patterns
+	return _options
+}
+
+// SetMaxAttempts : Allow user to set MaxAttempts
+func (_options *RemoveMirroringPatternsOptions) SetMaxAttempts(maxAttempts int64) *RemoveMirroringPatternsOptions {
+	_options.MaxAttempts = core.Int64Ptr(maxAttempts)
+	return _options
+}
+
+// SetHeaders : Allow user to set Headers
+func (options *RemoveMirroringPatternsOptions) SetHeaders(param map[string]string) *RemoveMirroringPatternsOptions {
+	options.Headers = param
+	return options
+}
@@ -0,0 +1,165 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminrestv1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// selectionServer serves the mirroring topic selection endpoint. Before answering each GET, it calls interfere with
+// the number of GETs so far, which lets a test change the selection the way another client would.
+type selectionServer struct {
+	mutex     sync.Mutex
+	includes  []string
+	gets      int
+	replaces  int
+	interfere func(server *selectionServer, gets int)
+}
+
+func (server *selectionServer) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	switch req.Method {
+	case http.MethodGet:
+		server.gets++
+		if server.interfere != nil {
+			server.interfere(server, server.gets)
+		}
+	case http.MethodPost:
+		server.replaces++
+		var body MirroringTopicSelection
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		server.includes = body.Includes
+	}
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(res).Encode(map[string][]string{"includes": server.includes})
+}
+
+var _ = Describe(`Mirroring topic selection editing`, func() {
+	var server *selectionServer
+	var testServer *httptest.Server
+	var adminrestService *AdminrestV1
+
+	BeforeEach(func() {
+		mirroringRetryDelay = time.Millisecond
+		server = &selectionServer{includes: []string{"orders.*"}}
+		testServer = httptest.NewServer(server)
+		var err error
+		adminrestService, err = NewAdminrestV1(&AdminrestV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	It(`Add patterns that are not already selected`, func() {
+		options := adminrestService.NewAddMirroringPatternsOptions([]string{"payments.*", "orders.*"})
+		result, err := adminrestService.AddMirroringPatterns(options)
+		Expect(err).To(BeNil())
+		Expect(result.Includes).To(Equal([]string{"orders.*", "payments.*"}))
+		Expect(server.includes).To(Equal([]string{"orders.*", "payments.*"}))
+		Expect(server.gets).To(Equal(3))
+		Expect(server.replaces).To(Equal(1))
+	})
+	It(`Skip the write when nothing changes`, func() {
+		result, err := adminrestService.AddMirroringPatterns(adminrestService.NewAddMirroringPatternsOptions([]string{"orders.*"}))
+		Expect(err).To(BeNil())
+		Expect(result.Includes).To(Equal([]string{"orders.*"}))
+		Expect(server.replaces).To(Equal(0))
+	})
+	It(`Remove patterns`, func() {
+		server.includes = []string{"orders.*", "audit", "payments.*"}
+		result, err := adminrestService.RemoveMirroringPatterns(adminrestService.NewRemoveMirroringPatternsOptions([]string{"audit", "missing"}))
+		Expect(err).To(BeNil())
+		Expect(result.Includes).To(Equal([]string{"orders.*", "payments.*"}))
+	})
+	It(`Retry when the selection changes before the write`, func() {
+		server.interfere = func(server *selectionServer, gets int) {
+			if gets == 2 {
+				server.includes = append(server.includes, "audit")
+			}
+		}
+		result, err := adminrestService.AddMirroringPatterns(adminrestService.NewAddMirroringPatternsOptions([]string{"payments.*"}))
+		Expect(err).To(BeNil())
+		Expect(result.Includes).To(Equal([]string{"orders.*", "audit", "payments.*"}))
+		Expect(server.replaces).To(Equal(1))
+	})
+	It(`Reapply the edit when the selection changes after the write`, func() {
+		server.interfere = func(server *selectionServer, gets int) {
+			if gets == 3 {
+				// Another client replaced the selection with its own edit of the original.
+				server.includes = []string{"orders.*", "audit"}
+			}
+		}
+		result, err := adminrestService.AddMirroringPatterns(adminrestService.NewAddMirroringPatternsOptions([]string{"payments.*"}))
+		Expect(err).To(BeNil())
+		Expect(result.Includes).To(Equal([]string{"orders.*", "audit", "payments.*"}))
+		Expect(server.replaces).To(Equal(2))
+	})
+	It(`Give up when the selection keeps changing`, func() {
+		server.interfere = func(server *selectionServer, gets int) {
+			server.includes = append(server.includes, "other")
+		}
+		options := adminrestService.NewAddMirroringPatternsOptions([]string{"payments.*"}).SetMaxAttempts(3)
+		_, err := adminrestService.AddMirroringPatterns(options)
+		Expect(errors.Is(err, ErrMirroringConflict)).To(BeTrue())
+		Expect(err.Error()).To(Equal("AddMirroringPatterns: gave up after 3 attempts: mirroring topic selection changed concurrently"))
+		Expect(server.replaces).To(Equal(0))
+	})
+	It(`Reject invalid patterns before sending anything`, func() {
+		_, err := adminrestService.AddMirroringPatterns(adminrestService.NewAddMirroringPatternsOptions([]string{"orders(", "", "ok"}))
+		Expect(errors.Is(err, ErrInvalidPattern)).To(BeTrue())
+		Expect(err.Error()).To(Equal("AddMirroringPatterns: invalid pattern: \"orders(\" (missing closing ): `orders(`), an empty pattern"))
+		Expect(server.gets).To(Equal(0))
+
+		_, err = adminrestService.AddMirroringPatterns(nil)
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Accept Java patterns that cannot be checked`, func() {
+		java := []string{`orders(?!\.test).*`, `(?<=a)b`, `audit.*+`, `(?>ab)c`, `(a)\1`, `\p{javaLowerCase}+`, `[a-z&&[^q]]+`}
+		Expect(ValidateMirroringPatterns(java)).To(Succeed())
+		Expect(UnverifiableMirroringPatterns(append([]string{"orders.*"}, java...))).To(Equal(java))
+
+		for _, pattern := range []string{"[z-a]", "*orders", "orders)", `orders\`} {
+			Expect(errors.Is(ValidateMirroringPatterns([]string{pattern}), ErrInvalidPattern)).To(BeTrue(), pattern)
+		}
+
+		result, err := adminrestService.AddMirroringPatterns(adminrestService.NewAddMirroringPatternsOptions(java[:1]))
+		Expect(err).To(BeNil())
+		Expect(result.Includes).To(Equal([]string{"orders.*", `orders(?!\.test).*`}))
+	})
+	It(`Remove invalid patterns`, func() {
+		server.includes = []string{"orders.*", "orders("}
+		result, err := adminrestService.RemoveMirroringPatterns(adminrestService.NewRemoveMirroringPatternsOptions([]string{"orders("}))
+		Expect(err).To(BeNil())
+		Expect(result.Includes).To(Equal([]string{"orders.*"}))
+	})
+})
@@ -0,0 +1,544 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Code generated by internal/mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/stretchr/testify/mock"
+)
+
+// API : A mock implementation of adminrestv1.API.
+type API struct {
+	mock.Mock
+}
+
+// API must implement every method of adminrestv1.API.
+var _ adminrestv1.API = (*API)(nil)
+
+// NewAPI : constructs a mock API that fails the test on unexpected calls and asserts the expected calls were made
+// when the test finishes.
+func NewAPI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *API {
+	api := &API{}
+	api.Mock.Test(t)
+	t.Cleanup(func() { api.AssertExpectations(t) })
+	return api
+}
+
+// CreateTopicWithContext provides a mock function with given fields: ctx, createTopicOptions
+func (_m *API) CreateTopicWithContext(ctx context.Context, createTopicOptions *adminrestv1.CreateTopicOptions) (*core.DetailedResponse, error) {
+	ret := _m.Called(ctx, createTopicOptions)
+
+	var r0 *core.DetailedResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *adminrestv1.CreateTopicOptions) *core.DetailedResponse); ok {
+		r0 = rf(ctx, createTopicOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*core.DetailedResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *adminrestv1.CreateTopicOptions) error); ok {
+		r1 = rf(ctx, createTopicOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(error)
+	}
+
+	return r0, r1
+}
+
+// ListTopicsWithContext provides a mock function with given fields: ctx, listTopicsOptions
+func (_m *API) ListTopicsWithContext(ctx context.Context, listTopicsOptions *adminrestv1.ListTopicsOptions) ([]adminrestv1.TopicDetail, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, listTopicsOptions)
+
+	var r0 []adminrestv1.TopicDetail
+	if rf, ok := ret.Get(0).(func(context.Context, *adminrestv1.ListTopicsOptions) []adminrestv1.TopicDetail); ok {
+		r0 = rf(ctx, listTopicsOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]adminrestv1.TopicDetail)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *adminrestv1.ListTopicsOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, listTopicsOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *adminrestv1.ListTopicsOptions) error); ok {
+		r2 = rf(ctx, listTopicsOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// ListAllTopicsWithContext provides a mock function with given fields: ctx, listAllTopicsOptions
+func (_m *API) ListAllTopicsWithContext(ctx context.Context, listAllTopicsOptions *adminrestv1.ListAllTopicsOptions) (*adminrestv1.TopicList, error) {
+	ret := _m.Called(ctx, listAllTopicsOptions)
+
+	var r0 *adminrestv1.TopicList
+	if rf, ok := ret.Get(0).(func(context.Context, *adminrestv1.ListAllTopicsOptions) *adminrestv1.TopicList); ok {
+		r0 = rf(ctx, listAllTopicsOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*adminrestv1.TopicList)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *adminrestv1.ListAllTopicsOptions) error); ok {
+		r1 = rf(ctx, listAllTopicsOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(error)
+	}
+
+	return r0, r1
+}
+
+// GetTopicWithContext provides a mock function with given fields: ctx, getTopicOptions
+func (_m *API) GetTopicWithContext(ctx context.Context, getTopicOptions *adminrestv1.GetTopicOptions) (*adminrestv1.TopicDetail, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, getTopicOptions)
+
+	var r0 *adminrestv1.TopicDetail
+	if rf, ok := ret.Get(0).(func(context.Context, *adminrestv1.GetTopicOptions) *adminrestv1.TopicDetail); ok {
+		r0 = rf(ctx, getTopicOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*adminrestv1.TopicDetail)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *adminrestv1.GetTopicOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, getTopicOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *adminrestv1.GetTopicOptions) error); ok {
+		r2 = rf(ctx, getTopicOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// UpdateTopicWithContext provides a mock function with given fields: ctx, updateTopicOptions
+func (_m *API) UpdateTopicWithContext(ctx context.Context, updateTopicOptions *adminrestv1.UpdateTopicOptions) (*core.DetailedResponse, error) {
+	ret := _m.Called(ctx, updateTopicOptions)
+
+	var r0 *core.DetailedResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *adminrestv1.UpdateTopicOptions) *core.DetailedResponse); ok {
+		r0 = rf(ctx, updateTopicOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*core.DetailedResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *adminrestv1.UpdateTopicOptions) error); ok {
+		r1 = rf(ctx, updateTopicOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(error)
+	}
+
+	return r0, r1
+}
+
+// DeleteTopicWithContext provides a mock function with given fields: ctx, deleteTopicOptions
+func (_m *API) DeleteTopicWithContext(ctx context.Context, deleteTopicOptions *adminrestv1.DeleteTopicOptions) (*core.DetailedResponse, error) {
+	ret := _m.Called(ctx, deleteTopicOptions)
+
+	var r0 *core.DetailedResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *adminrestv1.DeleteTopicOptions) *core.DetailedResponse); ok {
+		r0 = rf(ctx, deleteTopicOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*core.DetailedResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *adminrestv1.DeleteTopicOptions) error); ok {
+		r1 = rf(ctx, deleteTopicOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(error)
+	}
+
+	return r0, r1
+}
+
+// CreateTopicAsyncWithContext provides a mock function with given fields: ctx, createTopicOptions
+func (_m *API) CreateTopicAsyncWithContext(ctx context.Context, createTopicOptions *adminrestv1.CreateTopicOptions) (*adminrestv1.Operation, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, createTopicOptions)
+
+	var r0 *adminrestv1.Operation
+	if rf, ok := ret.Get(0).(func(context.Context, *adminrestv1.CreateTopicOptions) *adminrestv1.Operation); ok {
+		r0 = rf(ctx, createTopicOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*adminrestv1.Operation)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *adminrestv1.CreateTopicOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, createTopicOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *adminrestv1.CreateTopicOptions) error); ok {
+		r2 = rf(ctx, createTopicOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// UpdateTopicAsyncWithContext provides a mock function with given fields: ctx, updateTopicOptions
+func (_m *API) UpdateTopicAsyncWithContext(ctx context.Context, updateTopicOptions *adminrestv1.UpdateTopicOptions) (*adminrestv1.Operation, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, updateTopicOptions)
+
+	var r0 *adminrestv1.Operation
+	if rf, ok := ret.Get(0).(func(context.Context, *adminrestv1.UpdateTopicOptions) *adminrestv1.Operation); ok {
+		r0 = rf(ctx, updateTopicOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*adminrestv1.Operation)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *adminrestv1.UpdateTopicOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, updateTopicOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *adminrestv1.UpdateTopicOptions) error); ok {
+		r2 = rf(ctx, updateTopicOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// DeleteTopicAsyncWithContext provides a mock function with given fields: ctx, deleteTopicOptions
+func (_m *API) DeleteTopicAsyncWithContext(ctx context.Context, deleteTopicOptions *adminrestv1.DeleteTopicOptions) (*adminrestv1.Operation, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, deleteTopicOptions)
+
+	var r0 *adminrestv1.Operation
+	if rf, ok := ret.Get(0).(func(context.Context, *adminrestv1.DeleteTopicOptions) *adminrestv1.Operation); ok {
+		r0 = rf(ctx, deleteTopicOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*adminrestv1.Operation)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *adminrestv1.DeleteTopicOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, deleteTopicOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *adminrestv1.DeleteTopicOptions) error); ok {
+		r2 = rf(ctx, deleteTopicOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// GetMirroringTopicSelectionWithContext provides a mock function with given fields: ctx, getMirroringTopicSelectionOptions
+func (_m *API) GetMirroringTopicSelectionWithContext(ctx context.Context, getMirroringTopicSelectionOptions *adminrestv1.GetMirroringTopicSelectionOptions) (*adminrestv1.MirroringTopicSelection, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, getMirroringTopicSelectionOptions)
+
+	var r0 *adminrestv1.MirroringTopicSelection
+	if rf, ok := ret.Get(0).(func(context.Context, *adminrestv1.GetMirroringTopicSelectionOptions) *adminrestv1.MirroringTopicSelection); ok {
+		r0 = rf(ctx, getMirroringTopicSelectionOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*adminrestv1.MirroringTopicSelection)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *adminrestv1.GetMirroringTopicSelectionOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, getMirroringTopicSelectionOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *adminrestv1.GetMirroringTopicSelectionOptions) error); ok {
+		r2 = rf(ctx, getMirroringTopicSelectionOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// ReplaceMirroringTopicSelectionWithContext provides a mock function with given fields: ctx, replaceMirroringTopicSelectionOptions
+func (_m *API) ReplaceMirroringTopicSelectionWithContext(ctx context.Context, replaceMirroringTopicSelectionOptions *adminrestv1.ReplaceMirroringTopicSelectionOptions) (*adminrestv1.MirroringTopicSelection, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, replaceMirroringTopicSelectionOptions)
+
+	var r0 *adminrestv1.MirroringTopicSelection
+	if rf, ok := ret.Get(0).(func(context.Context, *adminrestv1.ReplaceMirroringTopicSelectionOptions) *adminrestv1.MirroringTopicSelection); ok {
+		r0 = rf(ctx, replaceMirroringTopicSelectionOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*adminrestv1.MirroringTopicSelection)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *adminrestv1.ReplaceMirroringTopicSelectionOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, replaceMirroringTopicSelectionOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *adminrestv1.ReplaceMirroringTopicSelectionOptions) error); ok {
+		r2 = rf(ctx, replaceMirroringTopicSelectionOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// AddMirroringPatternsWithContext provides a mock function with given fields: ctx, addMirroringPatternsOptions
+func (_m *API) AddMirroringPatternsWithContext(ctx context.Context, addMirroringPatternsOptions *adminrestv1.AddMirroringPatternsOptions) (*adminrestv1.MirroringTopicSelection, error) {
+	ret := _m.Called(ctx, addMirroringPatternsOptions)
+
+	var r0 *adminrestv1.MirroringTopicSelection
+	if rf, ok := ret.Get(0).(func(context.Context, *adminrestv1.AddMirroringPatternsOptions) *adminrestv1.MirroringTopicSelection); ok {
+		r0 = rf(ctx, addMirroringPatternsOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*adminrestv1.MirroringTopicSelection)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *adminrestv1.AddMirroringPatternsOptions) error); ok {
+		r1 = rf(ctx, addMirroringPatternsOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(error)
+	}
+
+	return r0, r1
+}
+
+// RemoveMirroringPatternsWithContext provides a mock function with given fields: ctx, removeMirroringPatternsOptions
+func (_m *API) RemoveMirroringPatternsWithContext(ctx context.Context, removeMirroringPatternsOptions *adminrestv1.RemoveMirroringPatternsOptions) (*adminrestv1.MirroringTopicSelection, error) {
+	ret := _m.Called(ctx, removeMirroringPatternsOptions)
+
+	var r0 *adminrestv1.MirroringTopicSelection
+	if rf, ok := ret.Get(0).(func(context.Context, *adminrestv1.RemoveMirroringPatternsOptions) *adminrestv1.MirroringTopicSelection); ok {
+		r0 = rf(ctx, removeMirroringPatternsOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*adminrestv1.MirroringTopicSelection)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *adminrestv1.RemoveMirroringPatternsOptions) error); ok {
+		r1 = rf(ctx, removeMirroringPatternsOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(error)
+	}
+
+	return r0, r1
+}
+
+// GetMirroringActiveTopicsWithContext provides a mock function with given fields: ctx, getMirroringActiveTopicsOptions
+func (_m *API) GetMirroringActiveTopicsWithContext(ctx context.Context, getMirroringActiveTopicsOptions *adminrestv1.GetMirroringActiveTopicsOptions) (*adminrestv1.MirroringActiveTopics, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, getMirroringActiveTopicsOptions)
+
+	var r0 *adminrestv1.MirroringActiveTopics
+	if rf, ok := ret.Get(0).(func(context.Context, *adminrestv1.GetMirroringActiveTopicsOptions) *adminrestv1.MirroringActiveTopics); ok {
+		r0 = rf(ctx, getMirroringActiveTopicsOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*adminrestv1.MirroringActiveTopics)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *adminrestv1.GetMirroringActiveTopicsOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, getMirroringActiveTopicsOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *adminrestv1.GetMirroringActiveTopicsOptions) error); ok {
+		r2 = rf(ctx, getMirroringActiveTopicsOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateQuotaWithContext provides a mock function with given fields: ctx, createQuotaOptions
+func (_m *API) CreateQuotaWithContext(ctx context.Context, createQuotaOptions *adminrestv1.CreateQuotaOptions) (*core.DetailedResponse, error) {
+	ret := _m.Called(ctx, createQuotaOptions)
+
+	var r0 *core.DetailedResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *adminrestv1.CreateQuotaOptions) *core.DetailedResponse); ok {
+		r0 = rf(ctx, createQuotaOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*core.DetailedResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *adminrestv1.CreateQuotaOptions) error); ok {
+		r1 = rf(ctx, createQuotaOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(error)
+	}
+
+	return r0, r1
+}
+
+// UpdateQuotaWithContext provides a mock function with given fields: ctx, updateQuotaOptions
+func (_m *API) UpdateQuotaWithContext(ctx context.Context, updateQuotaOptions *adminrestv1.UpdateQuotaOptions) (*core.DetailedResponse, error) {
+	ret := _m.Called(ctx, updateQuotaOptions)
+
+	var r0 *core.DetailedResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *adminrestv1.UpdateQuotaOptions) *core.DetailedResponse); ok {
+		r0 = rf(ctx, updateQuotaOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*core.DetailedResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *adminrestv1.UpdateQuotaOptions) error); ok {
+		r1 = rf(ctx, updateQuotaOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(error)
+	}
+
+	return r0, r1
+}
+
+// UpsertQuotaWithContext provides a mock function with given fields: ctx, upsertQuotaOptions
+func (_m *API) UpsertQuotaWithContext(ctx context.Context, upsertQuotaOptions *adminrestv1.UpsertQuotaOptions) (bool, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, upsertQuotaOptions)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *adminrestv1.UpsertQuotaOptions) bool); ok {
+		r0 = rf(ctx, upsertQuotaOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *adminrestv1.UpsertQuotaOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, upsertQuotaOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *adminrestv1.UpsertQuotaOptions) error); ok {
+		r2 = rf(ctx, upsertQuotaOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// DeleteQuotaWithContext provides a mock function with given fields: ctx, deleteQuotaOptions
+func (_m *API) DeleteQuotaWithContext(ctx context.Context, deleteQuotaOptions *adminrestv1.DeleteQuotaOptions) (*core.DetailedResponse, error) {
+	ret := _m.Called(ctx, deleteQuotaOptions)
+
+	var r0 *core.DetailedResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *adminrestv1.DeleteQuotaOptions) *core.DetailedResponse); ok {
+		r0 = rf(ctx, deleteQuotaOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*core.DetailedResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *adminrestv1.DeleteQuotaOptions) error); ok {
+		r1 = rf(ctx, deleteQuotaOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(error)
+	}
+
+	return r0, r1
+}
+
+// GetQuotaWithContext provides a mock function with given fields: ctx, getQuotaOptions
+func (_m *API) GetQuotaWithContext(ctx context.Context, getQuotaOptions *adminrestv1.GetQuotaOptions) (*adminrestv1.QuotaDetail, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, getQuotaOptions)
+
+	var r0 *adminrestv1.QuotaDetail
+	if rf, ok := ret.Get(0).(func(context.Context, *adminrestv1.GetQuotaOptions) *adminrestv1.QuotaDetail); ok {
+		r0 = rf(ctx, getQuotaOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*adminrestv1.QuotaDetail)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *adminrestv1.GetQuotaOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, getQuotaOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *adminrestv1.GetQuotaOptions) error); ok {
+		r2 = rf(ctx, getQuotaOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// ListQuotasWithContext provides a mock function with given fields: ctx, listQuotasOptions
+func (_m *API) ListQuotasWithContext(ctx context.Context, listQuotasOptions *adminrestv1.ListQuotasOptions) (*adminrestv1.EntityQuotasList, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, listQuotasOptions)
+
+	var r0 *adminrestv1.EntityQuotasList
+	if rf, ok := ret.Get(0).(func(context.Context, *adminrestv1.ListQuotasOptions) *adminrestv1.EntityQuotasList); ok {
+		r0 = rf(ctx, listQuotasOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*adminrestv1.EntityQuotasList)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *adminrestv1.ListQuotasOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, listQuotasOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *adminrestv1.ListQuotasOptions) error); ok {
+		r2 = rf(ctx, listQuotasOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
@@ -0,0 +1,114 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mocks_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1/mocks"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+// recorder : A mock.TestingT that records failures and runs its cleanup functions when asked.
+type recorder struct {
+	errors   []string
+	cleanups []func()
+}
+
+func (r *recorder) Logf(format string, args ...interface{}) {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) FailNow() {
+	panic("FailNow")
+}
+
+func (r *recorder) Cleanup(cleanup func()) {
+	r.cleanups = append(r.cleanups, cleanup)
+}
+
+func (r *recorder) finish() {
+	for _, cleanup := range r.cleanups {
+		cleanup()
+	}
+}
+
+// topicNames lists the topics through the API, as application code would.
+func topicNames(ctx context.Context, api adminrestv1.API) ([]string, error) {
+	topics, _, err := api.ListTopicsWithContext(ctx, &adminrestv1.ListTopicsOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, topic := range topics {
+		names = append(names, *topic.Name)
+	}
+	return names, nil
+}
+
+var _ = Describe(`API`, func() {
+	It(`Return the values given to Return`, func() {
+		api := &mocks.API{}
+		api.On("ListTopicsWithContext", mock.Anything, mock.Anything).
+			Return([]adminrestv1.TopicDetail{{Name: core.StringPtr("orders")}, {Name: core.StringPtr("audit")}}, nil, nil).Once()
+		api.On("ListTopicsWithContext", mock.Anything, mock.Anything).Return(nil, nil, adminrestv1.ErrTopicNotFound)
+
+		names, err := topicNames(context.Background(), api)
+		Expect(err).To(BeNil())
+		Expect(names).To(Equal([]string{"orders", "audit"}))
+		_, err = topicNames(context.Background(), api)
+		Expect(err).To(Equal(adminrestv1.ErrTopicNotFound))
+		api.AssertNumberOfCalls(GinkgoT(), "ListTopicsWithContext", 2)
+	})
+	It(`Compute return values with functions`, func() {
+		api := &mocks.API{}
+		api.On("UpsertQuotaWithContext", mock.Anything, mock.Anything).Return(
+			func(ctx context.Context, options *adminrestv1.UpsertQuotaOptions) bool {
+				return *options.EntityName == "default"
+			},
+			func(ctx context.Context, options *adminrestv1.UpsertQuotaOptions) *core.DetailedResponse {
+				return &core.DetailedResponse{StatusCode: 201}
+			},
+			nil)
+
+		created, response, err := api.UpsertQuotaWithContext(context.Background(), &adminrestv1.UpsertQuotaOptions{EntityName: core.StringPtr("default")})
+		Expect(err).To(BeNil())
+		Expect(created).To(BeTrue())
+		Expect(response.StatusCode).To(Equal(201))
+		created, _, _ = api.UpsertQuotaWithContext(context.Background(), &adminrestv1.UpsertQuotaOptions{EntityName: core.StringPtr("iam-ServiceId-1234")})
+		Expect(created).To(BeFalse())
+	})
+	It(`Assert the expected calls were made when the test finishes`, func() {
+		t := &recorder{}
+		api := mocks.NewAPI(t)
+		api.On("DeleteTopicWithContext", mock.Anything, mock.Anything).Return(nil, nil)
+		t.finish()
+		Expect(t.errors).To(HaveLen(1))
+		Expect(t.errors[0]).To(ContainSubstring("needs to make 1 more call(s)"))
+
+		Expect(func() {
+			_, _ = api.DeleteQuotaWithContext(context.Background(), &adminrestv1.DeleteQuotaOptions{})
+		}).To(Panic())
+	})
+})
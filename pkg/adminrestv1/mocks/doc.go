@@ -0,0 +1,30 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package mocks : A testify mock of the adminrestv1 API
+//
+// API records the calls made to it and returns the values given to On(...).Return(...). A return value may also be a
+// function with the parameters of the method, which is called to compute it.
+//
+//	api := mocks.NewAPI(t)
+//	api.On("ListTopicsWithContext", mock.Anything, mock.Anything).Return([]adminrestv1.TopicDetail{{Name: core.StringPtr("orders")}}, nil, nil)
+//
+// api.go is generated from the adminrestv1.API interface by internal/mockgen, which writes mocks in the form
+// that mockery does. mockgen is part of the module, so go generate needs no other tool. Run go generate in this
+// directory when the interface changes.
+package mocks
+
+//go:generate go run github.com/IBM/eventstreams-go-sdk/internal/mockgen -source ../api.go -interface API -import github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1 -output api.go
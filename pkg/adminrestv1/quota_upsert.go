@@ -0,0 +1,119 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminrestv1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// UpsertQuota : Create or update the quotas of an entity
+// Updates the quotas of the entity, or creates them if the entity has none. Rates that are not set are left unchanged
+// on update. If the quotas are created by another client between the update and the create, the update is retried.
+func (adminrest *AdminrestV1) UpsertQuota(upsertQuotaOptions *UpsertQuotaOptions) (created bool, response *core.DetailedResponse, err error) {
+	return adminrest.UpsertQuotaWithContext(context.Background(), upsertQuotaOptions)
+}
+
+// UpsertQuotaWithContext is an alternate form of the UpsertQuota method which supports a Context parameter
+func (adminrest *AdminrestV1) UpsertQuotaWithContext(ctx context.Context, upsertQuotaOptions *UpsertQuotaOptions) (created bool, response *core.DetailedResponse, err error) {
+	err = core.ValidateNotNil(upsertQuotaOptions, "upsertQuotaOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(upsertQuotaOptions, "upsertQuotaOptions")
+	if err != nil {
+		return
+	}
+
+	updateQuotaOptions := &UpdateQuotaOptions{
+		EntityName:       upsertQuotaOptions.EntityName,
+		ProducerByteRate: upsertQuotaOptions.ProducerByteRate,
+		ConsumerByteRate: upsertQuotaOptions.ConsumerByteRate,
+		Headers:          upsertQuotaOptions.Headers,
+	}
+	response, err = adminrest.UpdateQuotaWithContext(ctx, updateQuotaOptions)
+	if !errors.Is(err, ErrQuotaNotFound) {
+		return
+	}
+
+	createQuotaOptions := &CreateQuotaOptions{
+		EntityName:       upsertQuotaOptions.EntityName,
+		ProducerByteRate: upsertQuotaOptions.ProducerByteRate,
+		ConsumerByteRate: upsertQuotaOptions.ConsumerByteRate,
+		Headers:          upsertQuotaOptions.Headers,
+	}
+	response, err = adminrest.CreateQuotaWithContext(ctx, createQuotaOptions)
+	if err == nil {
+		return true, response, nil
+	}
+
+	// The create fails if another client created the quotas first; in that case they can now be updated.
+	getQuotaOptions := &GetQuotaOptions{EntityName: upsertQuotaOptions.EntityName, Headers: upsertQuotaOptions.Headers}
+	if _, _, getErr := adminrest.GetQuotaWithContext(ctx, getQuotaOptions); getErr != nil {
+		return
+	}
+	response, err = adminrest.UpdateQuotaWithContext(ctx, updateQuotaOptions)
+	return
+}
+
+// UpsertQuotaOptions : The UpsertQuota options.
+type UpsertQuotaOptions struct {
+	// The entity name of the quotas can be `default` or an IAM Service ID that starts with an `iam-ServiceId` prefix.
+	EntityName *string `json:"entity_name" validate:"required,ne="`
+
+	// The producer byte rate quota value.
+	ProducerByteRate *int64 `json:"producer_byte_rate,omitempty"`
+
+	// The consumer byte rate quota value.
+	ConsumerByteRate *int64 `json:"consumer_byte_rate,omitempty"`
+
+	// Allows users to set headers on API requests
+	Headers map[string]string
+}
+
+// NewUpsertQuotaOptions : Instantiate UpsertQuotaOptions
+func (*AdminrestV1) NewUpsertQuotaOptions(entityName string) *UpsertQuotaOptions {
+	return &UpsertQuotaOptions{
+		EntityName: core.StringPtr(entityName),
+	}
+}
+
+// SetEntityName : Allow user to set EntityName
+func (_options *UpsertQuotaOptions) SetEntityName(entityName string) *UpsertQuotaOptions {
+	_options.EntityName = core.StringPtr(entityName)
+	return _options
+}
+
+// SetProducerByteRate : Allow user to set ProducerByteRate
+func (_options *UpsertQuotaOptions) SetProducerByteRate(producerByteRate int64) *UpsertQuotaOptions {
+	_options.ProducerByteRate = core.Int64Ptr(producerByteRate)
+	return _options
+}
+
+// SetConsumerByteRate : Allow user to set ConsumerByteRate
+func (_options *UpsertQuotaOptions) SetConsumerByteRate(consumerByteRate int64) *UpsertQuotaOptions {
+	_options.ConsumerByteRate = core.Int64Ptr(consumerByteRate)
+	return _options
+}
+
+// SetHeaders : Allow user to set Headers
+func (options *UpsertQuotaOptions) SetHeaders(param map[string]string) *UpsertQuotaOptions {
+	options.Headers = param
+	return options
+}
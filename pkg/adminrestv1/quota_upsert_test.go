@@ -0,0 +1,136 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminrestv1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// quotaServer serves the quota endpoints from a map of rates. When raceCreate is set, another client creates the
+// quota just before the first CreateQuota request arrives. When rejectCreate is set, CreateQuota fails.
+type quotaServer struct {
+	mutex        sync.Mutex
+	quotas       map[string]map[string]int64
+	requests     []string
+	raceCreate   bool
+	rejectCreate bool
+}
+
+func (server *quotaServer) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	name := strings.TrimPrefix(req.URL.Path, "/admin/quotas/")
+	server.requests = append(server.requests, req.Method)
+	res.Header().Set("Content-Type", "application/json")
+	quota, exists := server.quotas[name]
+	body := map[string]int64{}
+	_ = json.NewDecoder(req.Body).Decode(&body)
+
+	switch {
+	case req.Method == http.MethodPost && server.raceCreate:
+		server.raceCreate = false
+		server.quotas[name] = map[string]int64{"producer_byte_rate": 1}
+		res.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = res.Write([]byte(`{"error_code":42200,"message":"Quota already exists."}`))
+	case req.Method == http.MethodPost && server.rejectCreate:
+		res.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = res.Write([]byte(`{"error_code":42200,"message":"Quota values must be greater than zero."}`))
+	case req.Method == http.MethodPost:
+		server.quotas[name] = body
+		res.WriteHeader(http.StatusCreated)
+		_, _ = res.Write([]byte(`{}`))
+	case !exists:
+		res.WriteHeader(http.StatusNotFound)
+		_, _ = res.Write([]byte(`{"error_code":40400,"message":"Quota does not exist."}`))
+	case req.Method == http.MethodPatch:
+		for key, value := range body {
+			quota[key] = value
+		}
+		res.WriteHeader(http.StatusAccepted)
+		_, _ = res.Write([]byte(`{}`))
+	default:
+		_ = json.NewEncoder(res).Encode(quota)
+	}
+}
+
+var _ = Describe(`UpsertQuota`, func() {
+	var server *quotaServer
+	var testServer *httptest.Server
+	var adminrestService *AdminrestV1
+
+	BeforeEach(func() {
+		server = &quotaServer{quotas: map[string]map[string]int64{
+			"iam-ServiceId-a": {"producer_byte_rate": 1024, "consumer_byte_rate": 2048},
+		}}
+		testServer = httptest.NewServer(server)
+		var err error
+		adminrestService, err = NewAdminrestV1(&AdminrestV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	It(`Update existing quotas`, func() {
+		created, response, err := adminrestService.UpsertQuota(adminrestService.NewUpsertQuotaOptions("iam-ServiceId-a").SetProducerByteRate(4096))
+		Expect(err).To(BeNil())
+		Expect(created).To(BeFalse())
+		Expect(response.StatusCode).To(Equal(http.StatusAccepted))
+		Expect(server.quotas["iam-ServiceId-a"]).To(Equal(map[string]int64{"producer_byte_rate": 4096, "consumer_byte_rate": 2048}))
+		Expect(server.requests).To(Equal([]string{"PATCH"}))
+	})
+	It(`Create missing quotas`, func() {
+		options := adminrestService.NewUpsertQuotaOptions("iam-ServiceId-b").SetProducerByteRate(100).SetConsumerByteRate(200)
+		created, response, err := adminrestService.UpsertQuota(options)
+		Expect(err).To(BeNil())
+		Expect(created).To(BeTrue())
+		Expect(response.StatusCode).To(Equal(http.StatusCreated))
+		Expect(server.quotas["iam-ServiceId-b"]).To(Equal(map[string]int64{"producer_byte_rate": 100, "consumer_byte_rate": 200}))
+		Expect(server.requests).To(Equal([]string{"PATCH", "POST"}))
+	})
+	It(`Update quotas created by another client in between`, func() {
+		server.raceCreate = true
+		created, _, err := adminrestService.UpsertQuota(adminrestService.NewUpsertQuotaOptions("iam-ServiceId-b").SetConsumerByteRate(200))
+		Expect(err).To(BeNil())
+		Expect(created).To(BeFalse())
+		Expect(server.quotas["iam-ServiceId-b"]).To(Equal(map[string]int64{"producer_byte_rate": 1, "consumer_byte_rate": 200}))
+		Expect(server.requests).To(Equal([]string{"PATCH", "POST", "GET", "PATCH"}))
+	})
+	It(`Return the error of a failed create`, func() {
+		server.rejectCreate = true
+		_, response, err := adminrestService.UpsertQuota(adminrestService.NewUpsertQuotaOptions("iam-ServiceId-b").SetConsumerByteRate(200))
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(HavePrefix("CreateQuota: Quota values must be greater than zero."))
+		Expect(response.StatusCode).To(Equal(http.StatusUnprocessableEntity))
+		Expect(server.requests).To(Equal([]string{"PATCH", "POST", "GET"}))
+
+		_, _, err = adminrestService.UpsertQuota(nil)
+		Expect(err).ToNot(BeNil())
+	})
+})
@@ -0,0 +1,184 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Names of the quota values in the Configs of a quota Action.
+const (
+	QuotaConsumerByteRate = "consumer_byte_rate"
+	QuotaProducerByteRate = "producer_byte_rate"
+)
+
+// PlanQuotas compares the desired quotas with the quotas in the instance and returns the actions needed to reconcile
+// them. A rate that is not set in the desired quota is left unchanged. When prune is true, the quotas of entities
+// that are not in the desired list are deleted. Creates are ordered before updates, and updates before deletes; each
+// group is sorted by entity name.
+func (reconciler *Reconciler) PlanQuotas(ctx context.Context, desired []adminrestv1.EntityQuotaDetail, prune bool) (plan *Plan, err error) {
+	var problems []string
+	desiredByName := make(map[string]adminrestv1.EntityQuotaDetail)
+	for _, quota := range desired {
+		name := core.StringNilMapper(quota.EntityName)
+		switch {
+		case name == "":
+			problems = append(problems, "a quota in the desired state has no entity name")
+			continue
+		case quota.ProducerByteRate == nil && quota.ConsumerByteRate == nil:
+			problems = append(problems, fmt.Sprintf("quota %s sets neither a producer nor a consumer byte rate", name))
+			continue
+		case (quota.ProducerByteRate != nil && *quota.ProducerByteRate <= 0) ||
+			(quota.ConsumerByteRate != nil && *quota.ConsumerByteRate <= 0):
+			problems = append(problems, fmt.Sprintf("quota %s has a byte rate that is not greater than zero", name))
+			continue
+		}
+		if _, exists := desiredByName[name]; exists {
+			problems = append(problems, fmt.Sprintf("quota %s is listed more than once", name))
+			continue
+		}
+		desiredByName[name] = quota
+	}
+	if len(problems) > 0 {
+		return nil, &PlanError{Problems: problems}
+	}
+
+	live, _, err := reconciler.client.ListQuotasWithContext(ctx, reconciler.client.NewListQuotasOptions())
+	if err != nil {
+		return
+	}
+	liveByName := make(map[string]adminrestv1.EntityQuotaDetail)
+	for _, quota := range live.Data {
+		if quota.EntityName != nil {
+			liveByName[*quota.EntityName] = quota
+		}
+	}
+
+	var names []string
+	for name := range desiredByName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var creates, updates, deletes []Action
+	for _, name := range names {
+		quota := desiredByName[name]
+		current, exists := liveByName[name]
+		if !exists {
+			creates = append(creates, Action{
+				Type:     ActionCreate,
+				Resource: ResourceQuota,
+				Name:     name,
+				Configs:  rateChanges(nil, quota),
+			})
+			continue
+		}
+		if changes := rateChanges(&current, quota); len(changes) > 0 {
+			updates = append(updates, Action{Type: ActionUpdate, Resource: ResourceQuota, Name: name, Configs: changes})
+		}
+	}
+
+	if prune {
+		var pruned []string
+		for name := range liveByName {
+			if _, wanted := desiredByName[name]; !wanted {
+				pruned = append(pruned, name)
+			}
+		}
+		sort.Strings(pruned)
+		for _, name := range pruned {
+			deletes = append(deletes, Action{Type: ActionDelete, Resource: ResourceQuota, Name: name})
+		}
+	}
+
+	plan = &Plan{Actions: []Action{}}
+	plan.Actions = append(plan.Actions, creates...)
+	plan.Actions = append(plan.Actions, updates...)
+	plan.Actions = append(plan.Actions, deletes...)
+	return
+}
+
+// ReconcileQuotas plans the changes needed to reach the desired quotas, as PlanQuotas does, and applies them. It
+// returns the plan, which holds the changes that were attempted; use PlanQuotas alone for a dry run.
+func (reconciler *Reconciler) ReconcileQuotas(ctx context.Context, desired []adminrestv1.EntityQuotaDetail, prune bool) (*Plan, error) {
+	plan, err := reconciler.PlanQuotas(ctx, desired, prune)
+	if err != nil {
+		return nil, err
+	}
+	return plan, reconciler.Apply(ctx, plan)
+}
+
+// rateChanges returns the changes needed to bring the current quota, which is nil for a new quota, to the desired
+// rates, sorted by name.
+func rateChanges(current *adminrestv1.EntityQuotaDetail, desired adminrestv1.EntityQuotaDetail) []ConfigChange {
+	var changes []ConfigChange
+	add := func(name string, from *int64, to *int64) {
+		if to == nil || (from != nil && *from == *to) {
+			return
+		}
+		change := ConfigChange{Name: name, To: strconv.FormatInt(*to, 10)}
+		if from != nil {
+			change.From = core.StringPtr(strconv.FormatInt(*from, 10))
+		} else if current != nil {
+			change.From = core.StringPtr("unset")
+		}
+		changes = append(changes, change)
+	}
+	var producer, consumer *int64
+	if current != nil {
+		producer, consumer = current.ProducerByteRate, current.ConsumerByteRate
+	}
+	add(QuotaConsumerByteRate, consumer, desired.ConsumerByteRate)
+	add(QuotaProducerByteRate, producer, desired.ProducerByteRate)
+	return changes
+}
+
+// applyQuotaAction performs a single action on a quota. Creates and updates are made with UpsertQuota, so they
+// succeed even if the quota was created or deleted since the plan was made.
+func (reconciler *Reconciler) applyQuotaAction(ctx context.Context, action Action) (err error) {
+	switch action.Type {
+	case ActionCreate, ActionUpdate:
+		upsertQuotaOptions := reconciler.client.NewUpsertQuotaOptions(action.Name)
+		for _, config := range action.Configs {
+			var rate int64
+			rate, err = strconv.ParseInt(config.To, 10, 64)
+			if err != nil {
+				return fmt.Errorf("the %s value %q is not a whole number", config.Name, config.To)
+			}
+			switch config.Name {
+			case QuotaConsumerByteRate:
+				upsertQuotaOptions.SetConsumerByteRate(rate)
+			case QuotaProducerByteRate:
+				upsertQuotaOptions.SetProducerByteRate(rate)
+			default:
+				return fmt.Errorf("unsupported quota value '%s'", config.Name)
+			}
+		}
+		_, _, err = reconciler.client.UpsertQuotaWithContext(ctx, upsertQuotaOptions)
+	case ActionDelete:
+		_, err = reconciler.client.DeleteQuotaWithContext(ctx, reconciler.client.NewDeleteQuotaOptions(action.Name))
+	default:
+		err = fmt.Errorf("unsupported action type '%s'", action.Type)
+	}
+	return
+}
@@ -0,0 +1,120 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1/fake"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Quota reconciliation`, func() {
+	var server *fake.Server
+	var client *adminrestv1.AdminrestV1
+
+	BeforeEach(func() {
+		server = fake.NewServer(nil)
+		server.SetQuota("default", fake.Quota{ProducerByteRate: 1048576, ConsumerByteRate: 1048576})
+		server.SetQuota("iam-ServiceId-orders", fake.Quota{ProducerByteRate: 524288})
+		server.SetQuota("iam-ServiceId-legacy", fake.Quota{ConsumerByteRate: 1024})
+		var err error
+		client, err = server.NewClient()
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	// changeCount returns the number of requests that changed quotas.
+	changeCount := func() int {
+		return server.RequestCount(fake.OperationCreateQuota) +
+			server.RequestCount(fake.OperationUpdateQuota) +
+			server.RequestCount(fake.OperationDeleteQuota)
+	}
+
+	desired := []adminrestv1.EntityQuotaDetail{
+		{EntityName: core.StringPtr("default"), ProducerByteRate: core.Int64Ptr(1048576), ConsumerByteRate: core.Int64Ptr(1048576)},
+		{EntityName: core.StringPtr("iam-ServiceId-orders"), ProducerByteRate: core.Int64Ptr(2097152), ConsumerByteRate: core.Int64Ptr(4194304)},
+		{EntityName: core.StringPtr("iam-ServiceId-payments"), ProducerByteRate: core.Int64Ptr(65536)},
+	}
+
+	It(`Plan creates and updates without deletes`, func() {
+		plan, err := New(client, nil).PlanQuotas(context.Background(), desired, false)
+		Expect(err).To(BeNil())
+		Expect(plan.String()).To(Equal(
+			"+ create quota iam-ServiceId-payments (producer_byte_rate=65536)\n" +
+				"~ update quota iam-ServiceId-orders (consumer_byte_rate: unset -> 4194304, producer_byte_rate: 524288 -> 2097152)\n"))
+		Expect(changeCount()).To(Equal(0))
+
+		buf, err := json.Marshal(plan)
+		Expect(err).To(BeNil())
+		Expect(string(buf)).To(ContainSubstring(`{"type":"create","resource":"quota","name":"iam-ServiceId-payments","configs":[{"name":"producer_byte_rate","to":"65536"}]}`))
+	})
+	It(`Plan deletes when pruning`, func() {
+		plan, err := New(client, nil).PlanQuotas(context.Background(), desired, true)
+		Expect(err).To(BeNil())
+		Expect(plan.Actions).To(HaveLen(3))
+		Expect(plan.Actions[2]).To(Equal(Action{Type: ActionDelete, Resource: ResourceQuota, Name: "iam-ServiceId-legacy"}))
+	})
+	It(`Reconcile the quotas`, func() {
+		plan, err := New(client, nil).ReconcileQuotas(context.Background(), desired, true)
+		Expect(err).To(BeNil())
+		Expect(plan.Actions).To(HaveLen(3))
+
+		quota, ok := server.Quota("iam-ServiceId-orders")
+		Expect(ok).To(BeTrue())
+		Expect(quota).To(Equal(fake.Quota{ProducerByteRate: 2097152, ConsumerByteRate: 4194304}))
+		quota, ok = server.Quota("iam-ServiceId-payments")
+		Expect(ok).To(BeTrue())
+		Expect(quota).To(Equal(fake.Quota{ProducerByteRate: 65536}))
+		_, ok = server.Quota("iam-ServiceId-legacy")
+		Expect(ok).To(BeFalse())
+
+		plan, err = New(client, nil).PlanQuotas(context.Background(), desired, true)
+		Expect(err).To(BeNil())
+		Expect(plan.IsEmpty()).To(BeTrue())
+	})
+	It(`Apply a stale plan`, func() {
+		plan, err := New(client, nil).PlanQuotas(context.Background(), desired, false)
+		Expect(err).To(BeNil())
+		server.SetQuota("iam-ServiceId-payments", fake.Quota{ConsumerByteRate: 1})
+
+		Expect(New(client, nil).Apply(context.Background(), plan)).To(Succeed())
+		quota, _ := server.Quota("iam-ServiceId-payments")
+		Expect(quota).To(Equal(fake.Quota{ProducerByteRate: 65536, ConsumerByteRate: 1}))
+	})
+	It(`Refuse invalid desired quotas`, func() {
+		_, err := New(client, nil).PlanQuotas(context.Background(), []adminrestv1.EntityQuotaDetail{
+			{EntityName: core.StringPtr("iam-ServiceId-a")},
+			{EntityName: core.StringPtr("iam-ServiceId-b"), ProducerByteRate: core.Int64Ptr(0)},
+			{ProducerByteRate: core.Int64Ptr(1)},
+			{EntityName: core.StringPtr("default"), ProducerByteRate: core.Int64Ptr(1)},
+			{EntityName: core.StringPtr("default"), ConsumerByteRate: core.Int64Ptr(1)},
+		}, false)
+		Expect(err).To(MatchError("cannot plan the requested changes: " +
+			"quota iam-ServiceId-a sets neither a producer nor a consumer byte rate; " +
+			"quota iam-ServiceId-b has a byte rate that is not greater than zero; " +
+			"a quota in the desired state has no entity name; " +
+			"quota default is listed more than once"))
+		Expect(server.RequestCount(fake.OperationListQuotas)).To(Equal(0))
+	})
+})
@@ -0,0 +1,514 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package reconcile : Declarative management of Event Streams topics and quotas
+//
+// A Reconciler compares a desired list of topics with the topics that exist in an Event Streams instance and
+// produces a Plan of CreateTopic, UpdateTopic and (optionally) DeleteTopic calls. PlanQuotas does the same for the
+// producer and consumer byte rate quotas of entities. A Plan can be printed for review, serialized as JSON, and later
+// applied with Reconciler.Apply.
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// TopicSpec : The desired state of a single topic.
+type TopicSpec struct {
+	// The name of the topic.
+	Name string `json:"name" yaml:"name"`
+
+	// The desired number of partitions. Zero leaves the partition count unmanaged: new topics are created with the
+	// service default and the partition count of existing topics is not changed.
+	Partitions int64 `json:"partitions,omitempty" yaml:"partitions,omitempty"`
+
+	// The desired topic configs, keyed by config name (for example 'retention.ms'). Configs that are not listed are
+	// left unchanged. GetTopic reports only the configs in adminrestv1.TopicConfigs, so other configs, such as
+	// 'max.message.bytes', are set when the topic is created; for existing topics they are listed in
+	// Plan.Unverified instead of being compared.
+	Configs map[string]string `json:"configs,omitempty" yaml:"configs,omitempty"`
+}
+
+// ActionType : The kind of change an Action makes.
+type ActionType string
+
+// Constants associated with the Action.Type property.
+const (
+	ActionCreate ActionType = "create"
+	ActionUpdate ActionType = "update"
+	ActionDelete ActionType = "delete"
+)
+
+// Constants associated with the Action.Resource property.
+const (
+	ResourceTopic = "topic"
+	ResourceQuota = "quota"
+)
+
+// PartitionChange : A change to the partition count of a topic.
+type PartitionChange struct {
+	// The current partition count, or zero if the topic does not exist yet.
+	From int64 `json:"from"`
+
+	// The partition count after the change.
+	To int64 `json:"to"`
+}
+
+// ConfigChange : A change to a single topic config.
+type ConfigChange struct {
+	// The name of the config property.
+	Name string `json:"name"`
+
+	// The current value, or nil if the current value is not known.
+	From *string `json:"from,omitempty"`
+
+	// The value after the change.
+	To string `json:"to"`
+}
+
+// Action : A single operation in a Plan.
+type Action struct {
+	// The kind of change.
+	Type ActionType `json:"type"`
+
+	// The kind of resource being changed, ResourceTopic or ResourceQuota.
+	Resource string `json:"resource"`
+
+	// The name of the resource.
+	Name string `json:"name"`
+
+	// The partition change, if any.
+	Partitions *PartitionChange `json:"partitions,omitempty"`
+
+	// The config changes, sorted by config name. For a quota, the changes to the 'consumer_byte_rate' and
+	// 'producer_byte_rate' values.
+	Configs []ConfigChange `json:"configs,omitempty"`
+}
+
+// String returns a one line description of the action.
+func (action Action) String() string {
+	var symbol string
+	switch action.Type {
+	case ActionCreate:
+		symbol = "+"
+	case ActionUpdate:
+		symbol = "~"
+	case ActionDelete:
+		symbol = "-"
+	default:
+		symbol = "?"
+	}
+
+	var details []string
+	if action.Partitions != nil {
+		if action.Type == ActionCreate {
+			details = append(details, fmt.Sprintf("partitions=%d", action.Partitions.To))
+		} else {
+			details = append(details, fmt.Sprintf("partitions: %d -> %d", action.Partitions.From, action.Partitions.To))
+		}
+	}
+	for _, config := range action.Configs {
+		if action.Type == ActionCreate {
+			details = append(details, fmt.Sprintf("%s=%s", config.Name, config.To))
+		} else if config.From == nil {
+			details = append(details, fmt.Sprintf("%s: (unknown) -> %s", config.Name, config.To))
+		} else {
+			details = append(details, fmt.Sprintf("%s: %s -> %s", config.Name, *config.From, config.To))
+		}
+	}
+
+	line := fmt.Sprintf("%s %s %s %s", symbol, action.Type, action.Resource, action.Name)
+	if len(details) > 0 {
+		line += " (" + strings.Join(details, ", ") + ")"
+	}
+	return line
+}
+
+// UnverifiedConfig : A desired config of an existing topic that GetTopic does not report, so the plan cannot tell
+// whether the topic has the value. Apply does not change it.
+type UnverifiedConfig struct {
+	// The name of the topic.
+	Topic string `json:"topic"`
+
+	// The name of the config property.
+	Name string `json:"name"`
+
+	// The desired value.
+	Value string `json:"value"`
+}
+
+// String returns a one line description of the config.
+func (config UnverifiedConfig) String() string {
+	return fmt.Sprintf("? topic %s: %s=%s is not reported by the service, so it is not checked or changed",
+		config.Topic, config.Name, config.Value)
+}
+
+// Plan : The ordered list of actions needed to bring an instance to the desired state.
+type Plan struct {
+	Actions []Action `json:"actions"`
+
+	// The desired configs of existing topics that cannot be compared with the topics, sorted by topic and config
+	// name. They are not actions, so a plan with only unverified configs is empty.
+	Unverified []UnverifiedConfig `json:"unverified,omitempty"`
+}
+
+// IsEmpty returns true if the plan contains no actions.
+func (plan *Plan) IsEmpty() bool {
+	return plan == nil || len(plan.Actions) == 0
+}
+
+// String returns a human readable description of the plan, one action per line, followed by the unverified configs.
+func (plan *Plan) String() string {
+	var builder strings.Builder
+	if plan.IsEmpty() {
+		builder.WriteString("No changes.\n")
+	} else {
+		for _, action := range plan.Actions {
+			builder.WriteString(action.String())
+			builder.WriteString("\n")
+		}
+	}
+	if plan != nil {
+		for _, config := range plan.Unverified {
+			builder.WriteString(config.String())
+			builder.WriteString("\n")
+		}
+	}
+	return builder.String()
+}
+
+// PlanError : Returned by Reconciler.Plan when the desired state contains changes that cannot be made.
+type PlanError struct {
+	// A description of every change that was refused.
+	Problems []string
+}
+
+// Error implements the error interface.
+func (planError *PlanError) Error() string {
+	return "cannot plan the requested changes: " + strings.Join(planError.Problems, "; ")
+}
+
+// Options : The Reconciler options.
+type Options struct {
+	// When true, topics that exist in the instance but are not in the desired state are deleted.
+	Prune bool
+
+	// A filter applied to the topics read from the instance, using the same syntax as
+	// ListTopicsOptions.TopicFilter. Topics that do not match the filter are never deleted, and Plan refuses desired
+	// topics that do not match it.
+	TopicFilter string
+}
+
+// Reconciler : Plans and applies changes to the topics of an Event Streams instance.
+type Reconciler struct {
+	client  *adminrestv1.AdminrestV1
+	options Options
+}
+
+// New : constructs a Reconciler that uses the specified client.
+func New(client *adminrestv1.AdminrestV1, options *Options) *Reconciler {
+	reconciler := &Reconciler{
+		client: client,
+	}
+	if options != nil {
+		reconciler.options = *options
+	}
+	return reconciler
+}
+
+// Plan compares the desired topics with the topics in the instance and returns the actions needed to reconcile them.
+// Creates are ordered before updates, and updates before deletes; each group is sorted by topic name.
+func (reconciler *Reconciler) Plan(ctx context.Context, desired []TopicSpec) (plan *Plan, err error) {
+	var problems []string
+	desiredByName := make(map[string]TopicSpec)
+	for _, spec := range desired {
+		if spec.Name == "" {
+			problems = append(problems, "a topic in the desired state has no name")
+			continue
+		}
+		if _, exists := desiredByName[spec.Name]; exists {
+			problems = append(problems, fmt.Sprintf("topic %s is listed more than once", spec.Name))
+			continue
+		}
+		if spec.Partitions < 0 {
+			problems = append(problems, fmt.Sprintf("topic %s has a negative partition count", spec.Name))
+			continue
+		}
+		desiredByName[spec.Name] = spec
+	}
+
+	matchesFilter, err := adminrestv1.TopicFilterMatcher(reconciler.options.TopicFilter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid topic filter %q: %w", reconciler.options.TopicFilter, err)
+	}
+	for _, name := range sortedKeys(desiredByName) {
+		if !matchesFilter(name) {
+			// The topic would not be listed, so it would be planned as a create whether or not it exists.
+			problems = append(problems, fmt.Sprintf("topic %s does not match the topic filter %s", name, reconciler.options.TopicFilter))
+			delete(desiredByName, name)
+		}
+	}
+
+	listOptions := reconciler.client.NewListAllTopicsOptions()
+	if reconciler.options.TopicFilter != "" {
+		listOptions.SetTopicFilter(reconciler.options.TopicFilter)
+	}
+	live, err := reconciler.client.ListAllTopicsWithContext(ctx, listOptions)
+	if err != nil {
+		return
+	}
+	liveNames := make(map[string]bool)
+	for _, topic := range live.Topics {
+		if topic.Name != nil {
+			liveNames[*topic.Name] = true
+		}
+	}
+
+	var creates, updates, deletes []Action
+	var unverified []UnverifiedConfig
+	for _, name := range sortedKeys(desiredByName) {
+		spec := desiredByName[name]
+		if !liveNames[name] {
+			creates = append(creates, createAction(spec))
+			continue
+		}
+
+		var detail *adminrestv1.TopicDetail
+		detail, _, err = reconciler.client.GetTopicWithContext(ctx, reconciler.client.NewGetTopicOptions(name))
+		if err != nil {
+			return
+		}
+
+		var action *Action
+		action, err = updateAction(spec, detail)
+		if err != nil {
+			problems = append(problems, err.Error())
+			err = nil
+			continue
+		}
+		if action != nil {
+			updates = append(updates, *action)
+		}
+		unverified = append(unverified, unverifiedConfigs(spec)...)
+	}
+
+	if reconciler.options.Prune {
+		var names []string
+		for name := range liveNames {
+			if _, wanted := desiredByName[name]; !wanted {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			deletes = append(deletes, Action{Type: ActionDelete, Resource: ResourceTopic, Name: name})
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, &PlanError{Problems: problems}
+	}
+
+	plan = &Plan{Actions: []Action{}, Unverified: unverified}
+	plan.Actions = append(plan.Actions, creates...)
+	plan.Actions = append(plan.Actions, updates...)
+	plan.Actions = append(plan.Actions, deletes...)
+	return
+}
+
+// Apply makes the changes described by the plan, in order. It stops at the first action that fails and returns an
+// error identifying that action. Deletes are only performed when the plan contains them, so a plan created without
+// Options.Prune never removes topics.
+func (reconciler *Reconciler) Apply(ctx context.Context, plan *Plan) error {
+	if plan == nil {
+		return nil
+	}
+	for _, action := range plan.Actions {
+		err := reconciler.applyAction(ctx, action)
+		if err != nil {
+			return fmt.Errorf("%s %s %s: %w", action.Type, action.Resource, action.Name, err)
+		}
+	}
+	return nil
+}
+
+// applyAction performs a single action.
+func (reconciler *Reconciler) applyAction(ctx context.Context, action Action) (err error) {
+	switch action.Resource {
+	case ResourceTopic:
+		return reconciler.applyTopicAction(ctx, action)
+	case ResourceQuota:
+		return reconciler.applyQuotaAction(ctx, action)
+	}
+	return fmt.Errorf("unsupported resource '%s'", action.Resource)
+}
+
+// applyTopicAction performs a single action on a topic.
+func (reconciler *Reconciler) applyTopicAction(ctx context.Context, action Action) (err error) {
+	switch action.Type {
+	case ActionCreate:
+		createTopicOptions := reconciler.client.NewCreateTopicOptions().SetName(action.Name)
+		if action.Partitions != nil {
+			createTopicOptions.SetPartitionCount(action.Partitions.To)
+		}
+		for _, config := range action.Configs {
+			createTopicOptions.Configs = append(createTopicOptions.Configs, adminrestv1.ConfigCreate{
+				Name:  core.StringPtr(config.Name),
+				Value: core.StringPtr(config.To),
+			})
+		}
+		_, err = reconciler.client.CreateTopicWithContext(ctx, createTopicOptions)
+	case ActionUpdate:
+		updateTopicOptions := reconciler.client.NewUpdateTopicOptions(action.Name)
+		if action.Partitions != nil {
+			if action.Partitions.To < action.Partitions.From {
+				return fmt.Errorf("the partition count cannot be reduced from %d to %d", action.Partitions.From, action.Partitions.To)
+			}
+			updateTopicOptions.SetNewTotalPartitionCount(action.Partitions.To)
+		}
+		for _, config := range action.Configs {
+			updateTopicOptions.Configs = append(updateTopicOptions.Configs, adminrestv1.ConfigUpdate{
+				Name:  core.StringPtr(config.Name),
+				Value: core.StringPtr(config.To),
+			})
+		}
+		_, err = reconciler.client.UpdateTopicWithContext(ctx, updateTopicOptions)
+	case ActionDelete:
+		_, err = reconciler.client.DeleteTopicWithContext(ctx, reconciler.client.NewDeleteTopicOptions(action.Name))
+	default:
+		err = fmt.Errorf("unsupported action type '%s'", action.Type)
+	}
+	return
+}
+
+// createAction returns the action that creates the topic described by spec.
+func createAction(spec TopicSpec) Action {
+	action := Action{Type: ActionCreate, Resource: ResourceTopic, Name: spec.Name}
+	if spec.Partitions > 0 {
+		action.Partitions = &PartitionChange{To: spec.Partitions}
+	}
+	for _, name := range sortedConfigNames(spec.Configs) {
+		action.Configs = append(action.Configs, ConfigChange{Name: name, To: spec.Configs[name]})
+	}
+	return action
+}
+
+// updateAction returns the action that brings the topic described by detail to spec, or nil if no change is needed.
+// An error is returned if the change cannot be made.
+func updateAction(spec TopicSpec, detail *adminrestv1.TopicDetail) (*Action, error) {
+	action := Action{Type: ActionUpdate, Resource: ResourceTopic, Name: spec.Name}
+
+	if spec.Partitions > 0 && detail != nil && detail.Partitions != nil && spec.Partitions != *detail.Partitions {
+		if spec.Partitions < *detail.Partitions {
+			return nil, fmt.Errorf("topic %s has %d partitions and cannot be reduced to %d", spec.Name, *detail.Partitions, spec.Partitions)
+		}
+		action.Partitions = &PartitionChange{From: *detail.Partitions, To: spec.Partitions}
+	}
+
+	current, err := liveConfigs(detail)
+	if err != nil {
+		return nil, fmt.Errorf("topic %s: %s", spec.Name, err.Error())
+	}
+	for _, name := range sortedConfigNames(spec.Configs) {
+		if !reportedConfigs[name] {
+			// Listed by unverifiedConfigs.
+			continue
+		}
+		value, known := current[name]
+		if known && value == spec.Configs[name] {
+			continue
+		}
+		change := ConfigChange{Name: name, To: spec.Configs[name]}
+		if known {
+			change.From = core.StringPtr(value)
+		}
+		action.Configs = append(action.Configs, change)
+	}
+
+	if action.Partitions == nil && len(action.Configs) == 0 {
+		return nil, nil
+	}
+	return &action, nil
+}
+
+// unverifiedConfigs returns the desired configs of an existing topic that GetTopic does not report.
+func unverifiedConfigs(spec TopicSpec) []UnverifiedConfig {
+	var configs []UnverifiedConfig
+	for _, name := range sortedConfigNames(spec.Configs) {
+		if !reportedConfigs[name] {
+			configs = append(configs, UnverifiedConfig{Topic: spec.Name, Name: name, Value: spec.Configs[name]})
+		}
+	}
+	return configs
+}
+
+// reportedConfigs holds the names of the configs that TopicDetail reports, which are the only configs that can be
+// compared with the desired state.
+var reportedConfigs = reportedConfigNames()
+
+// reportedConfigNames returns the names of the fields of adminrestv1.TopicConfigs.
+func reportedConfigNames() map[string]bool {
+	names := make(map[string]bool)
+	configsType := reflect.TypeOf(adminrestv1.TopicConfigs{})
+	for i := 0; i < configsType.NumField(); i++ {
+		if name := strings.Split(configsType.Field(i).Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// liveConfigs returns the configs reported for a topic, keyed by config name.
+func liveConfigs(detail *adminrestv1.TopicDetail) (configs map[string]string, err error) {
+	configs = make(map[string]string)
+	if detail == nil || detail.Configs == nil {
+		return
+	}
+	buf, err := json.Marshal(detail.Configs)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(buf, &configs)
+	return
+}
+
+// sortedKeys returns the names of the desired topics in sorted order.
+func sortedKeys(specs map[string]TopicSpec) []string {
+	names := make([]string, 0, len(specs))
+	for name := range specs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortedConfigNames returns the config names in sorted order.
+func sortedConfigNames(configs map[string]string) []string {
+	names := make([]string, 0, len(configs))
+	for name := range configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
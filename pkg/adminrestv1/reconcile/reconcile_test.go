@@ -0,0 +1,278 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// topicState is the state of a topic held by the test server.
+type topicState struct {
+	partitions int64
+	configs    map[string]string
+}
+
+// topicServer is a minimal in-memory implementation of the topic endpoints used by the Reconciler.
+type topicServer struct {
+	mutex  sync.Mutex
+	topics map[string]*topicState
+	calls  []string
+}
+
+func (server *topicServer) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	defer GinkgoRecover()
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	path := req.URL.EscapedPath()
+	name := strings.TrimPrefix(path, "/admin/topics/")
+	res.Header().Set("Content-type", "application/json")
+
+	switch {
+	case path == "/admin/topics" && req.Method == "GET":
+		var names []string
+		for topicName := range server.topics {
+			names = append(names, topicName)
+		}
+		sort.Strings(names)
+		topics := []map[string]interface{}{}
+		for _, topicName := range names {
+			topics = append(topics, map[string]interface{}{"name": topicName, "partitions": server.topics[topicName].partitions})
+		}
+		body, _ := json.Marshal(topics)
+		res.Header().Set("X-Total-Count", fmt.Sprintf("%d", len(topics)))
+		res.WriteHeader(200)
+		fmt.Fprintf(res, "%s", body)
+	case path == "/admin/topics" && req.Method == "POST":
+		var body struct {
+			Name           string `json:"name"`
+			PartitionCount int64  `json:"partition_count"`
+			Configs        []struct {
+				Name  string `json:"name"`
+				Value string `json:"value"`
+			} `json:"configs"`
+		}
+		Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+		server.calls = append(server.calls, "create "+body.Name)
+		topic := &topicState{partitions: body.PartitionCount, configs: map[string]string{}}
+		if topic.partitions == 0 {
+			topic.partitions = 1
+		}
+		for _, config := range body.Configs {
+			topic.configs[config.Name] = config.Value
+		}
+		server.topics[body.Name] = topic
+		res.WriteHeader(202)
+	case req.Method == "GET":
+		topic, ok := server.topics[name]
+		if !ok {
+			res.WriteHeader(404)
+			fmt.Fprintf(res, `{"error_code": 40403, "message": "topic not found", "incident_id": "abc"}`)
+			return
+		}
+		body, _ := json.Marshal(map[string]interface{}{"name": name, "partitions": topic.partitions, "configs": topic.configs})
+		res.WriteHeader(200)
+		fmt.Fprintf(res, "%s", body)
+	case req.Method == "PATCH":
+		var body struct {
+			NewTotalPartitionCount int64 `json:"new_total_partition_count"`
+			Configs                []struct {
+				Name  string `json:"name"`
+				Value string `json:"value"`
+			} `json:"configs"`
+		}
+		Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+		server.calls = append(server.calls, "update "+name)
+		topic := server.topics[name]
+		if body.NewTotalPartitionCount != 0 {
+			topic.partitions = body.NewTotalPartitionCount
+		}
+		for _, config := range body.Configs {
+			topic.configs[config.Name] = config.Value
+		}
+		res.WriteHeader(202)
+	case req.Method == "DELETE":
+		server.calls = append(server.calls, "delete "+name)
+		delete(server.topics, name)
+		res.WriteHeader(202)
+	default:
+		res.WriteHeader(405)
+	}
+}
+
+var _ = Describe(`Reconciler`, func() {
+	var testServer *httptest.Server
+	var server *topicServer
+	var client *adminrestv1.AdminrestV1
+
+	BeforeEach(func() {
+		server = &topicServer{topics: map[string]*topicState{
+			"orders":   {partitions: 3, configs: map[string]string{"retention.ms": "86400000", "cleanup.policy": "delete"}},
+			"payments": {partitions: 1, configs: map[string]string{"retention.ms": "3600000"}},
+			"legacy":   {partitions: 1, configs: map[string]string{}},
+		}}
+		testServer = httptest.NewServer(server)
+		var err error
+		client, err = adminrestv1.NewAdminrestV1(&adminrestv1.AdminrestV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	desired := []TopicSpec{
+		{Name: "payments", Partitions: 4, Configs: map[string]string{"retention.ms": "3600000", "segment.ms": "600000"}},
+		{Name: "orders", Partitions: 3, Configs: map[string]string{"retention.ms": "86400000"}},
+		{Name: "audit", Partitions: 2, Configs: map[string]string{"cleanup.policy": "compact"}},
+	}
+
+	Describe(`Plan(ctx context.Context, desired []TopicSpec)`, func() {
+		It(`Plan creates and updates without deletes`, func() {
+			plan, err := New(client, nil).Plan(context.Background(), desired)
+			Expect(err).To(BeNil())
+			Expect(plan.Actions).To(HaveLen(2))
+
+			Expect(plan.Actions[0].Type).To(Equal(ActionCreate))
+			Expect(plan.Actions[0].Name).To(Equal("audit"))
+			Expect(plan.Actions[0].Partitions.To).To(Equal(int64(2)))
+
+			Expect(plan.Actions[1].Type).To(Equal(ActionUpdate))
+			Expect(plan.Actions[1].Name).To(Equal("payments"))
+			Expect(*plan.Actions[1].Partitions).To(Equal(PartitionChange{From: 1, To: 4}))
+			Expect(plan.Actions[1].Configs).To(HaveLen(1))
+			Expect(plan.Actions[1].Configs[0].Name).To(Equal("segment.ms"))
+			Expect(plan.Actions[1].Configs[0].From).To(BeNil())
+			Expect(plan.Actions[1].Configs[0].To).To(Equal("600000"))
+
+			Expect(plan.String()).To(Equal(
+				"+ create topic audit (partitions=2, cleanup.policy=compact)\n" +
+					"~ update topic payments (partitions: 1 -> 4, segment.ms: (unknown) -> 600000)\n"))
+			Expect(server.calls).To(BeEmpty())
+		})
+		It(`Plan deletes when pruning is enabled`, func() {
+			plan, err := New(client, &Options{Prune: true}).Plan(context.Background(), desired)
+			Expect(err).To(BeNil())
+			Expect(plan.Actions).To(HaveLen(3))
+			Expect(plan.Actions[2].Type).To(Equal(ActionDelete))
+			Expect(plan.Actions[2].Name).To(Equal("legacy"))
+			Expect(plan.Actions[2].String()).To(Equal("- delete topic legacy"))
+		})
+		It(`Plan nothing when the instance matches`, func() {
+			plan, err := New(client, nil).Plan(context.Background(), []TopicSpec{
+				{Name: "orders", Configs: map[string]string{"cleanup.policy": "delete"}},
+			})
+			Expect(err).To(BeNil())
+			Expect(plan.IsEmpty()).To(BeTrue())
+			Expect(plan.String()).To(Equal("No changes.\n"))
+		})
+		It(`Create topics with configs that GetTopic does not report, and then list them as unverified`, func() {
+			reconciler := New(client, nil)
+			specs := []TopicSpec{
+				{Name: "orders", Configs: map[string]string{"retention.ms": "86400000", "max.message.bytes": "2097152"}},
+				{Name: "audit", Configs: map[string]string{"max.message.bytes": "2097152"}},
+			}
+			plan, err := reconciler.Plan(context.Background(), specs)
+			Expect(err).To(BeNil())
+			Expect(plan.Unverified).To(Equal([]UnverifiedConfig{{Topic: "orders", Name: "max.message.bytes", Value: "2097152"}}))
+			Expect(plan.String()).To(Equal("+ create topic audit (max.message.bytes=2097152)\n" +
+				"? topic orders: max.message.bytes=2097152 is not reported by the service, so it is not checked or changed\n"))
+			Expect(reconciler.Apply(context.Background(), plan)).To(Succeed())
+			Expect(server.calls).To(Equal([]string{"create audit"}))
+
+			plan, err = reconciler.Plan(context.Background(), specs)
+			Expect(err).To(BeNil())
+			Expect(plan.IsEmpty()).To(BeTrue())
+			Expect(plan.String()).To(Equal("No changes.\n" +
+				"? topic audit: max.message.bytes=2097152 is not reported by the service, so it is not checked or changed\n" +
+				"? topic orders: max.message.bytes=2097152 is not reported by the service, so it is not checked or changed\n"))
+		})
+		It(`Refuse desired topics that do not match the topic filter`, func() {
+			plan, err := New(client, &Options{TopicFilter: "pay*", Prune: true}).Plan(context.Background(), desired)
+			Expect(plan).To(BeNil())
+			Expect(err).To(MatchError("cannot plan the requested changes: topic audit does not match the topic filter pay*; " +
+				"topic orders does not match the topic filter pay*"))
+
+			_, err = New(client, &Options{TopicFilter: "/[/"}).Plan(context.Background(), desired)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(HavePrefix(`invalid topic filter "/[/"`))
+		})
+		It(`Refuse to reduce partitions`, func() {
+			plan, err := New(client, nil).Plan(context.Background(), []TopicSpec{
+				{Name: "orders", Partitions: 1},
+				{Name: "audit", Partitions: 1},
+				{Name: "audit", Partitions: 2},
+			})
+			Expect(plan).To(BeNil())
+			planErr, ok := err.(*PlanError)
+			Expect(ok).To(BeTrue())
+			Expect(planErr.Problems).To(HaveLen(2))
+			Expect(err.Error()).To(ContainSubstring("topic orders has 3 partitions and cannot be reduced to 1"))
+			Expect(err.Error()).To(ContainSubstring("topic audit is listed more than once"))
+		})
+	})
+
+	Describe(`Apply(ctx context.Context, plan *Plan)`, func() {
+		It(`Apply a plan that has been serialized`, func() {
+			reconciler := New(client, &Options{Prune: true})
+			plan, err := reconciler.Plan(context.Background(), desired)
+			Expect(err).To(BeNil())
+
+			buf, err := json.Marshal(plan)
+			Expect(err).To(BeNil())
+			var decoded Plan
+			Expect(json.Unmarshal(buf, &decoded)).To(Succeed())
+			Expect(decoded).To(Equal(*plan))
+
+			Expect(reconciler.Apply(context.Background(), &decoded)).To(Succeed())
+			Expect(server.calls).To(Equal([]string{"create audit", "update payments", "delete legacy"}))
+			Expect(server.topics["payments"].partitions).To(Equal(int64(4)))
+			Expect(server.topics["payments"].configs["segment.ms"]).To(Equal("600000"))
+			Expect(server.topics["audit"].configs["cleanup.policy"]).To(Equal("compact"))
+
+			plan, err = reconciler.Plan(context.Background(), desired)
+			Expect(err).To(BeNil())
+			Expect(plan.IsEmpty()).To(BeTrue())
+		})
+		It(`Refuse a plan that reduces partitions`, func() {
+			plan := &Plan{Actions: []Action{{
+				Type:       ActionUpdate,
+				Resource:   ResourceTopic,
+				Name:       "orders",
+				Partitions: &PartitionChange{From: 3, To: 1},
+			}}}
+			err := New(client, nil).Apply(context.Background(), plan)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("update topic orders"))
+			Expect(server.calls).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,271 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1/reconcile"
+)
+
+// Kind : The kind of object a Result describes.
+type Kind string
+
+// Constants associated with the Result.Kind property.
+const (
+	KindTopic     Kind = "topic"
+	KindMirroring Kind = "mirroring"
+	KindQuota     Kind = "quota"
+)
+
+// Outcome : What Restore did, or would do, with an object.
+type Outcome string
+
+// Constants associated with the Result.Outcome property.
+const (
+	OutcomeCreated   Outcome = "created"
+	OutcomeUpdated   Outcome = "updated"
+	OutcomeUnchanged Outcome = "unchanged"
+	OutcomeFailed    Outcome = "failed"
+)
+
+// Result : The outcome of restoring one object.
+type Result struct {
+	// The kind of object.
+	Kind Kind `json:"kind"`
+
+	// The name of the topic or the entity of the quota. Empty for the mirroring topic selection.
+	Name string `json:"name,omitempty"`
+
+	// What happened to the object.
+	Outcome Outcome `json:"outcome"`
+
+	// Why the object could not be restored, when Outcome is OutcomeFailed.
+	Error string `json:"error,omitempty"`
+}
+
+// String returns a one line description of the result.
+func (result Result) String() string {
+	description := string(result.Kind)
+	if result.Name != "" {
+		description += " " + result.Name
+	}
+	description += ": " + string(result.Outcome)
+	if result.Error != "" {
+		description += ": " + result.Error
+	}
+	return description
+}
+
+// Report : The outcome of Restore for every object in the snapshot.
+type Report struct {
+	// True if the report describes a dry run, and the instance was not changed.
+	DryRun bool `json:"dry_run"`
+
+	// The results, for topics, then the mirroring topic selection, then quotas.
+	Results []Result `json:"results"`
+}
+
+// Failed returns the results whose outcome is OutcomeFailed.
+func (report *Report) Failed() []Result {
+	var failed []Result
+	for _, result := range report.Results {
+		if result.Outcome == OutcomeFailed {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// String returns a human readable description of the report, one object per line.
+func (report *Report) String() string {
+	var buf bytes.Buffer
+	for _, result := range report.Results {
+		fmt.Fprintf(&buf, "%s\n", result)
+	}
+	if report.DryRun {
+		buf.WriteString("Dry run: no changes were made.\n")
+	}
+	return buf.String()
+}
+
+// RestoreOptions : The Restore options.
+type RestoreOptions struct {
+	// When true, Restore reports what it would do without changing the instance.
+	DryRun bool
+}
+
+// Restore : loads a snapshot into the instance the client is connected to.
+//
+// Topics that do not exist are created and topics that do are updated with UpdateTopic, using a reconcile.Plan; the
+// topic changes are checked before any is made, and an error is returned without changes if one cannot be made, such
+// as reducing a partition count. Configs that UpdateTopic cannot change, such as 'min.insync.replicas', are restored
+// when a topic is created and left as they are on topics that exist. Topics and quotas that are not in the snapshot
+// are left alone. The mirroring topic selection is replaced if it differs. A quota that exists is updated with
+// UpdateQuota and one that does not is created with CreateQuota; rates missing from the snapshot are left unchanged.
+//
+// A failure to restore one object does not stop the others. The report lists the outcome for every object, and an
+// error is returned if any of them failed.
+func Restore(ctx context.Context, client *adminrestv1.AdminrestV1, snapshot *Snapshot, options *RestoreOptions) (*Report, error) {
+	if options == nil {
+		options = &RestoreOptions{}
+	}
+	report := &Report{DryRun: options.DryRun, Results: []Result{}}
+
+	reconciler := reconcile.New(client, nil)
+	plan, err := reconciler.Plan(ctx, snapshot.Topics)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: planning topic changes: %w", err)
+	}
+	actions := make(map[string]reconcile.Action, len(plan.Actions))
+	for _, action := range plan.Actions {
+		if action, ok := updatableAction(action); ok {
+			actions[action.Name] = action
+		}
+	}
+	for _, spec := range snapshot.Topics {
+		action, changed := actions[spec.Name]
+		if !changed {
+			report.Results = append(report.Results, Result{Kind: KindTopic, Name: spec.Name, Outcome: OutcomeUnchanged})
+			continue
+		}
+		outcome := OutcomeUpdated
+		if action.Type == reconcile.ActionCreate {
+			outcome = OutcomeCreated
+		}
+		if !options.DryRun {
+			err = reconciler.Apply(ctx, &reconcile.Plan{Actions: []reconcile.Action{action}})
+		}
+		report.Results = append(report.Results, result(KindTopic, spec.Name, outcome, err))
+	}
+
+	if snapshot.Mirroring != nil {
+		report.Results = append(report.Results, restoreMirroring(ctx, client, snapshot.Mirroring, options.DryRun))
+	}
+
+	if len(snapshot.Quotas) > 0 {
+		report.Results = append(report.Results, restoreQuotas(ctx, client, snapshot.Quotas, options.DryRun)...)
+	}
+
+	if failed := report.Failed(); len(failed) > 0 {
+		return report, fmt.Errorf("snapshot: %d of %d objects could not be restored, starting with %s",
+			len(failed), len(report.Results), failed[0])
+	}
+	return report, nil
+}
+
+// updatableAction drops the config changes that UpdateTopic cannot make from an update action, and returns false if
+// the action has nothing left to change.
+func updatableAction(action reconcile.Action) (reconcile.Action, bool) {
+	if action.Type != reconcile.ActionUpdate {
+		return action, true
+	}
+	var configs []reconcile.ConfigChange
+	for _, config := range action.Configs {
+		for _, name := range adminrestv1.UpdatableTopicConfigs {
+			if config.Name == name {
+				configs = append(configs, config)
+				break
+			}
+		}
+	}
+	action.Configs = configs
+	return action, action.Partitions != nil || len(configs) > 0
+}
+
+// restoreMirroring replaces the mirroring topic selection if it differs from the snapshot.
+func restoreMirroring(ctx context.Context, client *adminrestv1.AdminrestV1, mirroring *Mirroring, dryRun bool) Result {
+	current, _, err := client.GetMirroringTopicSelectionWithContext(ctx, client.NewGetMirroringTopicSelectionOptions())
+	if err != nil {
+		return result(KindMirroring, "", OutcomeFailed, err)
+	}
+	if sameStrings(current.Includes, mirroring.Includes) {
+		return result(KindMirroring, "", OutcomeUnchanged, nil)
+	}
+	if !dryRun {
+		_, _, err = client.ReplaceMirroringTopicSelectionWithContext(ctx,
+			client.NewReplaceMirroringTopicSelectionOptions().SetIncludes(mirroring.Includes))
+	}
+	return result(KindMirroring, "", OutcomeUpdated, err)
+}
+
+// restoreQuotas creates or updates each quota in the snapshot.
+func restoreQuotas(ctx context.Context, client *adminrestv1.AdminrestV1, quotas []Quota, dryRun bool) []Result {
+	results := make([]Result, 0, len(quotas))
+	list, _, err := client.ListQuotasWithContext(ctx, client.NewListQuotasOptions())
+	if err != nil {
+		for _, quota := range quotas {
+			results = append(results, result(KindQuota, quota.EntityName, OutcomeFailed, err))
+		}
+		return results
+	}
+	current := make(map[string]adminrestv1.EntityQuotaDetail, len(list.Data))
+	for _, detail := range list.Data {
+		if detail.EntityName != nil {
+			current[*detail.EntityName] = detail
+		}
+	}
+
+	for _, quota := range quotas {
+		detail, exists := current[quota.EntityName]
+		switch {
+		case exists && sameRate(detail.ProducerByteRate, quota.ProducerByteRate) && sameRate(detail.ConsumerByteRate, quota.ConsumerByteRate):
+			results = append(results, result(KindQuota, quota.EntityName, OutcomeUnchanged, nil))
+		case exists:
+			if !dryRun {
+				options := client.NewUpdateQuotaOptions(quota.EntityName)
+				options.ProducerByteRate, options.ConsumerByteRate = quota.ProducerByteRate, quota.ConsumerByteRate
+				_, err = client.UpdateQuotaWithContext(ctx, options)
+			}
+			results = append(results, result(KindQuota, quota.EntityName, OutcomeUpdated, err))
+		default:
+			if !dryRun {
+				options := client.NewCreateQuotaOptions(quota.EntityName)
+				options.ProducerByteRate, options.ConsumerByteRate = quota.ProducerByteRate, quota.ConsumerByteRate
+				_, err = client.CreateQuotaWithContext(ctx, options)
+			}
+			results = append(results, result(KindQuota, quota.EntityName, OutcomeCreated, err))
+		}
+		err = nil
+	}
+	return results
+}
+
+// result returns a Result with the outcome, or OutcomeFailed if err is not nil.
+func result(kind Kind, name string, outcome Outcome, err error) Result {
+	if err != nil {
+		return Result{Kind: kind, Name: name, Outcome: OutcomeFailed, Error: err.Error()}
+	}
+	return Result{Kind: kind, Name: name, Outcome: outcome}
+}
+
+// sameRate returns true if the snapshot leaves a rate unset, or sets it to the current value.
+func sameRate(current *int64, desired *int64) bool {
+	return desired == nil || (current != nil && *current == *desired)
+}
+
+// sameStrings returns true if the lists hold the same strings in the same order.
+func sameStrings(a []string, b []string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
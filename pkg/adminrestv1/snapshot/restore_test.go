@@ -0,0 +1,131 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot
+
+import (
+	"context"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1/fake"
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1/reconcile"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Restore(ctx context.Context, client *adminrestv1.AdminrestV1, snapshot *Snapshot, options *RestoreOptions)`, func() {
+	var source, target *fake.Server
+	var sourceClient, targetClient *adminrestv1.AdminrestV1
+
+	BeforeEach(func() {
+		source = fake.NewServer(&fake.ServerOptions{MirroringEnabled: true})
+		source.AddTopic(fake.Topic{Name: "payments", Partitions: 4, Configs: map[string]string{"retention.ms": "3600000"}})
+		source.AddTopic(fake.Topic{Name: "orders", Partitions: 3, Configs: map[string]string{"cleanup.policy": "compact"}})
+		source.AddTopic(fake.Topic{Name: "audit", Partitions: 1})
+		source.SetMirroringTopicSelection([]string{"orders"})
+		source.SetQuota("default", fake.Quota{ProducerByteRate: 1024, ConsumerByteRate: 2048})
+		source.SetQuota("iam-ServiceId-123", fake.Quota{ConsumerByteRate: 4096})
+		sourceClient, _ = source.NewClient()
+
+		target = fake.NewServer(&fake.ServerOptions{MirroringEnabled: true})
+		target.AddTopic(fake.Topic{Name: "payments", Partitions: 2})
+		target.AddTopic(fake.Topic{Name: "audit", Partitions: 1})
+		target.AddTopic(fake.Topic{Name: "scratch", Partitions: 1})
+		target.SetQuota("default", fake.Quota{ProducerByteRate: 1})
+		targetClient, _ = target.NewClient()
+	})
+	AfterEach(func() {
+		source.Close()
+		target.Close()
+	})
+
+	// take returns a snapshot of the source instance.
+	take := func() *Snapshot {
+		snapshot, err := Take(context.Background(), sourceClient, nil)
+		Expect(err).To(BeNil())
+		return snapshot
+	}
+
+	It(`Create and update objects and report each one`, func() {
+		report, err := Restore(context.Background(), targetClient, take(), nil)
+		Expect(err).To(BeNil())
+		Expect(report.String()).To(Equal(
+			"topic audit: unchanged\n" +
+				"topic orders: created\n" +
+				"topic payments: updated\n" +
+				"mirroring: updated\n" +
+				"quota default: updated\n" +
+				"quota iam-ServiceId-123: created\n"))
+
+		orders, _ := target.Topic("orders")
+		Expect(orders.Partitions).To(Equal(int64(3)))
+		Expect(orders.Configs["cleanup.policy"]).To(Equal("compact"))
+		payments, _ := target.Topic("payments")
+		Expect(payments.Partitions).To(Equal(int64(4)))
+		Expect(payments.Configs["retention.ms"]).To(Equal("3600000"))
+		Expect(target.TopicNames()).To(ContainElement("scratch"))
+		Expect(target.MirroringTopicSelection()).To(Equal([]string{"orders"}))
+		quota, _ := target.Quota("default")
+		Expect(quota).To(Equal(fake.Quota{ProducerByteRate: 1024, ConsumerByteRate: 2048}))
+
+		report, err = Restore(context.Background(), targetClient, take(), nil)
+		Expect(err).To(BeNil())
+		for _, result := range report.Results {
+			Expect(result.Outcome).To(Equal(OutcomeUnchanged))
+		}
+	})
+	It(`Leave configs that UpdateTopic cannot change on existing topics`, func() {
+		source.AddTopic(fake.Topic{Name: "audit", Partitions: 1, Configs: map[string]string{"min.insync.replicas": "1", "retention.ms": "60000"}})
+		target.AddTopic(fake.Topic{Name: "ledger", Partitions: 1})
+		source.AddTopic(fake.Topic{Name: "ledger", Partitions: 1, Configs: map[string]string{"min.insync.replicas": "1"}})
+
+		report, err := Restore(context.Background(), targetClient, take(), nil)
+		Expect(err).To(BeNil())
+		Expect(report.Results[0].String()).To(Equal("topic audit: updated"))
+		Expect(report.Results[1].String()).To(Equal("topic ledger: unchanged"))
+		audit, _ := target.Topic("audit")
+		Expect(audit.Configs["retention.ms"]).To(Equal("60000"))
+		Expect(audit.Configs).ToNot(HaveKey("min.insync.replicas"))
+		orders, _ := target.Topic("orders")
+		Expect(orders.Partitions).To(Equal(int64(3)))
+	})
+	It(`Report changes without making them in a dry run`, func() {
+		report, err := Restore(context.Background(), targetClient, take(), &RestoreOptions{DryRun: true})
+		Expect(err).To(BeNil())
+		Expect(report.Results).To(HaveLen(6))
+		Expect(report.String()).To(HaveSuffix("Dry run: no changes were made.\n"))
+		Expect(target.RequestCount(fake.OperationCreateTopic) + target.RequestCount(fake.OperationUpdateTopic) +
+			target.RequestCount(fake.OperationReplaceMirroringTopicSelection) +
+			target.RequestCount(fake.OperationCreateQuota) + target.RequestCount(fake.OperationUpdateQuota)).To(Equal(0))
+	})
+	It(`Continue past objects that cannot be restored`, func() {
+		snapshot := &Snapshot{
+			FormatVersion: FormatVersion,
+			Topics:        []reconcile.TopicSpec{{Name: "orders", Partitions: 1, Configs: map[string]string{"retention.ms": "-5"}}},
+			Quotas:        []Quota{{EntityName: "someone", ProducerByteRate: core.Int64Ptr(1)}, {EntityName: "default", ProducerByteRate: core.Int64Ptr(10)}},
+		}
+		report, err := Restore(context.Background(), targetClient, snapshot, nil)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(HavePrefix("snapshot: 2 of 3 objects could not be restored, starting with topic orders: failed: "))
+		Expect(report.Failed()).To(HaveLen(2))
+		Expect(report.Results[2]).To(Equal(Result{Kind: KindQuota, Name: "default", Outcome: OutcomeUpdated}))
+
+		_, err = Restore(context.Background(), targetClient, &Snapshot{Topics: []reconcile.TopicSpec{{Name: "payments", Partitions: 1}}}, nil)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("topic payments has 2 partitions and cannot be reduced to 1"))
+	})
+})
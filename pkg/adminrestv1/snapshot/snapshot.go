@@ -0,0 +1,202 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package snapshot : Export and import the admin state of an Event Streams instance
+//
+// Take captures every topic with its partition count and configs, the mirroring topic selection and the quotas of an
+// instance in a single Snapshot, which can be written as YAML or JSON. Restore loads a Snapshot into another instance
+// through the AdminrestV1 create and update calls, choosing the call for each object from what already exists, and
+// reports the outcome for every object.
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1/reconcile"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"gopkg.in/yaml.v2"
+)
+
+// FormatVersion is the version of the Snapshot document format. Read accepts documents of this version only.
+const FormatVersion = 1
+
+// ErrUnsupportedFormat is returned by Read when a document has a different format version.
+var ErrUnsupportedFormat = errors.New("snapshot: unsupported format version")
+
+// Snapshot : The admin state of an Event Streams instance.
+type Snapshot struct {
+	// The version of the document format.
+	FormatVersion int `json:"format_version" yaml:"format_version"`
+
+	// The topics, sorted by name. Each lists every config reported by the instance.
+	Topics []reconcile.TopicSpec `json:"topics" yaml:"topics"`
+
+	// The mirroring topic selection, or nil if the instance is not the target of a mirroring relationship.
+	Mirroring *Mirroring `json:"mirroring,omitempty" yaml:"mirroring,omitempty"`
+
+	// The quotas, sorted by entity name.
+	Quotas []Quota `json:"quotas,omitempty" yaml:"quotas,omitempty"`
+}
+
+// Mirroring : The mirroring topic selection of an instance.
+type Mirroring struct {
+	// The patterns of the topics selected for mirroring.
+	Includes []string `json:"includes" yaml:"includes"`
+}
+
+// Quota : The quota of an entity.
+type Quota struct {
+	// The entity the quota applies to: 'default' or an IAM service ID.
+	EntityName string `json:"entity_name" yaml:"entity_name"`
+
+	// The producer byte rate quota, in bytes per second, or nil if there is none.
+	ProducerByteRate *int64 `json:"producer_byte_rate,omitempty" yaml:"producer_byte_rate,omitempty"`
+
+	// The consumer byte rate quota, in bytes per second, or nil if there is none.
+	ConsumerByteRate *int64 `json:"consumer_byte_rate,omitempty" yaml:"consumer_byte_rate,omitempty"`
+}
+
+// TakeOptions : The Take options.
+type TakeOptions struct {
+	// A filter applied to the topics, using the same syntax as ListTopicsOptions.TopicFilter. Empty means every topic.
+	TopicFilter string
+}
+
+// Take : captures the admin state of the instance the client is connected to.
+func Take(ctx context.Context, client *adminrestv1.AdminrestV1, options *TakeOptions) (*Snapshot, error) {
+	if options == nil {
+		options = &TakeOptions{}
+	}
+	snapshot := &Snapshot{FormatVersion: FormatVersion, Topics: []reconcile.TopicSpec{}}
+
+	listOptions := client.NewListAllTopicsOptions()
+	if options.TopicFilter != "" {
+		listOptions.SetTopicFilter(options.TopicFilter)
+	}
+	topics, err := client.ListAllTopicsWithContext(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: listing topics: %w", err)
+	}
+	for i := range topics.Topics {
+		spec, err := topicSpec(&topics.Topics[i])
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Topics = append(snapshot.Topics, spec)
+	}
+	sort.Slice(snapshot.Topics, func(i, j int) bool { return snapshot.Topics[i].Name < snapshot.Topics[j].Name })
+
+	selection, response, err := client.GetMirroringTopicSelectionWithContext(ctx, client.NewGetMirroringTopicSelectionOptions())
+	if err != nil && !isNotFound(response) {
+		return nil, fmt.Errorf("snapshot: getting the mirroring topic selection: %w", err)
+	}
+	if err == nil {
+		snapshot.Mirroring = &Mirroring{Includes: append([]string{}, selection.Includes...)}
+	}
+
+	quotas, _, err := client.ListQuotasWithContext(ctx, client.NewListQuotasOptions())
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: listing quotas: %w", err)
+	}
+	for _, detail := range quotas.Data {
+		if detail.EntityName == nil {
+			continue
+		}
+		snapshot.Quotas = append(snapshot.Quotas, Quota{
+			EntityName:       *detail.EntityName,
+			ProducerByteRate: detail.ProducerByteRate,
+			ConsumerByteRate: detail.ConsumerByteRate,
+		})
+	}
+	sort.Slice(snapshot.Quotas, func(i, j int) bool { return snapshot.Quotas[i].EntityName < snapshot.Quotas[j].EntityName })
+	return snapshot, nil
+}
+
+// topicSpec returns the TopicSpec that recreates a topic.
+func topicSpec(detail *adminrestv1.TopicDetail) (spec reconcile.TopicSpec, err error) {
+	if detail.Name == nil {
+		return spec, errors.New("snapshot: the instance returned a topic without a name")
+	}
+	spec.Name = *detail.Name
+	if detail.Partitions != nil {
+		spec.Partitions = *detail.Partitions
+	}
+	if detail.Configs != nil {
+		buf, err := json.Marshal(detail.Configs)
+		if err == nil {
+			err = json.Unmarshal(buf, &spec.Configs)
+		}
+		if err != nil {
+			return spec, fmt.Errorf("snapshot: reading the configs of topic %s: %w", spec.Name, err)
+		}
+	}
+	return spec, nil
+}
+
+// WriteYAML writes the snapshot as a YAML document.
+func (snapshot *Snapshot) WriteYAML(w io.Writer) error {
+	buf, err := yaml.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buf)
+	return err
+}
+
+// WriteJSON writes the snapshot as an indented JSON document.
+func (snapshot *Snapshot) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(snapshot)
+}
+
+// Read : reads a snapshot written by WriteYAML or WriteJSON. A document that starts with '{' is read as JSON and any
+// other document as YAML. Unknown fields are rejected, so that a misspelt field is not silently ignored.
+func Read(r io.Reader) (*Snapshot, error) {
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &Snapshot{}
+	if trimmed := bytes.TrimSpace(buf); len(trimmed) > 0 && trimmed[0] == '{' {
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(snapshot)
+	} else {
+		err = yaml.UnmarshalStrict(buf, snapshot)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: %w", err)
+	}
+	if snapshot.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("%w %d", ErrUnsupportedFormat, snapshot.FormatVersion)
+	}
+	return snapshot, nil
+}
+
+// isNotFound returns true if the response has HTTP status code 404.
+func isNotFound(response *core.DetailedResponse) bool {
+	return response != nil && response.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,111 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1/fake"
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1/reconcile"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Snapshot`, func() {
+	var server *fake.Server
+	var client *adminrestv1.AdminrestV1
+
+	BeforeEach(func() {
+		server = fake.NewServer(&fake.ServerOptions{MirroringEnabled: true})
+		server.AddTopic(fake.Topic{Name: "payments", Partitions: 4, Configs: map[string]string{"retention.ms": "3600000"}})
+		server.AddTopic(fake.Topic{Name: "orders", Partitions: 3, Configs: map[string]string{"cleanup.policy": "compact"}})
+		server.SetMirroringTopicSelection([]string{"orders", "payments.*"})
+		server.SetQuota("default", fake.Quota{ProducerByteRate: 1024})
+		client, _ = server.NewClient()
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe(`Take(ctx context.Context, client *adminrestv1.AdminrestV1, options *TakeOptions)`, func() {
+		It(`Capture topics, the mirroring topic selection and quotas`, func() {
+			snapshot, err := Take(context.Background(), client, nil)
+			Expect(err).To(BeNil())
+			Expect(snapshot.FormatVersion).To(Equal(FormatVersion))
+			Expect(snapshot.Topics).To(HaveLen(2))
+			Expect(snapshot.Topics[0].Name).To(Equal("orders"))
+			Expect(snapshot.Topics[0].Partitions).To(Equal(int64(3)))
+			Expect(snapshot.Topics[0].Configs).To(HaveKeyWithValue("cleanup.policy", "compact"))
+			Expect(snapshot.Topics[0].Configs).To(HaveKeyWithValue("retention.ms", fake.DefaultTopicConfigs["retention.ms"]))
+			Expect(snapshot.Topics[1].Configs).To(HaveKeyWithValue("retention.ms", "3600000"))
+			Expect(snapshot.Mirroring).To(Equal(&Mirroring{Includes: []string{"orders", "payments.*"}}))
+			Expect(snapshot.Quotas).To(Equal([]Quota{{EntityName: "default", ProducerByteRate: core.Int64Ptr(1024)}}))
+		})
+		It(`Filter topics and leave out mirroring when it is not enabled`, func() {
+			unmirrored := fake.NewServer(nil)
+			defer unmirrored.Close()
+			unmirrored.AddTopic(fake.Topic{Name: "orders", Partitions: 1})
+			unmirrored.AddTopic(fake.Topic{Name: "audit", Partitions: 1})
+			unmirroredClient, _ := unmirrored.NewClient()
+
+			snapshot, err := Take(context.Background(), unmirroredClient, &TakeOptions{TopicFilter: "ord*"})
+			Expect(err).To(BeNil())
+			Expect(snapshot.Topics).To(HaveLen(1))
+			Expect(snapshot.Mirroring).To(BeNil())
+			Expect(snapshot.Quotas).To(BeEmpty())
+		})
+	})
+
+	Describe(`Read(r io.Reader)`, func() {
+		It(`Read a snapshot written as YAML or JSON`, func() {
+			snapshot, err := Take(context.Background(), client, nil)
+			Expect(err).To(BeNil())
+
+			var yamlDocument, jsonDocument bytes.Buffer
+			Expect(snapshot.WriteYAML(&yamlDocument)).To(Succeed())
+			Expect(yamlDocument.String()).To(HavePrefix("format_version: 1\ntopics:\n- name: orders\n  partitions: 3\n"))
+			Expect(snapshot.WriteJSON(&jsonDocument)).To(Succeed())
+
+			fromYAML, err := Read(&yamlDocument)
+			Expect(err).To(BeNil())
+			Expect(fromYAML).To(Equal(snapshot))
+			fromJSON, err := Read(&jsonDocument)
+			Expect(err).To(BeNil())
+			Expect(fromJSON).To(Equal(snapshot))
+		})
+		It(`Reject unknown fields and format versions`, func() {
+			_, err := Read(strings.NewReader("format_version: 1\ntopic:\n- name: orders\n"))
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("field topic not found"))
+
+			_, err = Read(strings.NewReader(`{"format_version": 1, "quota": []}`))
+			Expect(err).ToNot(BeNil())
+
+			_, err = Read(strings.NewReader("format_version: 2\ntopics: []\n"))
+			Expect(errors.Is(err, ErrUnsupportedFormat)).To(BeTrue())
+
+			snapshot, err := Read(strings.NewReader("format_version: 1\ntopics:\n- name: orders\n  configs:\n    retention.ms: \"60000\"\n"))
+			Expect(err).To(BeNil())
+			Expect(snapshot.Topics).To(Equal([]reconcile.TopicSpec{{Name: "orders", Configs: map[string]string{"retention.ms": "60000"}}}))
+		})
+	})
+})
@@ -0,0 +1,566 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminrestv1
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Unlimited values of the retention configs.
+const (
+	// UnlimitedRetention : The value of Retention and LocalRetention that keeps messages forever.
+	UnlimitedRetention time.Duration = -time.Millisecond
+
+	// UnlimitedDuration : The value of a duration config set to 9223372036854775807 milliseconds, the largest Kafka
+	// accepts, which is the default of 'max.compaction.lag.ms' and 'message.timestamp.difference.max.ms'. It is
+	// longer than a time.Duration can hold, so it is kept as the largest time.Duration and sent back unchanged.
+	UnlimitedDuration time.Duration = math.MaxInt64
+
+	// UnlimitedRetentionBytes : The value of RetentionBytes and LocalRetentionBytes that does not limit the size of
+	// a partition.
+	UnlimitedRetentionBytes ByteSize = -1
+)
+
+// CleanupPolicy : The value of the 'cleanup.policy' config.
+type CleanupPolicy string
+
+// Values of the 'cleanup.policy' config.
+const (
+	CleanupPolicyDelete           CleanupPolicy = "delete"
+	CleanupPolicyCompact          CleanupPolicy = "compact"
+	CleanupPolicyCompactAndDelete CleanupPolicy = "compact,delete"
+)
+
+// CompressionType : The value of the 'compression.type' config.
+type CompressionType string
+
+// Values of the 'compression.type' config.
+const (
+	CompressionTypeProducer     CompressionType = "producer"
+	CompressionTypeUncompressed CompressionType = "uncompressed"
+	CompressionTypeGzip         CompressionType = "gzip"
+	CompressionTypeSnappy       CompressionType = "snappy"
+	CompressionTypeLz4          CompressionType = "lz4"
+	CompressionTypeZstd         CompressionType = "zstd"
+)
+
+// TimestampType : The value of the 'message.timestamp.type' config.
+type TimestampType string
+
+// Values of the 'message.timestamp.type' config.
+const (
+	TimestampTypeCreateTime    TimestampType = "CreateTime"
+	TimestampTypeLogAppendTime TimestampType = "LogAppendTime"
+)
+
+// TypedTopicConfigs : The Kafka topic-level configs, with a Go type for each value.
+//
+// A nil field is not set. Durations are sent to the service in milliseconds. Configs without a field, such as
+// 'message.format.version', are kept in Other, keyed by config name.
+type TypedTopicConfigs struct {
+	// cleanup.policy
+	CleanupPolicy *CleanupPolicy
+
+	// compression.type
+	CompressionType *CompressionType
+
+	// delete.retention.ms
+	DeleteRetention *time.Duration
+
+	// file.delete.delay.ms
+	FileDeleteDelay *time.Duration
+
+	// flush.messages
+	FlushMessages *int64
+
+	// flush.ms
+	FlushInterval *time.Duration
+
+	// index.interval.bytes
+	IndexIntervalBytes *ByteSize
+
+	// local.retention.bytes
+	LocalRetentionBytes *ByteSize
+
+	// local.retention.ms
+	LocalRetention *time.Duration
+
+	// max.compaction.lag.ms
+	MaxCompactionLag *time.Duration
+
+	// max.message.bytes
+	MaxMessageBytes *ByteSize
+
+	// message.downconversion.enable
+	MessageDownconversionEnable *bool
+
+	// message.timestamp.difference.max.ms
+	MessageTimestampDifferenceMax *time.Duration
+
+	// message.timestamp.type
+	MessageTimestampType *TimestampType
+
+	// min.cleanable.dirty.ratio
+	MinCleanableDirtyRatio *float64
+
+	// min.compaction.lag.ms
+	MinCompactionLag *time.Duration
+
+	// min.insync.replicas
+	MinInsyncReplicas *int64
+
+	// preallocate
+	Preallocate *bool
+
+	// remote.storage.enable
+	RemoteStorageEnable *bool
+
+	// retention.bytes
+	RetentionBytes *ByteSize
+
+	// retention.ms
+	Retention *time.Duration
+
+	// segment.bytes
+	SegmentBytes *ByteSize
+
+	// segment.index.bytes
+	SegmentIndexBytes *ByteSize
+
+	// segment.jitter.ms
+	SegmentJitter *time.Duration
+
+	// segment.ms
+	Segment *time.Duration
+
+	// unclean.leader.election.enable
+	UncleanLeaderElectionEnable *bool
+
+	// Configs that have no field, keyed by config name. Names of the configs above are not allowed.
+	Other map[string]string
+}
+
+// topicConfigField associates a config name with the field of TypedTopicConfigs that holds its value.
+type topicConfigField struct {
+	name  string
+	field func(configs *TypedTopicConfigs) interface{}
+}
+
+// topicConfigFields lists the typed configs in order of name.
+var topicConfigFields = []topicConfigField{
+	{"cleanup.policy", func(c *TypedTopicConfigs) interface{} { return &c.CleanupPolicy }},
+	{"compression.type", func(c *TypedTopicConfigs) interface{} { return &c.CompressionType }},
+	{"delete.retention.ms", func(c *TypedTopicConfigs) interface{} { return &c.DeleteRetention }},
+	{"file.delete.delay.ms", func(c *TypedTopicConfigs) interface{} { return &c.FileDeleteDelay }},
+	{"flush.messages", func(c *TypedTopicConfigs) interface{} { return &c.FlushMessages }},
+	{"flush.ms", func(c *TypedTopicConfigs) interface{} { return &c.FlushInterval }},
+	{"index.interval.bytes", func(c *TypedTopicConfigs) interface{} { return &c.IndexIntervalBytes }},
+	{"local.retention.bytes", func(c *TypedTopicConfigs) interface{} { return &c.LocalRetentionBytes }},
+	{"local.retention.ms", func(c *TypedTopicConfigs) interface{} { return &c.LocalRetention }},
+	{"max.compaction.lag.ms", func(c *TypedTopicConfigs) interface{} { return &c.MaxCompactionLag }},
+	{"max.message.bytes", func(c *TypedTopicConfigs) interface{} { return &c.MaxMessageBytes }},
+	{"message.downconversion.enable", func(c *TypedTopicConfigs) interface{} { return &c.MessageDownconversionEnable }},
+	{"message.timestamp.difference.max.ms", func(c *TypedTopicConfigs) interface{} { return &c.MessageTimestampDifferenceMax }},
+	{"message.timestamp.type", func(c *TypedTopicConfigs) interface{} { return &c.MessageTimestampType }},
+	{"min.cleanable.dirty.ratio", func(c *TypedTopicConfigs) interface{} { return &c.MinCleanableDirtyRatio }},
+	{"min.compaction.lag.ms", func(c *TypedTopicConfigs) interface{} { return &c.MinCompactionLag }},
+	{"min.insync.replicas", func(c *TypedTopicConfigs) interface{} { return &c.MinInsyncReplicas }},
+	{"preallocate", func(c *TypedTopicConfigs) interface{} { return &c.Preallocate }},
+	{"remote.storage.enable", func(c *TypedTopicConfigs) interface{} { return &c.RemoteStorageEnable }},
+	{"retention.bytes", func(c *TypedTopicConfigs) interface{} { return &c.RetentionBytes }},
+	{"retention.ms", func(c *TypedTopicConfigs) interface{} { return &c.Retention }},
+	{"segment.bytes", func(c *TypedTopicConfigs) interface{} { return &c.SegmentBytes }},
+	{"segment.index.bytes", func(c *TypedTopicConfigs) interface{} { return &c.SegmentIndexBytes }},
+	{"segment.jitter.ms", func(c *TypedTopicConfigs) interface{} { return &c.SegmentJitter }},
+	{"segment.ms", func(c *TypedTopicConfigs) interface{} { return &c.Segment }},
+	{"unclean.leader.election.enable", func(c *TypedTopicConfigs) interface{} { return &c.UncleanLeaderElectionEnable }},
+}
+
+// findTopicConfigField returns the field for a config name, or nil if the config has no field.
+func findTopicConfigField(name string) *topicConfigField {
+	i := sort.Search(len(topicConfigFields), func(i int) bool { return topicConfigFields[i].name >= name })
+	if i < len(topicConfigFields) && topicConfigFields[i].name == name {
+		return &topicConfigFields[i]
+	}
+	return nil
+}
+
+// TypedTopicConfigNames returns the names of the configs that have a field in TypedTopicConfigs, in order.
+func TypedTopicConfigNames() []string {
+	names := make([]string, len(topicConfigFields))
+	for i, field := range topicConfigFields {
+		names[i] = field.name
+	}
+	return names
+}
+
+// TopicConfigError : The error returned when a config value cannot be converted to its Go type, or a config in Other
+// has a field.
+type TopicConfigError struct {
+	// The config name.
+	Name string
+
+	// The config value.
+	Value string
+
+	// The reason the value is not valid.
+	Reason string
+}
+
+// Error implements the error interface.
+func (configError *TopicConfigError) Error() string {
+	return fmt.Sprintf("topic config %s=%q: %s", configError.Name, configError.Value, configError.Reason)
+}
+
+// Is reports whether the error matches ErrInvalidConfig.
+func (configError *TopicConfigError) Is(target error) bool {
+	return target == ErrInvalidConfig
+}
+
+// ParseTopicConfigs : converts config values keyed by config name to TypedTopicConfigs. Configs without a field are
+// copied to Other. Byte sizes may have units, such as 1GiB, as in configs written by hand; use the Typed method of
+// TopicConfigs for the values sent to and reported by the service. A *TopicConfigError is returned for the first
+// value, in order of name, that does not convert.
+func ParseTopicConfigs(configs map[string]string) (*TypedTopicConfigs, error) {
+	return parseTopicConfigs(configs, true)
+}
+
+// parseTopicConfigs converts config values to TypedTopicConfigs, accepting byte sizes with units when units is true.
+// The service accepts plain numbers of bytes only.
+func parseTopicConfigs(configs map[string]string, units bool) (*TypedTopicConfigs, error) {
+	names := make([]string, 0, len(configs))
+	for name := range configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	typed := &TypedTopicConfigs{}
+	for _, name := range names {
+		value := configs[name]
+		field := findTopicConfigField(name)
+		if field == nil {
+			if typed.Other == nil {
+				typed.Other = make(map[string]string)
+			}
+			typed.Other[name] = value
+			continue
+		}
+		if reason := setTopicConfig(field.field(typed), value, units); reason != "" {
+			return nil, &TopicConfigError{Name: name, Value: value, Reason: reason}
+		}
+	}
+	return typed, nil
+}
+
+// maxDurationMillis is the largest number of milliseconds that a time.Duration can hold.
+const maxDurationMillis = math.MaxInt64 / int64(time.Millisecond)
+
+// setTopicConfig converts the value and stores it in the field, returning the reason if it does not convert. Byte
+// sizes may have units only when units is true.
+func setTopicConfig(field interface{}, value string, units bool) string {
+	switch field := field.(type) {
+	case **CleanupPolicy:
+		policy := CleanupPolicy(value)
+		*field = &policy
+	case **CompressionType:
+		compressionType := CompressionType(value)
+		*field = &compressionType
+	case **TimestampType:
+		timestampType := TimestampType(value)
+		*field = &timestampType
+	case **time.Duration:
+		ms, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return "expected a whole number of milliseconds"
+		}
+		if ms == math.MaxInt64 {
+			duration := UnlimitedDuration
+			*field = &duration
+			break
+		}
+		if ms > maxDurationMillis || ms < -maxDurationMillis {
+			return fmt.Sprintf("expected at most %d milliseconds, or %d for no limit", maxDurationMillis, int64(math.MaxInt64))
+		}
+		duration := time.Duration(ms) * time.Millisecond
+		*field = &duration
+	case **ByteSize:
+		if !units {
+			bytes, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return "expected a whole number of bytes"
+			}
+			size := ByteSize(bytes)
+			*field = &size
+			break
+		}
+		size, err := ParseByteSize(value)
+		if err != nil {
+			return "expected a number of bytes, such as 1048576 or 1MiB"
+		}
+		*field = &size
+	case **int64:
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return "expected a whole number"
+		}
+		*field = &number
+	case **float64:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "expected a number"
+		}
+		*field = &number
+	case **bool:
+		flag, err := strconv.ParseBool(value)
+		if err != nil {
+			return "expected true or false"
+		}
+		*field = &flag
+	}
+	return ""
+}
+
+// formatTopicConfig returns the value of the field as sent to the service, and false if the field is not set.
+func formatTopicConfig(field interface{}) (string, bool) {
+	switch field := field.(type) {
+	case **CleanupPolicy:
+		if *field != nil {
+			return string(**field), true
+		}
+	case **CompressionType:
+		if *field != nil {
+			return string(**field), true
+		}
+	case **TimestampType:
+		if *field != nil {
+			return string(**field), true
+		}
+	case **time.Duration:
+		if *field != nil {
+			if **field == UnlimitedDuration {
+				return strconv.FormatInt(math.MaxInt64, 10), true
+			}
+			return strconv.FormatInt(int64(**field/time.Millisecond), 10), true
+		}
+	case **ByteSize:
+		if *field != nil {
+			return strconv.FormatInt(int64(**field), 10), true
+		}
+	case **int64:
+		if *field != nil {
+			return strconv.FormatInt(**field, 10), true
+		}
+	case **float64:
+		if *field != nil {
+			return strconv.FormatFloat(**field, 'g', -1, 64), true
+		}
+	case **bool:
+		if *field != nil {
+			return strconv.FormatBool(**field), true
+		}
+	}
+	return "", false
+}
+
+// Map returns the configs that are set, keyed by config name, in the string form sent to the service. An error is
+// returned if Other has a config that has a field.
+func (configs *TypedTopicConfigs) Map() (map[string]string, error) {
+	values := make(map[string]string)
+	for name, value := range configs.Other {
+		if findTopicConfigField(name) != nil {
+			return nil, &TopicConfigError{Name: name, Value: value, Reason: "set the typed field instead of Other"}
+		}
+		values[name] = value
+	}
+	for _, field := range topicConfigFields {
+		if value, ok := formatTopicConfig(field.field(configs)); ok {
+			values[field.name] = value
+		}
+	}
+	return values, nil
+}
+
+// sortedTopicConfigs returns the names and values of the configs that are set, in order of name.
+func (configs *TypedTopicConfigs) sortedTopicConfigs() ([]string, map[string]string, error) {
+	values, err := configs.Map()
+	if err != nil {
+		return nil, nil, err
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, values, nil
+}
+
+// ConfigCreates returns the configs that are set as the Configs of a CreateTopicOptions, in order of name.
+func (configs *TypedTopicConfigs) ConfigCreates() ([]ConfigCreate, error) {
+	names, values, err := configs.sortedTopicConfigs()
+	if err != nil {
+		return nil, err
+	}
+	creates := make([]ConfigCreate, 0, len(names))
+	for _, name := range names {
+		creates = append(creates, ConfigCreate{Name: core.StringPtr(name), Value: core.StringPtr(values[name])})
+	}
+	return creates, nil
+}
+
+// ConfigUpdates returns the configs that are set as the Configs of an UpdateTopicOptions, in order of name, followed
+// by an update that resets each of the named configs to its default value.
+func (configs *TypedTopicConfigs) ConfigUpdates(resetToDefault ...string) ([]ConfigUpdate, error) {
+	names, values, err := configs.sortedTopicConfigs()
+	if err != nil {
+		return nil, err
+	}
+	updates := make([]ConfigUpdate, 0, len(names)+len(resetToDefault))
+	for _, name := range names {
+		updates = append(updates, ConfigUpdate{Name: core.StringPtr(name), Value: core.StringPtr(values[name])})
+	}
+	for _, name := range resetToDefault {
+		if _, ok := values[name]; ok {
+			return nil, &TopicConfigError{Name: name, Value: values[name], Reason: "cannot be both set and reset to its default value"}
+		}
+		updates = append(updates, ConfigUpdate{Name: core.StringPtr(name), ResetToDefault: core.BoolPtr(true)})
+	}
+	return updates, nil
+}
+
+// ParseConfigCreates : converts the Configs of a CreateTopicOptions to TypedTopicConfigs.
+func ParseConfigCreates(configs []ConfigCreate) (*TypedTopicConfigs, error) {
+	values := make(map[string]string)
+	for _, config := range configs {
+		values[core.StringNilMapper(config.Name)] = core.StringNilMapper(config.Value)
+	}
+	return parseTopicConfigs(values, false)
+}
+
+// ParseConfigUpdates : converts the Configs of an UpdateTopicOptions to TypedTopicConfigs, and returns the names of
+// the configs that are reset to their default value separately.
+func ParseConfigUpdates(configs []ConfigUpdate) (typed *TypedTopicConfigs, resetToDefault []string, err error) {
+	values := make(map[string]string)
+	for _, config := range configs {
+		if config.ResetToDefault != nil && *config.ResetToDefault {
+			resetToDefault = append(resetToDefault, core.StringNilMapper(config.Name))
+			continue
+		}
+		values[core.StringNilMapper(config.Name)] = core.StringNilMapper(config.Value)
+	}
+	typed, err = parseTopicConfigs(values, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	return
+}
+
+// String returns the configs that are set, in order of name, with byte sizes such as 'retention.bytes' formatted as
+// ByteSize and durations such as 'retention.ms' formatted as time.Duration, for example
+// "cleanup.policy=delete, retention.bytes=1GiB, retention.ms=168h0m0s".
+func (topicConfigs *TopicConfigs) String() string {
+	typed, err := topicConfigs.Typed()
+	if err != nil {
+		return fmt.Sprintf("invalid configs: %s", err.Error())
+	}
+	return typed.String()
+}
+
+// String returns the configs that are set, in order of name, with byte sizes formatted as ByteSize and durations
+// formatted as time.Duration.
+func (configs *TypedTopicConfigs) String() string {
+	var parts []string
+	for _, field := range topicConfigFields {
+		value := field.field(configs)
+		var text string
+		switch value := value.(type) {
+		case **ByteSize:
+			if *value == nil {
+				continue
+			}
+			text = (**value).String()
+			if **value < 0 {
+				text = strconv.FormatInt(int64(**value), 10)
+			}
+		case **time.Duration:
+			if *value == nil {
+				continue
+			}
+			text = (**value).String()
+			if **value < 0 || **value == UnlimitedDuration {
+				text, _ = formatTopicConfig(value)
+			}
+		default:
+			var ok bool
+			if text, ok = formatTopicConfig(value); !ok {
+				continue
+			}
+		}
+		parts = append(parts, field.name+"="+text)
+	}
+	names := make([]string, 0, len(configs.Other))
+	for name := range configs.Other {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		parts = append(parts, name+"="+configs.Other[name])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Typed converts the configs reported by GetTopic and ListTopics to TypedTopicConfigs.
+func (topicConfigs *TopicConfigs) Typed() (*TypedTopicConfigs, error) {
+	values := make(map[string]string)
+	buf, err := json.Marshal(topicConfigs)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(buf, &values)
+	if err != nil {
+		return nil, err
+	}
+	return parseTopicConfigs(values, false)
+}
+
+// SetTypedConfigs : Allow user to set Configs from TypedTopicConfigs
+func (_options *CreateTopicOptions) SetTypedConfigs(configs *TypedTopicConfigs) (*CreateTopicOptions, error) {
+	creates, err := configs.ConfigCreates()
+	if err != nil {
+		return _options, err
+	}
+	_options.Configs = creates
+	return _options, nil
+}
+
+// SetTypedConfigs : Allow user to set Configs from TypedTopicConfigs, resetting the named configs to their default
+// value
+func (_options *UpdateTopicOptions) SetTypedConfigs(configs *TypedTopicConfigs, resetToDefault ...string) (*UpdateTopicOptions, error) {
+	updates, err := configs.ConfigUpdates(resetToDefault...)
+	if err != nil {
+		return _options, err
+	}
+	_options.Configs = updates
+	return _options, nil
+}
@@ -0,0 +1,166 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminrestv1
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`TypedTopicConfigs`, func() {
+	It(`List the typed configs in order of name`, func() {
+		names := TypedTopicConfigNames()
+		Expect(sort.StringsAreSorted(names)).To(BeTrue())
+		Expect(names).To(ContainElement("compression.type"))
+		Expect(names).To(ContainElement("message.timestamp.type"))
+		for _, name := range names {
+			Expect(findTopicConfigField(name)).ToNot(BeNil())
+		}
+	})
+	It(`Parse config values to Go types`, func() {
+		typed, err := ParseTopicConfigs(map[string]string{
+			"cleanup.policy":                "compact,delete",
+			"compression.type":              "zstd",
+			"retention.ms":                  "604800000",
+			"retention.bytes":               "-1",
+			"max.message.bytes":             "1048588",
+			"message.downconversion.enable": "false",
+			"message.timestamp.type":        "LogAppendTime",
+			"min.cleanable.dirty.ratio":     "0.25",
+			"min.insync.replicas":           "2",
+			"message.format.version":        "3.0-IV1",
+		})
+		Expect(err).To(BeNil())
+		Expect(*typed.CleanupPolicy).To(Equal(CleanupPolicyCompactAndDelete))
+		Expect(*typed.CompressionType).To(Equal(CompressionTypeZstd))
+		Expect(*typed.Retention).To(Equal(7 * 24 * time.Hour))
+		Expect(*typed.RetentionBytes).To(Equal(UnlimitedRetentionBytes))
+		Expect(*typed.MaxMessageBytes).To(Equal(ByteSize(1048588)))
+		Expect(*typed.MessageDownconversionEnable).To(BeFalse())
+		Expect(*typed.MessageTimestampType).To(Equal(TimestampTypeLogAppendTime))
+		Expect(*typed.MinCleanableDirtyRatio).To(Equal(0.25))
+		Expect(*typed.MinInsyncReplicas).To(Equal(int64(2)))
+		Expect(typed.Segment).To(BeNil())
+		Expect(typed.Other).To(Equal(map[string]string{"message.format.version": "3.0-IV1"}))
+	})
+	It(`Reject values that do not convert`, func() {
+		_, err := ParseTopicConfigs(map[string]string{"retention.ms": "7d", "segment.bytes": "lots"})
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal(`topic config retention.ms="7d": expected a whole number of milliseconds`))
+		Expect(errors.Is(err, ErrInvalidConfig)).To(BeTrue())
+
+		_, err = ParseTopicConfigs(map[string]string{"preallocate": "yes"})
+		Expect(err.Error()).To(Equal(`topic config preallocate="yes": expected true or false`))
+	})
+	It(`Format configs in the form sent to the service`, func() {
+		retention := 36 * time.Hour
+		policy := CleanupPolicyCompact
+		typed := &TypedTopicConfigs{
+			Retention:     &retention,
+			CleanupPolicy: &policy,
+			Other:         map[string]string{"follower.replication.throttled.replicas": "*"},
+		}
+		values, err := typed.Map()
+		Expect(err).To(BeNil())
+		Expect(values).To(Equal(map[string]string{
+			"retention.ms":   "129600000",
+			"cleanup.policy": "compact",
+			"follower.replication.throttled.replicas": "*",
+		}))
+
+		unlimited := UnlimitedRetention
+		values, err = (&TypedTopicConfigs{Retention: &unlimited}).Map()
+		Expect(err).To(BeNil())
+		Expect(values).To(Equal(map[string]string{"retention.ms": "-1"}))
+	})
+	It(`Keep Kafka's unlimited durations unchanged`, func() {
+		values := map[string]string{
+			"max.compaction.lag.ms":               "9223372036854775807",
+			"message.timestamp.difference.max.ms": "9223372036854775807",
+		}
+		typed, err := ParseTopicConfigs(values)
+		Expect(err).To(BeNil())
+		Expect(*typed.MaxCompactionLag).To(Equal(UnlimitedDuration))
+		Expect(*typed.MessageTimestampDifferenceMax).To(Equal(UnlimitedDuration))
+		Expect(typed.Map()).To(Equal(values))
+		Expect(typed.String()).To(Equal("max.compaction.lag.ms=9223372036854775807, " +
+			"message.timestamp.difference.max.ms=9223372036854775807"))
+
+		_, err = ParseTopicConfigs(map[string]string{"max.compaction.lag.ms": "9223372036854775806"})
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal(`topic config max.compaction.lag.ms="9223372036854775806": ` +
+			"expected at most 9223372036854 milliseconds, or 9223372036854775807 for no limit"))
+	})
+	It(`Reject typed configs in Other`, func() {
+		_, err := (&TypedTopicConfigs{Other: map[string]string{"retention.ms": "1000"}}).Map()
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("set the typed field instead of Other"))
+	})
+	It(`Convert to and from ConfigCreate`, func() {
+		segment := ByteSize(512 * 1024 * 1024)
+		compression := CompressionTypeLz4
+		typed := &TypedTopicConfigs{SegmentBytes: &segment, CompressionType: &compression}
+
+		options, err := new(AdminrestV1).NewCreateTopicOptions().SetName("orders").SetTypedConfigs(typed)
+		Expect(err).To(BeNil())
+		Expect(options.Configs).To(Equal([]ConfigCreate{
+			{Name: core.StringPtr("compression.type"), Value: core.StringPtr("lz4")},
+			{Name: core.StringPtr("segment.bytes"), Value: core.StringPtr("536870912")},
+		}))
+
+		parsed, err := ParseConfigCreates(options.Configs)
+		Expect(err).To(BeNil())
+		Expect(parsed).To(Equal(typed))
+	})
+	It(`Convert to and from ConfigUpdate`, func() {
+		lag := time.Minute
+		typed := &TypedTopicConfigs{MinCompactionLag: &lag}
+
+		options, err := new(AdminrestV1).NewUpdateTopicOptions("orders").SetTypedConfigs(typed, "retention.ms")
+		Expect(err).To(BeNil())
+		Expect(options.Configs).To(Equal([]ConfigUpdate{
+			{Name: core.StringPtr("min.compaction.lag.ms"), Value: core.StringPtr("60000")},
+			{Name: core.StringPtr("retention.ms"), ResetToDefault: core.BoolPtr(true)},
+		}))
+
+		parsed, reset, err := ParseConfigUpdates(options.Configs)
+		Expect(err).To(BeNil())
+		Expect(parsed).To(Equal(typed))
+		Expect(reset).To(Equal([]string{"retention.ms"}))
+
+		_, err = typed.ConfigUpdates("min.compaction.lag.ms")
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Convert the configs reported by GetTopic`, func() {
+		topicConfigs := &TopicConfigs{
+			CleanupPolicy:     core.StringPtr("delete"),
+			RetentionMs:       core.StringPtr("86400000"),
+			SegmentIndexBytes: core.StringPtr("10485760"),
+		}
+		typed, err := topicConfigs.Typed()
+		Expect(err).To(BeNil())
+		Expect(*typed.CleanupPolicy).To(Equal(CleanupPolicyDelete))
+		Expect(*typed.Retention).To(Equal(24 * time.Hour))
+		Expect(*typed.SegmentIndexBytes).To(Equal(ByteSize(10485760)))
+		Expect(typed.Other).To(BeNil())
+	})
+})
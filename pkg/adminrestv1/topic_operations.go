@@ -0,0 +1,341 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminrestv1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// DefaultOperationTimeout is the longest Operation.Wait polls for when no timeout has been set.
+const DefaultOperationTimeout = 2 * time.Minute
+
+// DefaultOperationPollInterval is the delay before the second poll of Operation.Wait. The delay doubles after every
+// poll, up to DefaultOperationMaxPollInterval.
+const DefaultOperationPollInterval = 250 * time.Millisecond
+
+// DefaultOperationMaxPollInterval is the longest delay between two polls of Operation.Wait.
+const DefaultOperationMaxPollInterval = 5 * time.Second
+
+// OperationKind : The kind of change an Operation waits for.
+type OperationKind string
+
+// Kinds of Operation, matching the names of the operations that start them.
+const (
+	OperationKindCreateTopic OperationKind = "CreateTopic"
+	OperationKindUpdateTopic OperationKind = "UpdateTopic"
+	OperationKindDeleteTopic OperationKind = "DeleteTopic"
+)
+
+// Operation : A topic change accepted by the service, which may not be visible yet.
+//
+// CreateTopic, UpdateTopic and DeleteTopic return as soon as the service accepts the request, with status code 202.
+// Wait polls GetTopic until the change can be seen: the topic exists with the requested partition count and config
+// values, or, after a delete, GetTopic responds with 404.
+type Operation struct {
+	client          *AdminrestV1
+	kind            OperationKind
+	topicName       string
+	headers         map[string]string
+	partitions      *int64
+	configs         map[string]string
+	timeout         time.Duration
+	pollInterval    time.Duration
+	maxPollInterval time.Duration
+}
+
+// newOperation returns an Operation with the default timeout and poll intervals.
+func (adminrest *AdminrestV1) newOperation(kind OperationKind, topicName string, headers map[string]string) *Operation {
+	return &Operation{
+		client:          adminrest,
+		kind:            kind,
+		topicName:       topicName,
+		headers:         headers,
+		configs:         make(map[string]string),
+		timeout:         DefaultOperationTimeout,
+		pollInterval:    DefaultOperationPollInterval,
+		maxPollInterval: DefaultOperationMaxPollInterval,
+	}
+}
+
+// Kind returns the kind of change the operation waits for.
+func (operation *Operation) Kind() OperationKind {
+	return operation.kind
+}
+
+// TopicName returns the name of the topic the operation changes.
+func (operation *Operation) TopicName() string {
+	return operation.topicName
+}
+
+// SetTimeout sets the longest time Wait polls for. A deadline on the Context passed to Wait also applies.
+func (operation *Operation) SetTimeout(timeout time.Duration) *Operation {
+	operation.timeout = timeout
+	return operation
+}
+
+// SetPollInterval sets the delay before the second poll of Wait and the longest delay between two polls. The delay
+// doubles after every poll until it reaches maxInterval.
+func (operation *Operation) SetPollInterval(interval time.Duration, maxInterval time.Duration) *Operation {
+	if interval <= 0 {
+		interval = DefaultOperationPollInterval
+	}
+	if maxInterval < interval {
+		maxInterval = interval
+	}
+	operation.pollInterval = interval
+	operation.maxPollInterval = maxInterval
+	return operation
+}
+
+// OperationTimeoutError : The error returned by Operation.Wait when the change is not visible before the timeout.
+type OperationTimeoutError struct {
+	// The kind of change that was waited for.
+	Kind OperationKind
+
+	// The name of the topic.
+	TopicName string
+
+	// How long Wait polled for.
+	Elapsed time.Duration
+
+	// The number of times GetTopic was called.
+	Polls int
+
+	// Why the last poll did not see the change, for example "partitions is 1, want 3".
+	Reason string
+}
+
+// Error implements the error interface.
+func (timeoutError *OperationTimeoutError) Error() string {
+	message := fmt.Sprintf("%s: topic %s did not converge within %s after %d polls", timeoutError.Kind,
+		timeoutError.TopicName, timeoutError.Elapsed.Round(time.Millisecond), timeoutError.Polls)
+	if timeoutError.Reason != "" {
+		message += ": " + timeoutError.Reason
+	}
+	return message
+}
+
+// Unwrap returns context.DeadlineExceeded, so the error can be matched with errors.Is.
+func (timeoutError *OperationTimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// Poll calls GetTopic once and reports whether the change is visible. When it is not, reason says why. The topic is
+// nil after a delete. Responses with status code 404 (other than after a delete), 429 and 5xx are not errors, as they
+// are expected while the change is in progress. A request that gets no response, for example because the connection
+// is refused, returns its error.
+func (operation *Operation) Poll(ctx context.Context) (done bool, topic *TopicDetail, reason string, err error) {
+	options := &GetTopicOptions{TopicName: core.StringPtr(operation.topicName), Headers: operation.headers}
+	topic, response, err := operation.client.GetTopicWithContext(ctx, options)
+	if err != nil {
+		if errors.Is(err, ErrTopicNotFound) {
+			if operation.kind == OperationKindDeleteTopic {
+				return true, nil, "", nil
+			}
+			return false, nil, "the topic does not exist yet", nil
+		}
+		if transient(response) {
+			return false, nil, err.Error(), nil
+		}
+		return false, nil, "", err
+	}
+	if operation.kind == OperationKindDeleteTopic {
+		return false, topic, "the topic still exists", nil
+	}
+	reason = operation.mismatch(topic)
+	return reason == "", topic, reason, nil
+}
+
+// Wait polls GetTopic, with a delay that doubles after every poll, until the change is visible. It returns the topic
+// as last seen, or nil after a delete. If the change is not visible before the timeout or the deadline of ctx, an
+// *OperationTimeoutError is returned; if ctx is cancelled, ctx.Err() is returned.
+func (operation *Operation) Wait(ctx context.Context) (*TopicDetail, error) {
+	start := time.Now()
+	waitCtx, cancel := context.WithTimeout(ctx, operation.timeout)
+	defer cancel()
+
+	polls := 0
+	interval := operation.pollInterval
+	reason := ""
+	for {
+		polls++
+		done, topic, pollReason, err := operation.Poll(waitCtx)
+		if done {
+			return topic, nil
+		}
+		if waitCtx.Err() != nil {
+			return nil, operation.stopped(ctx, start, polls, reason)
+		}
+		if err != nil {
+			return nil, err
+		}
+		reason = pollReason
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-waitCtx.Done():
+			timer.Stop()
+			return nil, operation.stopped(ctx, start, polls, reason)
+		}
+		interval *= 2
+		if interval > operation.maxPollInterval {
+			interval = operation.maxPollInterval
+		}
+	}
+}
+
+// stopped returns the error for a Wait that ran out of time, or the error of ctx if it was cancelled.
+func (operation *Operation) stopped(ctx context.Context, start time.Time, polls int, reason string) error {
+	if ctx.Err() == context.Canceled {
+		return ctx.Err()
+	}
+	return &OperationTimeoutError{
+		Kind:      operation.kind,
+		TopicName: operation.topicName,
+		Elapsed:   time.Since(start),
+		Polls:     polls,
+		Reason:    reason,
+	}
+}
+
+// mismatch describes the first difference between the topic and the requested change, or returns "" if there is none.
+// Only the configs reported by GetTopic are compared.
+func (operation *Operation) mismatch(topic *TopicDetail) string {
+	if operation.partitions != nil && (topic.Partitions == nil || *topic.Partitions != *operation.partitions) {
+		current := "not reported"
+		if topic.Partitions != nil {
+			current = fmt.Sprintf("%d", *topic.Partitions)
+		}
+		return fmt.Sprintf("partitions is %s, want %d", current, *operation.partitions)
+	}
+	if len(operation.configs) == 0 {
+		return ""
+	}
+	reported := reportedConfigs(topic)
+	names := make([]string, 0, len(operation.configs))
+	for name := range operation.configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value, ok := reported[name]
+		if ok && value != operation.configs[name] {
+			return fmt.Sprintf("config %s is %q, want %q", name, value, operation.configs[name])
+		}
+	}
+	return ""
+}
+
+// reportedConfigs returns the config values in the Configs of a topic, keyed by config name.
+func reportedConfigs(topic *TopicDetail) map[string]string {
+	configs := make(map[string]string)
+	if topic.Configs == nil {
+		return configs
+	}
+	buf, err := json.Marshal(topic.Configs)
+	if err == nil {
+		_ = json.Unmarshal(buf, &configs)
+	}
+	return configs
+}
+
+// transient returns true if the response has a status code that is expected to change when the request is retried.
+// A missing response, from a DNS, connection or TLS failure, is not transient: polling would hide it until the timeout.
+func transient(response *core.DetailedResponse) bool {
+	if response == nil {
+		return false
+	}
+	return response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= http.StatusInternalServerError
+}
+
+// CreateTopicAsync : Create a new topic and return an Operation that waits for it
+// Create a new topic. The returned Operation waits for the topic to exist with the requested partition count and
+// config values.
+func (adminrest *AdminrestV1) CreateTopicAsync(createTopicOptions *CreateTopicOptions) (operation *Operation, response *core.DetailedResponse, err error) {
+	return adminrest.CreateTopicAsyncWithContext(context.Background(), createTopicOptions)
+}
+
+// CreateTopicAsyncWithContext is an alternate form of the CreateTopicAsync method which supports a Context parameter
+func (adminrest *AdminrestV1) CreateTopicAsyncWithContext(ctx context.Context, createTopicOptions *CreateTopicOptions) (operation *Operation, response *core.DetailedResponse, err error) {
+	response, err = adminrest.CreateTopicWithContext(ctx, createTopicOptions)
+	if err != nil {
+		return
+	}
+	operation = adminrest.newOperation(OperationKindCreateTopic, core.StringNilMapper(createTopicOptions.Name), createTopicOptions.Headers)
+	if createTopicOptions.PartitionCount != nil {
+		operation.partitions = core.Int64Ptr(*createTopicOptions.PartitionCount)
+	} else if createTopicOptions.Partitions != nil {
+		operation.partitions = core.Int64Ptr(*createTopicOptions.Partitions)
+	}
+	for _, config := range createTopicOptions.Configs {
+		if config.Name != nil && config.Value != nil {
+			operation.configs[*config.Name] = *config.Value
+		}
+	}
+	return
+}
+
+// UpdateTopicAsync : Update a topic and return an Operation that waits for the change
+// Increase the partition count of a topic or change its configs. The returned Operation waits for the topic to have
+// the requested partition count and config values. Configs reset to their default value are not waited for.
+func (adminrest *AdminrestV1) UpdateTopicAsync(updateTopicOptions *UpdateTopicOptions) (operation *Operation, response *core.DetailedResponse, err error) {
+	return adminrest.UpdateTopicAsyncWithContext(context.Background(), updateTopicOptions)
+}
+
+// UpdateTopicAsyncWithContext is an alternate form of the UpdateTopicAsync method which supports a Context parameter
+func (adminrest *AdminrestV1) UpdateTopicAsyncWithContext(ctx context.Context, updateTopicOptions *UpdateTopicOptions) (operation *Operation, response *core.DetailedResponse, err error) {
+	response, err = adminrest.UpdateTopicWithContext(ctx, updateTopicOptions)
+	if err != nil {
+		return
+	}
+	operation = adminrest.newOperation(OperationKindUpdateTopic, *updateTopicOptions.TopicName, updateTopicOptions.Headers)
+	if updateTopicOptions.NewTotalPartitionCount != nil {
+		operation.partitions = core.Int64Ptr(*updateTopicOptions.NewTotalPartitionCount)
+	}
+	for _, config := range updateTopicOptions.Configs {
+		if config.Name == nil || config.Value == nil || (config.ResetToDefault != nil && *config.ResetToDefault) {
+			continue
+		}
+		operation.configs[*config.Name] = *config.Value
+	}
+	return
+}
+
+// DeleteTopicAsync : Delete a topic and return an Operation that waits for it to be gone
+// Delete a topic. The returned Operation waits for GetTopic to respond with 404.
+func (adminrest *AdminrestV1) DeleteTopicAsync(deleteTopicOptions *DeleteTopicOptions) (operation *Operation, response *core.DetailedResponse, err error) {
+	return adminrest.DeleteTopicAsyncWithContext(context.Background(), deleteTopicOptions)
+}
+
+// DeleteTopicAsyncWithContext is an alternate form of the DeleteTopicAsync method which supports a Context parameter
+func (adminrest *AdminrestV1) DeleteTopicAsyncWithContext(ctx context.Context, deleteTopicOptions *DeleteTopicOptions) (operation *Operation, response *core.DetailedResponse, err error) {
+	response, err = adminrest.DeleteTopicWithContext(ctx, deleteTopicOptions)
+	if err != nil {
+		return
+	}
+	operation = adminrest.newOperation(OperationKindDeleteTopic, *deleteTopicOptions.TopicName, deleteTopicOptions.Headers)
+	return
+}
@@ -0,0 +1,276 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminrestv1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// laggingServer accepts topic changes with status code 202 but only shows them to GetTopic after a number of polls,
+// the way the service does while a change is being applied.
+type laggingServer struct {
+	mutex      sync.Mutex
+	lag        int
+	exists     bool
+	partitions int64
+	configs    map[string]string
+	pending    func()
+	remaining  int
+	polls      int
+	failures   []int
+}
+
+func (server *laggingServer) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	res.Header().Set("Content-Type", "application/json")
+	switch req.Method {
+	case http.MethodPost:
+		var body struct {
+			Name           string         `json:"name"`
+			PartitionCount int64          `json:"partition_count"`
+			Configs        []ConfigCreate `json:"configs"`
+		}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		server.schedule(func() {
+			server.exists = true
+			server.partitions = body.PartitionCount
+			for _, config := range body.Configs {
+				server.configs[*config.Name] = *config.Value
+			}
+		})
+		res.WriteHeader(http.StatusAccepted)
+	case http.MethodPatch:
+		var body struct {
+			NewTotalPartitionCount int64          `json:"new_total_partition_count"`
+			Configs                []ConfigUpdate `json:"configs"`
+		}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		server.schedule(func() {
+			if body.NewTotalPartitionCount != 0 {
+				server.partitions = body.NewTotalPartitionCount
+			}
+			for _, config := range body.Configs {
+				if config.Value != nil {
+					server.configs[*config.Name] = *config.Value
+				}
+			}
+		})
+		res.WriteHeader(http.StatusAccepted)
+	case http.MethodDelete:
+		server.schedule(func() { server.exists = false })
+		res.WriteHeader(http.StatusAccepted)
+	case http.MethodGet:
+		server.polls++
+		if len(server.failures) > 0 {
+			status := server.failures[0]
+			server.failures = server.failures[1:]
+			res.WriteHeader(status)
+			fmt.Fprintf(res, `{"error_code":%d,"message":"%s"}`, status*100, http.StatusText(status))
+			return
+		}
+		if server.pending != nil {
+			server.remaining--
+			if server.remaining < 0 {
+				server.pending()
+				server.pending = nil
+			}
+		}
+		if !server.exists {
+			res.WriteHeader(http.StatusNotFound)
+			_, _ = res.Write([]byte(`{"error_code":40403,"message":"topic not found"}`))
+			return
+		}
+		body, _ := json.Marshal(map[string]interface{}{
+			"name":       strings.TrimPrefix(req.URL.Path, "/admin/topics/"),
+			"partitions": server.partitions,
+			"configs":    server.configs,
+		})
+		res.WriteHeader(http.StatusOK)
+		_, _ = res.Write(body)
+	}
+}
+
+// schedule makes the change visible after the configured number of polls.
+func (server *laggingServer) schedule(change func()) {
+	server.pending = change
+	server.remaining = server.lag
+}
+
+func (server *laggingServer) pollCount() int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return server.polls
+}
+
+var _ = Describe(`Operation`, func() {
+	var server *laggingServer
+	var testServer *httptest.Server
+	var adminrestService *AdminrestV1
+
+	BeforeEach(func() {
+		server = &laggingServer{lag: 2, configs: map[string]string{"retention.ms": "86400000"}}
+		testServer = httptest.NewServer(server)
+		var err error
+		adminrestService, err = NewAdminrestV1(&AdminrestV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	createOptions := func() *CreateTopicOptions {
+		return adminrestService.NewCreateTopicOptions().
+			SetName("orders").
+			SetPartitionCount(3).
+			SetConfigs([]ConfigCreate{{Name: core.StringPtr("retention.ms"), Value: core.StringPtr("3600000")}})
+	}
+
+	It(`Wait for a created topic to appear`, func() {
+		operation, response, err := adminrestService.CreateTopicAsync(createOptions())
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusAccepted))
+		Expect(operation.Kind()).To(Equal(OperationKindCreateTopic))
+		Expect(operation.TopicName()).To(Equal("orders"))
+
+		topic, err := operation.SetPollInterval(time.Millisecond, 4*time.Millisecond).Wait(context.Background())
+		Expect(err).To(BeNil())
+		Expect(*topic.Name).To(Equal("orders"))
+		Expect(*topic.Partitions).To(Equal(int64(3)))
+		Expect(*topic.Configs.RetentionMs).To(Equal("3600000"))
+		Expect(server.pollCount()).To(Equal(3))
+	})
+	It(`Wait for the partition count and configs of an updated topic`, func() {
+		server.exists, server.partitions = true, 1
+		options := adminrestService.NewUpdateTopicOptions("orders").
+			SetNewTotalPartitionCount(6).
+			SetConfigs([]ConfigUpdate{
+				{Name: core.StringPtr("retention.ms"), Value: core.StringPtr("7200000")},
+				{Name: core.StringPtr("segment.ms"), ResetToDefault: core.BoolPtr(true)},
+			})
+		operation, _, err := adminrestService.UpdateTopicAsync(options)
+		Expect(err).To(BeNil())
+
+		done, topic, reason, err := operation.Poll(context.Background())
+		Expect(err).To(BeNil())
+		Expect(done).To(BeFalse())
+		Expect(*topic.Partitions).To(Equal(int64(1)))
+		Expect(reason).To(Equal("partitions is 1, want 6"))
+
+		topic, err = operation.SetPollInterval(time.Millisecond, time.Millisecond).Wait(context.Background())
+		Expect(err).To(BeNil())
+		Expect(*topic.Partitions).To(Equal(int64(6)))
+		Expect(*topic.Configs.RetentionMs).To(Equal("7200000"))
+	})
+	It(`Wait for a deleted topic to return 404`, func() {
+		server.exists, server.partitions = true, 1
+		operation, _, err := adminrestService.DeleteTopicAsync(adminrestService.NewDeleteTopicOptions("orders"))
+		Expect(err).To(BeNil())
+
+		topic, err := operation.SetPollInterval(time.Millisecond, time.Millisecond).Wait(context.Background())
+		Expect(err).To(BeNil())
+		Expect(topic).To(BeNil())
+		Expect(server.pollCount()).To(Equal(3))
+	})
+	It(`Keep polling through 429 and 5xx responses`, func() {
+		server.failures = []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}
+		operation, _, err := adminrestService.CreateTopicAsync(createOptions())
+		Expect(err).To(BeNil())
+
+		_, err = operation.SetPollInterval(time.Millisecond, time.Millisecond).Wait(context.Background())
+		Expect(err).To(BeNil())
+		Expect(server.pollCount()).To(Equal(5))
+	})
+	It(`Return other errors immediately`, func() {
+		server.failures = []int{http.StatusForbidden}
+		operation, _, err := adminrestService.CreateTopicAsync(createOptions())
+		Expect(err).To(BeNil())
+
+		_, err = operation.Wait(context.Background())
+		Expect(errors.Is(err, ErrForbidden)).To(BeTrue())
+		Expect(server.pollCount()).To(Equal(1))
+	})
+	It(`Return transport errors immediately`, func() {
+		operation, _, err := adminrestService.CreateTopicAsync(createOptions())
+		Expect(err).To(BeNil())
+		testServer.Close()
+
+		_, err = operation.SetPollInterval(time.Millisecond, time.Millisecond).Wait(context.Background())
+		Expect(err).ToNot(BeNil())
+		var timeoutError *OperationTimeoutError
+		Expect(errors.As(err, &timeoutError)).To(BeFalse())
+		Expect(server.pollCount()).To(Equal(0))
+	})
+	It(`Return a timeout error with the last reason`, func() {
+		server.lag = 1000
+		operation, _, err := adminrestService.CreateTopicAsync(createOptions())
+		Expect(err).To(BeNil())
+
+		_, err = operation.SetTimeout(50*time.Millisecond).SetPollInterval(time.Millisecond, 5*time.Millisecond).Wait(context.Background())
+		var timeoutError *OperationTimeoutError
+		Expect(errors.As(err, &timeoutError)).To(BeTrue())
+		Expect(timeoutError.Kind).To(Equal(OperationKindCreateTopic))
+		Expect(timeoutError.TopicName).To(Equal("orders"))
+		Expect(timeoutError.Reason).To(Equal("the topic does not exist yet"))
+		Expect(timeoutError.Polls).To(BeNumerically(">", 1))
+		Expect(timeoutError.Elapsed).To(BeNumerically(">=", 50*time.Millisecond))
+		Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+		Expect(err.Error()).To(HavePrefix("CreateTopic: topic orders did not converge within"))
+	})
+	It(`Use the deadline of the context`, func() {
+		server.lag = 1000
+		operation, _, err := adminrestService.CreateTopicAsync(createOptions())
+		Expect(err).To(BeNil())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+		_, err = operation.SetPollInterval(time.Millisecond, 5*time.Millisecond).Wait(ctx)
+		var timeoutError *OperationTimeoutError
+		Expect(errors.As(err, &timeoutError)).To(BeTrue())
+	})
+	It(`Return the error of a cancelled context`, func() {
+		server.lag = 1000
+		operation, _, err := adminrestService.CreateTopicAsync(createOptions())
+		Expect(err).To(BeNil())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+		_, err = operation.SetPollInterval(time.Millisecond, 5*time.Millisecond).Wait(ctx)
+		Expect(err).To(Equal(context.Canceled))
+	})
+	It(`Return the error of the request that starts the operation`, func() {
+		operation, _, err := adminrestService.CreateTopicAsync(nil)
+		Expect(err).ToNot(BeNil())
+		Expect(operation).To(BeNil())
+	})
+})
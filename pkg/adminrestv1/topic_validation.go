@@ -0,0 +1,505 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminrestv1
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// maxTopicNameLength is the longest topic name Kafka accepts.
+const maxTopicNameLength = 249
+
+// topicNamePattern matches the characters Kafka allows in a topic name.
+var topicNamePattern = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// UpdatableTopicConfigs lists the configs that UpdateTopic can change.
+var UpdatableTopicConfigs = []string{
+	"cleanup.policy",
+	"retention.bytes",
+	"retention.ms",
+	"segment.bytes",
+	"segment.index.bytes",
+	"segment.ms",
+}
+
+// otherTopicConfigNames lists the Kafka topic configs that have no field in TypedTopicConfigs.
+var otherTopicConfigNames = []string{
+	"follower.replication.throttled.replicas",
+	"leader.replication.throttled.replicas",
+	"message.format.version",
+	"message.timestamp.after.max.ms",
+	"message.timestamp.before.max.ms",
+}
+
+// TopicLimits : The limits of an Event Streams plan, checked by the Validate methods of the topic options.
+//
+// A zero value means no limit. LiteTopicLimits, StandardTopicLimits and EnterpriseTopicLimits return the limits of
+// each plan at the time of writing; the returned value can be changed to match a particular instance.
+type TopicLimits struct {
+	// The name of the plan, used in messages.
+	Plan string
+
+	// The largest number of partitions across all the topics of the instance.
+	MaxPartitions int64
+
+	// The longest value of 'retention.ms'. When set, unlimited retention is not allowed.
+	MaxRetention time.Duration
+
+	// The largest value of 'retention.bytes'. When set, unlimited retention is not allowed.
+	MaxRetentionBytes ByteSize
+
+	// The largest value of 'max.message.bytes'.
+	MaxMessageBytes ByteSize
+}
+
+// LiteTopicLimits returns the limits of the Lite plan.
+func LiteTopicLimits() *TopicLimits {
+	return &TopicLimits{
+		Plan:              "Lite",
+		MaxPartitions:     1,
+		MaxRetention:      24 * time.Hour,
+		MaxRetentionBytes: 100 * Mebibyte,
+		MaxMessageBytes:   Mebibyte,
+	}
+}
+
+// StandardTopicLimits returns the limits of the Standard plan.
+func StandardTopicLimits() *TopicLimits {
+	return &TopicLimits{
+		Plan:              "Standard",
+		MaxPartitions:     100,
+		MaxRetention:      30 * 24 * time.Hour,
+		MaxRetentionBytes: Gibibyte,
+		MaxMessageBytes:   Mebibyte,
+	}
+}
+
+// EnterpriseTopicLimits returns the limits of the Enterprise plan with one capacity unit.
+func EnterpriseTopicLimits() *TopicLimits {
+	return &TopicLimits{
+		Plan:            "Enterprise",
+		MaxPartitions:   3000,
+		MaxMessageBytes: 10 * Mebibyte,
+	}
+}
+
+// ValidationProblem : A reason a topic request is not valid.
+type ValidationProblem struct {
+	// The part of the request with the problem, for example "name", "partition_count" or "configs[retention.ms]".
+	Field string
+
+	// A description of the problem.
+	Message string
+
+	// The sentinel error the problem corresponds to, such as ErrInvalidPartitions or ErrInvalidConfig, if any.
+	err error
+}
+
+// String returns a description of the problem.
+func (problem ValidationProblem) String() string {
+	return problem.Field + ": " + problem.Message
+}
+
+// ValidationError : The error returned by the Validate methods of the topic options.
+type ValidationError struct {
+	// The name of the operation, for example "CreateTopic".
+	Operation string
+
+	// Every problem found, in the order of the fields of the request.
+	Problems []ValidationProblem
+}
+
+// Error implements the error interface.
+func (validationError *ValidationError) Error() string {
+	problems := make([]string, len(validationError.Problems))
+	for i, problem := range validationError.Problems {
+		problems[i] = problem.String()
+	}
+	return fmt.Sprintf("%s: invalid request: %s", validationError.Operation, strings.Join(problems, "; "))
+}
+
+// Is reports whether one of the problems matches a sentinel error of this package, such as ErrInvalidPartitions or
+// ErrInvalidConfig.
+func (validationError *ValidationError) Is(target error) bool {
+	for _, problem := range validationError.Problems {
+		if problem.err != nil && problem.err == target {
+			return true
+		}
+	}
+	return false
+}
+
+// validator collects the problems of a request.
+type validator struct {
+	limits   *TopicLimits
+	problems []ValidationProblem
+}
+
+// report records a problem.
+func (v *validator) report(sentinel error, field string, format string, args ...interface{}) {
+	v.problems = append(v.problems, ValidationProblem{Field: field, Message: fmt.Sprintf(format, args...), err: sentinel})
+}
+
+// result returns a *ValidationError if any problems were found.
+func (v *validator) result(operation string) error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Operation: operation, Problems: v.problems}
+}
+
+// planSuffix returns the name of the plan for messages about its limits.
+func (v *validator) planSuffix() string {
+	if v.limits.Plan == "" {
+		return ""
+	}
+	return " of the " + v.limits.Plan + " plan"
+}
+
+// Validate : checks the request before it is sent, returning a *ValidationError that lists every problem found.
+//
+// The name must be a legal Kafka topic name, the partition count must be at least 1, and config names and values must
+// be valid. When limits is not nil, the limits of the plan are also checked. When the existing topics of the instance
+// are given, the name must not be taken or collide with an existing name in metric names, where '.' and '_' are the
+// same, and the partitions of the existing topics count towards MaxPartitions.
+func (_options *CreateTopicOptions) Validate(limits *TopicLimits, existing ...TopicDetail) error {
+	v := &validator{limits: limits}
+	if v.limits == nil {
+		v.limits = &TopicLimits{}
+	}
+
+	name := core.StringNilMapper(_options.Name)
+	v.checkTopicName(name)
+	for _, topic := range existing {
+		existingName := core.StringNilMapper(topic.Name)
+		if existingName == name {
+			v.report(ErrTopicAlreadyExists, "name", "topic %s already exists", name)
+		} else if name != "" && metricName(existingName) == metricName(name) {
+			v.report(nil, "name", "collides with the existing topic %s, because '.' and '_' are the same in metric names", existingName)
+		}
+	}
+
+	partitions, field := int64(1), "partition_count"
+	if _options.PartitionCount != nil {
+		partitions = *_options.PartitionCount
+	} else if _options.Partitions != nil {
+		partitions, field = *_options.Partitions, "partitions"
+	}
+	if partitions < 1 {
+		v.report(ErrInvalidPartitions, field, "must be at least 1, not %d", partitions)
+	} else {
+		v.checkTotalPartitions(field, partitions, "", existing)
+	}
+
+	values := make(map[string]string)
+	for _, config := range _options.Configs {
+		configName := core.StringNilMapper(config.Name)
+		if _, ok := values[configName]; ok {
+			v.report(ErrInvalidConfig, "configs["+configName+"]", "is set more than once")
+			continue
+		}
+		values[configName] = core.StringNilMapper(config.Value)
+	}
+	v.checkTopicConfigs(values, nil)
+	return v.result("CreateTopic")
+}
+
+// Validate : checks the request before it is sent, returning a *ValidationError that lists every problem found.
+//
+// The request must change the partition count or at least one config, only configs listed in UpdatableTopicConfigs
+// can be changed, and config values must be valid. When limits is not nil, the limits of the plan are also checked.
+// When the existing topics of the instance are given, the new partition count must be larger than the current count of
+// the topic, and the partitions of the other topics count towards MaxPartitions.
+func (_options *UpdateTopicOptions) Validate(limits *TopicLimits, existing ...TopicDetail) error {
+	v := &validator{limits: limits}
+	if v.limits == nil {
+		v.limits = &TopicLimits{}
+	}
+
+	name := core.StringNilMapper(_options.TopicName)
+	if name == "" {
+		v.report(nil, "topic_name", "is required")
+	}
+	if _options.NewTotalPartitionCount == nil && len(_options.Configs) == 0 {
+		v.report(nil, "new_total_partition_count", "or configs must be set")
+	}
+
+	var current *TopicDetail
+	for i := range existing {
+		if core.StringNilMapper(existing[i].Name) == name {
+			current = &existing[i]
+		}
+	}
+	if len(existing) > 0 && current == nil && name != "" {
+		v.report(ErrTopicNotFound, "topic_name", "topic %s does not exist", name)
+	}
+
+	if _options.NewTotalPartitionCount != nil {
+		partitions := *_options.NewTotalPartitionCount
+		switch {
+		case partitions < 1:
+			v.report(ErrInvalidPartitions, "new_total_partition_count", "must be at least 1, not %d", partitions)
+		case current != nil && current.Partitions != nil && partitions <= *current.Partitions:
+			v.report(ErrInvalidPartitions, "new_total_partition_count",
+				"must be larger than the current partition count %d, not %d", *current.Partitions, partitions)
+		default:
+			v.checkTotalPartitions("new_total_partition_count", partitions, name, existing)
+		}
+	}
+
+	values := make(map[string]string)
+	var reset []string
+	for _, config := range _options.Configs {
+		configName := core.StringNilMapper(config.Name)
+		_, set := values[configName]
+		if set || containsString(reset, configName) {
+			v.report(ErrInvalidConfig, "configs["+configName+"]", "is set more than once")
+			continue
+		}
+		if config.ResetToDefault != nil && *config.ResetToDefault {
+			reset = append(reset, configName)
+			continue
+		}
+		values[configName] = core.StringNilMapper(config.Value)
+	}
+	for _, configName := range reset {
+		if !containsString(UpdatableTopicConfigs, configName) {
+			v.report(ErrInvalidConfig, "configs["+configName+"]", "cannot be changed by UpdateTopic")
+		}
+	}
+	v.checkTopicConfigs(values, UpdatableTopicConfigs)
+	return v.result("UpdateTopic")
+}
+
+// checkTopicName checks that the name is a legal Kafka topic name.
+func (v *validator) checkTopicName(name string) {
+	switch {
+	case name == "":
+		v.report(nil, "name", "is required")
+	case len(name) > maxTopicNameLength:
+		v.report(nil, "name", "must be at most %d characters long, not %d", maxTopicNameLength, len(name))
+	case name == "." || name == "..":
+		v.report(nil, "name", "cannot be %q", name)
+	case !topicNamePattern.MatchString(name):
+		v.report(nil, "name", "can only contain the characters a-z, A-Z, 0-9, '.', '_' and '-'")
+	case strings.HasPrefix(name, "__"):
+		v.report(nil, "name", "cannot start with '__', which is reserved for internal topics")
+	}
+}
+
+// checkTotalPartitions checks that the partitions of a topic, together with those of the existing topics other than
+// the one named, do not exceed MaxPartitions.
+func (v *validator) checkTotalPartitions(field string, partitions int64, name string, existing []TopicDetail) {
+	if v.limits.MaxPartitions == 0 {
+		return
+	}
+	total := partitions
+	for _, topic := range existing {
+		if core.StringNilMapper(topic.Name) != name && topic.Partitions != nil {
+			total += *topic.Partitions
+		}
+	}
+	if total <= v.limits.MaxPartitions {
+		return
+	}
+	if total == partitions {
+		v.report(ErrInvalidPartitions, field, "%d exceeds the limit of %d partitions%s",
+			partitions, v.limits.MaxPartitions, v.planSuffix())
+		return
+	}
+	v.report(ErrInvalidPartitions, field, "%d would bring the instance to %d partitions, over the limit of %d%s",
+		partitions, total, v.limits.MaxPartitions, v.planSuffix())
+}
+
+// checkTopicConfigs checks config names and values. When allowed is not nil, only the configs it lists are accepted.
+func (v *validator) checkTopicConfigs(values map[string]string, allowed []string) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	typed := &TypedTopicConfigs{}
+	for _, name := range names {
+		field := "configs[" + name + "]"
+		value := values[name]
+		if findTopicConfigField(name) == nil && !containsString(otherTopicConfigNames, name) {
+			if suggestion := suggestTopicConfigName(name); suggestion != "" {
+				v.report(ErrInvalidConfig, field, "is not a topic config, did you mean %s?", suggestion)
+			} else {
+				v.report(ErrInvalidConfig, field, "is not a topic config")
+			}
+			continue
+		}
+		if allowed != nil && !containsString(allowed, name) {
+			v.report(ErrInvalidConfig, field, "cannot be changed by UpdateTopic")
+			continue
+		}
+		configField := findTopicConfigField(name)
+		if configField == nil {
+			continue
+		}
+		if reason := setTopicConfig(configField.field(typed), value, false); reason != "" {
+			v.report(ErrInvalidConfig, field, "%q is not valid: %s", value, reason)
+		}
+	}
+	v.checkTopicConfigValues(typed)
+}
+
+// checkTopicConfigValues checks the ranges of the typed config values, and the limits of the plan.
+func (v *validator) checkTopicConfigValues(c *TypedTopicConfigs) {
+	if c.CleanupPolicy != nil {
+		switch *c.CleanupPolicy {
+		case CleanupPolicyDelete, CleanupPolicyCompact, CleanupPolicyCompactAndDelete, "delete,compact":
+		default:
+			v.report(ErrInvalidConfig, "configs[cleanup.policy]", "must be delete, compact or compact,delete, not %q", *c.CleanupPolicy)
+		}
+	}
+	if c.CompressionType != nil {
+		switch *c.CompressionType {
+		case CompressionTypeProducer, CompressionTypeUncompressed, CompressionTypeGzip, CompressionTypeSnappy,
+			CompressionTypeLz4, CompressionTypeZstd:
+		default:
+			v.report(ErrInvalidConfig, "configs[compression.type]",
+				"must be producer, uncompressed, gzip, snappy, lz4 or zstd, not %q", *c.CompressionType)
+		}
+	}
+	if c.MessageTimestampType != nil && *c.MessageTimestampType != TimestampTypeCreateTime &&
+		*c.MessageTimestampType != TimestampTypeLogAppendTime {
+		v.report(ErrInvalidConfig, "configs[message.timestamp.type]", "must be CreateTime or LogAppendTime, not %q",
+			*c.MessageTimestampType)
+	}
+
+	v.checkDuration("delete.retention.ms", c.DeleteRetention, 0)
+	v.checkDuration("file.delete.delay.ms", c.FileDeleteDelay, 0)
+	v.checkDuration("flush.ms", c.FlushInterval, 0)
+	v.checkDuration("local.retention.ms", c.LocalRetention, -2*time.Millisecond)
+	v.checkDuration("max.compaction.lag.ms", c.MaxCompactionLag, time.Millisecond)
+	v.checkDuration("message.timestamp.difference.max.ms", c.MessageTimestampDifferenceMax, 0)
+	v.checkDuration("min.compaction.lag.ms", c.MinCompactionLag, 0)
+	v.checkDuration("retention.ms", c.Retention, UnlimitedRetention)
+	v.checkDuration("segment.jitter.ms", c.SegmentJitter, 0)
+	v.checkDuration("segment.ms", c.Segment, time.Millisecond)
+	v.checkBytes("index.interval.bytes", c.IndexIntervalBytes, 0)
+	v.checkBytes("local.retention.bytes", c.LocalRetentionBytes, -2)
+	v.checkBytes("max.message.bytes", c.MaxMessageBytes, 0)
+	v.checkBytes("retention.bytes", c.RetentionBytes, UnlimitedRetentionBytes)
+	v.checkBytes("segment.bytes", c.SegmentBytes, 14)
+	v.checkBytes("segment.index.bytes", c.SegmentIndexBytes, 4)
+	if c.FlushMessages != nil && *c.FlushMessages < 1 {
+		v.report(ErrInvalidConfig, "configs[flush.messages]", "must be at least 1, not %d", *c.FlushMessages)
+	}
+	if c.MinInsyncReplicas != nil && *c.MinInsyncReplicas < 1 {
+		v.report(ErrInvalidConfig, "configs[min.insync.replicas]", "must be at least 1, not %d", *c.MinInsyncReplicas)
+	}
+	if c.MinCleanableDirtyRatio != nil && (*c.MinCleanableDirtyRatio < 0 || *c.MinCleanableDirtyRatio > 1) {
+		v.report(ErrInvalidConfig, "configs[min.cleanable.dirty.ratio]", "must be between 0 and 1, not %g", *c.MinCleanableDirtyRatio)
+	}
+	if c.MinCompactionLag != nil && c.MaxCompactionLag != nil && *c.MinCompactionLag > *c.MaxCompactionLag {
+		v.report(ErrInvalidConfig, "configs[min.compaction.lag.ms]", "must not be longer than max.compaction.lag.ms")
+	}
+
+	if v.limits.MaxRetention > 0 && c.Retention != nil && (*c.Retention < 0 || *c.Retention > v.limits.MaxRetention) {
+		v.report(ErrInvalidConfig, "configs[retention.ms]", "exceeds the limit of %s%s",
+			v.limits.MaxRetention, v.planSuffix())
+	}
+	if v.limits.MaxRetentionBytes > 0 && c.RetentionBytes != nil &&
+		(*c.RetentionBytes < 0 || *c.RetentionBytes > v.limits.MaxRetentionBytes) {
+		v.report(ErrInvalidConfig, "configs[retention.bytes]", "exceeds the limit of %s%s",
+			v.limits.MaxRetentionBytes, v.planSuffix())
+	}
+	if v.limits.MaxMessageBytes > 0 && c.MaxMessageBytes != nil && *c.MaxMessageBytes > v.limits.MaxMessageBytes {
+		v.report(ErrInvalidConfig, "configs[max.message.bytes]", "exceeds the limit of %s%s",
+			v.limits.MaxMessageBytes, v.planSuffix())
+	}
+}
+
+// checkDuration checks that a duration config is not less than the minimum.
+func (v *validator) checkDuration(name string, value *time.Duration, minimum time.Duration) {
+	if value != nil && *value < minimum {
+		v.report(ErrInvalidConfig, "configs["+name+"]", "must be at least %d, not %d",
+			minimum/time.Millisecond, *value/time.Millisecond)
+	}
+}
+
+// checkBytes checks that a byte config is not less than the minimum.
+func (v *validator) checkBytes(name string, value *ByteSize, minimum ByteSize) {
+	if value != nil && *value < minimum {
+		v.report(ErrInvalidConfig, "configs["+name+"]", "must be at least %d, not %d", int64(minimum), int64(*value))
+	}
+}
+
+// metricName returns the form of a topic name used in metric names, in which '.' and '_' are the same.
+func metricName(name string) string {
+	return strings.Replace(name, ".", "_", -1)
+}
+
+// suggestTopicConfigName returns the typed config name closest to a misspelled name, or "" if none is close.
+func suggestTopicConfigName(name string) string {
+	best, bestDistance := "", 3
+	for _, field := range topicConfigFields {
+		if distance := editDistance(name, field.name); distance < bestDistance {
+			best, bestDistance = field.name, distance
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(minInt(previous[j]+1, current[j-1]+1), previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// minInt returns the smaller of two ints.
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// containsString returns true if the list contains the value.
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminrestv1
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Topic request validation`, func() {
+	service := new(AdminrestV1)
+
+	problems := func(err error) []string {
+		Expect(err).ToNot(BeNil())
+		var validationError *ValidationError
+		Expect(errors.As(err, &validationError)).To(BeTrue())
+		messages := []string{}
+		for _, problem := range validationError.Problems {
+			messages = append(messages, problem.String())
+		}
+		return messages
+	}
+
+	existing := []TopicDetail{
+		{Name: core.StringPtr("orders"), Partitions: core.Int64Ptr(3)},
+		{Name: core.StringPtr("audit.log"), Partitions: core.Int64Ptr(1)},
+	}
+
+	Describe(`CreateTopicOptions.Validate`, func() {
+		It(`Accept a valid request`, func() {
+			options := service.NewCreateTopicOptions().SetName("payments").SetPartitionCount(3).
+				SetConfigs([]ConfigCreate{{Name: core.StringPtr("retention.ms"), Value: core.StringPtr("86400000")}})
+			Expect(options.Validate(nil)).To(BeNil())
+			Expect(options.Validate(StandardTopicLimits(), existing...)).To(BeNil())
+		})
+		It(`Accept Kafka's default unlimited durations`, func() {
+			options := service.NewCreateTopicOptions().SetName("payments").SetConfigs([]ConfigCreate{
+				{Name: core.StringPtr("max.compaction.lag.ms"), Value: core.StringPtr("9223372036854775807")},
+				{Name: core.StringPtr("message.timestamp.difference.max.ms"), Value: core.StringPtr("9223372036854775807")},
+			})
+			Expect(options.Validate(nil)).To(BeNil())
+			Expect(options.Validate(StandardTopicLimits(), existing...)).To(BeNil())
+		})
+		It(`Check topic names`, func() {
+			for name, message := range map[string]string{
+				"":                        "name: is required",
+				strings.Repeat("a", 250):  "name: must be at most 249 characters long, not 250",
+				"..":                      `name: cannot be ".."`,
+				"orders/eu":               "name: can only contain the characters a-z, A-Z, 0-9, '.', '_' and '-'",
+				"__consumer_offsets_copy": "name: cannot start with '__', which is reserved for internal topics",
+			} {
+				Expect(problems(service.NewCreateTopicOptions().SetName(name).Validate(nil))).To(Equal([]string{message}))
+			}
+		})
+		It(`Check the name against existing topics`, func() {
+			err := service.NewCreateTopicOptions().SetName("orders").Validate(nil, existing...)
+			Expect(problems(err)).To(Equal([]string{"name: topic orders already exists"}))
+			Expect(errors.Is(err, ErrTopicAlreadyExists)).To(BeTrue())
+
+			err = service.NewCreateTopicOptions().SetName("audit_log").Validate(nil, existing...)
+			Expect(problems(err)).To(Equal([]string{
+				"name: collides with the existing topic audit.log, because '.' and '_' are the same in metric names",
+			}))
+		})
+		It(`Check partitions against the plan`, func() {
+			err := service.NewCreateTopicOptions().SetName("payments").SetPartitions(0).Validate(nil)
+			Expect(problems(err)).To(Equal([]string{"partitions: must be at least 1, not 0"}))
+			Expect(errors.Is(err, ErrInvalidPartitions)).To(BeTrue())
+
+			err = service.NewCreateTopicOptions().SetName("payments").SetPartitionCount(2).Validate(LiteTopicLimits())
+			Expect(problems(err)).To(Equal([]string{"partition_count: 2 exceeds the limit of 1 partitions of the Lite plan"}))
+
+			limits := StandardTopicLimits()
+			limits.MaxPartitions = 6
+			err = service.NewCreateTopicOptions().SetName("payments").SetPartitionCount(3).Validate(limits, existing...)
+			Expect(problems(err)).To(Equal([]string{
+				"partition_count: 3 would bring the instance to 7 partitions, over the limit of 6 of the Standard plan",
+			}))
+		})
+		It(`Check config names and values`, func() {
+			options := service.NewCreateTopicOptions().SetName("payments").SetConfigs([]ConfigCreate{
+				{Name: core.StringPtr("retention.msx"), Value: core.StringPtr("1000")},
+				{Name: core.StringPtr("segment.bytes"), Value: core.StringPtr("10")},
+				{Name: core.StringPtr("compression.type"), Value: core.StringPtr("brotli")},
+				{Name: core.StringPtr("min.cleanable.dirty.ratio"), Value: core.StringPtr("1.5")},
+				{Name: core.StringPtr("preallocate"), Value: core.StringPtr("maybe")},
+				{Name: core.StringPtr("segment.bytes"), Value: core.StringPtr("1048576")},
+				{Name: core.StringPtr("message.format.version"), Value: core.StringPtr("3.0")},
+				{Name: core.StringPtr("retention.bytes"), Value: core.StringPtr("1GiB")},
+			})
+			err := options.Validate(nil)
+			Expect(problems(err)).To(Equal([]string{
+				"configs[segment.bytes]: is set more than once",
+				`configs[preallocate]: "maybe" is not valid: expected true or false`,
+				`configs[retention.bytes]: "1GiB" is not valid: expected a whole number of bytes`,
+				"configs[retention.msx]: is not a topic config, did you mean retention.ms?",
+				`configs[compression.type]: must be producer, uncompressed, gzip, snappy, lz4 or zstd, not "brotli"`,
+				"configs[segment.bytes]: must be at least 14, not 10",
+				"configs[min.cleanable.dirty.ratio]: must be between 0 and 1, not 1.5",
+			}))
+			Expect(errors.Is(err, ErrInvalidConfig)).To(BeTrue())
+			Expect(err.Error()).To(HavePrefix("CreateTopic: invalid request: configs[segment.bytes]: is set more than once; "))
+		})
+		It(`Check configs against the plan`, func() {
+			options := service.NewCreateTopicOptions().SetName("payments").SetConfigs([]ConfigCreate{
+				{Name: core.StringPtr("retention.ms"), Value: core.StringPtr("-1")},
+				{Name: core.StringPtr("retention.bytes"), Value: core.StringPtr("2147483648")},
+				{Name: core.StringPtr("max.message.bytes"), Value: core.StringPtr("2097152")},
+			})
+			Expect(problems(options.Validate(StandardTopicLimits()))).To(Equal([]string{
+				"configs[retention.ms]: exceeds the limit of 720h0m0s of the Standard plan",
+				"configs[retention.bytes]: exceeds the limit of 1GiB of the Standard plan",
+				"configs[max.message.bytes]: exceeds the limit of 1MiB of the Standard plan",
+			}))
+			Expect(options.Validate(EnterpriseTopicLimits())).To(BeNil())
+		})
+	})
+
+	Describe(`UpdateTopicOptions.Validate`, func() {
+		It(`Accept a valid request`, func() {
+			options := service.NewUpdateTopicOptions("orders").SetNewTotalPartitionCount(6).
+				SetConfigs([]ConfigUpdate{
+					{Name: core.StringPtr("cleanup.policy"), Value: core.StringPtr("compact")},
+					{Name: core.StringPtr("retention.ms"), ResetToDefault: core.BoolPtr(true)},
+				})
+			Expect(options.Validate(nil)).To(BeNil())
+			Expect(options.Validate(StandardTopicLimits(), existing...)).To(BeNil())
+		})
+		It(`Require a change`, func() {
+			Expect(problems(service.NewUpdateTopicOptions("orders").Validate(nil))).To(Equal([]string{
+				"new_total_partition_count: or configs must be set",
+			}))
+		})
+		It(`Check the partition count against the current count`, func() {
+			err := service.NewUpdateTopicOptions("orders").SetNewTotalPartitionCount(3).Validate(nil, existing...)
+			Expect(problems(err)).To(Equal([]string{
+				"new_total_partition_count: must be larger than the current partition count 3, not 3",
+			}))
+			Expect(errors.Is(err, ErrInvalidPartitions)).To(BeTrue())
+
+			limits := &TopicLimits{MaxPartitions: 10}
+			err = service.NewUpdateTopicOptions("orders").SetNewTotalPartitionCount(10).Validate(limits, existing...)
+			Expect(problems(err)).To(Equal([]string{
+				"new_total_partition_count: 10 would bring the instance to 11 partitions, over the limit of 10",
+			}))
+
+			err = service.NewUpdateTopicOptions("payments").SetNewTotalPartitionCount(2).Validate(nil, existing...)
+			Expect(errors.Is(err, ErrTopicNotFound)).To(BeTrue())
+		})
+		It(`Only accept configs that UpdateTopic can change`, func() {
+			options := service.NewUpdateTopicOptions("orders").SetConfigs([]ConfigUpdate{
+				{Name: core.StringPtr("min.insync.replicas"), Value: core.StringPtr("2")},
+				{Name: core.StringPtr("compression.type"), ResetToDefault: core.BoolPtr(true)},
+				{Name: core.StringPtr("segment.ms"), Value: core.StringPtr("0")},
+			})
+			Expect(problems(options.Validate(nil))).To(Equal([]string{
+				"configs[compression.type]: cannot be changed by UpdateTopic",
+				"configs[min.insync.replicas]: cannot be changed by UpdateTopic",
+				"configs[segment.ms]: must be at least 1, not 0",
+			}))
+		})
+	})
+})
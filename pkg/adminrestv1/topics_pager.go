@@ -0,0 +1,372 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminrestv1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// DefaultTopicsPerPage is the page size used by TopicsPager when ListTopicsOptions.PerPage is not set.
+const DefaultTopicsPerPage int64 = 100
+
+// DefaultMaxConcurrentRequests is the number of pages ListAllTopics fetches at once when no limit is set.
+const DefaultMaxConcurrentRequests = 4
+
+// headerNameTotalCount is the response header in which ListTopics reports the total number of topics.
+const headerNameTotalCount = "X-Total-Count"
+
+// TopicsPager can be used to simplify the use of the "ListTopics" method.
+type TopicsPager struct {
+	hasNext        bool
+	options        *ListTopicsOptions
+	client         *AdminrestV1
+	pageNumber     int64
+	retrieved      int64
+	totalCount     *int64
+	maxConcurrency int
+}
+
+// NewTopicsPager returns a new TopicsPager instance.
+func (adminrest *AdminrestV1) NewTopicsPager(options *ListTopicsOptions) (pager *TopicsPager, err error) {
+	if options == nil {
+		options = &ListTopicsOptions{}
+	}
+	if options.Page != nil {
+		err = fmt.Errorf("the 'options.Page' field should not be set")
+		return
+	}
+	if options.PerPage != nil && *options.PerPage <= 0 {
+		err = fmt.Errorf("the 'options.PerPage' field must be greater than zero")
+		return
+	}
+
+	var optionsCopy ListTopicsOptions = *options
+	if optionsCopy.PerPage == nil {
+		optionsCopy.PerPage = core.Int64Ptr(DefaultTopicsPerPage)
+	}
+	pager = &TopicsPager{
+		hasNext:        true,
+		options:        &optionsCopy,
+		client:         adminrest,
+		pageNumber:     1,
+		maxConcurrency: 1,
+	}
+	return
+}
+
+// SetMaxConcurrency sets the number of pages that GetAll may request at the same time once the total
+// number of topics is known. A value of 1 (the default) retrieves pages one after another.
+func (pager *TopicsPager) SetMaxConcurrency(maxConcurrency int) *TopicsPager {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+	pager.maxConcurrency = maxConcurrency
+	return pager
+}
+
+// HasNext returns true if there are potentially more results to be retrieved.
+func (pager *TopicsPager) HasNext() bool {
+	return pager.hasNext
+}
+
+// TotalCount returns the total number of topics reported by the service in the X-Total-Count header
+// of the most recent page, or nil if no page has been retrieved or the header was not returned.
+func (pager *TopicsPager) TotalCount() *int64 {
+	return pager.totalCount
+}
+
+// GetNextWithContext returns the next page of results using the specified Context.
+func (pager *TopicsPager) GetNextWithContext(ctx context.Context) (page []TopicDetail, err error) {
+	if !pager.HasNext() {
+		return nil, fmt.Errorf("no more results available")
+	}
+
+	page, totalCount, err := pager.getPage(ctx, pager.pageNumber)
+	if err != nil {
+		return
+	}
+
+	if pager.pageNumber == 1 && totalCount != nil && int64(len(page)) < *totalCount && int64(len(page)) < *pager.options.PerPage && len(page) > 0 {
+		// The service capped the page size below the requested value, so request
+		// pages of the size it actually serves to keep the page numbers aligned.
+		pager.options.PerPage = core.Int64Ptr(int64(len(page)))
+	}
+
+	pager.pageNumber++
+	pager.retrieved += int64(len(page))
+	if totalCount != nil {
+		pager.totalCount = totalCount
+		pager.hasNext = len(page) > 0 && pager.retrieved < *totalCount
+	} else {
+		pager.hasNext = int64(len(page)) >= *pager.options.PerPage
+	}
+	return
+}
+
+// GetAllWithContext returns all results by invoking GetNextWithContext() repeatedly
+// until all pages of results have been retrieved. When the pager allows more than one
+// concurrent request and the service reports the total number of topics, the pages
+// following the first one are retrieved concurrently.
+func (pager *TopicsPager) GetAllWithContext(ctx context.Context) (allItems []TopicDetail, err error) {
+	if pager.HasNext() && pager.pageNumber == 1 {
+		var firstPage []TopicDetail
+		firstPage, err = pager.GetNextWithContext(ctx)
+		if err != nil {
+			return
+		}
+		allItems = append(allItems, firstPage...)
+	}
+
+	if pager.HasNext() && pager.maxConcurrency > 1 && pager.totalCount != nil {
+		var remaining []TopicDetail
+		remaining, err = pager.getRemainingConcurrently(ctx)
+		if err != nil {
+			return
+		}
+		allItems = append(allItems, remaining...)
+		return
+	}
+
+	for pager.HasNext() {
+		var nextPage []TopicDetail
+		nextPage, err = pager.GetNextWithContext(ctx)
+		if err != nil {
+			return
+		}
+		allItems = append(allItems, nextPage...)
+	}
+	return
+}
+
+// GetNext invokes GetNextWithContext() using context.Background() as the Context parameter.
+func (pager *TopicsPager) GetNext() (page []TopicDetail, err error) {
+	return pager.GetNextWithContext(context.Background())
+}
+
+// GetAll invokes GetAllWithContext() using context.Background() as the Context parameter.
+func (pager *TopicsPager) GetAll() (allItems []TopicDetail, err error) {
+	return pager.GetAllWithContext(context.Background())
+}
+
+// getPage retrieves a single page of topics along with the total count reported by the service, if any.
+func (pager *TopicsPager) getPage(ctx context.Context, pageNumber int64) (page []TopicDetail, totalCount *int64, err error) {
+	var optionsCopy ListTopicsOptions = *pager.options
+	optionsCopy.Page = core.Int64Ptr(pageNumber)
+
+	page, response, err := pager.client.ListTopicsWithContext(ctx, &optionsCopy)
+	if err != nil {
+		return
+	}
+	if response != nil && response.Headers != nil {
+		if value := response.Headers.Get(headerNameTotalCount); value != "" {
+			count, parseErr := strconv.ParseInt(value, 10, 64)
+			if parseErr != nil {
+				err = fmt.Errorf("invalid %s header value '%s': %s", headerNameTotalCount, value, parseErr.Error())
+				return
+			}
+			totalCount = &count
+		}
+	}
+	return
+}
+
+// getRemainingConcurrently retrieves every page after the ones already returned, using at most
+// maxConcurrency requests at a time. The pages are returned in order.
+func (pager *TopicsPager) getRemainingConcurrently(ctx context.Context) (allItems []TopicDetail, err error) {
+	pageSize := *pager.options.PerPage
+	remaining := *pager.totalCount - pager.retrieved
+	pageCount := (remaining + pageSize - 1) / pageSize
+	firstPage := pager.pageNumber
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make([][]TopicDetail, pageCount)
+	semaphore := make(chan struct{}, pager.maxConcurrency)
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	setErr := func(e error) {
+		errOnce.Do(func() {
+			err = e
+			cancel()
+		})
+	}
+
+	for i := int64(0); i < pageCount; i++ {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			setErr(ctx.Err())
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(index int64) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			page, _, pageErr := pager.getPage(ctx, firstPage+index)
+			if pageErr != nil {
+				setErr(pageErr)
+				return
+			}
+			pages[index] = page
+		}(i)
+	}
+	wg.Wait()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, page := range pages {
+		pager.retrieved += int64(len(page))
+		allItems = append(allItems, page...)
+	}
+	pager.pageNumber = firstPage + pageCount
+	pager.hasNext = false
+	return
+}
+
+// TopicList : The complete list of topics returned by ListAllTopics.
+type TopicList struct {
+	// The topics, in the order returned by the service.
+	Topics []TopicDetail `json:"topics"`
+
+	// The total number of topics reported by the service in the X-Total-Count header, if it was returned.
+	TotalCount *int64 `json:"total_count,omitempty"`
+}
+
+// ListAllTopics : Get the complete list of topics
+// Retrieves every page of ListTopics results and returns the topics together with the total count reported by the
+// service.
+func (adminrest *AdminrestV1) ListAllTopics(listAllTopicsOptions *ListAllTopicsOptions) (result *TopicList, err error) {
+	return adminrest.ListAllTopicsWithContext(context.Background(), listAllTopicsOptions)
+}
+
+// ListAllTopicsWithContext is an alternate form of the ListAllTopics method which supports a Context parameter
+func (adminrest *AdminrestV1) ListAllTopicsWithContext(ctx context.Context, listAllTopicsOptions *ListAllTopicsOptions) (result *TopicList, err error) {
+	if listAllTopicsOptions == nil {
+		listAllTopicsOptions = adminrest.NewListAllTopicsOptions()
+	}
+
+	pager, err := adminrest.NewTopicsPager(&ListTopicsOptions{
+		TopicFilter: listAllTopicsOptions.TopicFilter,
+		PerPage:     listAllTopicsOptions.PerPage,
+		Headers:     listAllTopicsOptions.Headers,
+	})
+	if err != nil {
+		return
+	}
+
+	maxConcurrency := DefaultMaxConcurrentRequests
+	if listAllTopicsOptions.MaxConcurrentRequests != nil {
+		maxConcurrency = int(*listAllTopicsOptions.MaxConcurrentRequests)
+	}
+	pager.SetMaxConcurrency(maxConcurrency)
+
+	topics, err := pager.GetAllWithContext(ctx)
+	if err != nil {
+		return
+	}
+	if topics == nil {
+		topics = []TopicDetail{}
+	}
+
+	result = &TopicList{
+		Topics:     topics,
+		TotalCount: pager.TotalCount(),
+	}
+	return
+}
+
+// TopicFilterMatcher : returns a function that reports whether a topic name matches a filter in the syntax of
+// ListTopicsOptions.TopicFilter, so that names can be checked against a filter without listing topics. An empty
+// filter matches every name.
+func TopicFilterMatcher(filter string) (func(name string) bool, error) {
+	if filter == "" {
+		return func(string) bool { return true }, nil
+	}
+	var pattern string
+	if len(filter) >= 2 && strings.HasPrefix(filter, "/") && strings.HasSuffix(filter, "/") {
+		pattern = filter[1 : len(filter)-1]
+	} else {
+		parts := strings.Split(filter, "*")
+		for i, part := range parts {
+			parts[i] = regexp.QuoteMeta(part)
+		}
+		pattern = "^" + strings.Join(parts, ".*") + "$"
+	}
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return compiled.MatchString, nil
+}
+
+// ListAllTopicsOptions : The ListAllTopics options.
+type ListAllTopicsOptions struct {
+	// A filter to be applied to the topic names, using the same syntax as ListTopicsOptions.TopicFilter.
+	TopicFilter *string
+
+	// The number of topics to request per page. Defaults to DefaultTopicsPerPage.
+	PerPage *int64
+
+	// The maximum number of pages requested at the same time. Defaults to DefaultMaxConcurrentRequests.
+	MaxConcurrentRequests *int64
+
+	// Allows users to set headers on API requests
+	Headers map[string]string
+}
+
+// NewListAllTopicsOptions : Instantiate ListAllTopicsOptions
+func (*AdminrestV1) NewListAllTopicsOptions() *ListAllTopicsOptions {
+	return &ListAllTopicsOptions{}
+}
+
+// SetTopicFilter : Allow user to set TopicFilter
+func (_options *ListAllTopicsOptions) SetTopicFilter(topicFilter string) *ListAllTopicsOptions {
+	_options.TopicFilter = core.StringPtr(topicFilter)
+	return _options
+}
+
+// SetPerPage : Allow user to set PerPage
+func (_options *ListAllTopicsOptions) SetPerPage(perPage int64) *ListAllTopicsOptions {
+	_options.PerPage = core.Int64Ptr(perPage)
+	return _options
+}
+
+// SetMaxConcurrentRequests : Allow user to set MaxConcurrentRequests
+func (_options *ListAllTopicsOptions) SetMaxConcurrentRequests(maxConcurrentRequests int64) *ListAllTopicsOptions {
+	_options.MaxConcurrentRequests = core.Int64Ptr(maxConcurrentRequests)
+	return _options
+}
+
+// SetHeaders : Allow user to set Headers
+func (options *ListAllTopicsOptions) SetHeaders(param map[string]string) *ListAllTopicsOptions {
+	options.Headers = param
+	return options
+}
@@ -0,0 +1,281 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminrestv1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`TopicsPager`, func() {
+	var testServer *httptest.Server
+
+	// newPagingServer serves topicCount topics named topic-0001 onwards. When maxPerPage is greater than
+	// zero the server caps the page size. When sendTotal is false the X-Total-Count header is omitted.
+	newPagingServer := func(topicCount int, maxPerPage int, sendTotal bool, requests *int32, inFlight *int32, maxInFlight *int32) *httptest.Server {
+		var mutex sync.Mutex
+		return httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			defer GinkgoRecover()
+
+			Expect(req.URL.EscapedPath()).To(Equal("/admin/topics"))
+			Expect(req.Method).To(Equal("GET"))
+			atomic.AddInt32(requests, 1)
+
+			current := atomic.AddInt32(inFlight, 1)
+			defer atomic.AddInt32(inFlight, -1)
+			mutex.Lock()
+			if current > *maxInFlight {
+				*maxInFlight = current
+			}
+			mutex.Unlock()
+			time.Sleep(20 * time.Millisecond)
+
+			page, _ := strconv.Atoi(req.URL.Query().Get("page"))
+			perPage, _ := strconv.Atoi(req.URL.Query().Get("per_page"))
+			if maxPerPage > 0 && perPage > maxPerPage {
+				perPage = maxPerPage
+			}
+
+			topics := []map[string]interface{}{}
+			for i := (page - 1) * perPage; i < page*perPage && i < topicCount; i++ {
+				topics = append(topics, map[string]interface{}{"name": fmt.Sprintf("topic-%04d", i+1), "partitions": 1})
+			}
+			body, _ := json.Marshal(topics)
+
+			res.Header().Set("Content-type", "application/json")
+			if sendTotal {
+				res.Header().Set("X-Total-Count", strconv.Itoa(topicCount))
+			}
+			res.WriteHeader(200)
+			fmt.Fprintf(res, "%s", body)
+		}))
+	}
+
+	newService := func() *AdminrestV1 {
+		adminrestService, serviceErr := NewAdminrestV1(&AdminrestV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(serviceErr).To(BeNil())
+		Expect(adminrestService).ToNot(BeNil())
+		return adminrestService
+	}
+
+	topicNames := func(topics []TopicDetail) []string {
+		names := []string{}
+		for _, topic := range topics {
+			names = append(names, *topic.Name)
+		}
+		return names
+	}
+
+	Describe(`NewTopicsPager(options *ListTopicsOptions)`, func() {
+		It(`Reject options with the page already set`, func() {
+			adminrestService, _ := NewAdminrestV1(&AdminrestV1Options{Authenticator: &core.NoAuthAuthenticator{}})
+			pager, err := adminrestService.NewTopicsPager(adminrestService.NewListTopicsOptions().SetPage(2))
+			Expect(err).ToNot(BeNil())
+			Expect(pager).To(BeNil())
+
+			pager, err = adminrestService.NewTopicsPager(adminrestService.NewListTopicsOptions().SetPerPage(0))
+			Expect(err).ToNot(BeNil())
+			Expect(pager).To(BeNil())
+		})
+	})
+	Describe(`Paging with the X-Total-Count header`, func() {
+		var requests, inFlight, maxInFlight int32
+		BeforeEach(func() {
+			requests, inFlight, maxInFlight = 0, 0, 0
+			testServer = newPagingServer(25, 0, true, &requests, &inFlight, &maxInFlight)
+		})
+		It(`Invoke GetNext until HasNext returns false`, func() {
+			adminrestService := newService()
+			pager, err := adminrestService.NewTopicsPager(adminrestService.NewListTopicsOptions().SetPerPage(10))
+			Expect(err).To(BeNil())
+			Expect(pager.TotalCount()).To(BeNil())
+
+			var pageSizes []int
+			for pager.HasNext() {
+				page, err := pager.GetNext()
+				Expect(err).To(BeNil())
+				pageSizes = append(pageSizes, len(page))
+			}
+			Expect(pageSizes).To(Equal([]int{10, 10, 5}))
+			Expect(*pager.TotalCount()).To(Equal(int64(25)))
+
+			_, err = pager.GetNext()
+			Expect(err).ToNot(BeNil())
+		})
+		It(`Invoke GetAll sequentially`, func() {
+			adminrestService := newService()
+			pager, err := adminrestService.NewTopicsPager(adminrestService.NewListTopicsOptions().SetPerPage(10))
+			Expect(err).To(BeNil())
+
+			allTopics, err := pager.GetAll()
+			Expect(err).To(BeNil())
+			Expect(allTopics).To(HaveLen(25))
+			Expect(requests).To(Equal(int32(3)))
+			Expect(maxInFlight).To(Equal(int32(1)))
+		})
+		It(`Invoke ListAllTopics with concurrent page requests`, func() {
+			adminrestService := newService()
+			options := adminrestService.NewListAllTopicsOptions().
+				SetPerPage(2).
+				SetMaxConcurrentRequests(3).
+				SetTopicFilter("topic-*").
+				SetHeaders(map[string]string{"x-custom-header": "x-custom-value"})
+
+			result, err := adminrestService.ListAllTopics(options)
+			Expect(err).To(BeNil())
+			Expect(result).ToNot(BeNil())
+			Expect(*result.TotalCount).To(Equal(int64(25)))
+			Expect(result.Topics).To(HaveLen(25))
+			Expect(topicNames(result.Topics)[0]).To(Equal("topic-0001"))
+			Expect(topicNames(result.Topics)[24]).To(Equal("topic-0025"))
+			Expect(requests).To(Equal(int32(13)))
+			Expect(maxInFlight).To(BeNumerically("<=", 3))
+			Expect(maxInFlight).To(BeNumerically(">", 1))
+		})
+		It(`Invoke ListAllTopics with a cancelled context`, func() {
+			adminrestService := newService()
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			result, err := adminrestService.ListAllTopicsWithContext(ctx, nil)
+			Expect(err).ToNot(BeNil())
+			Expect(result).To(BeNil())
+		})
+		AfterEach(func() {
+			testServer.Close()
+		})
+	})
+	Describe(`Paging when the service caps the page size`, func() {
+		var requests, inFlight, maxInFlight int32
+		BeforeEach(func() {
+			requests, inFlight, maxInFlight = 0, 0, 0
+			testServer = newPagingServer(23, 5, true, &requests, &inFlight, &maxInFlight)
+		})
+		It(`Invoke ListAllTopics successfully`, func() {
+			adminrestService := newService()
+			result, err := adminrestService.ListAllTopics(adminrestService.NewListAllTopicsOptions().SetPerPage(10))
+			Expect(err).To(BeNil())
+			Expect(result.Topics).To(HaveLen(23))
+			names := topicNames(result.Topics)
+			for i, name := range names {
+				Expect(name).To(Equal(fmt.Sprintf("topic-%04d", i+1)))
+			}
+		})
+		AfterEach(func() {
+			testServer.Close()
+		})
+	})
+	Describe(`Paging without the X-Total-Count header`, func() {
+		var requests, inFlight, maxInFlight int32
+		BeforeEach(func() {
+			requests, inFlight, maxInFlight = 0, 0, 0
+			testServer = newPagingServer(20, 0, false, &requests, &inFlight, &maxInFlight)
+		})
+		It(`Invoke ListAllTopics successfully`, func() {
+			adminrestService := newService()
+			result, err := adminrestService.ListAllTopics(adminrestService.NewListAllTopicsOptions().SetPerPage(10))
+			Expect(err).To(BeNil())
+			Expect(result.TotalCount).To(BeNil())
+			Expect(result.Topics).To(HaveLen(20))
+			// The third request returns an empty page, which ends the iteration.
+			Expect(requests).To(Equal(int32(3)))
+			Expect(maxInFlight).To(Equal(int32(1)))
+		})
+		AfterEach(func() {
+			testServer.Close()
+		})
+	})
+	Describe(`Paging with an invalid X-Total-Count header`, func() {
+		BeforeEach(func() {
+			testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+				res.Header().Set("Content-type", "application/json")
+				res.Header().Set("X-Total-Count", "lots")
+				res.WriteHeader(200)
+				fmt.Fprintf(res, "%s", `[{"name": "Name"}]`)
+			}))
+		})
+		It(`Invoke ListAllTopics with error`, func() {
+			adminrestService := newService()
+			result, err := adminrestService.ListAllTopics(nil)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("X-Total-Count"))
+			Expect(result).To(BeNil())
+		})
+		AfterEach(func() {
+			testServer.Close()
+		})
+	})
+	Describe(`Paging with an operation error`, func() {
+		BeforeEach(func() {
+			testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+				if req.URL.Query().Get("page") == "2" {
+					res.Header().Set("Content-type", "application/json")
+					res.WriteHeader(503)
+					fmt.Fprintf(res, "%s", `{"error_code": 50301, "message": "Unknown Kafka Error", "incident_id": "abc"}`)
+					return
+				}
+				res.Header().Set("Content-type", "application/json")
+				res.Header().Set("X-Total-Count", "30")
+				res.WriteHeader(200)
+				fmt.Fprintf(res, "%s", `[{"name": "a"}, {"name": "b"}, {"name": "c"}, {"name": "d"}, {"name": "e"}, {"name": "f"}, {"name": "g"}, {"name": "h"}, {"name": "i"}, {"name": "j"}]`)
+			}))
+		})
+		It(`Invoke ListAllTopics with error`, func() {
+			adminrestService := newService()
+			result, err := adminrestService.ListAllTopics(adminrestService.NewListAllTopicsOptions().SetPerPage(10))
+			Expect(err).ToNot(BeNil())
+			Expect(result).To(BeNil())
+		})
+		AfterEach(func() {
+			testServer.Close()
+		})
+	})
+	Describe(`TopicFilterMatcher(filter string)`, func() {
+		It(`Match names against wildcard and regular expression filters`, func() {
+			matches, err := TopicFilterMatcher("")
+			Expect(err).To(BeNil())
+			Expect(matches("orders")).To(BeTrue())
+
+			matches, err = TopicFilterMatcher("topic-*.v1")
+			Expect(err).To(BeNil())
+			Expect(matches("topic-orders.v1")).To(BeTrue())
+			Expect(matches("topic-orders-v1")).To(BeFalse())
+			Expect(matches("my-topic-orders.v1")).To(BeFalse())
+
+			matches, err = TopicFilterMatcher("/orders-[0-9]+/")
+			Expect(err).To(BeNil())
+			Expect(matches("orders-12")).To(BeTrue())
+			Expect(matches("orders-x")).To(BeFalse())
+
+			_, err = TopicFilterMatcher("/[/")
+			Expect(err).ToNot(BeNil())
+		})
+	})
+})
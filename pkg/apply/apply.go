@@ -0,0 +1,477 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package apply : Manage an Event Streams instance from a directory of YAML manifests
+//
+// Manifests describe the desired Topics, Quotas, MirroringSelection, Schemas and SchemaRules of an instance, one
+// YAML document for each resource:
+//
+//	kind: Topic
+//	name: orders
+//	partitions: 6
+//	configs:
+//	  retention.bytes: 1GiB
+//	---
+//	kind: Quota
+//	name: iam-ServiceId-1234
+//	producer_byte_rate: 10MiB/s
+//	---
+//	kind: MirroringSelection
+//	includes: ["orders.*"]
+//	---
+//	kind: Schema
+//	name: orders-value
+//	file: orders-value.avsc
+//	---
+//	kind: SchemaRule
+//	name: orders-value
+//	config: BACKWARD
+//
+// An Applier reads the live state through the list and get calls of AdminrestV1 and SchemaregistryV1, and plans the
+// changes that bring the instance to the desired state. A Plan can be printed as a diff for review and then applied.
+// Topics and quotas are planned and applied with the reconcile package.
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1/reconcile"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Options : The Applier options.
+type Options struct {
+	// When true, resources of the kinds that appear in the manifests are deleted if they are not in the manifests.
+	// Kinds without any manifest are never pruned, so a directory of topics leaves quotas and schemas alone. Schema
+	// rules are pruned from the schemas in the manifests only, and the global rule and the mirroring topic selection
+	// are never deleted.
+	Prune bool
+}
+
+// Applier : Plans and applies the changes described by manifests.
+type Applier struct {
+	admin   *adminrestv1.AdminrestV1
+	schemas *schemaregistryv1.SchemaregistryV1
+	options Options
+}
+
+// New : constructs an Applier. A client may be nil when the manifests have no resources it manages.
+func New(admin *adminrestv1.AdminrestV1, schemas *schemaregistryv1.SchemaregistryV1, options *Options) *Applier {
+	applier := &Applier{
+		admin:   admin,
+		schemas: schemas,
+	}
+	if options != nil {
+		applier.options = *options
+	}
+	return applier
+}
+
+// order returns the position of a change in a plan. Resources are created before the resources that depend on them
+// and deleted after: topics come first and the mirroring topic selection that refers to them later, and a schema
+// rule is set after its schema is created and before a new version is checked against it.
+func order(change *Change) int {
+	if change.Type == reconcile.ActionDelete {
+		switch change.Kind {
+		case KindSchemaRule:
+			return 10
+		case KindSchema:
+			return 11
+		case KindQuota:
+			return 12
+		}
+		return 13
+	}
+	switch change.Kind {
+	case KindTopic:
+		if change.Type == reconcile.ActionCreate {
+			return 1
+		}
+		return 2
+	case KindQuota:
+		return 3
+	case KindSchemaRule:
+		if change.Name == "" {
+			return 4
+		}
+		return 6
+	case KindSchema:
+		if change.Type == reconcile.ActionCreate {
+			return 5
+		}
+		return 7
+	}
+	return 8
+}
+
+// Plan compares the manifests with the live state and returns the changes needed to reconcile them. A
+// *reconcile.PlanError is returned if the manifests ask for changes that cannot be made.
+func (applier *Applier) Plan(ctx context.Context, manifests *Manifests) (*Plan, error) {
+	plan := &Plan{Changes: []Change{}}
+	planner := &planner{applier: applier, ctx: ctx, plan: plan}
+	for _, step := range []func(*Manifests) error{planner.topics, planner.quotas, planner.mirroring, planner.schemas} {
+		if err := step(manifests); err != nil {
+			return nil, err
+		}
+	}
+	if len(planner.problems) > 0 {
+		return nil, &reconcile.PlanError{Problems: planner.problems}
+	}
+	sort.SliceStable(plan.Changes, func(i, j int) bool { return order(&plan.Changes[i]) < order(&plan.Changes[j]) })
+	return plan, nil
+}
+
+// Apply makes the changes of a plan made by this Applier, in order. It stops at the first change that fails and
+// returns an error identifying that change.
+func (applier *Applier) Apply(ctx context.Context, plan *Plan) error {
+	if plan == nil {
+		return nil
+	}
+	for i := range plan.Changes {
+		change := &plan.Changes[i]
+		if change.action != nil {
+			// The reconcile package identifies the action in its error.
+			err := reconcile.New(applier.admin, nil).Apply(ctx, &reconcile.Plan{Actions: []reconcile.Action{*change.action}})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if err := applier.applyChange(ctx, change); err != nil {
+			return fmt.Errorf("%s %s: %w", change.Type, change.label(), err)
+		}
+	}
+	return nil
+}
+
+// applyChange makes a change to the mirroring topic selection, a schema or a schema rule.
+func (applier *Applier) applyChange(ctx context.Context, change *Change) (err error) {
+	const ruleType = schemaregistryv1.RuleTypeCompatibilityConst
+	schemas := applier.schemas
+	switch {
+	case change.Kind == KindMirroringSelection:
+		options := applier.admin.NewReplaceMirroringTopicSelectionOptions().SetIncludes(change.includes)
+		_, _, err = applier.admin.ReplaceMirroringTopicSelectionWithContext(ctx, options)
+	case change.Kind == KindSchema && change.Type == reconcile.ActionCreate:
+		_, _, err = schemas.CreateSchemaWithContext(ctx, schemas.NewCreateSchemaOptions().SetID(change.Name).SetSchema(change.schema))
+	case change.Kind == KindSchema && change.Type == reconcile.ActionUpdate:
+		_, _, err = schemas.CreateVersionWithContext(ctx, schemas.NewCreateVersionOptions(change.Name).SetSchema(change.schema))
+	case change.Kind == KindSchema && change.Type == reconcile.ActionDelete:
+		_, err = schemas.DeleteSchemaWithContext(ctx, schemas.NewDeleteSchemaOptions(change.Name))
+	case change.Kind == KindSchemaRule && change.Name == "":
+		_, _, err = schemas.UpdateGlobalRuleWithContext(ctx, schemas.NewUpdateGlobalRuleOptions(ruleType, ruleType, change.config))
+	case change.Kind == KindSchemaRule && change.Type == reconcile.ActionCreate:
+		_, _, err = schemas.CreateSchemaRuleWithContext(ctx, schemas.NewCreateSchemaRuleOptions(change.Name, ruleType, change.config))
+	case change.Kind == KindSchemaRule && change.Type == reconcile.ActionUpdate:
+		_, _, err = schemas.UpdateSchemaRuleWithContext(ctx, schemas.NewUpdateSchemaRuleOptions(change.Name, ruleType, ruleType, change.config))
+	case change.Kind == KindSchemaRule && change.Type == reconcile.ActionDelete:
+		_, err = schemas.DeleteSchemaRuleWithContext(ctx, schemas.NewDeleteSchemaRuleOptions(change.Name, ruleType))
+	default:
+		err = fmt.Errorf("unsupported change")
+	}
+	return
+}
+
+// planner builds a plan.
+type planner struct {
+	applier  *Applier
+	ctx      context.Context
+	plan     *Plan
+	problems []string
+}
+
+// add appends a change to the plan.
+func (planner *planner) add(change Change) {
+	planner.plan.Changes = append(planner.plan.Changes, change)
+}
+
+// adminClient returns the Admin REST API client, or an error naming the kind that needs it.
+func (planner *planner) adminClient(kind Kind) (*adminrestv1.AdminrestV1, error) {
+	if planner.applier.admin == nil {
+		return nil, fmt.Errorf("apply: %s manifests need an Admin REST API client", kind)
+	}
+	return planner.applier.admin, nil
+}
+
+// topics plans the changes to topics with the reconcile package.
+func (planner *planner) topics(manifests *Manifests) error {
+	if len(manifests.Topics) == 0 {
+		return nil
+	}
+	client, err := planner.adminClient(KindTopic)
+	if err != nil {
+		return err
+	}
+	var specs []reconcile.TopicSpec
+	for _, topic := range manifests.Topics {
+		spec := reconcile.TopicSpec{Name: topic.Name, Partitions: topic.Partitions}
+		typed, err := adminrestv1.ParseTopicConfigs(topic.Configs)
+		if err == nil {
+			spec.Configs, err = typed.Map()
+		}
+		if err != nil {
+			return err
+		}
+		specs = append(specs, spec)
+	}
+	actions, err := reconcile.New(client, &reconcile.Options{Prune: planner.applier.options.Prune}).Plan(planner.ctx, specs)
+	if err != nil {
+		return err
+	}
+	for i := range actions.Actions {
+		action := &actions.Actions[i]
+		change := Change{Kind: KindTopic, Type: action.Type, Name: action.Name, action: action}
+		if action.Partitions != nil {
+			change.Diff = append(change.Diff, valueDiff("partitions", strconv.FormatInt(action.Partitions.From, 10),
+				strconv.FormatInt(action.Partitions.To, 10), action.Type == reconcile.ActionCreate)...)
+		}
+		for _, config := range action.Configs {
+			from := ""
+			if config.From != nil {
+				from = *config.From
+			}
+			change.Diff = append(change.Diff, valueDiff(config.Name, from, config.To, from == "")...)
+		}
+		planner.add(change)
+	}
+	for _, config := range actions.Unverified {
+		planner.plan.Warnings = append(planner.plan.Warnings, fmt.Sprintf(
+			"topic %s: %s=%s is not reported by the Admin REST API, so it is not checked or changed",
+			config.Topic, config.Name, config.Value))
+	}
+	return nil
+}
+
+// quotas plans the changes to quotas with the reconcile package.
+func (planner *planner) quotas(manifests *Manifests) error {
+	if len(manifests.Quotas) == 0 {
+		return nil
+	}
+	client, err := planner.adminClient(KindQuota)
+	if err != nil {
+		return err
+	}
+	var desired []adminrestv1.EntityQuotaDetail
+	for _, quota := range manifests.Quotas {
+		detail := adminrestv1.EntityQuotaDetail{EntityName: core.StringPtr(quota.Name)}
+		if quota.ProducerByteRate != nil {
+			detail.ProducerByteRate = core.Int64Ptr(int64(*quota.ProducerByteRate))
+		}
+		if quota.ConsumerByteRate != nil {
+			detail.ConsumerByteRate = core.Int64Ptr(int64(*quota.ConsumerByteRate))
+		}
+		desired = append(desired, detail)
+	}
+	actions, err := reconcile.New(client, nil).PlanQuotas(planner.ctx, desired, planner.applier.options.Prune)
+	if err != nil {
+		return err
+	}
+	for i := range actions.Actions {
+		action := &actions.Actions[i]
+		change := Change{Kind: KindQuota, Type: action.Type, Name: action.Name, action: action}
+		for _, config := range action.Configs {
+			from := ""
+			if config.From != nil && *config.From != "unset" {
+				from = formatRate(*config.From)
+			}
+			change.Diff = append(change.Diff, valueDiff(config.Name, from, formatRate(config.To), from == "")...)
+		}
+		planner.add(change)
+	}
+	return nil
+}
+
+// formatRate returns a rate in bytes per second with units, or as it is if it is not a number.
+func formatRate(value string) string {
+	rate, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return value
+	}
+	return adminrestv1.ByteRate(rate).String()
+}
+
+// valueDiff returns the lines of a changed value, without the removed line when the old value is not known.
+func valueDiff(name string, from string, to string, unknown bool) []DiffLine {
+	if unknown {
+		return added(name + ": " + to)
+	}
+	return []DiffLine{{Op: DiffRemoved, Text: name + ": " + from}, {Op: DiffAdded, Text: name + ": " + to}}
+}
+
+// mirroring plans the change to the mirroring topic selection. The order of the patterns does not matter.
+func (planner *planner) mirroring(manifests *Manifests) error {
+	if manifests.Mirroring == nil {
+		return nil
+	}
+	client, err := planner.adminClient(KindMirroringSelection)
+	if err != nil {
+		return err
+	}
+	live, _, err := client.GetMirroringTopicSelectionWithContext(planner.ctx, client.NewGetMirroringTopicSelectionOptions())
+	if err != nil {
+		return fmt.Errorf("apply: getting the mirroring topic selection: %w", err)
+	}
+	desired := manifests.Mirroring.Includes
+	wanted := make(map[string]bool)
+	for _, pattern := range desired {
+		wanted[pattern] = true
+	}
+	existing := make(map[string]bool)
+	var diff []DiffLine
+	changed := false
+	for _, pattern := range live.Includes {
+		existing[pattern] = true
+		if wanted[pattern] {
+			diff = append(diff, DiffLine{Op: DiffContext, Text: pattern})
+		} else {
+			diff = append(diff, DiffLine{Op: DiffRemoved, Text: pattern})
+			changed = true
+		}
+	}
+	for _, pattern := range desired {
+		if !existing[pattern] {
+			diff = append(diff, DiffLine{Op: DiffAdded, Text: pattern})
+			existing[pattern] = true
+			changed = true
+		}
+	}
+	if changed {
+		planner.add(Change{Kind: KindMirroringSelection, Type: reconcile.ActionUpdate, Diff: diff,
+			includes: append([]string{}, desired...)})
+	}
+	return nil
+}
+
+// schemas plans the changes to schemas and schema rules.
+func (planner *planner) schemas(manifests *Manifests) error {
+	if len(manifests.Schemas) == 0 && len(manifests.SchemaRules) == 0 {
+		return nil
+	}
+	client := planner.applier.schemas
+	if client == nil {
+		return errors.New("apply: Schema and SchemaRule manifests need a schema registry client")
+	}
+	ctx := planner.ctx
+	ids, _, err := client.ListSchemasWithContext(ctx, client.NewListSchemasOptions())
+	if err != nil {
+		return fmt.Errorf("apply: listing schemas: %w", err)
+	}
+	live := make(map[string]bool)
+	for _, id := range ids {
+		live[id] = true
+	}
+
+	declared := make(map[string]bool)
+	for _, schema := range sortedSchemas(manifests.Schemas) {
+		declared[schema.Name] = true
+		if !live[schema.Name] {
+			planner.add(Change{Kind: KindSchema, Type: reconcile.ActionCreate, Name: schema.Name,
+				Diff: added(schemaLines(schema.Schema)...), schema: schema.Schema})
+			continue
+		}
+		latest, _, err := client.GetLatestSchemaWithContext(ctx, client.NewGetLatestSchemaOptions(schema.Name))
+		if err != nil {
+			return fmt.Errorf("apply: getting schema %s: %w", schema.Name, err)
+		}
+		if !reflect.DeepEqual(latest, schema.Schema) {
+			planner.add(Change{Kind: KindSchema, Type: reconcile.ActionUpdate, Name: schema.Name,
+				Diff: diffLines(schemaLines(latest), schemaLines(schema.Schema)), schema: schema.Schema})
+		}
+	}
+	pruneSchemas := planner.applier.options.Prune && len(manifests.Schemas) > 0
+	if pruneSchemas {
+		for _, id := range ids {
+			if !declared[id] {
+				planner.add(Change{Kind: KindSchema, Type: reconcile.ActionDelete, Name: id})
+			}
+		}
+	}
+
+	ruled := make(map[string]bool)
+	for _, rule := range manifests.SchemaRules {
+		ruled[rule.Name] = true
+		if rule.Name != "" && !live[rule.Name] && !declared[rule.Name] {
+			planner.problems = append(planner.problems, fmt.Sprintf("%s: schema %s does not exist", rule.source, rule.Name))
+			continue
+		}
+		current := ""
+		if rule.Name == "" {
+			globalRule, _, err := client.GetGlobalRuleWithContext(ctx, client.NewGetGlobalRuleOptions(schemaregistryv1.RuleTypeCompatibilityConst))
+			if err != nil {
+				return fmt.Errorf("apply: getting the global rule: %w", err)
+			}
+			current = core.StringNilMapper(globalRule.Config)
+		} else if live[rule.Name] {
+			if current, err = planner.schemaRule(rule.Name); err != nil {
+				return err
+			}
+		}
+		switch {
+		case current == rule.Config:
+		case current == "":
+			planner.add(Change{Kind: KindSchemaRule, Type: reconcile.ActionCreate, Name: rule.Name,
+				Diff: added("config: " + rule.Config), config: rule.Config})
+		default:
+			planner.add(Change{Kind: KindSchemaRule, Type: reconcile.ActionUpdate, Name: rule.Name,
+				Diff: valueDiff("config", current, rule.Config, false), config: rule.Config})
+		}
+	}
+	if planner.applier.options.Prune && len(manifests.SchemaRules) > 0 {
+		for _, schema := range sortedSchemas(manifests.Schemas) {
+			if ruled[schema.Name] || !live[schema.Name] {
+				continue
+			}
+			current, err := planner.schemaRule(schema.Name)
+			if err != nil {
+				return err
+			}
+			if current != "" {
+				planner.add(Change{Kind: KindSchemaRule, Type: reconcile.ActionDelete, Name: schema.Name,
+					Diff: []DiffLine{{Op: DiffRemoved, Text: "config: " + current}}})
+			}
+		}
+	}
+	return nil
+}
+
+// schemaRule returns the configuration of the rule of a schema, or "" if the schema has no rule of its own.
+func (planner *planner) schemaRule(id string) (string, error) {
+	client := planner.applier.schemas
+	options := client.NewGetSchemaRuleOptions(id, schemaregistryv1.RuleTypeCompatibilityConst)
+	rule, response, err := client.GetSchemaRuleWithContext(planner.ctx, options)
+	if response != nil && response.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("apply: getting the rule of schema %s: %w", id, err)
+	}
+	return core.StringNilMapper(rule.Config), nil
+}
+
+// sortedSchemas returns the schemas sorted by name.
+func sortedSchemas(schemas []Schema) []Schema {
+	sorted := append([]Schema{}, schemas...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
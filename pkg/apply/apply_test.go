@@ -0,0 +1,247 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apply
+
+import (
+	"context"
+	"strings"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	adminfake "github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1/fake"
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1/reconcile"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+	schemafake "github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Applier`, func() {
+	const orderV1 = `{"type": "record", "name": "Order", "fields": [{"name": "id", "type": "string"}]}`
+
+	var adminServer *adminfake.Server
+	var schemaServer *schemafake.Server
+	var admin *adminrestv1.AdminrestV1
+	var schemas *schemaregistryv1.SchemaregistryV1
+
+	BeforeEach(func() {
+		adminServer = adminfake.NewServer(&adminfake.ServerOptions{MirroringEnabled: true})
+		adminServer.AddTopic(adminfake.Topic{Name: "orders", Partitions: 3})
+		adminServer.AddTopic(adminfake.Topic{Name: "legacy"})
+		adminServer.SetMirroringTopicSelection([]string{"legacy", "orders.*"})
+		adminServer.SetQuota("default", adminfake.Quota{ProducerByteRate: 1048576})
+		adminServer.SetQuota("iam-ServiceId-old", adminfake.Quota{ConsumerByteRate: 1024})
+		schemaServer = schemafake.NewServer(nil)
+
+		var err error
+		admin, err = adminServer.NewClient()
+		Expect(err).To(BeNil())
+		schemas, err = schemaServer.NewClient()
+		Expect(err).To(BeNil())
+		_, _, err = schemas.CreateSchema(schemas.NewCreateSchemaOptions().SetID("orders-value").SetSchema(parseSchema(orderV1)))
+		Expect(err).To(BeNil())
+		_, _, err = schemas.CreateSchema(schemas.NewCreateSchemaOptions().SetID("legacy-value").SetSchema(parseSchema(orderV1)))
+		Expect(err).To(BeNil())
+		_, _, err = schemas.CreateSchemaRule(schemas.NewCreateSchemaRuleOptions("legacy-value", "COMPATIBILITY", "FULL"))
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		adminServer.Close()
+		schemaServer.Close()
+	})
+
+	manifests := func(text string) *Manifests {
+		manifests, err := Read(strings.NewReader(text), "manifests.yaml", ".")
+		Expect(err).To(BeNil())
+		return manifests
+	}
+
+	desired := `
+kind: Topic
+name: orders
+partitions: 6
+configs:
+  retention.ms: 604800000
+---
+kind: Topic
+name: audit
+configs:
+  retention.bytes: 1GiB
+---
+kind: Quota
+name: default
+producer_byte_rate: 2MiB/s
+consumer_byte_rate: 2MiB/s
+---
+kind: MirroringSelection
+includes: ["orders.*", "audit"]
+---
+kind: Schema
+name: orders-value
+schema:
+  type: record
+  name: Order
+  fields:
+    - {name: id, type: string}
+    - {name: total, type: double, default: 0}
+---
+kind: Schema
+name: audit-value
+schema: {type: string}
+---
+kind: Schema
+name: legacy-value
+schema: {type: record, name: Order, fields: [{name: id, type: string}]}
+---
+kind: SchemaRule
+config: BACKWARD
+---
+kind: SchemaRule
+name: audit-value
+config: FULL
+`
+
+	It(`Plan the changes in dependency order`, func() {
+		plan, err := New(admin, schemas, nil).Plan(context.Background(), manifests(desired))
+		Expect(err).To(BeNil())
+		Expect(plan.String()).To(Equal(`+ Topic audit
++     retention.bytes: 1073741824
+~ Topic orders
+-     partitions: 3
++     partitions: 6
+-     retention.ms: 86400000
++     retention.ms: 604800000
+~ Quota default
++     consumer_byte_rate: 2MiB/s
+-     producer_byte_rate: 1MiB/s
++     producer_byte_rate: 2MiB/s
+~ SchemaRule (global)
+-     config: NONE
++     config: BACKWARD
++ Schema audit-value
++     {
++       "type": "string"
++     }
++ SchemaRule audit-value
++     config: FULL
+~ Schema orders-value
+      {
+        "fields": [
+          {
+            "name": "id",
+            "type": "string"
++         },
++         {
++           "default": 0,
++           "name": "total",
++           "type": "double"
+          }
+        ],
+        "name": "Order",
+        "type": "record"
+      }
+~ MirroringSelection (instance)
+-     legacy
+      orders.*
++     audit
+`))
+		Expect(plan.Summary()).To(Equal("Plan: 3 to create, 5 to update, 0 to delete."))
+	})
+	It(`Prune the kinds in the manifests`, func() {
+		plan, err := New(admin, schemas, &Options{Prune: true}).Plan(context.Background(), manifests(desired))
+		Expect(err).To(BeNil())
+		var deletes []string
+		for _, change := range plan.Changes {
+			if change.Type == reconcile.ActionDelete {
+				deletes = append(deletes, change.header())
+			}
+		}
+		Expect(deletes).To(Equal([]string{
+			"- SchemaRule legacy-value",
+			"- Quota iam-ServiceId-old",
+			"- Topic legacy",
+		}))
+
+		plan, err = New(admin, nil, &Options{Prune: true}).Plan(context.Background(), manifests("kind: Topic\nname: orders\n"))
+		Expect(err).To(BeNil())
+		Expect(plan.String()).To(Equal("- Topic legacy\n"))
+	})
+	It(`Apply the plan`, func() {
+		applier := New(admin, schemas, &Options{Prune: true})
+		plan, err := applier.Plan(context.Background(), manifests(desired))
+		Expect(err).To(BeNil())
+		Expect(applier.Apply(context.Background(), plan)).To(Succeed())
+
+		Expect(adminServer.TopicNames()).To(Equal([]string{"audit", "orders"}))
+		orders, _ := adminServer.Topic("orders")
+		Expect(orders.Partitions).To(Equal(int64(6)))
+		Expect(adminServer.MirroringTopicSelection()).To(Equal([]string{"orders.*", "audit"}))
+		quota, _ := adminServer.Quota("default")
+		Expect(quota).To(Equal(adminfake.Quota{ProducerByteRate: 2097152, ConsumerByteRate: 2097152}))
+		_, ok := adminServer.Quota("iam-ServiceId-old")
+		Expect(ok).To(BeFalse())
+
+		Expect(schemaServer.SchemaIDs()).To(ConsistOf("audit-value", "legacy-value", "orders-value"))
+		Expect(schemaServer.Versions("orders-value")).To(HaveLen(2))
+		Expect(schemaServer.GlobalRule()).To(Equal("BACKWARD"))
+		rule, _ := schemaServer.SchemaRule("audit-value")
+		Expect(rule).To(Equal("FULL"))
+		_, ok = schemaServer.SchemaRule("legacy-value")
+		Expect(ok).To(BeFalse())
+
+		plan, err = applier.Plan(context.Background(), manifests(desired))
+		Expect(err).To(BeNil())
+		Expect(plan.IsEmpty()).To(BeTrue())
+		Expect(plan.String()).To(Equal("No changes.\n"))
+	})
+	It(`Warn about topic configs that cannot be checked`, func() {
+		applier := New(admin, nil, nil)
+		plan, err := applier.Plan(context.Background(), manifests(`
+kind: Topic
+name: orders
+configs:
+  max.message.bytes: 2MiB
+`))
+		Expect(err).To(BeNil())
+		Expect(plan.IsEmpty()).To(BeTrue())
+		Expect(plan.Warnings).To(Equal([]string{
+			"topic orders: max.message.bytes=2097152 is not reported by the Admin REST API, so it is not checked or changed",
+		}))
+		Expect(plan.String()).To(Equal("No changes.\n" +
+			"! topic orders: max.message.bytes=2097152 is not reported by the Admin REST API, so it is not checked or changed\n"))
+	})
+	It(`Stop at the first change that fails`, func() {
+		plan, err := New(admin, schemas, nil).Plan(context.Background(), manifests(desired))
+		Expect(err).To(BeNil())
+		schemaServer.SetGlobalRule("FULL")
+		adminServer.AddTopic(adminfake.Topic{Name: "orders", Partitions: 8})
+
+		err = New(admin, schemas, nil).Apply(context.Background(), plan)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(HavePrefix("update topic orders: "))
+		Expect(adminServer.MirroringTopicSelection()).To(Equal([]string{"legacy", "orders.*"}))
+	})
+	It(`Refuse manifests that need a missing client or schema`, func() {
+		_, err := New(admin, nil, nil).Plan(context.Background(), manifests("kind: SchemaRule\nconfig: FULL\n"))
+		Expect(err).To(MatchError("apply: Schema and SchemaRule manifests need a schema registry client"))
+
+		_, err = New(nil, schemas, nil).Plan(context.Background(), manifests("kind: Quota\nname: default\nproducer_byte_rate: 1MiB/s\n"))
+		Expect(err).To(MatchError("apply: Quota manifests need an Admin REST API client"))
+
+		_, err = New(admin, schemas, nil).Plan(context.Background(), manifests("kind: SchemaRule\nname: missing-value\nconfig: FULL\n"))
+		Expect(err).To(MatchError("cannot plan the requested changes: manifests.yaml#1: schema missing-value does not exist"))
+	})
+})
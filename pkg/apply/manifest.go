@@ -0,0 +1,409 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apply
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/compat"
+	"gopkg.in/yaml.v2"
+)
+
+// Kind : The kind of resource described by a manifest.
+type Kind string
+
+// The kinds of manifest.
+const (
+	KindTopic              Kind = "Topic"
+	KindQuota              Kind = "Quota"
+	KindMirroringSelection Kind = "MirroringSelection"
+	KindSchema             Kind = "Schema"
+	KindSchemaRule         Kind = "SchemaRule"
+)
+
+// Topic : The desired state of a topic.
+type Topic struct {
+	Kind Kind `yaml:"kind"`
+
+	// The name of the topic.
+	Name string `yaml:"name"`
+
+	// The number of partitions. Zero leaves the partition count unmanaged.
+	Partitions int64 `yaml:"partitions,omitempty"`
+
+	// The topic configs, keyed by config name. Byte sizes may have units, such as 1GiB. Configs that are not listed
+	// are left unchanged.
+	Configs map[string]string `yaml:"configs,omitempty"`
+
+	source string
+}
+
+// Quota : The desired quota of an entity.
+type Quota struct {
+	Kind Kind `yaml:"kind"`
+
+	// The entity the quota applies to: 'default' or an IAM service ID.
+	Name string `yaml:"name"`
+
+	// The producer byte rate, such as 1048576 or 1MiB/s. A rate that is not set is left unchanged.
+	ProducerByteRate *adminrestv1.ByteRate `yaml:"producer_byte_rate,omitempty"`
+
+	// The consumer byte rate, such as 1048576 or 1MiB/s. A rate that is not set is left unchanged.
+	ConsumerByteRate *adminrestv1.ByteRate `yaml:"consumer_byte_rate,omitempty"`
+
+	source string
+}
+
+// MirroringSelection : The desired mirroring topic selection. There can be only one.
+type MirroringSelection struct {
+	Kind Kind `yaml:"kind"`
+
+	// The patterns of the topics to mirror.
+	Includes []string `yaml:"includes"`
+
+	source string
+}
+
+// Schema : The desired latest version of a schema. When it differs from the latest version in the registry, a new
+// version is created.
+type Schema struct {
+	Kind Kind `yaml:"kind"`
+
+	// The ID of the schema.
+	Name string `yaml:"name"`
+
+	// The schema document, written in the manifest as YAML or JSON.
+	Schema map[string]interface{} `yaml:"schema,omitempty"`
+
+	// A file holding the JSON schema document, relative to the manifest, instead of Schema.
+	File string `yaml:"file,omitempty"`
+
+	source string
+}
+
+// SchemaRule : The desired COMPATIBILITY rule of a schema, or the global rule.
+type SchemaRule struct {
+	Kind Kind `yaml:"kind"`
+
+	// The ID of the schema, or empty for the global rule.
+	Name string `yaml:"name,omitempty"`
+
+	// The rule configuration, such as BACKWARD or FULL_TRANSITIVE.
+	Config string `yaml:"config"`
+
+	source string
+}
+
+// Manifests : The desired state read from a set of manifests.
+type Manifests struct {
+	Topics      []Topic
+	Quotas      []Quota
+	Mirroring   *MirroringSelection
+	Schemas     []Schema
+	SchemaRules []SchemaRule
+}
+
+// ManifestError : Returned when manifests cannot be read or do not describe a valid state.
+type ManifestError struct {
+	// A description of every problem found, starting with the file and document it was found in.
+	Problems []string
+}
+
+// Error implements the error interface.
+func (manifestError *ManifestError) Error() string {
+	return "invalid manifests: " + strings.Join(manifestError.Problems, "; ")
+}
+
+// LoadDir : reads every .yaml and .yml file in a directory, in name order. Subdirectories are not read.
+func LoadDir(dir string) (*Manifests, error) {
+	return LoadFiles(dir)
+}
+
+// LoadFiles : reads manifests from files, each of which may hold several YAML documents, and directories, whose
+// .yaml and .yml files are read in the order of their names. Schema files are read relative to the manifest that
+// names them.
+func LoadFiles(paths ...string) (*Manifests, error) {
+	loader := &loader{manifests: &Manifests{}}
+	for _, path := range paths {
+		files, err := manifestFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range files {
+			file, err := os.Open(name)
+			if err != nil {
+				return nil, err
+			}
+			loader.read(file, name, filepath.Dir(name))
+			file.Close()
+		}
+	}
+	return loader.result()
+}
+
+// manifestFiles returns the path when it is a file, or the manifest files in it when it is a directory.
+func manifestFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	entries, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		extension := filepath.Ext(entry.Name())
+		if !entry.IsDir() && (extension == ".yaml" || extension == ".yml") {
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Read : reads manifests from a stream of YAML documents. Schema files are read relative to dir.
+func Read(r io.Reader, source string, dir string) (*Manifests, error) {
+	loader := &loader{manifests: &Manifests{}}
+	loader.read(r, source, dir)
+	return loader.result()
+}
+
+// loader collects manifests and the problems found in them.
+type loader struct {
+	manifests *Manifests
+	problems  []string
+}
+
+// report records a problem.
+func (loader *loader) report(source string, format string, args ...interface{}) {
+	loader.problems = append(loader.problems, source+": "+fmt.Sprintf(format, args...))
+}
+
+// result returns the manifests, or a *ManifestError if problems were found.
+func (loader *loader) result() (*Manifests, error) {
+	loader.check()
+	if len(loader.problems) > 0 {
+		return nil, &ManifestError{Problems: loader.problems}
+	}
+	return loader.manifests, nil
+}
+
+// read decodes each document of a stream.
+func (loader *loader) read(r io.Reader, name string, dir string) {
+	decoder := yaml.NewDecoder(r)
+	for index := 1; ; index++ {
+		source := fmt.Sprintf("%s#%d", name, index)
+		var document interface{}
+		err := decoder.Decode(&document)
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			loader.report(source, "%s", err.Error())
+			return
+		}
+		if document == nil {
+			continue
+		}
+		// Each document is decoded twice: once to find its kind, and again strictly into the type of that kind.
+		buf, err := yaml.Marshal(document)
+		if err != nil {
+			loader.report(source, "%s", err.Error())
+			continue
+		}
+		var header struct {
+			Kind Kind `yaml:"kind"`
+		}
+		if err = yaml.Unmarshal(buf, &header); err != nil {
+			loader.report(source, "%s", err.Error())
+			continue
+		}
+		loader.add(buf, header.Kind, source, dir)
+	}
+}
+
+// add decodes a document of the kind.
+func (loader *loader) add(buf []byte, kind Kind, source string, dir string) {
+	var err error
+	switch kind {
+	case KindTopic:
+		topic := Topic{source: source}
+		if err = yaml.UnmarshalStrict(buf, &topic); err == nil {
+			loader.manifests.Topics = append(loader.manifests.Topics, topic)
+		}
+	case KindQuota:
+		quota := Quota{source: source}
+		if err = yaml.UnmarshalStrict(buf, &quota); err == nil {
+			loader.manifests.Quotas = append(loader.manifests.Quotas, quota)
+		}
+	case KindMirroringSelection:
+		selection := MirroringSelection{source: source}
+		if err = yaml.UnmarshalStrict(buf, &selection); err == nil {
+			if loader.manifests.Mirroring != nil {
+				loader.report(source, "there is already a MirroringSelection in %s", loader.manifests.Mirroring.source)
+				return
+			}
+			loader.manifests.Mirroring = &selection
+		}
+	case KindSchema:
+		var document struct {
+			Kind   Kind        `yaml:"kind"`
+			Name   string      `yaml:"name"`
+			Schema interface{} `yaml:"schema,omitempty"`
+			File   string      `yaml:"file,omitempty"`
+		}
+		if err = yaml.UnmarshalStrict(buf, &document); err == nil {
+			schema := Schema{Kind: document.Kind, Name: document.Name, File: document.File, source: source}
+			schema.Schema, err = loader.schemaDocument(document.Schema, document.File, dir)
+			if err == nil {
+				loader.manifests.Schemas = append(loader.manifests.Schemas, schema)
+			}
+		}
+	case KindSchemaRule:
+		rule := SchemaRule{source: source}
+		if err = yaml.UnmarshalStrict(buf, &rule); err == nil {
+			rule.Config = strings.ToUpper(rule.Config)
+			loader.manifests.SchemaRules = append(loader.manifests.SchemaRules, rule)
+		}
+	case "":
+		err = fmt.Errorf("no kind")
+	default:
+		err = fmt.Errorf("unknown kind %q, use %s, %s, %s, %s or %s", kind,
+			KindTopic, KindQuota, KindMirroringSelection, KindSchema, KindSchemaRule)
+	}
+	if err != nil {
+		loader.report(source, "%s", err.Error())
+	}
+}
+
+// schemaDocument returns the schema written in a manifest, or read from a file, as it would be decoded from JSON.
+func (loader *loader) schemaDocument(raw interface{}, file string, dir string) (map[string]interface{}, error) {
+	var buf []byte
+	var err error
+	switch {
+	case raw != nil && file != "":
+		return nil, fmt.Errorf("set either schema or file, not both")
+	case raw != nil:
+		buf, err = json.Marshal(jsonValue(raw))
+	case file != "":
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(dir, file)
+		}
+		buf, err = ioutil.ReadFile(file)
+	default:
+		return nil, fmt.Errorf("set schema or file")
+	}
+	if err != nil {
+		return nil, err
+	}
+	var schema map[string]interface{}
+	if err = json.NewDecoder(bytes.NewReader(buf)).Decode(&schema); err != nil {
+		return nil, fmt.Errorf("the schema is not a JSON object: %w", err)
+	}
+	return schema, nil
+}
+
+// jsonValue converts a value decoded from YAML, whose mappings have keys of any type, into one that can be encoded as
+// JSON.
+func jsonValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			result[fmt.Sprint(key)] = jsonValue(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, item := range value {
+			result[i] = jsonValue(item)
+		}
+		return result
+	}
+	return value
+}
+
+// check reports manifests that are incomplete or listed more than once.
+func (loader *loader) check() {
+	manifests := loader.manifests
+	topics := make(map[string]string)
+	for _, topic := range manifests.Topics {
+		loader.checkName(topics, KindTopic, topic.Name, topic.source)
+		if topic.Partitions < 0 {
+			loader.report(topic.source, "topic %s has a negative partition count", topic.Name)
+		}
+		if _, err := adminrestv1.ParseTopicConfigs(topic.Configs); err != nil {
+			loader.report(topic.source, "topic %s: %s", topic.Name, err.Error())
+		}
+	}
+	quotas := make(map[string]string)
+	for _, quota := range manifests.Quotas {
+		loader.checkName(quotas, KindQuota, quota.Name, quota.source)
+		if quota.ProducerByteRate == nil && quota.ConsumerByteRate == nil {
+			loader.report(quota.source, "quota %s sets neither producer_byte_rate nor consumer_byte_rate", quota.Name)
+		}
+	}
+	if manifests.Mirroring != nil {
+		if err := adminrestv1.ValidateMirroringPatterns(manifests.Mirroring.Includes); err != nil {
+			loader.report(manifests.Mirroring.source, "%s", err.Error())
+		}
+	}
+	schemas := make(map[string]string)
+	for _, schema := range manifests.Schemas {
+		loader.checkName(schemas, KindSchema, schema.Name, schema.source)
+	}
+	rules := make(map[string]string)
+	for _, rule := range manifests.SchemaRules {
+		name := rule.Name
+		if name == "" {
+			name = "(global)"
+		}
+		if source, ok := rules[name]; ok {
+			loader.report(rule.source, "the SchemaRule of %s is already in %s", name, source)
+		}
+		rules[name] = rule.source
+		if !compat.Mode(rule.Config).Valid() {
+			loader.report(rule.source, "invalid rule config %q", rule.Config)
+		}
+	}
+}
+
+// checkName reports a manifest without a name, or with the name of an earlier manifest of the same kind.
+func (loader *loader) checkName(seen map[string]string, kind Kind, name string, source string) {
+	if name == "" {
+		loader.report(source, "the %s has no name", kind)
+		return
+	}
+	if earlier, ok := seen[name]; ok {
+		loader.report(source, "%s %s is already in %s", kind, name, earlier)
+		return
+	}
+	seen[name] = source
+}
@@ -0,0 +1,188 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apply
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Manifests`, func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "manifests")
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	// writeFile writes a file in the directory.
+	writeFile := func(name string, contents string) {
+		Expect(ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0600)).To(Succeed())
+	}
+
+	It(`Load every kind from a directory`, func() {
+		writeFile("topics.yaml", `
+kind: Topic
+name: orders
+partitions: 6
+configs:
+  retention.bytes: 1GiB
+  retention.ms: 604800000
+---
+kind: Topic
+name: payments
+`)
+		writeFile("access.yml", `
+kind: Quota
+name: iam-ServiceId-1234
+producer_byte_rate: 10MiB/s
+consumer_byte_rate: 1048576
+---
+kind: MirroringSelection
+includes: ["orders.*"]
+`)
+		writeFile("schemas.yaml", `
+kind: Schema
+name: orders-value
+file: order.avsc
+---
+kind: Schema
+name: payments-value
+schema:
+  type: record
+  name: Payment
+  fields:
+    - {name: amount, type: double}
+---
+kind: SchemaRule
+config: backward
+---
+kind: SchemaRule
+name: orders-value
+config: FULL
+`)
+		writeFile("order.avsc", `{"type": "record", "name": "Order", "fields": [{"name": "id", "type": "string"}]}`)
+		writeFile("README.md", "not a manifest")
+
+		manifests, err := LoadDir(dir)
+		Expect(err).To(BeNil())
+		Expect(manifests.Topics).To(HaveLen(2))
+		Expect(manifests.Topics[0].Name).To(Equal("orders"))
+		Expect(manifests.Topics[0].Configs).To(Equal(map[string]string{"retention.bytes": "1GiB", "retention.ms": "604800000"}))
+		Expect(manifests.Quotas).To(HaveLen(1))
+		Expect(*manifests.Quotas[0].ProducerByteRate).To(Equal(adminrestv1.ByteRate(10485760)))
+		Expect(*manifests.Quotas[0].ConsumerByteRate).To(Equal(adminrestv1.ByteRate(1048576)))
+		Expect(manifests.Mirroring.Includes).To(Equal([]string{"orders.*"}))
+		Expect(manifests.Schemas).To(HaveLen(2))
+		Expect(manifests.Schemas[0].Schema["name"]).To(Equal("Order"))
+		Expect(manifests.Schemas[1].Schema).To(Equal(map[string]interface{}{
+			"type":   "record",
+			"name":   "Payment",
+			"fields": []interface{}{map[string]interface{}{"name": "amount", "type": "double"}},
+		}))
+		Expect(manifests.SchemaRules).To(Equal([]SchemaRule{
+			{Kind: KindSchemaRule, Config: "BACKWARD", source: filepath.Join(dir, "schemas.yaml") + "#3"},
+			{Kind: KindSchemaRule, Name: "orders-value", Config: "FULL", source: filepath.Join(dir, "schemas.yaml") + "#4"},
+		}))
+	})
+	It(`Report every problem with its document`, func() {
+		_, err := Read(strings.NewReader(`
+kind: Topic
+partitions: 3
+---
+kind: Topic
+name: orders
+configs:
+  retention.bytes: 10M
+---
+kind: Topic
+name: orders
+partitons: 3
+---
+kind: Quota
+name: default
+---
+kind: Topc
+name: audit
+---
+name: audit
+---
+kind: MirroringSelection
+includes: ["orders["]
+---
+kind: Schema
+name: orders-value
+---
+kind: SchemaRule
+config: LOOSE
+`), "manifests.yaml", dir)
+		Expect(err).To(BeAssignableToTypeOf(&ManifestError{}))
+		problems := err.(*ManifestError).Problems
+		Expect(problems).To(HaveLen(9))
+		Expect(problems[0]).To(Equal("manifests.yaml#3: yaml: unmarshal errors:\n  line 3: field partitons not found in type apply.Topic"))
+		Expect(problems[1:4]).To(Equal([]string{
+			`manifests.yaml#5: unknown kind "Topc", use Topic, Quota, MirroringSelection, Schema or SchemaRule`,
+			`manifests.yaml#6: no kind`,
+			`manifests.yaml#8: set schema or file`,
+		}))
+		Expect(problems[4]).To(Equal("manifests.yaml#1: the Topic has no name"))
+		Expect(problems[5]).To(HavePrefix("manifests.yaml#2: topic orders: "))
+		Expect(problems[5]).To(ContainSubstring("retention.bytes"))
+		Expect(problems[6]).To(Equal("manifests.yaml#4: quota default sets neither producer_byte_rate nor consumer_byte_rate"))
+		Expect(problems[7]).To(HavePrefix("manifests.yaml#7: "))
+		Expect(problems[8]).To(Equal(`manifests.yaml#9: invalid rule config "LOOSE"`))
+	})
+	It(`Refuse duplicates`, func() {
+		_, err := Read(strings.NewReader(`
+kind: Topic
+name: orders
+---
+kind: Topic
+name: orders
+---
+kind: MirroringSelection
+includes: []
+---
+kind: MirroringSelection
+includes: []
+---
+kind: SchemaRule
+config: NONE
+---
+kind: SchemaRule
+config: FULL
+`), "manifests.yaml", dir)
+		Expect(err).To(MatchError("invalid manifests: " +
+			"manifests.yaml#4: there is already a MirroringSelection in manifests.yaml#3; " +
+			"manifests.yaml#2: Topic orders is already in manifests.yaml#1; " +
+			"manifests.yaml#6: the SchemaRule of (global) is already in manifests.yaml#5"))
+	})
+	It(`Refuse a schema with both a document and a file`, func() {
+		_, err := Read(strings.NewReader("kind: Schema\nname: a\nfile: a.avsc\nschema: {type: string}\n"), "a.yaml", dir)
+		Expect(err).To(MatchError("invalid manifests: a.yaml#1: set either schema or file, not both"))
+	})
+})
@@ -0,0 +1,250 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apply
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1/reconcile"
+)
+
+// Kinds of DiffLine.
+const (
+	DiffContext = " "
+	DiffRemoved = "-"
+	DiffAdded   = "+"
+)
+
+// DiffLine : A line of the difference between the live and the desired state of a resource.
+type DiffLine struct {
+	// DiffContext for a line that stays, DiffRemoved for a line of the live state and DiffAdded for a line of the
+	// desired state.
+	Op string `json:"op"`
+
+	// The line, such as 'partitions: 3'.
+	Text string `json:"text"`
+}
+
+// Change : A change to a single resource.
+type Change struct {
+	// The kind of resource.
+	Kind Kind `json:"kind"`
+
+	// Whether the resource is created, updated or deleted. Updating a schema creates a new version.
+	Type reconcile.ActionType `json:"type"`
+
+	// The name of the resource. Empty for the mirroring topic selection and the global schema rule.
+	Name string `json:"name,omitempty"`
+
+	// The difference between the live and the desired state.
+	Diff []DiffLine `json:"diff,omitempty"`
+
+	// The action for a topic or quota.
+	action *reconcile.Action
+
+	// The patterns of a mirroring topic selection.
+	includes []string
+
+	// The document of a schema.
+	schema map[string]interface{}
+
+	// The configuration of a schema rule.
+	config string
+}
+
+// label returns the resource the change applies to, as used in messages.
+func (change *Change) label() string {
+	switch change.Kind {
+	case KindMirroringSelection:
+		return "mirroring topic selection"
+	case KindSchemaRule:
+		if change.Name == "" {
+			return "global schema rule"
+		}
+		return "schema rule " + change.Name
+	}
+	return strings.ToLower(string(change.Kind)) + " " + change.Name
+}
+
+// header returns the first line of the change in a diff.
+func (change *Change) header() string {
+	symbol := "~"
+	switch change.Type {
+	case reconcile.ActionCreate:
+		symbol = DiffAdded
+	case reconcile.ActionDelete:
+		symbol = DiffRemoved
+	}
+	name := change.Name
+	if name == "" {
+		name = "(global)"
+		if change.Kind == KindMirroringSelection {
+			name = "(instance)"
+		}
+	}
+	return fmt.Sprintf("%s %s %s", symbol, change.Kind, name)
+}
+
+// Plan : The changes that bring an instance to the state described by a set of manifests, in the order they are
+// applied. A Plan can be printed or encoded as JSON for review, but only the Applier that made it can apply it.
+type Plan struct {
+	Changes []Change `json:"changes"`
+
+	// Desired state that the plan cannot check, such as topic configs that the Admin REST API does not report. The
+	// Applier does not change it.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// IsEmpty returns true if the plan contains no changes.
+func (plan *Plan) IsEmpty() bool {
+	return plan == nil || len(plan.Changes) == 0
+}
+
+// Summary returns the number of creates, updates and deletes in the plan.
+func (plan *Plan) Summary() string {
+	counts := make(map[reconcile.ActionType]int)
+	if plan != nil {
+		for _, change := range plan.Changes {
+			counts[change.Type]++
+		}
+	}
+	return fmt.Sprintf("Plan: %d to create, %d to update, %d to delete.", counts[reconcile.ActionCreate],
+		counts[reconcile.ActionUpdate], counts[reconcile.ActionDelete])
+}
+
+// ANSI escape sequences used by WriteDiff.
+const (
+	colorReset  = "\x1b[0m"
+	colorBold   = "\x1b[1m"
+	colorRed    = "\x1b[31m"
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+)
+
+// WriteDiff writes each change as a header line followed by the lines of its diff, and then the warnings. When color
+// is true, added lines are green, removed lines red, and the headers of updates and the warnings yellow.
+func (plan *Plan) WriteDiff(w io.Writer, color bool) error {
+	paint := func(code string, text string) string {
+		if !color || code == "" {
+			return text
+		}
+		return code + text + colorReset
+	}
+	var builder strings.Builder
+	if plan.IsEmpty() {
+		builder.WriteString("No changes.\n")
+	}
+	for _, change := range plan.Changes {
+		headerColor := colorYellow
+		switch change.Type {
+		case reconcile.ActionCreate:
+			headerColor = colorGreen
+		case reconcile.ActionDelete:
+			headerColor = colorRed
+		}
+		builder.WriteString(paint(colorBold+headerColor, change.header()) + "\n")
+		for _, line := range change.Diff {
+			lineColor := ""
+			switch line.Op {
+			case DiffAdded:
+				lineColor = colorGreen
+			case DiffRemoved:
+				lineColor = colorRed
+			}
+			builder.WriteString(paint(lineColor, line.Op+"     "+line.Text) + "\n")
+		}
+	}
+	if plan != nil {
+		for _, warning := range plan.Warnings {
+			builder.WriteString(paint(colorYellow, "! "+warning) + "\n")
+		}
+	}
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+// String returns the diff of the plan without color.
+func (plan *Plan) String() string {
+	var builder strings.Builder
+	_ = plan.WriteDiff(&builder, false)
+	return builder.String()
+}
+
+// added returns diff lines that add each of the texts.
+func added(texts ...string) []DiffLine {
+	lines := make([]DiffLine, len(texts))
+	for i, text := range texts {
+		lines[i] = DiffLine{Op: DiffAdded, Text: text}
+	}
+	return lines
+}
+
+// diffLines returns the difference between two lists of lines, using their longest common subsequence.
+func diffLines(from []string, to []string) []DiffLine {
+	// common[i][j] is the length of the longest common subsequence of from[i:] and to[j:].
+	common := make([][]int, len(from)+1)
+	for i := range common {
+		common[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else if common[i+1][j] >= common[i][j+1] {
+				common[i][j] = common[i+1][j]
+			} else {
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+
+	var lines []DiffLine
+	i, j := 0, 0
+	for i < len(from) && j < len(to) {
+		switch {
+		case from[i] == to[j]:
+			lines = append(lines, DiffLine{Op: DiffContext, Text: from[i]})
+			i++
+			j++
+		case common[i+1][j] >= common[i][j+1]:
+			lines = append(lines, DiffLine{Op: DiffRemoved, Text: from[i]})
+			i++
+		default:
+			lines = append(lines, DiffLine{Op: DiffAdded, Text: to[j]})
+			j++
+		}
+	}
+	for ; i < len(from); i++ {
+		lines = append(lines, DiffLine{Op: DiffRemoved, Text: from[i]})
+	}
+	for ; j < len(to); j++ {
+		lines = append(lines, DiffLine{Op: DiffAdded, Text: to[j]})
+	}
+	return lines
+}
+
+// schemaLines returns a schema document as indented JSON, one line to an element.
+func schemaLines(schema map[string]interface{}) []string {
+	buf, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return []string{err.Error()}
+	}
+	return strings.Split(string(buf), "\n")
+}
@@ -0,0 +1,69 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apply
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1/reconcile"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// parseSchema decodes a JSON schema document.
+func parseSchema(text string) map[string]interface{} {
+	var schema map[string]interface{}
+	Expect(json.Unmarshal([]byte(text), &schema)).To(Succeed())
+	return schema
+}
+
+var _ = Describe(`Plan`, func() {
+	It(`Diff lines by their longest common subsequence`, func() {
+		Expect(diffLines([]string{"a", "b", "c", "d"}, []string{"a", "c", "e", "d", "f"})).To(Equal([]DiffLine{
+			{Op: DiffContext, Text: "a"},
+			{Op: DiffRemoved, Text: "b"},
+			{Op: DiffContext, Text: "c"},
+			{Op: DiffAdded, Text: "e"},
+			{Op: DiffContext, Text: "d"},
+			{Op: DiffAdded, Text: "f"},
+		}))
+		Expect(diffLines(nil, []string{"a"})).To(Equal([]DiffLine{{Op: DiffAdded, Text: "a"}}))
+		Expect(diffLines([]string{"a"}, nil)).To(Equal([]DiffLine{{Op: DiffRemoved, Text: "a"}}))
+	})
+	It(`Write a colored diff`, func() {
+		plan := &Plan{Changes: []Change{
+			{Kind: KindTopic, Type: reconcile.ActionCreate, Name: "audit", Diff: added("partitions: 3")},
+			{Kind: KindSchemaRule, Type: reconcile.ActionUpdate, Diff: valueDiff("config", "NONE", "FULL", false)},
+			{Kind: KindSchema, Type: reconcile.ActionDelete, Name: "legacy-value"},
+		}}
+		var builder strings.Builder
+		Expect(plan.WriteDiff(&builder, true)).To(Succeed())
+		Expect(builder.String()).To(Equal(
+			"\x1b[1m\x1b[32m+ Topic audit\x1b[0m\n" +
+				"\x1b[32m+     partitions: 3\x1b[0m\n" +
+				"\x1b[1m\x1b[33m~ SchemaRule (global)\x1b[0m\n" +
+				"\x1b[31m-     config: NONE\x1b[0m\n" +
+				"\x1b[32m+     config: FULL\x1b[0m\n" +
+				"\x1b[1m\x1b[31m- Schema legacy-value\x1b[0m\n"))
+		Expect(plan.Summary()).To(Equal("Plan: 1 to create, 1 to update, 1 to delete."))
+
+		buf, err := json.Marshal(plan.Changes[1])
+		Expect(err).To(BeNil())
+		Expect(string(buf)).To(Equal(`{"kind":"SchemaRule","type":"update","diff":[{"op":"-","text":"config: NONE"},{"op":"+","text":"config: FULL"}]}`))
+	})
+})
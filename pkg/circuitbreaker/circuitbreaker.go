@@ -0,0 +1,270 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package circuitbreaker : A circuit breaker for the Event Streams service clients
+//
+// A Breaker stops the requests of AdminrestV1 and SchemaregistryV1 clients through the middleware it returns from
+// Middleware while the service is failing, so that callers fail fast with ErrCircuitOpen instead of waiting on
+// requests that will not succeed. The breaker is closed to begin with. It opens after FailureThreshold consecutive
+// requests fail with a 5xx status code or without a response. Once OpenTimeout has passed it is half open, and lets
+// one probe through: the Probe function when one is set, such as the ListTopicsProbe of a client, or else the next
+// request. The breaker closes when the probe succeeds and opens again when it fails.
+//
+//	breaker := circuitbreaker.New(&circuitbreaker.Options{
+//		FailureThreshold: 5,
+//		OpenTimeout:      30 * time.Second,
+//		Probe:            circuitbreaker.ListTopicsProbe(serviceAPI),
+//		OnStateChange: func(from, to circuitbreaker.State) {
+//			log.Printf("Event Streams circuit breaker %s -> %s", from, to)
+//		},
+//	})
+//	serviceAPI.Use(breaker.Middleware())
+//
+// A request whose Context reaches its deadline before a response arrives counts as a failure, so that callers with
+// timeouts open the breaker when the service hangs. Requests that fail because their Context is cancelled, and
+// responses with other status codes such as 404 or 429, do not count as failures.
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/middleware"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// ErrCircuitOpen is returned, without sending the request, while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("the circuit breaker is open")
+
+// State : The state of a circuit breaker.
+type State int
+
+// The states of a circuit breaker.
+const (
+	// Requests are sent.
+	StateClosed State = iota
+
+	// Requests fail with ErrCircuitOpen.
+	StateOpen
+
+	// A probe is sent, and other requests fail with ErrCircuitOpen.
+	StateHalfOpen
+)
+
+func (state State) String() string {
+	switch state {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// Probe : A cheap request that shows whether the service is available again.
+type Probe func(ctx context.Context) (*core.DetailedResponse, error)
+
+// ListTopicsProbe : returns a Probe that lists one topic with the client.
+func ListTopicsProbe(client adminrestv1.API) Probe {
+	return func(ctx context.Context) (*core.DetailedResponse, error) {
+		_, response, err := client.ListTopicsWithContext(ctx, &adminrestv1.ListTopicsOptions{PerPage: core.Int64Ptr(1)})
+		return response, err
+	}
+}
+
+// ListQuotasProbe : returns a Probe that lists the quotas with the client.
+func ListQuotasProbe(client adminrestv1.API) Probe {
+	return func(ctx context.Context) (*core.DetailedResponse, error) {
+		_, response, err := client.ListQuotasWithContext(ctx, &adminrestv1.ListQuotasOptions{})
+		return response, err
+	}
+}
+
+// Options : The options of a Breaker.
+type Options struct {
+	// The number of consecutive failed requests that open the breaker. Defaults to 5.
+	FailureThreshold int
+
+	// The time the breaker stays open before it lets a probe through. Defaults to 30 seconds.
+	OpenTimeout time.Duration
+
+	// The request sent to find out whether the service is available again, with the Context of the request that
+	// triggered it. When nil, that request is the probe.
+	Probe Probe
+
+	// Called after the breaker changes state.
+	OnStateChange func(from State, to State)
+}
+
+// Breaker : A circuit breaker for the requests made by service clients.
+type Breaker struct {
+	failureThreshold int
+	openTimeout      time.Duration
+	probe            Probe
+	onStateChange    func(from State, to State)
+
+	mutex    sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+}
+
+// outcome : How a request counts towards the state of the breaker.
+type outcome int
+
+const (
+	succeeded outcome = iota
+	failed
+	ignored
+)
+
+// probeKey is the Context key that marks the requests of a Probe, which the breaker lets through.
+type probeKey struct{}
+
+// New : constructs a closed Breaker.
+func New(options *Options) *Breaker {
+	if options == nil {
+		options = &Options{}
+	}
+	breaker := &Breaker{
+		failureThreshold: options.FailureThreshold,
+		openTimeout:      options.OpenTimeout,
+		probe:            options.Probe,
+		onStateChange:    options.OnStateChange,
+	}
+	if breaker.failureThreshold < 1 {
+		breaker.failureThreshold = 5
+	}
+	if breaker.openTimeout <= 0 {
+		breaker.openTimeout = 30 * time.Second
+	}
+	return breaker
+}
+
+// State : returns the state of the breaker.
+func (breaker *Breaker) State() State {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+	return breaker.state
+}
+
+// Middleware : returns a middleware that sends calls while the breaker allows it and counts their failures.
+func (breaker *Breaker) Middleware() middleware.Middleware {
+	return func(next middleware.Handler) middleware.Handler {
+		return func(call *middleware.Call) (*core.DetailedResponse, error) {
+			ctx := call.Request.Context()
+			if ctx.Value(probeKey{}) != nil {
+				return next(call)
+			}
+			isProbe, err := breaker.allow(ctx)
+			if err != nil {
+				return nil, err
+			}
+			response, err := next(call)
+			breaker.record(isProbe, outcomeOf(ctx, response, err))
+			return response, err
+		}
+	}
+}
+
+// allow returns whether a request may be sent, and whether it is the probe of a half-open breaker.
+func (breaker *Breaker) allow(ctx context.Context) (isProbe bool, err error) {
+	breaker.mutex.Lock()
+	switch {
+	case breaker.state == StateClosed:
+		breaker.mutex.Unlock()
+		return false, nil
+	case breaker.state == StateHalfOpen, time.Since(breaker.openedAt) < breaker.openTimeout:
+		breaker.mutex.Unlock()
+		return false, ErrCircuitOpen
+	}
+	notify := breaker.setState(StateHalfOpen)
+	breaker.mutex.Unlock()
+	notify()
+	if breaker.probe == nil {
+		return true, nil
+	}
+
+	response, err := breaker.probe(context.WithValue(ctx, probeKey{}, true))
+	result := outcomeOf(ctx, response, err)
+	breaker.record(true, result)
+	if result != succeeded {
+		return false, ErrCircuitOpen
+	}
+	return false, nil
+}
+
+// record counts the outcome of a request.
+func (breaker *Breaker) record(isProbe bool, result outcome) {
+	breaker.mutex.Lock()
+	notify := func() {}
+	switch {
+	case isProbe && result == succeeded:
+		breaker.failures = 0
+		notify = breaker.setState(StateClosed)
+	case isProbe && result == failed:
+		notify = breaker.open()
+	case isProbe:
+		// The probe was canceled by its caller. The open time is kept, so the next request probes again.
+		notify = breaker.setState(StateOpen)
+	case breaker.state != StateClosed, result == ignored:
+	case result == succeeded:
+		breaker.failures = 0
+	default:
+		breaker.failures++
+		if breaker.failures >= breaker.failureThreshold {
+			notify = breaker.open()
+		}
+	}
+	breaker.mutex.Unlock()
+	notify()
+}
+
+// open opens the breaker. It is called with the mutex locked.
+func (breaker *Breaker) open() (notify func()) {
+	breaker.failures = 0
+	breaker.openedAt = time.Now()
+	return breaker.setState(StateOpen)
+}
+
+// setState changes the state of the breaker, and returns a function that reports the change once the mutex is
+// unlocked. It is called with the mutex locked.
+func (breaker *Breaker) setState(state State) (notify func()) {
+	from := breaker.state
+	breaker.state = state
+	if from == state || breaker.onStateChange == nil {
+		return func() {}
+	}
+	return func() { breaker.onStateChange(from, state) }
+}
+
+// outcomeOf returns how a request with the response and error counts.
+func outcomeOf(ctx context.Context, response *core.DetailedResponse, err error) outcome {
+	switch {
+	case response != nil && response.StatusCode >= 500:
+		return failed
+	case err == nil, response != nil:
+		return succeeded
+	case errors.Is(ctx.Err(), context.Canceled):
+		return ignored
+	}
+	return failed
+}
@@ -0,0 +1,232 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package circuitbreaker_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/circuitbreaker"
+	"github.com/IBM/eventstreams-go-sdk/pkg/middleware"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Circuit breaker`, func() {
+	var statusCode int
+	var sent int
+	var changes []string
+	var breaker *circuitbreaker.Breaker
+	var handler middleware.Handler
+
+	// call sends a call through the breaker with the context.
+	call := func(ctx context.Context) error {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost/", nil)
+		Expect(err).To(BeNil())
+		_, err = handler(&middleware.Call{Service: "adminrest", Operation: "ListTopics", Request: request})
+		return err
+	}
+
+	// newBreaker sets up the breaker and a handler that answers with statusCode, or fails without a response when it
+	// is 0.
+	newBreaker := func(options *circuitbreaker.Options) {
+		options.OnStateChange = func(from, to circuitbreaker.State) {
+			changes = append(changes, from.String()+" -> "+to.String())
+		}
+		breaker = circuitbreaker.New(options)
+		handler = middleware.Chain(func(call *middleware.Call) (*core.DetailedResponse, error) {
+			sent++
+			if statusCode == 0 {
+				return nil, errors.New("connection refused")
+			}
+			response := &core.DetailedResponse{StatusCode: statusCode}
+			if statusCode >= 400 {
+				return response, errors.New(http.StatusText(statusCode))
+			}
+			return response, nil
+		}, breaker.Middleware())
+	}
+
+	BeforeEach(func() {
+		statusCode, sent, changes = http.StatusOK, 0, nil
+	})
+
+	It(`Open after consecutive 5xx and transport failures and fail fast`, func() {
+		newBreaker(&circuitbreaker.Options{FailureThreshold: 3, OpenTimeout: time.Hour})
+
+		statusCode = http.StatusServiceUnavailable
+		Expect(call(context.Background())).ToNot(Succeed())
+		Expect(call(context.Background())).ToNot(Succeed())
+		statusCode = http.StatusNotFound
+		Expect(call(context.Background())).ToNot(Succeed())
+		Expect(breaker.State()).To(Equal(circuitbreaker.StateClosed))
+
+		statusCode = http.StatusInternalServerError
+		Expect(call(context.Background())).ToNot(Succeed())
+		statusCode = 0
+		Expect(call(context.Background())).ToNot(Succeed())
+		Expect(breaker.State()).To(Equal(circuitbreaker.StateClosed))
+		Expect(call(context.Background())).ToNot(Succeed())
+		Expect(breaker.State()).To(Equal(circuitbreaker.StateOpen))
+		Expect(sent).To(Equal(6))
+
+		statusCode = http.StatusOK
+		Expect(call(context.Background())).To(MatchError(circuitbreaker.ErrCircuitOpen))
+		Expect(sent).To(Equal(6))
+		Expect(changes).To(Equal([]string{"closed -> open"}))
+	})
+	It(`Not count requests whose context is cancelled`, func() {
+		newBreaker(&circuitbreaker.Options{FailureThreshold: 1})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		statusCode = 0
+		Expect(call(ctx)).ToNot(Succeed())
+		Expect(breaker.State()).To(Equal(circuitbreaker.StateClosed))
+	})
+	It(`Open when requests to a hanging service time out`, func() {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			select {
+			case <-release:
+			case <-req.Context().Done():
+			}
+		}))
+		defer server.Close()
+		defer close(release)
+
+		serviceAPI, err := adminrestv1.NewAdminrestV1(&adminrestv1.AdminrestV1Options{
+			URL:           server.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+		breaker = circuitbreaker.New(&circuitbreaker.Options{FailureThreshold: 2, OpenTimeout: time.Hour})
+		serviceAPI.Use(breaker.Middleware())
+
+		for i := 0; i < 2; i++ {
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			_, _, err = serviceAPI.ListTopicsWithContext(ctx, serviceAPI.NewListTopicsOptions())
+			cancel()
+			Expect(err).ToNot(BeNil())
+			Expect(err).ToNot(MatchError(circuitbreaker.ErrCircuitOpen))
+		}
+		Expect(breaker.State()).To(Equal(circuitbreaker.StateOpen))
+		_, _, err = serviceAPI.ListTopicsWithContext(context.Background(), serviceAPI.NewListTopicsOptions())
+		Expect(err).To(MatchError(circuitbreaker.ErrCircuitOpen))
+	})
+	It(`Let the next request through as the probe once the open timeout has passed`, func() {
+		newBreaker(&circuitbreaker.Options{FailureThreshold: 1, OpenTimeout: 20 * time.Millisecond})
+
+		statusCode = http.StatusBadGateway
+		Expect(call(context.Background())).ToNot(Succeed())
+		time.Sleep(30 * time.Millisecond)
+		Expect(call(context.Background())).ToNot(Succeed())
+		Expect(breaker.State()).To(Equal(circuitbreaker.StateOpen))
+		Expect(call(context.Background())).To(MatchError(circuitbreaker.ErrCircuitOpen))
+
+		time.Sleep(30 * time.Millisecond)
+		statusCode = http.StatusOK
+		Expect(call(context.Background())).To(Succeed())
+		Expect(breaker.State()).To(Equal(circuitbreaker.StateClosed))
+		Expect(sent).To(Equal(3))
+		Expect(changes).To(Equal([]string{
+			"closed -> open", "open -> half-open", "half-open -> open", "open -> half-open", "half-open -> closed",
+		}))
+	})
+	It(`Fail fast while the probe is in flight`, func() {
+		newBreaker(&circuitbreaker.Options{FailureThreshold: 1, OpenTimeout: time.Millisecond})
+		statusCode = http.StatusServiceUnavailable
+		Expect(call(context.Background())).ToNot(Succeed())
+		time.Sleep(5 * time.Millisecond)
+
+		probing, finish := make(chan struct{}), make(chan struct{})
+		slow := middleware.Chain(func(call *middleware.Call) (*core.DetailedResponse, error) {
+			close(probing)
+			<-finish
+			return &core.DetailedResponse{StatusCode: http.StatusOK}, nil
+		}, breaker.Middleware())
+		done := make(chan error)
+		go func() {
+			request, _ := http.NewRequest(http.MethodGet, "http://localhost/", nil)
+			_, err := slow(&middleware.Call{Operation: "ListTopics", Request: request})
+			done <- err
+		}()
+		<-probing
+		Expect(call(context.Background())).To(MatchError(circuitbreaker.ErrCircuitOpen))
+		close(finish)
+		Expect(<-done).To(BeNil())
+		Expect(breaker.State()).To(Equal(circuitbreaker.StateClosed))
+	})
+	It(`Probe with a cheap Admin REST API call`, func() {
+		available := false
+		var probes []string
+		server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.Header().Set("Content-Type", "application/json")
+			if req.URL.Query().Get("per_page") == "1" {
+				probes = append(probes, req.URL.RawQuery)
+			}
+			if !available {
+				res.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = res.Write([]byte(`{"error_code":50300,"message":"Service unavailable."}`))
+				return
+			}
+			_, _ = res.Write([]byte(`[]`))
+		}))
+		defer server.Close()
+
+		serviceAPI, err := adminrestv1.NewAdminrestV1(&adminrestv1.AdminrestV1Options{
+			URL:           server.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+		breaker = circuitbreaker.New(&circuitbreaker.Options{
+			FailureThreshold: 2,
+			OpenTimeout:      10 * time.Millisecond,
+			Probe:            circuitbreaker.ListTopicsProbe(serviceAPI),
+		})
+		serviceAPI.Use(breaker.Middleware())
+
+		for i := 0; i < 2; i++ {
+			_, err = serviceAPI.DeleteTopic(serviceAPI.NewDeleteTopicOptions("orders"))
+			Expect(err).ToNot(MatchError(circuitbreaker.ErrCircuitOpen))
+		}
+		_, err = serviceAPI.DeleteTopic(serviceAPI.NewDeleteTopicOptions("orders"))
+		Expect(err).To(MatchError(circuitbreaker.ErrCircuitOpen))
+
+		time.Sleep(20 * time.Millisecond)
+		_, err = serviceAPI.DeleteTopic(serviceAPI.NewDeleteTopicOptions("orders"))
+		Expect(err).To(MatchError(circuitbreaker.ErrCircuitOpen))
+		Expect(probes).To(Equal([]string{"per_page=1"}))
+
+		available = true
+		time.Sleep(20 * time.Millisecond)
+		_, err = serviceAPI.DeleteTopic(serviceAPI.NewDeleteTopicOptions("orders"))
+		Expect(err).To(BeNil())
+		Expect(breaker.State()).To(Equal(circuitbreaker.StateClosed))
+		Expect(probes).To(HaveLen(2))
+	})
+	It(`Name the states`, func() {
+		Expect(circuitbreaker.StateClosed.String()).To(Equal("closed"))
+		Expect(circuitbreaker.StateOpen.String()).To(Equal("open"))
+		Expect(circuitbreaker.StateHalfOpen.String()).To(Equal("half-open"))
+	})
+})
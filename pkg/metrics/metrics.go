@@ -0,0 +1,190 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package metrics : Prometheus metrics for the Event Streams service clients
+//
+// A Collector measures the requests made by AdminrestV1 and SchemaregistryV1 clients through the middleware it
+// returns from Middleware. It exports, labelled by service (adminrest or schemaregistry), operation and status class
+// (2xx, 4xx, 5xx and so on, or "error" when no response was received):
+//
+//	eventstreams_sdk_request_duration_seconds   a histogram of the time taken by each call, including retries
+//	eventstreams_sdk_requests_total             the number of calls
+//	eventstreams_sdk_request_errors_total       the number of calls that returned an error
+//	eventstreams_sdk_request_retries_total      the number of retries made by clients with EnableRetries
+//
+// The retries counter is labelled by service and operation only.
+//
+//	collector, err := metrics.New(prometheus.DefaultRegisterer, nil)
+//	...
+//	serviceAPI.Use(collector.Middleware())
+//
+// This package is a separate module, so that applications that do not use Prometheus do not depend on it.
+package metrics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/middleware"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Label names.
+const (
+	LabelService     = "service"
+	LabelOperation   = "operation"
+	LabelStatusClass = "status_class"
+)
+
+// StatusClassError is the status class of calls that received no response, for example because the connection
+// failed or the Context was canceled.
+const StatusClassError = "error"
+
+// Options : The options of a Collector.
+type Options struct {
+	// The namespace of the metric names. Defaults to "eventstreams".
+	Namespace string
+
+	// The subsystem of the metric names. Defaults to "sdk".
+	Subsystem string
+
+	// Labels added to every metric, such as the name of the instance the clients are for.
+	ConstLabels prometheus.Labels
+
+	// The buckets of the duration histogram, in seconds. Defaults to prometheus.DefBuckets.
+	Buckets []float64
+}
+
+// Collector : The Prometheus metrics of the requests made by service clients.
+type Collector struct {
+	duration *prometheus.HistogramVec
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	retries  *prometheus.CounterVec
+}
+
+// New : constructs a Collector and registers it with the registerer, or with prometheus.DefaultRegisterer when the
+// registerer is nil.
+func New(registerer prometheus.Registerer, options *Options) (*Collector, error) {
+	if options == nil {
+		options = &Options{}
+	}
+	namespace := options.Namespace
+	if namespace == "" {
+		namespace = "eventstreams"
+	}
+	subsystem := options.Subsystem
+	if subsystem == "" {
+		subsystem = "sdk"
+	}
+	callLabels := []string{LabelService, LabelOperation, LabelStatusClass}
+
+	collector := &Collector{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   namespace,
+			Subsystem:   subsystem,
+			Name:        "request_duration_seconds",
+			Help:        "The time taken by Event Streams API calls, including retries.",
+			ConstLabels: options.ConstLabels,
+			Buckets:     options.Buckets,
+		}, callLabels),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   subsystem,
+			Name:        "requests_total",
+			Help:        "The number of Event Streams API calls.",
+			ConstLabels: options.ConstLabels,
+		}, callLabels),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   subsystem,
+			Name:        "request_errors_total",
+			Help:        "The number of Event Streams API calls that returned an error.",
+			ConstLabels: options.ConstLabels,
+		}, callLabels),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   subsystem,
+			Name:        "request_retries_total",
+			Help:        "The number of times Event Streams API calls were retried.",
+			ConstLabels: options.ConstLabels,
+		}, []string{LabelService, LabelOperation}),
+	}
+
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+	if err := registerer.Register(collector); err != nil {
+		return nil, err
+	}
+	return collector, nil
+}
+
+// Describe implements prometheus.Collector.
+func (collector *Collector) Describe(descriptions chan<- *prometheus.Desc) {
+	collector.duration.Describe(descriptions)
+	collector.requests.Describe(descriptions)
+	collector.errors.Describe(descriptions)
+	collector.retries.Describe(descriptions)
+}
+
+// Collect implements prometheus.Collector.
+func (collector *Collector) Collect(metrics chan<- prometheus.Metric) {
+	collector.duration.Collect(metrics)
+	collector.requests.Collect(metrics)
+	collector.errors.Collect(metrics)
+	collector.retries.Collect(metrics)
+}
+
+// Middleware : returns a middleware that records the calls of a service client in the collector's metrics.
+func (collector *Collector) Middleware() middleware.Middleware {
+	return func(next middleware.Handler) middleware.Handler {
+		return func(call *middleware.Call) (*core.DetailedResponse, error) {
+			start := time.Now()
+			response, err := next(call)
+			collector.observe(call, response, err, time.Since(start))
+			return response, err
+		}
+	}
+}
+
+// observe records a call.
+func (collector *Collector) observe(call *middleware.Call, response *core.DetailedResponse, err error, duration time.Duration) {
+	labels := prometheus.Labels{
+		LabelService:     call.Service,
+		LabelOperation:   call.Operation,
+		LabelStatusClass: StatusClass(response),
+	}
+	collector.duration.With(labels).Observe(duration.Seconds())
+	collector.requests.With(labels).Inc()
+	if err != nil {
+		collector.errors.With(labels).Inc()
+	}
+	retries := collector.retries.WithLabelValues(call.Service, call.Operation)
+	if call.Attempts > 1 {
+		retries.Add(float64(call.Attempts - 1))
+	}
+}
+
+// StatusClass : returns the class of the HTTP status code of a response, such as "2xx", or StatusClassError when there
+// is no response.
+func StatusClass(response *core.DetailedResponse) string {
+	if response == nil || response.StatusCode < 100 {
+		return StatusClassError
+	}
+	return fmt.Sprintf("%dxx", response.StatusCode/100)
+}
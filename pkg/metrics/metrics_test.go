@@ -0,0 +1,147 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	adminfake "github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1/fake"
+	"github.com/IBM/eventstreams-go-sdk/pkg/metrics"
+	schemafake "github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/fake"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe(`Metrics collector`, func() {
+	var registry *prometheus.Registry
+	var collector *metrics.Collector
+
+	BeforeEach(func() {
+		var err error
+		registry = prometheus.NewPedanticRegistry()
+		collector, err = metrics.New(registry, nil)
+		Expect(err).To(BeNil())
+	})
+
+	It(`Count Admin REST API requests by operation and status class`, func() {
+		server := adminfake.NewServer(nil)
+		defer server.Close()
+		serviceAPI, err := server.NewClient()
+		Expect(err).To(BeNil())
+		serviceAPI.Use(collector.Middleware())
+
+		_, err = serviceAPI.CreateTopic(serviceAPI.NewCreateTopicOptions().SetName("orders"))
+		Expect(err).To(BeNil())
+		_, _, err = serviceAPI.GetTopic(serviceAPI.NewGetTopicOptions("orders"))
+		Expect(err).To(BeNil())
+		_, _, err = serviceAPI.GetTopic(serviceAPI.NewGetTopicOptions("missing"))
+		Expect(err).ToNot(BeNil())
+
+		expected := `
+# HELP eventstreams_sdk_request_errors_total The number of Event Streams API calls that returned an error.
+# TYPE eventstreams_sdk_request_errors_total counter
+eventstreams_sdk_request_errors_total{operation="GetTopic",service="adminrest",status_class="4xx"} 1
+# HELP eventstreams_sdk_requests_total The number of Event Streams API calls.
+# TYPE eventstreams_sdk_requests_total counter
+eventstreams_sdk_requests_total{operation="CreateTopic",service="adminrest",status_class="2xx"} 1
+eventstreams_sdk_requests_total{operation="GetTopic",service="adminrest",status_class="2xx"} 1
+eventstreams_sdk_requests_total{operation="GetTopic",service="adminrest",status_class="4xx"} 1
+`
+		Expect(testutil.GatherAndCompare(registry, strings.NewReader(expected),
+			"eventstreams_sdk_requests_total", "eventstreams_sdk_request_errors_total")).To(Succeed())
+		Expect(testutil.CollectAndCount(collector, "eventstreams_sdk_request_duration_seconds")).To(Equal(3))
+		Expect(testutil.CollectAndCount(collector, "eventstreams_sdk_request_retries_total")).To(Equal(2))
+	})
+	It(`Count schema registry requests`, func() {
+		server := schemafake.NewServer(nil)
+		defer server.Close()
+		schemaAPI, err := server.NewClient()
+		Expect(err).To(BeNil())
+		schemaAPI.Use(collector.Middleware())
+
+		schema := map[string]interface{}{"type": "string"}
+		_, _, err = schemaAPI.CreateSchema(schemaAPI.NewCreateSchemaOptions().SetID("orders-value").SetSchema(schema))
+		Expect(err).To(BeNil())
+		_, _, err = schemaAPI.GetLatestSchema(schemaAPI.NewGetLatestSchemaOptions("missing"))
+		Expect(err).ToNot(BeNil())
+
+		expected := `
+# HELP eventstreams_sdk_requests_total The number of Event Streams API calls.
+# TYPE eventstreams_sdk_requests_total counter
+eventstreams_sdk_requests_total{operation="CreateSchema",service="schemaregistry",status_class="2xx"} 1
+eventstreams_sdk_requests_total{operation="GetLatestSchema",service="schemaregistry",status_class="4xx"} 1
+`
+		Expect(testutil.GatherAndCompare(registry, strings.NewReader(expected), "eventstreams_sdk_requests_total")).To(Succeed())
+	})
+	It(`Count the retries made when retries are enabled`, func() {
+		failures := 2
+		server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.Header().Set("Content-Type", "application/json")
+			if failures > 0 {
+				failures--
+				res.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = res.Write([]byte(`{"error_code":50300,"message":"Service unavailable."}`))
+				return
+			}
+			_, _ = res.Write([]byte(`[]`))
+		}))
+		defer server.Close()
+
+		serviceAPI, err := adminrestv1.NewAdminrestV1(&adminrestv1.AdminrestV1Options{
+			URL:           server.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+		serviceAPI.Use(collector.Middleware())
+		serviceAPI.EnableRetries(3, 10*time.Millisecond)
+
+		_, _, err = serviceAPI.ListTopics(serviceAPI.NewListTopicsOptions())
+		Expect(err).To(BeNil())
+
+		expected := `
+# HELP eventstreams_sdk_request_retries_total The number of times Event Streams API calls were retried.
+# TYPE eventstreams_sdk_request_retries_total counter
+eventstreams_sdk_request_retries_total{operation="ListTopics",service="adminrest"} 2
+`
+		Expect(testutil.GatherAndCompare(registry, strings.NewReader(expected), "eventstreams_sdk_request_retries_total")).To(Succeed())
+	})
+	It(`Label calls without a response with the error status class`, func() {
+		Expect(metrics.StatusClass(nil)).To(Equal(metrics.StatusClassError))
+		Expect(metrics.StatusClass(&core.DetailedResponse{StatusCode: http.StatusAccepted})).To(Equal("2xx"))
+		Expect(metrics.StatusClass(&core.DetailedResponse{StatusCode: http.StatusBadGateway})).To(Equal("5xx"))
+	})
+	It(`Apply the options and refuse to register twice`, func() {
+		other, err := metrics.New(prometheus.NewRegistry(), &metrics.Options{
+			Namespace:   "app",
+			Subsystem:   "kafka",
+			ConstLabels: prometheus.Labels{"instance": "primary"},
+			Buckets:     []float64{0.1, 1},
+		})
+		Expect(err).To(BeNil())
+		Expect(testutil.CollectAndCount(other)).To(Equal(0))
+
+		_, err = metrics.New(registry, nil)
+		Expect(err).ToNot(BeNil())
+	})
+})
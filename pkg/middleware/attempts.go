@@ -0,0 +1,69 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// attemptsKey is the Context key of the attempt counter of a request.
+type attemptsKey struct{}
+
+// attemptCounter counts the attempts made to send a request.
+type attemptCounter struct {
+	attempts int32
+}
+
+// Send : sends the request of a call with send, and sets call.Attempts to the number of times it was sent. Service
+// clients call it at the end of their chain of middlewares.
+func Send(call *Call, send func(request *http.Request) (*core.DetailedResponse, error)) (*core.DetailedResponse, error) {
+	counter := &attemptCounter{}
+	request := call.Request.WithContext(context.WithValue(call.Request.Context(), attemptsKey{}, counter))
+	response, err := send(request)
+	call.Attempts = int(atomic.LoadInt32(&counter.attempts))
+	if call.Attempts == 0 {
+		// Without retries, the request is sent once.
+		call.Attempts = 1
+	}
+	return response, err
+}
+
+// CountAttempts : sets up an HTTP client made by core.BaseService.EnableRetries to count the attempts of the requests
+// sent with Send. Service clients call it from EnableRetries. Other HTTP clients are left unchanged.
+func CountAttempts(client *http.Client) {
+	if client == nil {
+		return
+	}
+	roundTripper, ok := client.Transport.(*retryablehttp.RoundTripper)
+	if !ok || roundTripper.Client == nil {
+		return
+	}
+	previous := roundTripper.Client.RequestLogHook
+	roundTripper.Client.RequestLogHook = func(logger retryablehttp.Logger, request *http.Request, retry int) {
+		if counter, ok := request.Context().Value(attemptsKey{}).(*attemptCounter); ok {
+			atomic.StoreInt32(&counter.attempts, int32(retry+1))
+		}
+		if previous != nil {
+			previous(logger, request, retry)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/middleware"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Attempts`, func() {
+	var server *httptest.Server
+	var failures int
+
+	BeforeEach(func() {
+		failures = 0
+		server = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			if failures > 0 {
+				failures--
+				res.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			res.WriteHeader(http.StatusNoContent)
+		}))
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	// send sends a GET request to the server through the service.
+	send := func(service *core.BaseService) *middleware.Call {
+		request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		Expect(err).To(BeNil())
+		call := &middleware.Call{Service: "adminrest", Operation: "ListTopics", Request: request}
+		_, err = middleware.Send(call, func(request *http.Request) (*core.DetailedResponse, error) {
+			return service.Request(request, nil)
+		})
+		Expect(err).To(BeNil())
+		return call
+	}
+
+	It(`Count one attempt without retries`, func() {
+		service, err := core.NewBaseService(&core.ServiceOptions{URL: server.URL, Authenticator: &core.NoAuthAuthenticator{}})
+		Expect(err).To(BeNil())
+		middleware.CountAttempts(service.Client)
+
+		Expect(send(service).Attempts).To(Equal(1))
+	})
+	It(`Count the retries of a client with retries enabled`, func() {
+		service, err := core.NewBaseService(&core.ServiceOptions{URL: server.URL, Authenticator: &core.NoAuthAuthenticator{}})
+		Expect(err).To(BeNil())
+		service.EnableRetries(3, 10*time.Millisecond)
+		middleware.CountAttempts(service.Client)
+
+		failures = 2
+		Expect(send(service).Attempts).To(Equal(3))
+		Expect(send(service).Attempts).To(Equal(1))
+	})
+})
@@ -0,0 +1,98 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package middleware : Request and response middleware for the Event Streams service clients
+//
+// AdminrestV1 and SchemaregistryV1 send every request through a chain of middlewares, given in the Middlewares field of
+// their options or added with Use. A middleware receives the *http.Request built for an operation, can change it or
+// replace it, passes it on by calling the next Handler, and sees the *core.DetailedResponse and error that come back.
+// This is the place for correlation IDs, audit logging, request signing and metrics:
+//
+//	audit := func(next middleware.Handler) middleware.Handler {
+//		return func(call *middleware.Call) (*core.DetailedResponse, error) {
+//			response, err := next(call)
+//			log.Printf("%s %s: %v", call.Service, call.Operation, err)
+//			return response, err
+//		}
+//	}
+//	serviceAPI.Use(audit)
+//
+// The response is nil when the request was not sent, for example because the connection failed. Errors returned for
+// unsuccessful responses are the errors returned by the client, such as *adminrestv1.APIError. Retries enabled with
+// EnableRetries happen inside the chain, so a middleware sees one call however many attempts it takes, and finds the
+// number of attempts in Call.Attempts.
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Call : A request made by a service client.
+type Call struct {
+	// The name of the service, such as "adminrest" or "schemaregistry".
+	Service string
+
+	// The name of the operation, such as "CreateTopic".
+	Operation string
+
+	// The request built for the operation. A middleware may modify it or set a new request before calling the next
+	// Handler.
+	Request *http.Request
+
+	// The number of times the request was sent, which is more than one when it was retried by a client with retries
+	// enabled. Set when the next Handler returns.
+	Attempts int
+}
+
+// Handler : Sends a call and returns the response.
+type Handler func(call *Call) (*core.DetailedResponse, error)
+
+// Middleware : Wraps a Handler with behaviour that runs before and after it.
+type Middleware func(next Handler) Handler
+
+// Chain : returns a Handler that runs the call through the middlewares in order, the first outermost, and then through
+// the handler.
+func Chain(handler Handler, middlewares ...Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i] != nil {
+			handler = middlewares[i](handler)
+		}
+	}
+	return handler
+}
+
+// Append : returns a new list of the middlewares followed by more, leaving the original list unchanged so that a
+// client and its clones do not share additions.
+func Append(middlewares []Middleware, more ...Middleware) []Middleware {
+	list := make([]Middleware, 0, len(middlewares)+len(more))
+	list = append(list, middlewares...)
+	return append(list, more...)
+}
+
+// RequestHeader : returns a Middleware that sets a header on every request to the value returned for the call, such
+// as a correlation ID. The header is left unchanged when the value is empty.
+func RequestHeader(name string, value func(call *Call) string) Middleware {
+	return func(next Handler) Handler {
+		return func(call *Call) (*core.DetailedResponse, error) {
+			if headerValue := value(call); headerValue != "" {
+				call.Request.Header.Set(name, headerValue)
+			}
+			return next(call)
+		}
+	}
+}
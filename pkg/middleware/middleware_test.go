@@ -0,0 +1,97 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/middleware"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Middleware`, func() {
+	var trace []string
+
+	// tracing returns a middleware that records when the call enters and leaves it.
+	tracing := func(name string) middleware.Middleware {
+		return func(next middleware.Handler) middleware.Handler {
+			return func(call *middleware.Call) (*core.DetailedResponse, error) {
+				trace = append(trace, name+" before")
+				response, err := next(call)
+				trace = append(trace, name+" after")
+				return response, err
+			}
+		}
+	}
+	send := func(call *middleware.Call) (*core.DetailedResponse, error) {
+		trace = append(trace, "send "+call.Request.Header.Get("X-Correlation-Id"))
+		return &core.DetailedResponse{StatusCode: http.StatusOK}, nil
+	}
+	newCall := func() *middleware.Call {
+		request, err := http.NewRequest(http.MethodGet, "https://example.com/admin/topics", nil)
+		Expect(err).To(BeNil())
+		return &middleware.Call{Service: "adminrest", Operation: "ListTopics", Request: request}
+	}
+
+	BeforeEach(func() {
+		trace = nil
+	})
+
+	It(`Run the middlewares in order around the handler`, func() {
+		response, err := middleware.Chain(send, tracing("first"), nil, tracing("second"))(newCall())
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(trace).To(Equal([]string{"first before", "second before", "send ", "second after", "first after"}))
+	})
+	It(`Let a middleware answer without calling the next handler`, func() {
+		refuse := func(next middleware.Handler) middleware.Handler {
+			return func(call *middleware.Call) (*core.DetailedResponse, error) {
+				return nil, errors.New("refused " + call.Operation)
+			}
+		}
+		response, err := middleware.Chain(send, tracing("first"), refuse)(newCall())
+		Expect(response).To(BeNil())
+		Expect(err).To(MatchError("refused ListTopics"))
+		Expect(trace).To(Equal([]string{"first before", "first after"}))
+	})
+	It(`Set request headers`, func() {
+		correlationID := middleware.RequestHeader("X-Correlation-Id", func(call *middleware.Call) string {
+			return call.Service + "-" + call.Operation
+		})
+		empty := middleware.RequestHeader("X-Empty", func(call *middleware.Call) string { return "" })
+		call := newCall()
+		_, err := middleware.Chain(send, correlationID, empty)(call)
+		Expect(err).To(BeNil())
+		Expect(trace).To(Equal([]string{"send adminrest-ListTopics"}))
+		Expect(call.Request.Header).ToNot(HaveKey("X-Empty"))
+	})
+	It(`Append without sharing the original list`, func() {
+		original := make([]middleware.Middleware, 1, 4)
+		original[0] = tracing("first")
+		first := middleware.Append(original, tracing("second"))
+		second := middleware.Append(original, tracing("third"))
+		Expect(original).To(HaveLen(1))
+		Expect(first).To(HaveLen(2))
+		Expect(second).To(HaveLen(2))
+
+		_, _ = middleware.Chain(send, first...)(newCall())
+		Expect(trace).To(Equal([]string{"first before", "second before", "send ", "second after", "first after"}))
+	})
+})
@@ -0,0 +1,255 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ratelimit : Client-side rate limiting and concurrency control for the Event Streams service clients
+//
+// A Limiter holds back the requests of AdminrestV1 and SchemaregistryV1 clients through the middleware it returns from
+// Middleware, so that bulk jobs stay within the request rate of a service instance instead of being answered with
+// HTTP status code 429. Each Limit is a token bucket, which lets Burst requests start at once and then Rate requests
+// a second, and a limit on the number of requests in flight:
+//
+//	limiter, err := ratelimit.New(&ratelimit.Options{
+//		Default: ratelimit.Limit{Rate: 20, Burst: 5, MaxInFlight: 10},
+//		Operations: map[string]ratelimit.Limit{
+//			"CreateTopic": {Rate: 1, MaxInFlight: 1},
+//		},
+//	})
+//	...
+//	serviceAPI.Use(limiter.Middleware())
+//
+// Operations with a limit of their own are limited separately from the rest, which share the default limit. A
+// Limiter can be used by several clients of the same service instance, so that they share its limits.
+//
+// When a response has HTTP status code 429 and a Retry-After header, the requests that share its limit are held back
+// until the time given. The request itself is not repeated; enable retries on the client for that. Requests wait for
+// as long as their Context allows, and return the error of the Context when it is done first.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/middleware"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Limit : The limits of a group of requests. A zero value imposes no limits.
+type Limit struct {
+	// The number of requests that may start per second, or 0 for no limit.
+	Rate float64
+
+	// The number of requests that may start at once before Rate applies. Defaults to 1 when Rate is set.
+	Burst int
+
+	// The number of requests that may be in flight at once, or 0 for no limit.
+	MaxInFlight int
+}
+
+// Options : The options of a Limiter.
+type Options struct {
+	// The limit shared by the operations that are not in Operations.
+	Default Limit
+
+	// The limits of operations, by operation name such as "CreateTopic", each applied to that operation alone.
+	Operations map[string]Limit
+}
+
+// Limiter : Limits the rate and concurrency of the requests made by service clients.
+type Limiter struct {
+	defaultLimiter *limiter
+	operations     map[string]*limiter
+}
+
+// New : constructs a Limiter, returning an error if a limit is negative.
+func New(options *Options) (*Limiter, error) {
+	if options == nil {
+		options = &Options{}
+	}
+	defaultLimiter, err := newLimiter("default", options.Default)
+	if err != nil {
+		return nil, err
+	}
+	limiter := &Limiter{
+		defaultLimiter: defaultLimiter,
+		operations:     make(map[string]*limiter, len(options.Operations)),
+	}
+	for operation, limit := range options.Operations {
+		limiter.operations[operation], err = newLimiter(operation, limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return limiter, nil
+}
+
+// Middleware : returns a middleware that holds back calls until the limits of their operation allow them.
+func (limiter *Limiter) Middleware() middleware.Middleware {
+	return func(next middleware.Handler) middleware.Handler {
+		return func(call *middleware.Call) (*core.DetailedResponse, error) {
+			operationLimiter := limiter.forOperation(call.Operation)
+			release, err := operationLimiter.acquire(call.Request.Context())
+			if err != nil {
+				return nil, err
+			}
+			response, err := next(call)
+			release()
+			if response != nil && response.StatusCode == http.StatusTooManyRequests {
+				if delay, ok := RetryAfter(response.Headers, time.Now()); ok {
+					operationLimiter.pause(delay)
+				}
+			}
+			return response, err
+		}
+	}
+}
+
+// forOperation returns the limiter of an operation.
+func (limiter *Limiter) forOperation(operation string) *limiter {
+	if operationLimiter, ok := limiter.operations[operation]; ok {
+		return operationLimiter
+	}
+	return limiter.defaultLimiter
+}
+
+// RetryAfter : returns the delay given by the Retry-After header, as a number of seconds or an HTTP date, and false if
+// there is no valid header.
+func RetryAfter(headers http.Header, now time.Time) (time.Duration, bool) {
+	value := headers.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if delay := date.Sub(now); delay > 0 {
+		return delay, true
+	}
+	return 0, true
+}
+
+// limiter : The token bucket and in-flight slots of a Limit.
+type limiter struct {
+	rate  float64
+	burst float64
+	slots chan struct{}
+
+	mutex        sync.Mutex
+	tokens       float64
+	updated      time.Time
+	blockedUntil time.Time
+}
+
+// newLimiter returns the limiter of a Limit, named in errors.
+func newLimiter(name string, limit Limit) (*limiter, error) {
+	if limit.Rate < 0 || math.IsNaN(limit.Rate) || limit.Burst < 0 || limit.MaxInFlight < 0 {
+		return nil, fmt.Errorf("the %s limit must not be negative", name)
+	}
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	l := &limiter{
+		rate:    limit.Rate,
+		burst:   burst,
+		tokens:  burst,
+		updated: time.Now(),
+	}
+	if limit.MaxInFlight > 0 {
+		l.slots = make(chan struct{}, limit.MaxInFlight)
+	}
+	return l, nil
+}
+
+// acquire waits until a request may start, and returns a function to call when it has finished.
+func (l *limiter) acquire(ctx context.Context) (release func(), err error) {
+	if err = ctx.Err(); err != nil {
+		return
+	}
+	if delay := l.reserve(time.Now()); delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			l.cancel()
+			return nil, ctx.Err()
+		}
+	}
+	if l.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return func() { <-l.slots }, nil
+	case <-ctx.Done():
+		l.cancel()
+		return nil, ctx.Err()
+	}
+}
+
+// reserve takes a token from the bucket, and returns how long to wait before using it.
+func (l *limiter) reserve(now time.Time) time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	var delay time.Duration
+	if l.blockedUntil.After(now) {
+		delay = l.blockedUntil.Sub(now)
+	}
+	if l.rate == 0 {
+		return delay
+	}
+	if elapsed := now.Sub(l.updated); elapsed > 0 {
+		l.tokens = math.Min(l.burst, l.tokens+elapsed.Seconds()*l.rate)
+		l.updated = now
+	}
+	l.tokens--
+	if l.tokens < 0 {
+		if wait := time.Duration(-l.tokens / l.rate * float64(time.Second)); wait > delay {
+			delay = wait
+		}
+	}
+	return delay
+}
+
+// cancel returns the token of a request that stopped waiting.
+func (l *limiter) cancel() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.rate > 0 {
+		l.tokens = math.Min(l.burst, l.tokens+1)
+	}
+}
+
+// pause holds back the requests that have not started for the delay.
+func (l *limiter) pause(delay time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if until := time.Now().Add(delay); until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
@@ -0,0 +1,215 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ratelimit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/middleware"
+	"github.com/IBM/eventstreams-go-sdk/pkg/ratelimit"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Limiter`, func() {
+	// handler returns a handler that answers every call with the status code.
+	handler := func(statusCode int) middleware.Handler {
+		return func(call *middleware.Call) (*core.DetailedResponse, error) {
+			return &core.DetailedResponse{StatusCode: statusCode, Headers: http.Header{}}, nil
+		}
+	}
+
+	// call runs an operation through the handler with the context.
+	call := func(ctx context.Context, handler middleware.Handler, operation string) error {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost/", nil)
+		Expect(err).To(BeNil())
+		_, err = handler(&middleware.Call{Service: "adminrest", Operation: operation, Request: request})
+		return err
+	}
+
+	It(`Reject negative limits`, func() {
+		_, err := ratelimit.New(&ratelimit.Options{Default: ratelimit.Limit{Rate: -1}})
+		Expect(err).ToNot(BeNil())
+		_, err = ratelimit.New(&ratelimit.Options{Operations: map[string]ratelimit.Limit{"CreateTopic": {MaxInFlight: -1}}})
+		Expect(err).To(MatchError("the CreateTopic limit must not be negative"))
+
+		limiter, err := ratelimit.New(nil)
+		Expect(err).To(BeNil())
+		Expect(call(context.Background(), middleware.Chain(handler(200), limiter.Middleware()), "ListTopics")).To(Succeed())
+	})
+	It(`Start requests at the rate of the limit after the burst`, func() {
+		limiter, err := ratelimit.New(&ratelimit.Options{Default: ratelimit.Limit{Rate: 20, Burst: 2}})
+		Expect(err).To(BeNil())
+		limited := middleware.Chain(handler(200), limiter.Middleware())
+
+		start := time.Now()
+		for i := 0; i < 4; i++ {
+			Expect(call(context.Background(), limited, "CreateTopic")).To(Succeed())
+		}
+		Expect(time.Since(start)).To(BeNumerically(">=", 90*time.Millisecond))
+	})
+	It(`Limit operations with a limit of their own separately`, func() {
+		limiter, err := ratelimit.New(&ratelimit.Options{
+			Operations: map[string]ratelimit.Limit{"CreateTopic": {Rate: 0.1}},
+		})
+		Expect(err).To(BeNil())
+		limited := middleware.Chain(handler(200), limiter.Middleware())
+
+		Expect(call(context.Background(), limited, "CreateTopic")).To(Succeed())
+		start := time.Now()
+		for i := 0; i < 10; i++ {
+			Expect(call(context.Background(), limited, "ListTopics")).To(Succeed())
+		}
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+	})
+	It(`Limit the requests in flight`, func() {
+		limiter, err := ratelimit.New(&ratelimit.Options{Default: ratelimit.Limit{MaxInFlight: 2}})
+		Expect(err).To(BeNil())
+		var mutex sync.Mutex
+		inFlight, maxInFlight := 0, 0
+		limited := middleware.Chain(func(call *middleware.Call) (*core.DetailedResponse, error) {
+			mutex.Lock()
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			mutex.Unlock()
+			time.Sleep(20 * time.Millisecond)
+			mutex.Lock()
+			inFlight--
+			mutex.Unlock()
+			return &core.DetailedResponse{StatusCode: 200}, nil
+		}, limiter.Middleware())
+
+		var wg sync.WaitGroup
+		for i := 0; i < 6; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				Expect(call(context.Background(), limited, "CreateVersion")).To(Succeed())
+			}()
+		}
+		wg.Wait()
+		Expect(maxInFlight).To(Equal(2))
+	})
+	It(`Stop waiting when the context is done`, func() {
+		limiter, err := ratelimit.New(&ratelimit.Options{Default: ratelimit.Limit{Rate: 0.1, MaxInFlight: 1}})
+		Expect(err).To(BeNil())
+		sent := 0
+		limited := middleware.Chain(func(call *middleware.Call) (*core.DetailedResponse, error) {
+			sent++
+			return &core.DetailedResponse{StatusCode: 200}, nil
+		}, limiter.Middleware())
+
+		Expect(call(context.Background(), limited, "CreateTopic")).To(Succeed())
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		Expect(call(ctx, limited, "CreateTopic")).To(MatchError(context.DeadlineExceeded))
+		canceled, cancelNow := context.WithCancel(context.Background())
+		cancelNow()
+		Expect(call(canceled, limited, "CreateTopic")).To(MatchError(context.Canceled))
+		Expect(sent).To(Equal(1))
+	})
+	It(`Return the token of a request that stopped waiting for a slot`, func() {
+		limiter, err := ratelimit.New(&ratelimit.Options{Default: ratelimit.Limit{Rate: 0.1, Burst: 2, MaxInFlight: 1}})
+		Expect(err).To(BeNil())
+		started, finish := make(chan struct{}, 1), make(chan struct{})
+		limited := middleware.Chain(func(call *middleware.Call) (*core.DetailedResponse, error) {
+			if call.Request.Header.Get("X-Hold") != "" {
+				started <- struct{}{}
+				<-finish
+			}
+			return &core.DetailedResponse{StatusCode: 200}, nil
+		}, limiter.Middleware())
+
+		done := make(chan error, 1)
+		go func() {
+			request, _ := http.NewRequest(http.MethodGet, "http://localhost/", nil)
+			request.Header.Set("X-Hold", "true")
+			_, err := limited(&middleware.Call{Service: "adminrest", Operation: "CreateTopic", Request: request})
+			done <- err
+		}()
+		<-started
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		Expect(call(ctx, limited, "CreateTopic")).To(MatchError(context.DeadlineExceeded))
+		close(finish)
+		Expect(<-done).To(Succeed())
+
+		ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		Expect(call(ctx, limited, "CreateTopic")).To(Succeed())
+	})
+	It(`Hold back requests for the Retry-After time of a 429 response`, func() {
+		throttled := true
+		server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.Header().Set("Content-Type", "application/json")
+			if throttled {
+				throttled = false
+				res.Header().Set("Retry-After", "1")
+				res.WriteHeader(http.StatusTooManyRequests)
+				_, _ = res.Write([]byte(`{"error_code":42900,"message":"Too many requests."}`))
+				return
+			}
+			_, _ = res.Write([]byte(`[]`))
+		}))
+		defer server.Close()
+
+		limiter, err := ratelimit.New(nil)
+		Expect(err).To(BeNil())
+		serviceAPI, err := adminrestv1.NewAdminrestV1(&adminrestv1.AdminrestV1Options{
+			URL:           server.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+			Middlewares:   []middleware.Middleware{limiter.Middleware()},
+		})
+		Expect(err).To(BeNil())
+
+		_, response, err := serviceAPI.ListTopics(serviceAPI.NewListTopicsOptions())
+		Expect(err).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusTooManyRequests))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, _, err = serviceAPI.ListTopicsWithContext(ctx, serviceAPI.NewListTopicsOptions())
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+
+		start := time.Now()
+		_, _, err = serviceAPI.ListTopics(serviceAPI.NewListTopicsOptions())
+		Expect(err).To(BeNil())
+		Expect(time.Since(start)).To(BeNumerically(">", 700*time.Millisecond))
+	})
+	It(`Parse Retry-After as seconds or a date`, func() {
+		now := time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+		delay, ok := ratelimit.RetryAfter(http.Header{"Retry-After": {"30"}}, now)
+		Expect(ok).To(BeTrue())
+		Expect(delay).To(Equal(30 * time.Second))
+		delay, ok = ratelimit.RetryAfter(http.Header{"Retry-After": {"Wed, 01 Mar 2023 12:00:05 GMT"}}, now)
+		Expect(ok).To(BeTrue())
+		Expect(delay).To(Equal(5 * time.Second))
+		_, ok = ratelimit.RetryAfter(http.Header{"Retry-After": {"soon"}}, now)
+		Expect(ok).To(BeFalse())
+		_, ok = ratelimit.RetryAfter(http.Header{}, now)
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -0,0 +1,62 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schemaregistryv1
+
+import (
+	"context"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/avro"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// API : The operations of the schema registry, in the form that takes a Context.
+// SchemaregistryV1 implements API. Code that depends on API rather than on *SchemaregistryV1 can be tested with the
+// mocks package, or with any other implementation.
+type API interface {
+	// Schemas
+	ListSchemasWithContext(ctx context.Context, listSchemasOptions *ListSchemasOptions) (result []string, response *core.DetailedResponse, err error)
+	CreateSchemaWithContext(ctx context.Context, createSchemaOptions *CreateSchemaOptions) (result *SchemaMetadata, response *core.DetailedResponse, err error)
+	GetLatestSchemaWithContext(ctx context.Context, getLatestSchemaOptions *GetLatestSchemaOptions) (result map[string]interface{}, response *core.DetailedResponse, err error)
+	UpdateSchemaWithContext(ctx context.Context, updateSchemaOptions *UpdateSchemaOptions) (result *SchemaMetadata, response *core.DetailedResponse, err error)
+	DeleteSchemaWithContext(ctx context.Context, deleteSchemaOptions *DeleteSchemaOptions) (response *core.DetailedResponse, err error)
+	GetSchemaByGlobalIDWithContext(ctx context.Context, getSchemaByGlobalIDOptions *GetSchemaByGlobalIDOptions) (result map[string]interface{}, response *core.DetailedResponse, err error)
+	GetVersionMetadataByContentWithContext(ctx context.Context, getVersionMetadataByContentOptions *GetVersionMetadataByContentOptions) (result *SchemaMetadata, response *core.DetailedResponse, err error)
+
+	// Versions
+	ListVersionsWithContext(ctx context.Context, listVersionsOptions *ListVersionsOptions) (result []int64, response *core.DetailedResponse, err error)
+	CreateVersionWithContext(ctx context.Context, createVersionOptions *CreateVersionOptions) (result *SchemaMetadata, response *core.DetailedResponse, err error)
+	GetVersionWithContext(ctx context.Context, getVersionOptions *GetVersionOptions) (result map[string]interface{}, response *core.DetailedResponse, err error)
+	DeleteVersionWithContext(ctx context.Context, deleteVersionOptions *DeleteVersionOptions) (response *core.DetailedResponse, err error)
+
+	// Rules
+	GetGlobalRuleWithContext(ctx context.Context, getGlobalRuleOptions *GetGlobalRuleOptions) (result *Rule, response *core.DetailedResponse, err error)
+	UpdateGlobalRuleWithContext(ctx context.Context, updateGlobalRuleOptions *UpdateGlobalRuleOptions) (result *Rule, response *core.DetailedResponse, err error)
+	CreateSchemaRuleWithContext(ctx context.Context, createSchemaRuleOptions *CreateSchemaRuleOptions) (result *Rule, response *core.DetailedResponse, err error)
+	GetSchemaRuleWithContext(ctx context.Context, getSchemaRuleOptions *GetSchemaRuleOptions) (result *Rule, response *core.DetailedResponse, err error)
+	UpdateSchemaRuleWithContext(ctx context.Context, updateSchemaRuleOptions *UpdateSchemaRuleOptions) (result *Rule, response *core.DetailedResponse, err error)
+	DeleteSchemaRuleWithContext(ctx context.Context, deleteSchemaRuleOptions *DeleteSchemaRuleOptions) (response *core.DetailedResponse, err error)
+
+	// Avro schemas
+	CreateAvroSchemaWithContext(ctx context.Context, createAvroSchemaOptions *CreateAvroSchemaOptions) (result *SchemaMetadata, response *core.DetailedResponse, err error)
+	CreateAvroVersionWithContext(ctx context.Context, createAvroVersionOptions *CreateAvroVersionOptions) (result *SchemaMetadata, response *core.DetailedResponse, err error)
+	UpdateAvroSchemaWithContext(ctx context.Context, updateAvroSchemaOptions *UpdateAvroSchemaOptions) (result *SchemaMetadata, response *core.DetailedResponse, err error)
+	GetLatestAvroSchemaWithContext(ctx context.Context, getLatestSchemaOptions *GetLatestSchemaOptions) (result *avro.Schema, response *core.DetailedResponse, err error)
+	GetAvroVersionWithContext(ctx context.Context, getVersionOptions *GetVersionOptions) (result *avro.Schema, response *core.DetailedResponse, err error)
+}
+
+// SchemaregistryV1 must implement every method of API.
+var _ API = (*SchemaregistryV1)(nil)
@@ -0,0 +1,140 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package avro
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// CanonicalForm : returns the Parsing Canonical Form of the schema, as defined by the Avro specification.
+//
+// The canonical form keeps only the attributes that affect how data is read and written: names are replaced by full
+// names, doc, aliases, defaults, logical types and other properties are removed, and a named type that has already
+// appeared is written as its full name. Two schemas with the same canonical form read and write the same data.
+func (schema *Schema) CanonicalForm() (string, error) {
+	if err := schema.Validate(); err != nil {
+		return "", err
+	}
+	var builder strings.Builder
+	writeCanonical(&builder, schema, make(map[*Schema]bool))
+	return builder.String(), nil
+}
+
+// Fingerprint64 : returns the 64-bit Rabin fingerprint (CRC-64-AVRO) of the Parsing Canonical Form of the schema.
+func (schema *Schema) Fingerprint64() (uint64, error) {
+	canonical, err := schema.CanonicalForm()
+	if err != nil {
+		return 0, err
+	}
+	return fingerprint64([]byte(canonical)), nil
+}
+
+// writeCanonical writes the canonical form of a valid schema.
+func writeCanonical(builder *strings.Builder, schema *Schema, written map[*Schema]bool) {
+	switch schema.Type {
+	case TypeArray:
+		builder.WriteString(`{"type":"array","items":`)
+		writeCanonical(builder, schema.Items, written)
+		builder.WriteString("}")
+	case TypeMap:
+		builder.WriteString(`{"type":"map","values":`)
+		writeCanonical(builder, schema.Values, written)
+		builder.WriteString("}")
+	case TypeUnion:
+		builder.WriteString("[")
+		for i, branch := range schema.Branches {
+			if i > 0 {
+				builder.WriteString(",")
+			}
+			writeCanonical(builder, branch, written)
+		}
+		builder.WriteString("]")
+	case TypeRecord, TypeEnum, TypeFixed:
+		if written[schema] {
+			writeString(builder, schema.FullName())
+			return
+		}
+		written[schema] = true
+		builder.WriteString(`{"name":`)
+		writeString(builder, schema.FullName())
+		builder.WriteString(`,"type":`)
+		writeString(builder, string(schema.Type))
+		switch schema.Type {
+		case TypeRecord:
+			builder.WriteString(`,"fields":[`)
+			for i, field := range schema.Fields {
+				if i > 0 {
+					builder.WriteString(",")
+				}
+				builder.WriteString(`{"name":`)
+				writeString(builder, field.Name)
+				builder.WriteString(`,"type":`)
+				writeCanonical(builder, field.Type, written)
+				builder.WriteString("}")
+			}
+			builder.WriteString("]")
+		case TypeEnum:
+			builder.WriteString(`,"symbols":[`)
+			for i, symbol := range schema.Symbols {
+				if i > 0 {
+					builder.WriteString(",")
+				}
+				writeString(builder, symbol)
+			}
+			builder.WriteString("]")
+		case TypeFixed:
+			builder.WriteString(`,"size":`)
+			builder.WriteString(strconv.Itoa(schema.Size))
+		}
+		builder.WriteString("}")
+	default:
+		writeString(builder, string(schema.Type))
+	}
+}
+
+// writeString writes a JSON string. Valid names and symbols never need escaping, so the result is already canonical.
+func writeString(builder *strings.Builder, value string) {
+	text, _ := json.Marshal(value)
+	builder.Write(text)
+}
+
+// emptyFingerprint is the CRC-64-AVRO fingerprint of no bytes, which is also its polynomial.
+const emptyFingerprint uint64 = 0xc15d213aa4d7a795
+
+// fingerprintTable is the lookup table for CRC-64-AVRO.
+var fingerprintTable = func() [256]uint64 {
+	var table [256]uint64
+	for i := range table {
+		fingerprint := uint64(i)
+		for j := 0; j < 8; j++ {
+			fingerprint = (fingerprint >> 1) ^ (emptyFingerprint & -(fingerprint & 1))
+		}
+		table[i] = fingerprint
+	}
+	return table
+}()
+
+// fingerprint64 returns the CRC-64-AVRO fingerprint of the bytes.
+func fingerprint64(data []byte) uint64 {
+	fingerprint := emptyFingerprint
+	for _, b := range data {
+		fingerprint = (fingerprint >> 8) ^ fingerprintTable[byte(fingerprint)^b]
+	}
+	return fingerprint
+}
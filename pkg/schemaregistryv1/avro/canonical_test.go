@@ -0,0 +1,69 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package avro_test
+
+import (
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/avro"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`CanonicalForm()`, func() {
+	// canonical returns the canonical form of the schema.
+	canonical := func(text string) string {
+		schema, err := avro.Parse(text)
+		Expect(err).To(BeNil())
+		form, err := schema.CanonicalForm()
+		Expect(err).To(BeNil())
+		return form
+	}
+
+	It(`Reduce a schema to its canonical form`, func() {
+		Expect(canonical(`{"type": "int", "logicalType": "date"}`)).To(Equal(`"int"`))
+		Expect(canonical(`{"type": "record", "name": "Citizen", "namespace": "com.example", "doc": "A citizen", "fields": [
+			{"name": "name", "type": "string", "doc": "Their name"},
+			{"name": "age", "type": "int", "default": 0},
+			{"name": "country", "type": {"symbols": ["GB", "FR"], "name": "Country", "type": "enum", "default": "GB"}},
+			{"name": "visited", "type": {"type": "array", "items": "Country"}},
+			{"name": "id", "type": {"type": "fixed", "size": 16, "name": "ID", "namespace": "com.other"}},
+			{"name": "friends", "type": {"type": "map", "values": ["null", "Citizen"]}}]}`)).To(Equal(
+			`{"name":"com.example.Citizen","type":"record","fields":[` +
+				`{"name":"name","type":"string"},` +
+				`{"name":"age","type":"int"},` +
+				`{"name":"country","type":{"name":"com.example.Country","type":"enum","symbols":["GB","FR"]}},` +
+				`{"name":"visited","type":{"type":"array","items":"com.example.Country"}},` +
+				`{"name":"id","type":{"name":"com.other.ID","type":"fixed","size":16}},` +
+				`{"name":"friends","type":{"type":"map","values":["null","com.example.Citizen"]}}]}`))
+	})
+	It(`Give equivalent schemas the same fingerprint`, func() {
+		null, err := avro.Parse(`"null"`)
+		Expect(err).To(BeNil())
+		fingerprint, err := null.Fingerprint64()
+		Expect(err).To(BeNil())
+		Expect(fingerprint).To(Equal(uint64(0x63dd24e7cc258f8a)))
+
+		first, _ := avro.Parse(`{"type": "record", "name": "R", "namespace": "a", "fields": [{"name": "f", "type": "long"}]}`)
+		second, _ := avro.Parse(`{"name": "a.R", "doc": "Another R", "type": "record", "fields": [{"type": "long", "name": "f", "default": 1}]}`)
+		firstFingerprint, _ := first.Fingerprint64()
+		secondFingerprint, _ := second.Fingerprint64()
+		Expect(firstFingerprint).To(Equal(secondFingerprint))
+	})
+	It(`Refuse an invalid schema`, func() {
+		_, err := avro.NewArray(nil).CanonicalForm()
+		Expect(err).ToNot(BeNil())
+	})
+})
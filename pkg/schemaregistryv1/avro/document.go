@@ -0,0 +1,221 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package avro
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalJSON returns the JSON form of the schema. A named type is written in full where it first appears and by
+// its full name after that.
+func (schema *Schema) MarshalJSON() ([]byte, error) {
+	document, err := schema.document()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(document)
+}
+
+// UnmarshalJSON parses and validates the JSON form of a schema.
+func (schema *Schema) UnmarshalJSON(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*schema = *parsed
+	return nil
+}
+
+// Map : returns the schema as a document suitable for CreateSchemaOptions.Schema and CreateVersionOptions.Schema. A
+// primitive type is written as an object such as {"type": "string"}. A union cannot be written as an object, so it
+// must be the type of a record field rather than a schema of its own.
+func (schema *Schema) Map() (map[string]interface{}, error) {
+	document, err := schema.document()
+	if err != nil {
+		return nil, err
+	}
+	switch value := document.(type) {
+	case map[string]interface{}:
+		return value, nil
+	case string:
+		return map[string]interface{}{"type": value}, nil
+	}
+	return nil, &SchemaError{Path: "(root)", Message: "a union cannot be registered as a schema of its own"}
+}
+
+// Validate : returns a *SchemaError describing the first problem with the schema, or nil if it is valid. Schemas
+// returned by Parse and ParseMap are always valid; schemas built with the constructors should be validated before
+// they are used.
+func (schema *Schema) Validate() error {
+	document, err := schema.document()
+	if err != nil {
+		return err
+	}
+	_, err = parseDocument(document)
+	return err
+}
+
+// document returns the JSON-decoded form of the schema.
+func (schema *Schema) document() (interface{}, error) {
+	w := &documentWriter{written: make(map[*Schema]bool)}
+	return w.write(schema, "", "")
+}
+
+// documentWriter holds the named types written so far.
+type documentWriter struct {
+	written map[*Schema]bool
+}
+
+// write returns the document of the schema at the path, omitting namespaces that match the enclosing namespace.
+func (w *documentWriter) write(schema *Schema, namespace string, path string) (interface{}, error) {
+	if schema == nil {
+		return nil, schemaError(path, "the schema is missing")
+	}
+	if schema.Type.IsPrimitive() {
+		if schema.LogicalType == "" && len(schema.Properties) == 0 {
+			return string(schema.Type), nil
+		}
+		object := withProperties(schema.Properties)
+		object["type"] = string(schema.Type)
+		writeLogicalType(object, schema)
+		return object, nil
+	}
+	switch schema.Type {
+	case TypeArray:
+		items, err := w.write(schema.Items, namespace, path+"[]")
+		if err != nil {
+			return nil, err
+		}
+		object := withProperties(schema.Properties)
+		object["type"], object["items"] = string(TypeArray), items
+		return object, nil
+	case TypeMap:
+		values, err := w.write(schema.Values, namespace, path+"{}")
+		if err != nil {
+			return nil, err
+		}
+		object := withProperties(schema.Properties)
+		object["type"], object["values"] = string(TypeMap), values
+		return object, nil
+	case TypeUnion:
+		branches := make([]interface{}, len(schema.Branches))
+		for i, branch := range schema.Branches {
+			document, err := w.write(branch, namespace, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			branches[i] = document
+		}
+		return branches, nil
+	case TypeRecord, TypeEnum, TypeFixed:
+		return w.writeNamed(schema, namespace, path)
+	}
+	return nil, schemaError(path, "unknown type %q", schema.Type)
+}
+
+// writeNamed returns the document of a record, enum or fixed schema, or its full name if it has already been written.
+func (w *documentWriter) writeNamed(schema *Schema, namespace string, path string) (interface{}, error) {
+	if w.written[schema] {
+		return schema.FullName(), nil
+	}
+	w.written[schema] = true
+	if path == "" {
+		path = schema.Name
+	}
+
+	object := withProperties(schema.Properties)
+	object["type"], object["name"] = string(schema.Type), schema.Name
+	if schema.Namespace != namespace && !containsDot(schema.Name) {
+		object["namespace"] = schema.Namespace
+	}
+	if schema.Doc != "" {
+		object["doc"] = schema.Doc
+	}
+	if len(schema.Aliases) > 0 {
+		object["aliases"] = stringsToJSON(schema.Aliases)
+	}
+	namespace = namespaceOf(schema.FullName())
+
+	switch schema.Type {
+	case TypeRecord:
+		fields := make([]interface{}, len(schema.Fields))
+		for i, field := range schema.Fields {
+			if field == nil {
+				return nil, schemaError(path, "field %d is missing", i)
+			}
+			fieldType, err := w.write(field.Type, namespace, path+"."+field.Name)
+			if err != nil {
+				return nil, err
+			}
+			fieldObject := withProperties(field.Properties)
+			fieldObject["name"], fieldObject["type"] = field.Name, fieldType
+			if field.Doc != "" {
+				fieldObject["doc"] = field.Doc
+			}
+			if field.HasDefault {
+				fieldObject["default"] = field.Default
+			}
+			if field.Order != "" {
+				fieldObject["order"] = field.Order
+			}
+			if len(field.Aliases) > 0 {
+				fieldObject["aliases"] = stringsToJSON(field.Aliases)
+			}
+			fields[i] = fieldObject
+		}
+		object["fields"] = fields
+	case TypeEnum:
+		object["symbols"] = stringsToJSON(schema.Symbols)
+		if schema.EnumDefault != "" {
+			object["default"] = schema.EnumDefault
+		}
+	case TypeFixed:
+		object["size"] = float64(schema.Size)
+		writeLogicalType(object, schema)
+	}
+	return object, nil
+}
+
+// writeLogicalType adds the logical type of a primitive or fixed schema to its document.
+func writeLogicalType(object map[string]interface{}, schema *Schema) {
+	if schema.LogicalType == "" {
+		return
+	}
+	object["logicalType"] = schema.LogicalType
+	if schema.LogicalType == LogicalTypeDecimal {
+		object["precision"], object["scale"] = float64(schema.Precision), float64(schema.Scale)
+	}
+}
+
+// withProperties returns a new object holding a copy of the properties.
+func withProperties(properties map[string]interface{}) map[string]interface{} {
+	object := make(map[string]interface{}, len(properties)+4)
+	for key, value := range properties {
+		object[key] = value
+	}
+	return object
+}
+
+// stringsToJSON returns the strings as a JSON-decoded array.
+func stringsToJSON(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, value := range values {
+		result[i] = value
+	}
+	return result
+}
@@ -0,0 +1,587 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package avro
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// SchemaError : A problem with an Avro schema.
+type SchemaError struct {
+	// The location of the problem, as a dotted path of record and field names, where [] is the items of an array, {}
+	// is the values of a map and [n] is a branch of a union.
+	Path string
+
+	// A description of the problem.
+	Message string
+}
+
+// Error returns a description of the problem.
+func (schemaError *SchemaError) Error() string {
+	return fmt.Sprintf("avro: invalid schema at %s: %s", schemaError.Path, schemaError.Message)
+}
+
+// namePattern matches each part of a valid name.
+var namePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Attributes with a meaning to Avro, which are not kept as properties.
+var (
+	primitiveAttributes = map[string]bool{"type": true, "logicalType": true, "precision": true, "scale": true}
+	recordAttributes    = map[string]bool{"type": true, "name": true, "namespace": true, "aliases": true, "doc": true, "fields": true}
+	enumAttributes      = map[string]bool{"type": true, "name": true, "namespace": true, "aliases": true, "doc": true, "symbols": true, "default": true}
+	fixedAttributes     = map[string]bool{"type": true, "name": true, "namespace": true, "aliases": true, "size": true, "logicalType": true, "precision": true, "scale": true}
+	arrayAttributes     = map[string]bool{"type": true, "items": true}
+	mapAttributes       = map[string]bool{"type": true, "values": true}
+	fieldAttributes     = map[string]bool{"name": true, "type": true, "doc": true, "default": true, "order": true, "aliases": true}
+)
+
+// Parse : parses and validates a schema in its JSON form.
+func Parse(text string) (*Schema, error) {
+	var document interface{}
+	if err := json.Unmarshal([]byte(text), &document); err != nil {
+		return nil, &SchemaError{Path: "(root)", Message: fmt.Sprintf("the schema is not valid JSON: %s", err.Error())}
+	}
+	return parseDocument(document)
+}
+
+// ParseMap : parses and validates a schema document such as the result of GetLatestSchema or GetVersion.
+func ParseMap(document map[string]interface{}) (*Schema, error) {
+	return parseDocument(document)
+}
+
+// parseDocument parses and validates a JSON-decoded schema.
+func parseDocument(document interface{}) (*Schema, error) {
+	p := &parser{names: make(map[string]*Schema)}
+	return p.parse(document, "", "")
+}
+
+// parser holds the named types declared so far while parsing a schema.
+type parser struct {
+	names map[string]*Schema
+}
+
+// parse parses the schema at the path, resolving unqualified names in the namespace.
+func (p *parser) parse(document interface{}, namespace string, path string) (*Schema, error) {
+	switch value := document.(type) {
+	case string:
+		return p.reference(value, namespace, path)
+	case []interface{}:
+		return p.parseUnion(value, namespace, path)
+	case map[string]interface{}:
+		return p.parseObject(value, namespace, path)
+	}
+	return nil, schemaError(path, "a schema must be a string, an array or an object, not %s", describeJSON(document))
+}
+
+// reference resolves a primitive type name or the name of a named type that has already been declared.
+func (p *parser) reference(name string, namespace string, path string) (*Schema, error) {
+	if Type(name).IsPrimitive() {
+		return Primitive(Type(name)), nil
+	}
+	if named, ok := p.names[qualify(name, namespace)]; ok {
+		return named, nil
+	}
+	if named, ok := p.names[name]; ok {
+		return named, nil
+	}
+	return nil, schemaError(path, "unknown type %q", name)
+}
+
+// parseUnion parses a union, which may not directly contain another union or two branches of the same type.
+func (p *parser) parseUnion(branches []interface{}, namespace string, path string) (*Schema, error) {
+	union := &Schema{Type: TypeUnion}
+	seen := make(map[string]bool)
+	for i, branch := range branches {
+		parsed, err := p.parse(branch, namespace, fmt.Sprintf("%s[%d]", path, i))
+		if err != nil {
+			return nil, err
+		}
+		if parsed.Type == TypeUnion {
+			return nil, schemaError(path, "a union may not immediately contain another union")
+		}
+		key := string(parsed.Type)
+		if parsed.Type.IsNamed() {
+			key = parsed.FullName()
+		}
+		if seen[key] {
+			return nil, schemaError(path, "a union may not contain %s more than once", key)
+		}
+		seen[key] = true
+		union.Branches = append(union.Branches, parsed)
+	}
+	return union, nil
+}
+
+// parseObject parses a schema written as a JSON object.
+func (p *parser) parseObject(object map[string]interface{}, namespace string, path string) (*Schema, error) {
+	kind, ok := object["type"].(string)
+	if !ok {
+		if nested, isNested := object["type"]; isNested {
+			// A type such as {"type": {"type": "array", ...}} or {"type": ["null", "string"]}.
+			return p.parse(nested, namespace, path)
+		}
+		return nil, schemaError(path, "the schema has no \"type\"")
+	}
+	switch Type(kind) {
+	case TypeRecord, "error", TypeEnum, TypeFixed:
+		return p.parseNamed(object, kind, namespace, path)
+	case TypeArray:
+		items, ok := object["items"]
+		if !ok {
+			return nil, schemaError(path, "an array schema must have \"items\"")
+		}
+		parsed, err := p.parse(items, namespace, path+"[]")
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: TypeArray, Items: parsed, Properties: properties(object, arrayAttributes)}, nil
+	case TypeMap:
+		values, ok := object["values"]
+		if !ok {
+			return nil, schemaError(path, "a map schema must have \"values\"")
+		}
+		parsed, err := p.parse(values, namespace, path+"{}")
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: TypeMap, Values: parsed, Properties: properties(object, mapAttributes)}, nil
+	}
+	if !Type(kind).IsPrimitive() {
+		// A reference to a named type, written as an object.
+		return p.reference(kind, namespace, path)
+	}
+	primitive := &Schema{Type: Type(kind), Properties: properties(object, primitiveAttributes)}
+	if err := parseLogicalType(primitive, object, path); err != nil {
+		return nil, err
+	}
+	return primitive, nil
+}
+
+// parseNamed parses a record, error, enum or fixed schema and declares its name.
+func (p *parser) parseNamed(object map[string]interface{}, kind string, namespace string, path string) (*Schema, error) {
+	name, ok := object["name"].(string)
+	if !ok || name == "" {
+		return nil, schemaError(path, "a %s schema must have a \"name\"", kind)
+	}
+	named := &Schema{Type: Type(kind)}
+	if kind == "error" {
+		named.Type = TypeRecord
+	}
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		named.Name, named.Namespace = name[i+1:], name[:i]
+	} else {
+		named.Name = name
+		named.Namespace = namespace
+		if explicit, ok := object["namespace"].(string); ok {
+			named.Namespace = explicit
+		}
+	}
+	if path == "" {
+		path = named.Name
+	}
+	if err := validateName(named.FullName(), path); err != nil {
+		return nil, err
+	}
+	if _, exists := p.names[named.FullName()]; exists {
+		return nil, schemaError(path, "the type %s is declared more than once", named.FullName())
+	}
+	namespace = named.Namespace
+	for _, alias := range stringList(object["aliases"]) {
+		named.Aliases = append(named.Aliases, alias)
+	}
+	named.Doc, _ = object["doc"].(string)
+	p.names[named.FullName()] = named
+
+	switch named.Type {
+	case TypeRecord:
+		named.Properties = properties(object, recordAttributes)
+		fields, ok := object["fields"].([]interface{})
+		if !ok {
+			return nil, schemaError(path, "a record schema must have a \"fields\" array")
+		}
+		seen := make(map[string]bool)
+		for i, document := range fields {
+			fieldObject, ok := document.(map[string]interface{})
+			if !ok {
+				return nil, schemaError(path, "field %d is not an object", i)
+			}
+			field, err := p.parseField(fieldObject, i, namespace, path)
+			if err != nil {
+				return nil, err
+			}
+			if seen[field.Name] {
+				return nil, schemaError(path, "field %s is declared more than once", field.Name)
+			}
+			seen[field.Name] = true
+			named.Fields = append(named.Fields, field)
+		}
+	case TypeEnum:
+		named.Properties = properties(object, enumAttributes)
+		symbols, ok := object["symbols"].([]interface{})
+		if !ok {
+			return nil, schemaError(path, "an enum schema must have a \"symbols\" array")
+		}
+		seen := make(map[string]bool)
+		for _, symbol := range symbols {
+			text, ok := symbol.(string)
+			if !ok || !namePattern.MatchString(text) {
+				return nil, schemaError(path, "the enum symbol %v is not a valid name", symbol)
+			}
+			if seen[text] {
+				return nil, schemaError(path, "the enum symbol %s is declared more than once", text)
+			}
+			seen[text] = true
+			named.Symbols = append(named.Symbols, text)
+		}
+		if enumDefault, ok := object["default"]; ok {
+			text, _ := enumDefault.(string)
+			if !seen[text] {
+				return nil, schemaError(path, "the enum default %v is not one of its symbols", enumDefault)
+			}
+			named.EnumDefault = text
+		}
+	case TypeFixed:
+		named.Properties = properties(object, fixedAttributes)
+		size, ok := toInt(object["size"])
+		if !ok || size < 0 {
+			return nil, schemaError(path, "a fixed schema must have a non-negative integer \"size\"")
+		}
+		named.Size = size
+		if err := parseLogicalType(named, object, path); err != nil {
+			return nil, err
+		}
+	}
+	return named, nil
+}
+
+// parseField parses the field of a record at index i.
+func (p *parser) parseField(object map[string]interface{}, i int, namespace string, path string) (*Field, error) {
+	name, ok := object["name"].(string)
+	if !ok || name == "" {
+		return nil, schemaError(path, "field %d has no \"name\"", i)
+	}
+	fieldPath := path + "." + name
+	if !namePattern.MatchString(name) {
+		return nil, schemaError(fieldPath, "%q is not a valid field name", name)
+	}
+	fieldType, ok := object["type"]
+	if !ok {
+		return nil, schemaError(fieldPath, "the field has no \"type\"")
+	}
+	parsed, err := p.parse(fieldType, namespace, fieldPath)
+	if err != nil {
+		return nil, err
+	}
+	field := &Field{Name: name, Type: parsed, Aliases: stringList(object["aliases"]), Properties: properties(object, fieldAttributes)}
+	field.Doc, _ = object["doc"].(string)
+	if order, ok := object["order"]; ok {
+		field.Order, _ = order.(string)
+		if field.Order != "ascending" && field.Order != "descending" && field.Order != "ignore" {
+			return nil, schemaError(fieldPath, "the order %v is not ascending, descending or ignore", order)
+		}
+	}
+	if defaultValue, ok := object["default"]; ok {
+		if problem := checkDefault(parsed, defaultValue, make(map[*Schema]bool)); problem != "" {
+			return nil, schemaError(fieldPath, "the default %s is not valid: %s", describeValue(defaultValue), problem)
+		}
+		field.HasDefault = true
+		field.Default = defaultValue
+	}
+	return field, nil
+}
+
+// parseLogicalType reads and validates the logical type of a primitive or fixed schema.
+func parseLogicalType(schema *Schema, object map[string]interface{}, path string) error {
+	logicalType, ok := object["logicalType"].(string)
+	if !ok {
+		return nil
+	}
+	schema.LogicalType = logicalType
+	expect := func(types ...Type) error {
+		for _, t := range types {
+			if schema.Type == t {
+				return nil
+			}
+		}
+		return schemaError(path, "the logical type %s cannot annotate %s", logicalType, schema.Type)
+	}
+	switch logicalType {
+	case LogicalTypeDecimal:
+		if err := expect(TypeBytes, TypeFixed); err != nil {
+			return err
+		}
+		precision, ok := toInt(object["precision"])
+		if !ok || precision <= 0 {
+			return schemaError(path, "a decimal must have a positive integer \"precision\"")
+		}
+		scale := 0
+		if _, hasScale := object["scale"]; hasScale {
+			scale, ok = toInt(object["scale"])
+			if !ok || scale < 0 || scale > precision {
+				return schemaError(path, "the scale of a decimal must be an integer from 0 to its precision %d", precision)
+			}
+		}
+		if schema.Type == TypeFixed {
+			if limit := int(math.Floor(math.Log10(2) * float64(8*schema.Size-1))); precision > limit {
+				return schemaError(path, "a fixed of size %d can hold a decimal precision of at most %d, not %d",
+					schema.Size, limit, precision)
+			}
+		}
+		schema.Precision, schema.Scale = precision, scale
+	case LogicalTypeUUID:
+		return expect(TypeString)
+	case LogicalTypeDate, LogicalTypeTimeMillis:
+		return expect(TypeInt)
+	case LogicalTypeTimeMicros, LogicalTypeTimestampMillis, LogicalTypeTimestampMicros,
+		LogicalTypeLocalTimestampMillis, LogicalTypeLocalTimestampMicros:
+		return expect(TypeLong)
+	case LogicalTypeDuration:
+		if err := expect(TypeFixed); err != nil {
+			return err
+		}
+		if schema.Size != 12 {
+			return schemaError(path, "a duration must be a fixed of size 12, not %d", schema.Size)
+		}
+	}
+	return nil
+}
+
+// checkDefault returns a description of why the JSON value is not a valid default for the schema, or the empty string
+// if it is valid. The default of a union is a value of its first branch.
+func checkDefault(schema *Schema, value interface{}, visiting map[*Schema]bool) string {
+	mismatch := fmt.Sprintf("expected %s", describeType(schema))
+	switch schema.Type {
+	case TypeNull:
+		if value != nil {
+			return mismatch
+		}
+	case TypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return mismatch
+		}
+	case TypeInt, TypeLong:
+		number, ok := toFloat(value)
+		if !ok || number != math.Trunc(number) {
+			return mismatch
+		}
+		if schema.Type == TypeInt && (number < math.MinInt32 || number > math.MaxInt32) {
+			return fmt.Sprintf("%v is out of range for int", value)
+		}
+	case TypeFloat, TypeDouble:
+		if _, ok := toFloat(value); !ok {
+			return mismatch
+		}
+	case TypeBytes, TypeString:
+		if _, ok := value.(string); !ok {
+			return mismatch
+		}
+	case TypeFixed:
+		text, ok := value.(string)
+		if !ok {
+			return mismatch
+		}
+		if length := len([]rune(text)); length != schema.Size {
+			return fmt.Sprintf("expected %d bytes, not %d", schema.Size, length)
+		}
+	case TypeEnum:
+		symbol, ok := value.(string)
+		if !ok {
+			return mismatch
+		}
+		for _, candidate := range schema.Symbols {
+			if candidate == symbol {
+				return ""
+			}
+		}
+		return fmt.Sprintf("%q is not a symbol of %s", symbol, schema.FullName())
+	case TypeArray:
+		items, ok := value.([]interface{})
+		if !ok {
+			return mismatch
+		}
+		for i, item := range items {
+			if problem := checkDefault(schema.Items, item, visiting); problem != "" {
+				return fmt.Sprintf("item %d: %s", i, problem)
+			}
+		}
+	case TypeMap:
+		entries, ok := value.(map[string]interface{})
+		if !ok {
+			return mismatch
+		}
+		for key, entry := range entries {
+			if problem := checkDefault(schema.Values, entry, visiting); problem != "" {
+				return fmt.Sprintf("value %q: %s", key, problem)
+			}
+		}
+	case TypeUnion:
+		if len(schema.Branches) == 0 {
+			return "an empty union has no values"
+		}
+		if problem := checkDefault(schema.Branches[0], value, visiting); problem != "" {
+			return fmt.Sprintf("a union default must match its first branch, %s", problem)
+		}
+	case TypeRecord:
+		record, ok := value.(map[string]interface{})
+		if !ok {
+			return mismatch
+		}
+		if visiting[schema] {
+			return ""
+		}
+		visiting[schema] = true
+		defer delete(visiting, schema)
+		for _, field := range schema.Fields {
+			fieldValue, present := record[field.Name]
+			if !present {
+				if field.HasDefault {
+					continue
+				}
+				return fmt.Sprintf("field %s has no value", field.Name)
+			}
+			if problem := checkDefault(field.Type, fieldValue, visiting); problem != "" {
+				return fmt.Sprintf("field %s: %s", field.Name, problem)
+			}
+		}
+	}
+	return ""
+}
+
+// validateName returns an error if a full name has a part that is not a valid name.
+func validateName(fullName string, path string) error {
+	for _, part := range strings.Split(fullName, ".") {
+		if !namePattern.MatchString(part) {
+			return schemaError(path, "%q is not a valid name", fullName)
+		}
+	}
+	return nil
+}
+
+// qualify returns the full name of a type, qualifying it with the namespace unless it is already qualified.
+func qualify(name string, namespace string) string {
+	if strings.Contains(name, ".") || namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// namespaceOf returns the namespace part of a full name.
+func namespaceOf(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[:i]
+	}
+	return ""
+}
+
+// containsDot returns true if a name is a full name.
+func containsDot(name string) bool {
+	return strings.Contains(name, ".")
+}
+
+// properties returns the attributes of an object that have no meaning to Avro, or nil if there are none.
+func properties(object map[string]interface{}, attributes map[string]bool) map[string]interface{} {
+	var result map[string]interface{}
+	for key, value := range object {
+		if attributes[key] {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]interface{})
+		}
+		result[key] = value
+	}
+	return result
+}
+
+// stringList returns the strings in a JSON array, ignoring any other values.
+func stringList(document interface{}) []string {
+	list, _ := document.([]interface{})
+	var result []string
+	for _, item := range list {
+		if text, ok := item.(string); ok {
+			result = append(result, text)
+		}
+	}
+	return result
+}
+
+// toFloat converts a JSON number to float64.
+func toFloat(value interface{}) (float64, bool) {
+	switch number := value.(type) {
+	case float64:
+		return number, true
+	case json.Number:
+		f, err := number.Float64()
+		return f, err == nil
+	case int:
+		return float64(number), true
+	case int64:
+		return float64(number), true
+	}
+	return 0, false
+}
+
+// toInt converts a JSON number with an integral value to int.
+func toInt(value interface{}) (int, bool) {
+	number, ok := toFloat(value)
+	if !ok || number != math.Trunc(number) || math.Abs(number) > math.MaxInt32 {
+		return 0, false
+	}
+	return int(number), true
+}
+
+// describeType describes a schema for messages.
+func describeType(schema *Schema) string {
+	if schema.Type.IsNamed() {
+		return string(schema.Type) + " " + schema.FullName()
+	}
+	return string(schema.Type)
+}
+
+// describeJSON describes the JSON type of a decoded value for messages.
+func describeJSON(document interface{}) string {
+	switch document.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "a boolean"
+	case float64, json.Number:
+		return "a number"
+	}
+	return fmt.Sprintf("%T", document)
+}
+
+// describeValue returns the JSON form of a value for messages.
+func describeValue(value interface{}) string {
+	text, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(text)
+}
+
+// schemaError returns a SchemaError at the path, naming the top level of the schema if the path is empty.
+func schemaError(path string, format string, args ...interface{}) *SchemaError {
+	if path == "" {
+		path = "(root)"
+	}
+	return &SchemaError{Path: path, Message: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,133 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package avro_test
+
+import (
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/avro"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Parse(text string)`, func() {
+	// parseError returns the error from parsing the schema.
+	parseError := func(text string) string {
+		_, err := avro.Parse(text)
+		Expect(err).ToNot(BeNil())
+		return err.Error()
+	}
+
+	It(`Parse every kind of schema`, func() {
+		schema, err := avro.Parse(`{"type": "record", "name": "Payment", "namespace": "com.example", "doc": "A payment",
+			"owner": "payments-team", "fields": [
+			{"name": "id", "type": {"type": "string", "logicalType": "uuid"}},
+			{"name": "amount", "type": {"type": "fixed", "name": "Amount", "size": 8, "logicalType": "decimal", "precision": 18, "scale": 2}},
+			{"name": "at", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+			{"name": "currency", "type": {"type": "enum", "name": "Currency", "symbols": ["EUR", "USD", "OTHER"], "default": "OTHER"}},
+			{"name": "tags", "type": {"type": "map", "values": "string"}, "default": {}},
+			{"name": "previous", "type": ["null", "Payment"], "default": null, "order": "ignore", "aliases": ["prior"]}]}`)
+		Expect(err).To(BeNil())
+		Expect(schema.FullName()).To(Equal("com.example.Payment"))
+		Expect(schema.Doc).To(Equal("A payment"))
+		Expect(schema.Properties).To(Equal(map[string]interface{}{"owner": "payments-team"}))
+		Expect(schema.Field("id").Type.LogicalType).To(Equal(avro.LogicalTypeUUID))
+
+		amount := schema.Field("amount").Type
+		Expect(amount.Type).To(Equal(avro.TypeFixed))
+		Expect(amount.Namespace).To(Equal("com.example"))
+		Expect([]int{amount.Size, amount.Precision, amount.Scale}).To(Equal([]int{8, 18, 2}))
+
+		Expect(schema.Field("currency").Type.EnumDefault).To(Equal("OTHER"))
+		Expect(schema.Field("tags").Type.Values.Type).To(Equal(avro.TypeString))
+
+		previous := schema.Field("previous")
+		Expect(previous.Type.Branches[1]).To(BeIdenticalTo(schema))
+		Expect(previous.HasDefault).To(BeTrue())
+		Expect(previous.Default).To(BeNil())
+		Expect(previous.Order).To(Equal("ignore"))
+		Expect(previous.Aliases).To(Equal([]string{"prior"}))
+	})
+	It(`Parse a schema document`, func() {
+		schema, err := avro.ParseMap(map[string]interface{}{"type": "array", "items": "long"})
+		Expect(err).To(BeNil())
+		Expect(schema.Items.Type).To(Equal(avro.TypeLong))
+	})
+	It(`Report where a schema is invalid`, func() {
+		_, err := avro.Parse(`{"type": "record", "name": "Payment", "fields": [{"name": "amount", "type": "money"}]}`)
+		schemaError, ok := err.(*avro.SchemaError)
+		Expect(ok).To(BeTrue())
+		Expect(schemaError.Path).To(Equal("Payment.amount"))
+		Expect(schemaError.Message).To(Equal(`unknown type "money"`))
+
+		Expect(parseError(`{"type": "record", "name": "Payment"`)).To(ContainSubstring("not valid JSON"))
+		Expect(parseError(`{"type": "record", "name": "P", "fields": [{"name": "a", "type": {"type": "array"}}]}`)).To(
+			Equal(`avro: invalid schema at P.a: an array schema must have "items"`))
+		Expect(parseError(`{"type": "record", "name": "P", "fields": [{"name": "a", "type": ["null", ["string"]]}]}`)).To(
+			ContainSubstring("a union may not immediately contain another union"))
+		Expect(parseError(`{"type": "record", "name": "P", "fields": [{"name": "a", "type": {"type": "map", "values": ["int", "int"]}}]}`)).To(
+			Equal(`avro: invalid schema at P.a{}: a union may not contain int more than once`))
+		Expect(parseError(`{"type": "record", "name": "P", "fields": [{"name": "a", "type": "int"}, {"name": "a", "type": "int"}]}`)).To(
+			ContainSubstring("field a is declared more than once"))
+		Expect(parseError(`{"type": "record", "name": "P", "fields": [{"name": "a-b", "type": "int"}]}`)).To(
+			ContainSubstring(`"a-b" is not a valid field name`))
+		Expect(parseError(`{"type": "record", "name": "P", "fields": [{"name": "a", "type": "int", "order": "up"}]}`)).To(
+			ContainSubstring("the order up is not ascending, descending or ignore"))
+		Expect(parseError(`{"type": "record", "name": "com.example.1P", "fields": []}`)).To(
+			ContainSubstring(`"com.example.1P" is not a valid name`))
+		Expect(parseError(`{"type": "enum", "name": "E", "symbols": ["A", "A"]}`)).To(
+			ContainSubstring("the enum symbol A is declared more than once"))
+		Expect(parseError(`{"type": "enum", "name": "E", "symbols": ["A"], "default": "B"}`)).To(
+			ContainSubstring("the enum default B is not one of its symbols"))
+		Expect(parseError(`{"type": "fixed", "name": "F", "size": 1.5}`)).To(
+			ContainSubstring(`a fixed schema must have a non-negative integer "size"`))
+		Expect(parseError(`[{"type": "fixed", "name": "F", "size": 1}, {"type": "enum", "name": "F", "symbols": []}]`)).To(
+			Equal(`avro: invalid schema at [1]: the type F is declared more than once`))
+	})
+	It(`Validate defaults`, func() {
+		Expect(parseError(`{"type": "record", "name": "P", "fields": [{"name": "a", "type": "int", "default": 1.5}]}`)).To(
+			Equal(`avro: invalid schema at P.a: the default 1.5 is not valid: expected int`))
+		Expect(parseError(`{"type": "record", "name": "P", "fields": [{"name": "a", "type": "int", "default": 3000000000}]}`)).To(
+			ContainSubstring("3e+09 is out of range for int"))
+		Expect(parseError(`{"type": "record", "name": "P", "fields": [{"name": "a", "type": ["null", "string"], "default": "x"}]}`)).To(
+			ContainSubstring("a union default must match its first branch, expected null"))
+		Expect(parseError(`{"type": "record", "name": "P", "fields": [{"name": "a", "type": {"type": "array", "items": "int"}, "default": [1, "2"]}]}`)).To(
+			ContainSubstring("item 1: expected int"))
+		Expect(parseError(`{"type": "record", "name": "P", "fields": [{"name": "a", "default": "Z",
+			"type": {"type": "enum", "name": "E", "symbols": ["A"]}}]}`)).To(ContainSubstring(`"Z" is not a symbol of E`))
+		Expect(parseError(`{"type": "record", "name": "P", "fields": [{"name": "a", "default": "abc",
+			"type": {"type": "fixed", "name": "F", "size": 2}}]}`)).To(ContainSubstring("expected 2 bytes, not 3"))
+		Expect(parseError(`{"type": "record", "name": "P", "fields": [{"name": "a", "default": {"x": 1},
+			"type": {"type": "record", "name": "Q", "fields": [{"name": "x", "type": "int"}, {"name": "y", "type": "string"}]}}]}`)).To(
+			ContainSubstring("field y has no value"))
+	})
+	It(`Validate logical types`, func() {
+		Expect(parseError(`{"type": "string", "logicalType": "date"}`)).To(
+			Equal(`avro: invalid schema at (root): the logical type date cannot annotate string`))
+		Expect(parseError(`{"type": "bytes", "logicalType": "decimal"}`)).To(
+			ContainSubstring(`a decimal must have a positive integer "precision"`))
+		Expect(parseError(`{"type": "bytes", "logicalType": "decimal", "precision": 4, "scale": 5}`)).To(
+			ContainSubstring("the scale of a decimal must be an integer from 0 to its precision 4"))
+		Expect(parseError(`{"type": "fixed", "name": "F", "size": 2, "logicalType": "decimal", "precision": 5}`)).To(
+			ContainSubstring("a fixed of size 2 can hold a decimal precision of at most 4, not 5"))
+		Expect(parseError(`{"type": "fixed", "name": "F", "size": 16, "logicalType": "duration"}`)).To(
+			ContainSubstring("a duration must be a fixed of size 12, not 16"))
+
+		schema, err := avro.Parse(`{"type": "int", "logicalType": "temperature", "unit": "celsius"}`)
+		Expect(err).To(BeNil())
+		Expect(schema.LogicalType).To(Equal("temperature"))
+		Expect(schema.Properties).To(Equal(map[string]interface{}{"unit": "celsius"}))
+	})
+})
@@ -0,0 +1,235 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package avro : A typed model of Avro schemas
+//
+// The schema registry holds Avro schemas, which SchemaregistryV1 passes around as map[string]interface{}. A Schema is
+// the typed equivalent, covering primitive types, records, enums, arrays, maps, unions, fixed types and logical
+// types. Parse and ParseMap validate a schema on the client, so that a mistake is reported with its location instead
+// of as an HTTP status code 400 from the registry, and CanonicalForm produces the Parsing Canonical Form used to
+// compare and fingerprint schemas.
+package avro
+
+import (
+	"strings"
+)
+
+// Type : The type of an Avro schema.
+type Type string
+
+// Avro types.
+const (
+	TypeNull    Type = "null"
+	TypeBoolean Type = "boolean"
+	TypeInt     Type = "int"
+	TypeLong    Type = "long"
+	TypeFloat   Type = "float"
+	TypeDouble  Type = "double"
+	TypeBytes   Type = "bytes"
+	TypeString  Type = "string"
+	TypeRecord  Type = "record"
+	TypeEnum    Type = "enum"
+	TypeArray   Type = "array"
+	TypeMap     Type = "map"
+	TypeUnion   Type = "union"
+	TypeFixed   Type = "fixed"
+)
+
+// IsPrimitive returns true for the primitive types, which can be referred to by name alone.
+func (t Type) IsPrimitive() bool {
+	switch t {
+	case TypeNull, TypeBoolean, TypeInt, TypeLong, TypeFloat, TypeDouble, TypeBytes, TypeString:
+		return true
+	}
+	return false
+}
+
+// IsNamed returns true for records, enums and fixed types, which have a name and may be referred to by it.
+func (t Type) IsNamed() bool {
+	return t == TypeRecord || t == TypeEnum || t == TypeFixed
+}
+
+// Logical types defined by the Avro specification.
+const (
+	LogicalTypeDecimal              = "decimal"
+	LogicalTypeUUID                 = "uuid"
+	LogicalTypeDate                 = "date"
+	LogicalTypeTimeMillis           = "time-millis"
+	LogicalTypeTimeMicros           = "time-micros"
+	LogicalTypeTimestampMillis      = "timestamp-millis"
+	LogicalTypeTimestampMicros      = "timestamp-micros"
+	LogicalTypeLocalTimestampMillis = "local-timestamp-millis"
+	LogicalTypeLocalTimestampMicros = "local-timestamp-micros"
+	LogicalTypeDuration             = "duration"
+)
+
+// Schema : An Avro schema.
+//
+// A named type that appears more than once in a schema, including a record that refers to itself, is represented by
+// the same *Schema at each place it appears.
+type Schema struct {
+	// The type of the schema.
+	Type Type
+
+	// The name of a record, enum or fixed type. It may be a full name, in which case Namespace is ignored.
+	Name string
+
+	// The namespace of a record, enum or fixed type.
+	Namespace string
+
+	// Alternative names of a record, enum or fixed type.
+	Aliases []string
+
+	// Documentation of a record or enum type.
+	Doc string
+
+	// The fields of a record.
+	Fields []*Field
+
+	// The symbols of an enum.
+	Symbols []string
+
+	// The symbol an enum reader uses for symbols it does not have. Empty if the enum has no default.
+	EnumDefault string
+
+	// The items of an array.
+	Items *Schema
+
+	// The values of a map.
+	Values *Schema
+
+	// The branches of a union.
+	Branches []*Schema
+
+	// The size in bytes of a fixed type.
+	Size int
+
+	// The logical type, such as LogicalTypeDecimal. Empty if there is none.
+	LogicalType string
+
+	// The precision and scale of a decimal.
+	Precision int
+	Scale     int
+
+	// Any other attributes of the schema, which are kept but have no meaning to Avro.
+	Properties map[string]interface{}
+}
+
+// Field : A field of a record.
+type Field struct {
+	// The name of the field.
+	Name string
+
+	// Alternative names of the field.
+	Aliases []string
+
+	// Documentation of the field.
+	Doc string
+
+	// The schema of the field's values.
+	Type *Schema
+
+	// True if the field has a default value, which may be nil for a null default.
+	HasDefault bool
+
+	// The default value, in its JSON form: nil, bool, float64, string, []interface{} or map[string]interface{}.
+	Default interface{}
+
+	// The sort order of the field: ascending, descending or ignore. Empty means ascending.
+	Order string
+
+	// Any other attributes of the field, which are kept but have no meaning to Avro.
+	Properties map[string]interface{}
+}
+
+// Primitive : returns a schema of a primitive type.
+func Primitive(t Type) *Schema {
+	return &Schema{Type: t}
+}
+
+// NewRecord : returns a record schema with the fields.
+func NewRecord(name string, namespace string, fields ...*Field) *Schema {
+	return &Schema{Type: TypeRecord, Name: name, Namespace: namespace, Fields: fields}
+}
+
+// NewEnum : returns an enum schema with the symbols.
+func NewEnum(name string, namespace string, symbols ...string) *Schema {
+	return &Schema{Type: TypeEnum, Name: name, Namespace: namespace, Symbols: symbols}
+}
+
+// NewFixed : returns a fixed schema of the size.
+func NewFixed(name string, namespace string, size int) *Schema {
+	return &Schema{Type: TypeFixed, Name: name, Namespace: namespace, Size: size}
+}
+
+// NewArray : returns an array schema with the items.
+func NewArray(items *Schema) *Schema {
+	return &Schema{Type: TypeArray, Items: items}
+}
+
+// NewMap : returns a map schema with the values.
+func NewMap(values *Schema) *Schema {
+	return &Schema{Type: TypeMap, Values: values}
+}
+
+// NewUnion : returns a union of the branches.
+func NewUnion(branches ...*Schema) *Schema {
+	return &Schema{Type: TypeUnion, Branches: branches}
+}
+
+// NewDecimal : returns a decimal logical type backed by bytes.
+func NewDecimal(precision int, scale int) *Schema {
+	return &Schema{Type: TypeBytes, LogicalType: LogicalTypeDecimal, Precision: precision, Scale: scale}
+}
+
+// NewField : returns a field without a default.
+func NewField(name string, schema *Schema) *Field {
+	return &Field{Name: name, Type: schema}
+}
+
+// WithDefault : sets the default value of the field, in its JSON form, and returns the field.
+func (field *Field) WithDefault(value interface{}) *Field {
+	field.HasDefault = true
+	field.Default = value
+	return field
+}
+
+// FullName returns the full name of a named type: its name qualified with its namespace.
+func (schema *Schema) FullName() string {
+	if strings.Contains(schema.Name, ".") || schema.Namespace == "" {
+		return schema.Name
+	}
+	return schema.Namespace + "." + schema.Name
+}
+
+// Field returns the field of a record with the name, or nil if there is none.
+func (schema *Schema) Field(name string) *Field {
+	for _, field := range schema.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// String returns the JSON form of the schema, or a description of the problem if it is not valid.
+func (schema *Schema) String() string {
+	text, err := schema.MarshalJSON()
+	if err != nil {
+		return err.Error()
+	}
+	return string(text)
+}
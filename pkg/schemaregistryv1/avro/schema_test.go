@@ -0,0 +1,83 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package avro_test
+
+import (
+	"encoding/json"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/avro"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Schema`, func() {
+	// citizen returns a record built with the constructors, which refers to its Country enum twice.
+	citizen := func() *avro.Schema {
+		country := avro.NewEnum("Country", "com.example", "GB", "FR", "US")
+		return avro.NewRecord("Citizen", "com.example",
+			avro.NewField("name", avro.Primitive(avro.TypeString)),
+			avro.NewField("age", avro.Primitive(avro.TypeInt)).WithDefault(0.0),
+			avro.NewField("nickname", avro.NewUnion(avro.Primitive(avro.TypeNull), avro.Primitive(avro.TypeString))).WithDefault(nil),
+			avro.NewField("born", country),
+			avro.NewField("visited", avro.NewArray(country)),
+			avro.NewField("balance", avro.NewDecimal(9, 2)),
+		)
+	}
+
+	It(`Write a schema built with the constructors`, func() {
+		schema := citizen()
+		Expect(schema.Validate()).To(Succeed())
+		Expect(schema.FullName()).To(Equal("com.example.Citizen"))
+		Expect(schema.Field("age").Default).To(Equal(0.0))
+		Expect(schema.Field("missing")).To(BeNil())
+
+		document, err := schema.Map()
+		Expect(err).To(BeNil())
+		Expect(document["namespace"]).To(Equal("com.example"))
+		fields := document["fields"].([]interface{})
+		Expect(fields[3].(map[string]interface{})["type"]).ToNot(HaveKey("namespace"))
+		Expect(fields[4].(map[string]interface{})["type"]).To(HaveKeyWithValue("items", "com.example.Country"))
+		Expect(fields[5].(map[string]interface{})["type"]).To(Equal(map[string]interface{}{
+			"type": "bytes", "logicalType": "decimal", "precision": 9.0, "scale": 2.0,
+		}))
+	})
+	It(`Round trip through JSON`, func() {
+		text, err := json.Marshal(citizen())
+		Expect(err).To(BeNil())
+		var parsed avro.Schema
+		Expect(json.Unmarshal(text, &parsed)).To(Succeed())
+		Expect(parsed.Field("born").Type).To(BeIdenticalTo(parsed.Field("visited").Type.Items))
+		Expect(parsed.String()).To(Equal(string(text)))
+	})
+	It(`Report problems with a schema built with the constructors`, func() {
+		schema := avro.NewRecord("Citizen", "",
+			avro.NewField("age", avro.Primitive(avro.TypeInt)).WithDefault("unknown"),
+		)
+		err := schema.Validate()
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal(`avro: invalid schema at Citizen.age: the default "unknown" is not valid: expected int`))
+
+		err = avro.NewRecord("Citizen", "", avro.NewField("address", nil)).Validate()
+		Expect(err.(*avro.SchemaError).Path).To(Equal("Citizen.address"))
+
+		_, err = avro.NewUnion(avro.Primitive(avro.TypeNull)).Map()
+		Expect(err).ToNot(BeNil())
+		document, err := avro.Primitive(avro.TypeString).Map()
+		Expect(err).To(BeNil())
+		Expect(document).To(Equal(map[string]interface{}{"type": "string"}))
+	})
+})
@@ -0,0 +1,262 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schemaregistryv1
+
+import (
+	"context"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/avro"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// The operations in this file are typed variants of CreateSchema, CreateVersion, UpdateSchema, GetLatestSchema and
+// GetVersion that accept and return an *avro.Schema. A schema is validated before it is sent, so that an invalid
+// schema fails with an *avro.SchemaError that describes the problem instead of with HTTP status code 400.
+
+// CreateAvroSchema : Create a schema from an *avro.Schema
+// Validates the schema and then creates it as CreateSchema does.
+func (schemaregistry *SchemaregistryV1) CreateAvroSchema(createAvroSchemaOptions *CreateAvroSchemaOptions) (result *SchemaMetadata, response *core.DetailedResponse, err error) {
+	return schemaregistry.CreateAvroSchemaWithContext(context.Background(), createAvroSchemaOptions)
+}
+
+// CreateAvroSchemaWithContext is an alternate form of the CreateAvroSchema method which supports a Context parameter
+func (schemaregistry *SchemaregistryV1) CreateAvroSchemaWithContext(ctx context.Context, createAvroSchemaOptions *CreateAvroSchemaOptions) (result *SchemaMetadata, response *core.DetailedResponse, err error) {
+	err = core.ValidateNotNil(createAvroSchemaOptions, "createAvroSchemaOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(createAvroSchemaOptions, "createAvroSchemaOptions")
+	if err != nil {
+		return
+	}
+	document, err := avroDocument(createAvroSchemaOptions.Schema)
+	if err != nil {
+		return
+	}
+	return schemaregistry.CreateSchemaWithContext(ctx, &CreateSchemaOptions{
+		Schema:  document,
+		ID:      createAvroSchemaOptions.ID,
+		Headers: createAvroSchemaOptions.Headers,
+	})
+}
+
+// CreateAvroVersion : Create a new version of a schema from an *avro.Schema
+// Validates the schema and then creates the version as CreateVersion does.
+func (schemaregistry *SchemaregistryV1) CreateAvroVersion(createAvroVersionOptions *CreateAvroVersionOptions) (result *SchemaMetadata, response *core.DetailedResponse, err error) {
+	return schemaregistry.CreateAvroVersionWithContext(context.Background(), createAvroVersionOptions)
+}
+
+// CreateAvroVersionWithContext is an alternate form of the CreateAvroVersion method which supports a Context parameter
+func (schemaregistry *SchemaregistryV1) CreateAvroVersionWithContext(ctx context.Context, createAvroVersionOptions *CreateAvroVersionOptions) (result *SchemaMetadata, response *core.DetailedResponse, err error) {
+	err = core.ValidateNotNil(createAvroVersionOptions, "createAvroVersionOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(createAvroVersionOptions, "createAvroVersionOptions")
+	if err != nil {
+		return
+	}
+	document, err := avroDocument(createAvroVersionOptions.Schema)
+	if err != nil {
+		return
+	}
+	return schemaregistry.CreateVersionWithContext(ctx, &CreateVersionOptions{
+		ID:      createAvroVersionOptions.ID,
+		Schema:  document,
+		Headers: createAvroVersionOptions.Headers,
+	})
+}
+
+// UpdateAvroSchema : Update a schema from an *avro.Schema
+// Validates the schema and then updates the schema as UpdateSchema does.
+func (schemaregistry *SchemaregistryV1) UpdateAvroSchema(updateAvroSchemaOptions *UpdateAvroSchemaOptions) (result *SchemaMetadata, response *core.DetailedResponse, err error) {
+	return schemaregistry.UpdateAvroSchemaWithContext(context.Background(), updateAvroSchemaOptions)
+}
+
+// UpdateAvroSchemaWithContext is an alternate form of the UpdateAvroSchema method which supports a Context parameter
+func (schemaregistry *SchemaregistryV1) UpdateAvroSchemaWithContext(ctx context.Context, updateAvroSchemaOptions *UpdateAvroSchemaOptions) (result *SchemaMetadata, response *core.DetailedResponse, err error) {
+	err = core.ValidateNotNil(updateAvroSchemaOptions, "updateAvroSchemaOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(updateAvroSchemaOptions, "updateAvroSchemaOptions")
+	if err != nil {
+		return
+	}
+	document, err := avroDocument(updateAvroSchemaOptions.Schema)
+	if err != nil {
+		return
+	}
+	return schemaregistry.UpdateSchemaWithContext(ctx, &UpdateSchemaOptions{
+		ID:      updateAvroSchemaOptions.ID,
+		Schema:  document,
+		Headers: updateAvroSchemaOptions.Headers,
+	})
+}
+
+// GetLatestAvroSchema : Get the latest version of a schema as an *avro.Schema
+// Retrieves the latest version of the schema as GetLatestSchema does and parses it.
+func (schemaregistry *SchemaregistryV1) GetLatestAvroSchema(getLatestSchemaOptions *GetLatestSchemaOptions) (result *avro.Schema, response *core.DetailedResponse, err error) {
+	return schemaregistry.GetLatestAvroSchemaWithContext(context.Background(), getLatestSchemaOptions)
+}
+
+// GetLatestAvroSchemaWithContext is an alternate form of the GetLatestAvroSchema method which supports a Context parameter
+func (schemaregistry *SchemaregistryV1) GetLatestAvroSchemaWithContext(ctx context.Context, getLatestSchemaOptions *GetLatestSchemaOptions) (result *avro.Schema, response *core.DetailedResponse, err error) {
+	document, response, err := schemaregistry.GetLatestSchemaWithContext(ctx, getLatestSchemaOptions)
+	if err != nil {
+		return
+	}
+	result, err = avro.ParseMap(document)
+	return
+}
+
+// GetAvroVersion : Get a version of a schema as an *avro.Schema
+// Retrieves the version of the schema as GetVersion does and parses it.
+func (schemaregistry *SchemaregistryV1) GetAvroVersion(getVersionOptions *GetVersionOptions) (result *avro.Schema, response *core.DetailedResponse, err error) {
+	return schemaregistry.GetAvroVersionWithContext(context.Background(), getVersionOptions)
+}
+
+// GetAvroVersionWithContext is an alternate form of the GetAvroVersion method which supports a Context parameter
+func (schemaregistry *SchemaregistryV1) GetAvroVersionWithContext(ctx context.Context, getVersionOptions *GetVersionOptions) (result *avro.Schema, response *core.DetailedResponse, err error) {
+	document, response, err := schemaregistry.GetVersionWithContext(ctx, getVersionOptions)
+	if err != nil {
+		return
+	}
+	result, err = avro.ParseMap(document)
+	return
+}
+
+// avroDocument validates a schema and returns it in the form sent to the registry.
+func avroDocument(schema *avro.Schema) (map[string]interface{}, error) {
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+	return schema.Map()
+}
+
+// CreateAvroSchemaOptions : The CreateAvroSchema options.
+type CreateAvroSchemaOptions struct {
+	// The Avro schema.
+	Schema *avro.Schema `json:"-" validate:"required"`
+
+	// The name to assign to the new schema. This must be unique. If this value is not specified then a UUID is used.
+	ID *string `json:"-"`
+
+	// Allows users to set headers on API requests
+	Headers map[string]string
+}
+
+// NewCreateAvroSchemaOptions : Instantiate CreateAvroSchemaOptions
+func (*SchemaregistryV1) NewCreateAvroSchemaOptions(schema *avro.Schema) *CreateAvroSchemaOptions {
+	return &CreateAvroSchemaOptions{
+		Schema: schema,
+	}
+}
+
+// SetSchema : Allow user to set Schema
+func (_options *CreateAvroSchemaOptions) SetSchema(schema *avro.Schema) *CreateAvroSchemaOptions {
+	_options.Schema = schema
+	return _options
+}
+
+// SetID : Allow user to set ID
+func (_options *CreateAvroSchemaOptions) SetID(ID string) *CreateAvroSchemaOptions {
+	_options.ID = core.StringPtr(ID)
+	return _options
+}
+
+// SetHeaders : Allow user to set Headers
+func (options *CreateAvroSchemaOptions) SetHeaders(param map[string]string) *CreateAvroSchemaOptions {
+	options.Headers = param
+	return options
+}
+
+// CreateAvroVersionOptions : The CreateAvroVersion options.
+type CreateAvroVersionOptions struct {
+	// A schema ID. This identifies the schema for which a new version will be created.
+	ID *string `json:"-" validate:"required,ne="`
+
+	// The Avro schema.
+	Schema *avro.Schema `json:"-" validate:"required"`
+
+	// Allows users to set headers on API requests
+	Headers map[string]string
+}
+
+// NewCreateAvroVersionOptions : Instantiate CreateAvroVersionOptions
+func (*SchemaregistryV1) NewCreateAvroVersionOptions(id string, schema *avro.Schema) *CreateAvroVersionOptions {
+	return &CreateAvroVersionOptions{
+		ID:     core.StringPtr(id),
+		Schema: schema,
+	}
+}
+
+// SetID : Allow user to set ID
+func (_options *CreateAvroVersionOptions) SetID(id string) *CreateAvroVersionOptions {
+	_options.ID = core.StringPtr(id)
+	return _options
+}
+
+// SetSchema : Allow user to set Schema
+func (_options *CreateAvroVersionOptions) SetSchema(schema *avro.Schema) *CreateAvroVersionOptions {
+	_options.Schema = schema
+	return _options
+}
+
+// SetHeaders : Allow user to set Headers
+func (options *CreateAvroVersionOptions) SetHeaders(param map[string]string) *CreateAvroVersionOptions {
+	options.Headers = param
+	return options
+}
+
+// UpdateAvroSchemaOptions : The UpdateAvroSchema options.
+type UpdateAvroSchemaOptions struct {
+	// The ID of the schema to update.
+	ID *string `json:"-" validate:"required,ne="`
+
+	// The Avro schema.
+	Schema *avro.Schema `json:"-" validate:"required"`
+
+	// Allows users to set headers on API requests
+	Headers map[string]string
+}
+
+// NewUpdateAvroSchemaOptions : Instantiate UpdateAvroSchemaOptions
+func (*SchemaregistryV1) NewUpdateAvroSchemaOptions(id string, schema *avro.Schema) *UpdateAvroSchemaOptions {
+	return &UpdateAvroSchemaOptions{
+		ID:     core.StringPtr(id),
+		Schema: schema,
+	}
+}
+
+// SetID : Allow user to set ID
+func (_options *UpdateAvroSchemaOptions) SetID(id string) *UpdateAvroSchemaOptions {
+	_options.ID = core.StringPtr(id)
+	return _options
+}
+
+// SetSchema : Allow user to set Schema
+func (_options *UpdateAvroSchemaOptions) SetSchema(schema *avro.Schema) *UpdateAvroSchemaOptions {
+	_options.Schema = schema
+	return _options
+}
+
+// SetHeaders : Allow user to set Headers
+func (options *UpdateAvroSchemaOptions) SetHeaders(param map[string]string) *UpdateAvroSchemaOptions {
+	options.Headers = param
+	return options
+}
@@ -0,0 +1,91 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schemaregistryv1_test
+
+import (
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/avro"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`SchemaregistryV1 Avro schemas`, func() {
+	var server *fake.Server
+	var schemaregistryService *schemaregistryv1.SchemaregistryV1
+
+	BeforeEach(func() {
+		server = fake.NewServer(nil)
+		var serviceErr error
+		schemaregistryService, serviceErr = server.NewClient()
+		Expect(serviceErr).To(BeNil())
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	// node returns a linked list record, which refers to itself.
+	node := func(fields ...*avro.Field) *avro.Schema {
+		schema := avro.NewRecord("Node", "com.example", avro.NewField("value", avro.Primitive(avro.TypeLong)))
+		schema.Fields = append(schema.Fields, avro.NewField("next", avro.NewUnion(avro.Primitive(avro.TypeNull), schema)).WithDefault(nil))
+		schema.Fields = append(schema.Fields, fields...)
+		return schema
+	}
+
+	It(`Create, update and get schemas as *avro.Schema`, func() {
+		metadata, _, err := schemaregistryService.CreateAvroSchema(schemaregistryService.NewCreateAvroSchemaOptions(node()).SetID("nodes"))
+		Expect(err).To(BeNil())
+		Expect(*metadata.Version).To(Equal(int64(1)))
+
+		label := avro.NewField("label", avro.Primitive(avro.TypeString)).WithDefault("")
+		metadata, _, err = schemaregistryService.CreateAvroVersion(schemaregistryService.NewCreateAvroVersionOptions("nodes", node(label)))
+		Expect(err).To(BeNil())
+		Expect(*metadata.Version).To(Equal(int64(2)))
+
+		metadata, _, err = schemaregistryService.UpdateAvroSchema(schemaregistryService.NewUpdateAvroSchemaOptions("nodes", node()))
+		Expect(err).To(BeNil())
+		Expect(*metadata.Version).To(Equal(int64(3)))
+
+		latest, response, err := schemaregistryService.GetLatestAvroSchema(schemaregistryService.NewGetLatestSchemaOptions("nodes"))
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(200))
+		Expect(latest.FullName()).To(Equal("com.example.Node"))
+		Expect(latest.Field("next").Type.Branches[1]).To(BeIdenticalTo(latest))
+
+		second, _, err := schemaregistryService.GetAvroVersion(schemaregistryService.NewGetVersionOptions("nodes", 2))
+		Expect(err).To(BeNil())
+		Expect(second.Field("label").Default).To(Equal(""))
+	})
+	It(`Reject an invalid schema without calling the registry`, func() {
+		invalid := node(avro.NewField("label", avro.Primitive(avro.TypeString)).WithDefault(0.0))
+		_, response, err := schemaregistryService.CreateAvroSchema(schemaregistryService.NewCreateAvroSchemaOptions(invalid))
+		Expect(response).To(BeNil())
+		Expect(err).To(BeAssignableToTypeOf(&avro.SchemaError{}))
+		Expect(err.Error()).To(Equal("avro: invalid schema at Node.label: the default 0 is not valid: expected string"))
+
+		_, _, err = schemaregistryService.CreateAvroVersion(schemaregistryService.NewCreateAvroVersionOptions("nodes", nil))
+		Expect(err).ToNot(BeNil())
+		_, _, err = schemaregistryService.UpdateAvroSchema(nil)
+		Expect(err).ToNot(BeNil())
+		Expect(server.RequestCount(fake.OperationCreateSchema) + server.RequestCount(fake.OperationCreateVersion)).To(Equal(0))
+	})
+	It(`Report the error from the registry`, func() {
+		_, response, err := schemaregistryService.GetLatestAvroSchema(schemaregistryService.NewGetLatestSchemaOptions("missing"))
+		Expect(err).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(404))
+	})
+})
@@ -0,0 +1,170 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatVersion is the version of the archive format written by Export. Import reads archives of this version only.
+const FormatVersion = 1
+
+// manifestName is the name of the archive entry that describes the rest of the archive. It is always the first entry.
+const manifestName = "manifest.json"
+
+// ErrInvalidArchive is returned by Import when the archive is not one written by Export.
+var ErrInvalidArchive = errors.New("backup: invalid archive")
+
+// Manifest : The first entry of an archive, which lists the schemas, versions and rules it holds.
+type Manifest struct {
+	// The version of the archive format.
+	FormatVersion int `json:"format_version"`
+
+	// When the archive was written.
+	ExportedAt time.Time `json:"exported_at"`
+
+	// The configuration of the global COMPATIBILITY rule.
+	GlobalRule string `json:"global_rule,omitempty"`
+
+	// The schemas in the archive, sorted by ID.
+	Schemas []SchemaEntry `json:"schemas"`
+}
+
+// SchemaEntry : A schema held by an archive.
+type SchemaEntry struct {
+	// The ID of the schema.
+	ID string `json:"id"`
+
+	// The version numbers of the schema, in ascending order. Each version is held in the entry named by versionPath.
+	Versions []int64 `json:"versions"`
+
+	// The configuration of the schema's COMPATIBILITY rule, or empty if it has none.
+	Rule string `json:"rule,omitempty"`
+}
+
+// archive is the content of an archive that has been read.
+type archive struct {
+	manifest  Manifest
+	documents map[string]map[int64]map[string]interface{}
+}
+
+// versionPath returns the name of the archive entry holding a version of a schema. The schema ID is escaped, so IDs
+// containing '/' cannot escape the schemas directory.
+func versionPath(id string, version int64) string {
+	return path.Join("schemas", url.PathEscape(id), strconv.FormatInt(version, 10)+".json")
+}
+
+// writeEntry writes a JSON entry to the archive.
+func writeEntry(writer *tar.Writer, name string, modified time.Time, value interface{}) error {
+	buf, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = writer.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(buf)),
+		ModTime:  modified,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(buf)
+	return err
+}
+
+// readArchive reads an archive, checking that it holds every version listed in its manifest.
+func readArchive(r io.Reader) (*archive, error) {
+	reader := tar.NewReader(r)
+	header, err := reader.Next()
+	if err == io.EOF || (err == nil && header.Name != manifestName) {
+		return nil, fmt.Errorf("%w: the first entry must be %s", ErrInvalidArchive, manifestName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidArchive, err.Error())
+	}
+	result := &archive{documents: make(map[string]map[int64]map[string]interface{})}
+	if err = json.NewDecoder(reader).Decode(&result.manifest); err != nil {
+		return nil, fmt.Errorf("%w: %s: %s", ErrInvalidArchive, manifestName, err.Error())
+	}
+	if result.manifest.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("%w: format version %d is not supported", ErrInvalidArchive, result.manifest.FormatVersion)
+	}
+
+	expected := make(map[string]bool)
+	for _, entry := range result.manifest.Schemas {
+		if !sort.SliceIsSorted(entry.Versions, func(i, j int) bool { return entry.Versions[i] < entry.Versions[j] }) ||
+			len(entry.Versions) == 0 {
+			return nil, fmt.Errorf("%w: schema %s must list its versions in ascending order", ErrInvalidArchive, entry.ID)
+		}
+		if _, listed := result.documents[entry.ID]; listed {
+			return nil, fmt.Errorf("%w: schema %s is listed more than once", ErrInvalidArchive, entry.ID)
+		}
+		result.documents[entry.ID] = make(map[int64]map[string]interface{})
+		for _, version := range entry.Versions {
+			expected[versionPath(entry.ID, version)] = true
+		}
+	}
+
+	for {
+		header, err = reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidArchive, err.Error())
+		}
+		if !expected[header.Name] {
+			return nil, fmt.Errorf("%w: unexpected entry %s", ErrInvalidArchive, header.Name)
+		}
+		delete(expected, header.Name)
+		id, version := parseVersionPath(header.Name)
+		var document map[string]interface{}
+		if err = json.NewDecoder(reader).Decode(&document); err != nil {
+			return nil, fmt.Errorf("%w: %s: %s", ErrInvalidArchive, header.Name, err.Error())
+		}
+		result.documents[id][version] = document
+	}
+	if len(expected) > 0 {
+		missing := make([]string, 0, len(expected))
+		for name := range expected {
+			missing = append(missing, name)
+		}
+		sort.Strings(missing)
+		return nil, fmt.Errorf("%w: missing %s", ErrInvalidArchive, strings.Join(missing, ", "))
+	}
+	return result, nil
+}
+
+// parseVersionPath returns the schema ID and version of an entry name returned by versionPath.
+func parseVersionPath(name string) (string, int64) {
+	directory, file := path.Split(name)
+	id, _ := url.PathUnescape(path.Base(directory))
+	version, _ := strconv.ParseInt(strings.TrimSuffix(file, ".json"), 10, 64)
+	return id, version
+}
@@ -0,0 +1,86 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/backup"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Archive format`, func() {
+	var server *fake.Server
+	var archiver *backup.Archiver
+
+	BeforeEach(func() {
+		server = fake.NewServer(nil)
+		client, _ := server.NewClient()
+		archiver = backup.New(client)
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	// build returns a tar archive of the named entries.
+	build := func(entries ...string) *bytes.Buffer {
+		var buf bytes.Buffer
+		writer := tar.NewWriter(&buf)
+		for i := 0; i < len(entries); i += 2 {
+			Expect(writer.WriteHeader(&tar.Header{Name: entries[i], Mode: 0644, Size: int64(len(entries[i+1]))})).To(Succeed())
+			_, err := writer.Write([]byte(entries[i+1]))
+			Expect(err).To(BeNil())
+		}
+		Expect(writer.Close()).To(Succeed())
+		return &buf
+	}
+	// importError returns the error from importing the archive.
+	importError := func(archive *bytes.Buffer) error {
+		_, err := archiver.Import(context.Background(), archive, nil)
+		Expect(errors.Is(err, backup.ErrInvalidArchive)).To(BeTrue())
+		return err
+	}
+	manifest := `{"format_version": 1, "schemas": [{"id": "citizens", "versions": [1, 2]}]}`
+	version := `{"type": "string"}`
+
+	It(`Reject archives that were not written by Export`, func() {
+		Expect(importError(bytes.NewBufferString(strings.Repeat("x", 1024))).Error()).To(HavePrefix("backup: invalid archive"))
+		Expect(importError(build("schemas/citizens/1.json", version)).Error()).To(ContainSubstring("the first entry must be manifest.json"))
+		Expect(importError(build("manifest.json", `{"format_version": 2}`)).Error()).To(ContainSubstring("format version 2 is not supported"))
+		Expect(importError(build("manifest.json", manifest, "schemas/citizens/1.json", version)).Error()).To(
+			ContainSubstring("missing schemas/citizens/2.json"))
+		Expect(importError(build("manifest.json", manifest, "schemas/citizens/1.json", version,
+			"schemas/citizens/2.json", version, "schemas/other/1.json", version)).Error()).To(
+			ContainSubstring("unexpected entry schemas/other/1.json"))
+		Expect(importError(build("manifest.json", `{"format_version": 1, "schemas": [{"id": "c", "versions": [2, 1]}]}`)).Error()).To(
+			ContainSubstring("schema c must list its versions in ascending order"))
+		Expect(server.RequestCount(fake.OperationListSchemas)).To(Equal(0))
+	})
+	It(`Import a minimal archive`, func() {
+		report, err := archiver.Import(context.Background(), build("manifest.json", manifest,
+			"schemas/citizens/2.json", version, "schemas/citizens/1.json", version), nil)
+		Expect(err).To(BeNil())
+		Expect(report.Schemas).To(Equal([]backup.ImportedSchema{{ID: "citizens", Versions: 2}}))
+		Expect(server.Versions("citizens")).To(HaveLen(2))
+	})
+})
@@ -0,0 +1,129 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package backup : Export and import the contents of an Event Streams schema registry
+//
+// Export writes every version of every schema, the per-schema rules and the global rule to a tar archive of JSON
+// documents. Import replays an archive into another registry, creating each schema under its original ID with its
+// versions in order and then restoring the rules, so a registry can be recovered after a disaster or promoted from
+// one environment to another.
+//
+// The archive holds a manifest.json entry followed by one schemas/ID/VERSION.json entry for each version. Version
+// numbers and global IDs are assigned by the registry that imports an archive, so a schema whose versions were not
+// numbered 1, 2, 3... is renumbered from 1, and its global IDs change.
+package backup
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Archiver : Exports and imports the contents of a schema registry.
+type Archiver struct {
+	client *schemaregistryv1.SchemaregistryV1
+}
+
+// New : constructs an Archiver that uses the specified client.
+func New(client *schemaregistryv1.SchemaregistryV1) *Archiver {
+	return &Archiver{client: client}
+}
+
+// Export writes an archive of the registry to w. The schemas, versions and rules are listed before any schema is
+// fetched, so the manifest can be written first; a version that is deleted while the export runs causes an error.
+func (archiver *Archiver) Export(ctx context.Context, w io.Writer) (*Manifest, error) {
+	client := archiver.client
+	manifest := &Manifest{FormatVersion: FormatVersion, ExportedAt: time.Now().UTC()}
+
+	rule, _, err := client.GetGlobalRuleWithContext(ctx,
+		client.NewGetGlobalRuleOptions(schemaregistryv1.GetGlobalRuleOptionsRuleCompatibilityConst))
+	if err != nil {
+		return nil, fmt.Errorf("backup: getting the global rule: %w", err)
+	}
+	if rule == nil || rule.Config == nil {
+		return nil, errors.New("backup: the registry returned the global rule without a config")
+	}
+	manifest.GlobalRule = *rule.Config
+
+	ids, _, err := client.ListSchemasWithContext(ctx, client.NewListSchemasOptions())
+	if err != nil {
+		return nil, fmt.Errorf("backup: listing schemas: %w", err)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		entry := SchemaEntry{ID: id}
+		entry.Versions, _, err = client.ListVersionsWithContext(ctx, client.NewListVersionsOptions(id))
+		if err != nil {
+			return nil, fmt.Errorf("backup: listing the versions of schema %s: %w", id, err)
+		}
+		sort.Slice(entry.Versions, func(i, j int) bool { return entry.Versions[i] < entry.Versions[j] })
+		entry.Rule, err = archiver.schemaRule(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Schemas = append(manifest.Schemas, entry)
+	}
+
+	writer := tar.NewWriter(w)
+	if err = writeEntry(writer, manifestName, manifest.ExportedAt, manifest); err != nil {
+		return nil, fmt.Errorf("backup: writing the archive: %w", err)
+	}
+	for _, entry := range manifest.Schemas {
+		for _, version := range entry.Versions {
+			document, _, err := client.GetVersionWithContext(ctx, client.NewGetVersionOptions(entry.ID, version))
+			if err != nil {
+				return nil, fmt.Errorf("backup: getting version %d of schema %s: %w", version, entry.ID, err)
+			}
+			if err = writeEntry(writer, versionPath(entry.ID, version), manifest.ExportedAt, document); err != nil {
+				return nil, fmt.Errorf("backup: writing the archive: %w", err)
+			}
+		}
+	}
+	if err = writer.Close(); err != nil {
+		return nil, fmt.Errorf("backup: writing the archive: %w", err)
+	}
+	return manifest, nil
+}
+
+// schemaRule returns the configuration of a schema's COMPATIBILITY rule, or the empty string if it has none.
+func (archiver *Archiver) schemaRule(ctx context.Context, id string) (string, error) {
+	client := archiver.client
+	rule, response, err := client.GetSchemaRuleWithContext(ctx,
+		client.NewGetSchemaRuleOptions(id, schemaregistryv1.GetSchemaRuleOptionsRuleCompatibilityConst))
+	if isNotFound(response) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("backup: getting the rule of schema %s: %w", id, err)
+	}
+	if rule == nil || rule.Config == nil {
+		return "", fmt.Errorf("backup: the registry returned the rule of schema %s without a config", id)
+	}
+	return *rule.Config, nil
+}
+
+// isNotFound returns true if the response has HTTP status code 404.
+func isNotFound(response *core.DetailedResponse) bool {
+	return response != nil && response.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,208 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/backup"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/fake"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// citizen returns a Citizen record schema with a name field and the extra fields.
+func citizen(fields ...map[string]interface{}) map[string]interface{} {
+	all := []interface{}{map[string]interface{}{"name": "name", "type": "string"}}
+	for _, field := range fields {
+		all = append(all, field)
+	}
+	return map[string]interface{}{"type": "record", "name": "Citizen", "fields": all}
+}
+
+// seed fills a registry with schemas whose histories include a deleted version, a change made under a relaxed rule
+// and an ID that contains a slash.
+func seed(client *schemaregistryv1.SchemaregistryV1, server *fake.Server) {
+	server.SetGlobalRule(schemaregistryv1.RuleConfigBackwardConst)
+	_, _, err := client.CreateSchema(client.NewCreateSchemaOptions().SetID("citizens").SetSchema(citizen()))
+	Expect(err).To(BeNil())
+	_, _, err = client.CreateVersion(client.NewCreateVersionOptions("citizens").SetSchema(
+		citizen(map[string]interface{}{"name": "age", "type": "int", "default": 0})))
+	Expect(err).To(BeNil())
+	_, _, err = client.CreateSchemaRule(client.NewCreateSchemaRuleOptions("citizens", "COMPATIBILITY", "NONE"))
+	Expect(err).To(BeNil())
+	_, _, err = client.CreateVersion(client.NewCreateVersionOptions("citizens").SetSchema(
+		citizen(map[string]interface{}{"name": "city", "type": "string"})))
+	Expect(err).To(BeNil())
+	_, _, err = client.UpdateSchemaRule(client.NewUpdateSchemaRuleOptions("citizens", "COMPATIBILITY", "COMPATIBILITY", "FORWARD"))
+	Expect(err).To(BeNil())
+	_, err = client.DeleteVersion(client.NewDeleteVersionOptions("citizens", 2))
+	Expect(err).To(BeNil())
+
+	_, _, err = client.CreateSchema(client.NewCreateSchemaOptions().SetID("team/payments").SetSchema(
+		map[string]interface{}{"type": "record", "name": "Payment", "fields": []interface{}{}}))
+	Expect(err).To(BeNil())
+}
+
+// entryNames returns the names of the entries of a tar archive.
+func entryNames(archive []byte) []string {
+	reader := tar.NewReader(bytes.NewReader(archive))
+	var names []string
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return names
+		}
+		Expect(err).To(BeNil())
+		names = append(names, header.Name)
+	}
+}
+
+var _ = Describe(`Archiver`, func() {
+	var source, target *fake.Server
+	var sourceClient, targetClient *schemaregistryv1.SchemaregistryV1
+	var archive bytes.Buffer
+
+	BeforeEach(func() {
+		source = fake.NewServer(nil)
+		target = fake.NewServer(nil)
+		sourceClient, _ = source.NewClient()
+		targetClient, _ = target.NewClient()
+		seed(sourceClient, source)
+		archive.Reset()
+	})
+	AfterEach(func() {
+		source.Close()
+		target.Close()
+	})
+
+	Describe(`Export(ctx context.Context, w io.Writer)`, func() {
+		It(`Write every schema, version and rule`, func() {
+			manifest, err := backup.New(sourceClient).Export(context.Background(), &archive)
+			Expect(err).To(BeNil())
+			Expect(manifest.FormatVersion).To(Equal(backup.FormatVersion))
+			Expect(manifest.GlobalRule).To(Equal("BACKWARD"))
+			Expect(manifest.Schemas).To(Equal([]backup.SchemaEntry{
+				{ID: "citizens", Versions: []int64{1, 3}, Rule: "FORWARD"},
+				{ID: "team/payments", Versions: []int64{1}},
+			}))
+			Expect(entryNames(archive.Bytes())).To(Equal([]string{
+				"manifest.json",
+				"schemas/citizens/1.json",
+				"schemas/citizens/3.json",
+				"schemas/team%2Fpayments/1.json",
+			}))
+		})
+		It(`Return an error for a rule without a config`, func() {
+			rules := map[string]string{
+				"/rules/COMPATIBILITY":                  `{"type":"COMPATIBILITY"}`,
+				"/artifacts/orders/rules/COMPATIBILITY": `{"type":"COMPATIBILITY"}`,
+			}
+			server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+				res.Header().Set("Content-Type", "application/json")
+				switch req.URL.Path {
+				case "/artifacts":
+					_, _ = res.Write([]byte(`["orders"]`))
+				case "/artifacts/orders/versions":
+					_, _ = res.Write([]byte(`[1]`))
+				default:
+					_, _ = res.Write([]byte(rules[req.URL.Path]))
+				}
+			}))
+			defer server.Close()
+			client, err := schemaregistryv1.NewSchemaregistryV1(&schemaregistryv1.SchemaregistryV1Options{
+				URL:           server.URL,
+				Authenticator: &core.NoAuthAuthenticator{},
+			})
+			Expect(err).To(BeNil())
+
+			_, err = backup.New(client).Export(context.Background(), &archive)
+			Expect(err).To(MatchError("backup: the registry returned the global rule without a config"))
+			rules["/rules/COMPATIBILITY"] = `{"type":"COMPATIBILITY","config":"BACKWARD"}`
+			_, err = backup.New(client).Export(context.Background(), &archive)
+			Expect(err).To(MatchError("backup: the registry returned the rule of schema orders without a config"))
+		})
+	})
+
+	Describe(`Import(ctx context.Context, r io.Reader, options *ImportOptions)`, func() {
+		BeforeEach(func() {
+			_, err := backup.New(sourceClient).Export(context.Background(), &archive)
+			Expect(err).To(BeNil())
+		})
+
+		It(`Replay an archive into another registry`, func() {
+			report, err := backup.New(targetClient).Import(context.Background(), &archive, nil)
+			Expect(err).To(BeNil())
+			Expect(report.String()).To(Equal(
+				"+ schema citizens (2 versions, COMPATIBILITY=FORWARD)\n" +
+					"+ schema team/payments (1 versions)\n" +
+					"~ global rule COMPATIBILITY=BACKWARD\n"))
+
+			Expect(target.SchemaIDs()).To(Equal([]string{"citizens", "team/payments"}))
+			sourceVersions, targetVersions := source.Versions("citizens"), target.Versions("citizens")
+			Expect(targetVersions).To(HaveLen(2))
+			for i := range targetVersions {
+				expected, _ := json.Marshal(sourceVersions[i].Schema)
+				Expect(json.Marshal(targetVersions[i].Schema)).To(MatchJSON(expected))
+			}
+			Expect(targetVersions[1].Version).To(Equal(int64(2)))
+
+			rule, ok := target.SchemaRule("citizens")
+			Expect(ok).To(BeTrue())
+			Expect(rule).To(Equal("FORWARD"))
+			_, ok = target.SchemaRule("team/payments")
+			Expect(ok).To(BeFalse())
+			Expect(target.GlobalRule()).To(Equal("BACKWARD"))
+		})
+		It(`Report what would be imported in a dry run`, func() {
+			report, err := backup.New(targetClient).Import(context.Background(), &archive, &backup.ImportOptions{
+				DryRun:         true,
+				SkipGlobalRule: true,
+			})
+			Expect(err).To(BeNil())
+			Expect(report.DryRun).To(BeTrue())
+			Expect(report.Schemas).To(HaveLen(2))
+			Expect(report.String()).To(HaveSuffix("Dry run: no changes were made.\n"))
+			Expect(target.SchemaIDs()).To(BeEmpty())
+			Expect(target.GlobalRule()).To(Equal("NONE"))
+		})
+		It(`Refuse or skip schemas that already exist`, func() {
+			_, _, err := targetClient.CreateSchema(targetClient.NewCreateSchemaOptions().SetID("citizens").SetSchema(citizen()))
+			Expect(err).To(BeNil())
+			saved := archive.Bytes()
+
+			_, err = backup.New(targetClient).Import(context.Background(), bytes.NewReader(saved), nil)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("backup: schemas already exist in the registry: citizens"))
+			Expect(target.SchemaIDs()).To(Equal([]string{"citizens"}))
+
+			report, err := backup.New(targetClient).Import(context.Background(), bytes.NewReader(saved), &backup.ImportOptions{SkipExisting: true})
+			Expect(err).To(BeNil())
+			Expect(report.Schemas[0]).To(Equal(backup.ImportedSchema{ID: "citizens", Skipped: true}))
+			Expect(target.Versions("citizens")).To(HaveLen(1))
+			Expect(target.SchemaIDs()).To(Equal([]string{"citizens", "team/payments"}))
+		})
+	})
+})
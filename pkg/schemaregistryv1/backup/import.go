@@ -0,0 +1,209 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+)
+
+// ImportOptions : The Import options.
+type ImportOptions struct {
+	// When true, Import reads and checks the archive and reports what it would do without changing the registry.
+	DryRun bool
+
+	// When true, schemas that already exist in the registry are skipped. When false, Import fails without making any
+	// changes if any schema in the archive already exists.
+	SkipExisting bool
+
+	// When true, the global rule of the registry is left unchanged.
+	SkipGlobalRule bool
+}
+
+// ImportedSchema : What Import did, or would do, with a schema in the archive.
+type ImportedSchema struct {
+	// The ID of the schema.
+	ID string `json:"id"`
+
+	// The number of versions created.
+	Versions int `json:"versions"`
+
+	// The configuration of the schema's COMPATIBILITY rule, or empty if it has none.
+	Rule string `json:"rule,omitempty"`
+
+	// True if the schema already existed and was skipped.
+	Skipped bool `json:"skipped,omitempty"`
+}
+
+// ImportReport : The result of Import.
+type ImportReport struct {
+	// True if the report describes a dry run, and the registry was not changed.
+	DryRun bool `json:"dry_run"`
+
+	// The schemas in the archive, in the order they were imported.
+	Schemas []ImportedSchema `json:"schemas"`
+
+	// The configuration the global COMPATIBILITY rule was set to, or empty if it was not changed.
+	GlobalRule string `json:"global_rule,omitempty"`
+}
+
+// String returns a human readable description of the import, one schema per line.
+func (report *ImportReport) String() string {
+	var buf bytes.Buffer
+	for _, schema := range report.Schemas {
+		if schema.Skipped {
+			fmt.Fprintf(&buf, "= schema %s already exists, skipped\n", schema.ID)
+			continue
+		}
+		fmt.Fprintf(&buf, "+ schema %s (%d versions", schema.ID, schema.Versions)
+		if schema.Rule != "" {
+			fmt.Fprintf(&buf, ", COMPATIBILITY=%s", schema.Rule)
+		}
+		buf.WriteString(")\n")
+	}
+	if report.GlobalRule != "" {
+		fmt.Fprintf(&buf, "~ global rule COMPATIBILITY=%s\n", report.GlobalRule)
+	}
+	if report.DryRun {
+		buf.WriteString("Dry run: no changes were made.\n")
+	}
+	return buf.String()
+}
+
+// Import replays an archive written by Export into the registry.
+//
+// Each schema is created under its original ID and its versions are created in ascending order. While a schema's
+// versions are replayed it is given a COMPATIBILITY rule of NONE, so that history the source registry accepted under
+// a different rule is not rejected; its archived rule, if any, is restored afterwards. The global rule is set last.
+// The archive is read in full and checked before any change is made. If an error occurs part way through, the
+// report lists the schemas that were imported before it.
+func (archiver *Archiver) Import(ctx context.Context, r io.Reader, options *ImportOptions) (*ImportReport, error) {
+	if options == nil {
+		options = &ImportOptions{}
+	}
+	contents, err := readArchive(r)
+	if err != nil {
+		return nil, err
+	}
+
+	client := archiver.client
+	ids, _, err := client.ListSchemasWithContext(ctx, client.NewListSchemasOptions())
+	if err != nil {
+		return nil, fmt.Errorf("backup: listing schemas: %w", err)
+	}
+	existing := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		existing[id] = true
+	}
+
+	planned := &ImportReport{DryRun: options.DryRun}
+	var conflicts []string
+	for _, entry := range contents.manifest.Schemas {
+		if existing[entry.ID] {
+			if !options.SkipExisting {
+				conflicts = append(conflicts, entry.ID)
+			}
+			planned.Schemas = append(planned.Schemas, ImportedSchema{ID: entry.ID, Skipped: true})
+			continue
+		}
+		planned.Schemas = append(planned.Schemas, ImportedSchema{ID: entry.ID, Versions: len(entry.Versions), Rule: entry.Rule})
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return nil, fmt.Errorf("backup: schemas already exist in the registry: %s", strings.Join(conflicts, ", "))
+	}
+	if !options.SkipGlobalRule {
+		planned.GlobalRule = contents.manifest.GlobalRule
+	}
+	if options.DryRun {
+		return planned, nil
+	}
+
+	report := &ImportReport{}
+	for i, entry := range contents.manifest.Schemas {
+		if planned.Schemas[i].Skipped {
+			report.Schemas = append(report.Schemas, planned.Schemas[i])
+			continue
+		}
+		if err = archiver.importSchema(ctx, entry, contents.documents[entry.ID]); err != nil {
+			return report, err
+		}
+		report.Schemas = append(report.Schemas, planned.Schemas[i])
+	}
+	if planned.GlobalRule != "" {
+		_, _, err = client.UpdateGlobalRuleWithContext(ctx, client.NewUpdateGlobalRuleOptions(
+			schemaregistryv1.UpdateGlobalRuleOptionsRuleCompatibilityConst,
+			schemaregistryv1.UpdateGlobalRuleOptionsTypeCompatibilityConst,
+			planned.GlobalRule))
+		if err != nil {
+			return report, fmt.Errorf("backup: setting the global rule to %s: %w", planned.GlobalRule, err)
+		}
+		report.GlobalRule = planned.GlobalRule
+	}
+	return report, nil
+}
+
+// importSchema creates a schema and its versions, and then restores its rule.
+func (archiver *Archiver) importSchema(ctx context.Context, entry SchemaEntry, documents map[int64]map[string]interface{}) error {
+	client := archiver.client
+	first := entry.Versions[0]
+	_, _, err := client.CreateSchemaWithContext(ctx,
+		client.NewCreateSchemaOptions().SetID(entry.ID).SetSchema(documents[first]))
+	if err != nil {
+		return fmt.Errorf("backup: creating schema %s from version %d: %w", entry.ID, first, err)
+	}
+
+	rule := entry.Rule
+	if len(entry.Versions) > 1 {
+		_, _, err = client.CreateSchemaRuleWithContext(ctx, client.NewCreateSchemaRuleOptions(entry.ID,
+			schemaregistryv1.CreateSchemaRuleOptionsTypeCompatibilityConst, schemaregistryv1.RuleConfigNoneConst))
+		if err != nil {
+			return fmt.Errorf("backup: relaxing the rule of schema %s: %w", entry.ID, err)
+		}
+		for _, version := range entry.Versions[1:] {
+			_, _, err = client.CreateVersionWithContext(ctx,
+				client.NewCreateVersionOptions(entry.ID).SetSchema(documents[version]))
+			if err != nil {
+				return fmt.Errorf("backup: creating schema %s version %d: %w", entry.ID, version, err)
+			}
+		}
+		if rule == "" {
+			_, err = client.DeleteSchemaRuleWithContext(ctx, client.NewDeleteSchemaRuleOptions(entry.ID,
+				schemaregistryv1.DeleteSchemaRuleOptionsRuleCompatibilityConst))
+			if err != nil {
+				return fmt.Errorf("backup: removing the rule of schema %s: %w", entry.ID, err)
+			}
+			return nil
+		}
+		_, _, err = client.UpdateSchemaRuleWithContext(ctx, client.NewUpdateSchemaRuleOptions(entry.ID,
+			schemaregistryv1.UpdateSchemaRuleOptionsRuleCompatibilityConst,
+			schemaregistryv1.UpdateSchemaRuleOptionsTypeCompatibilityConst, rule))
+	} else if rule != "" {
+		_, _, err = client.CreateSchemaRuleWithContext(ctx, client.NewCreateSchemaRuleOptions(entry.ID,
+			schemaregistryv1.CreateSchemaRuleOptionsTypeCompatibilityConst, rule))
+	}
+	if err != nil {
+		return fmt.Errorf("backup: setting the rule of schema %s to %s: %w", entry.ID, rule, err)
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package compat : Client-side checks of the schema registry COMPATIBILITY rule
+//
+// The registry refuses a new version of a schema with HTTP status code 409 when it breaks the COMPATIBILITY rule that
+// applies to the schema. Check evaluates the same rule locally, using the Avro schema resolution rules, so that
+// breaking changes can be found before CreateVersion is called. Each Incompatibility it reports says which existing
+// version is affected, where in the schema the problem is, and why the schemas cannot be resolved.
+package compat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/avro"
+)
+
+// Mode : A configuration of the COMPATIBILITY rule.
+type Mode string
+
+// The configurations of the COMPATIBILITY rule, matching the schemaregistryv1 RuleConfig constants.
+const (
+	ModeBackward           Mode = schemaregistryv1.RuleConfigBackwardConst
+	ModeBackwardTransitive Mode = schemaregistryv1.RuleConfigBackwardTransitiveConst
+	ModeForward            Mode = schemaregistryv1.RuleConfigForwardConst
+	ModeForwardTransitive  Mode = schemaregistryv1.RuleConfigForwardTransitiveConst
+	ModeFull               Mode = schemaregistryv1.RuleConfigFullConst
+	ModeFullTransitive     Mode = schemaregistryv1.RuleConfigFullTransitiveConst
+	ModeNone               Mode = schemaregistryv1.RuleConfigNoneConst
+)
+
+// Backward returns true if the mode requires the new schema to read data written with existing versions.
+func (mode Mode) Backward() bool {
+	return mode == ModeBackward || mode == ModeBackwardTransitive || mode == ModeFull || mode == ModeFullTransitive
+}
+
+// Forward returns true if the mode requires existing versions to read data written with the new schema.
+func (mode Mode) Forward() bool {
+	return mode == ModeForward || mode == ModeForwardTransitive || mode == ModeFull || mode == ModeFullTransitive
+}
+
+// Transitive returns true if the mode applies to every existing version rather than only the latest.
+func (mode Mode) Transitive() bool {
+	return mode == ModeBackwardTransitive || mode == ModeForwardTransitive || mode == ModeFullTransitive
+}
+
+// Valid returns true if the mode is one of the configurations of the COMPATIBILITY rule.
+func (mode Mode) Valid() bool {
+	return mode == ModeNone || mode.Backward() || mode.Forward()
+}
+
+// Direction : The direction in which data must be readable.
+type Direction string
+
+// Directions of a check.
+const (
+	// The new schema must read data written with an existing version.
+	DirectionBackward Direction = "BACKWARD"
+
+	// An existing version must read data written with the new schema.
+	DirectionForward Direction = "FORWARD"
+)
+
+// IncompatibilityType : The reason two schemas cannot be resolved.
+type IncompatibilityType string
+
+// Types of incompatibility.
+const (
+	// A field in the reader is not in the writer and has no default.
+	IncompatibilityMissingDefault IncompatibilityType = "READER_FIELD_MISSING_DEFAULT_VALUE"
+
+	// The writer's type is neither the reader's type nor promotable to it.
+	IncompatibilityTypeMismatch IncompatibilityType = "TYPE_MISMATCH"
+
+	// A record, enum or fixed type was renamed without an alias.
+	IncompatibilityNameMismatch IncompatibilityType = "NAME_MISMATCH"
+
+	// A fixed type changed size.
+	IncompatibilityFixedSizeMismatch IncompatibilityType = "FIXED_SIZE_MISMATCH"
+
+	// The writer's enum has symbols the reader does not have, and the reader's enum has no default.
+	IncompatibilityMissingEnumSymbols IncompatibilityType = "MISSING_ENUM_SYMBOLS"
+
+	// A type the writer can write is not a branch of the reader's union.
+	IncompatibilityMissingUnionBranch IncompatibilityType = "MISSING_UNION_BRANCH"
+)
+
+// Version : An existing version of a schema, as returned by GetVersion.
+type Version struct {
+	// The version number.
+	Version int64
+
+	// The schema document.
+	Schema map[string]interface{}
+}
+
+// Incompatibility : A reason the new schema breaks the COMPATIBILITY rule.
+type Incompatibility struct {
+	// The reason the schemas cannot be resolved.
+	Type IncompatibilityType
+
+	// The direction in which data cannot be read.
+	Direction Direction
+
+	// The existing version the new schema was checked against.
+	Version int64
+
+	// The location of the problem, as a dotted path of record and field names, where [] is the items of an array and
+	// {} is the values of a map.
+	Path string
+
+	// A description of the problem.
+	Message string
+}
+
+// String returns a description of the incompatibility.
+func (incompatibility Incompatibility) String() string {
+	return fmt.Sprintf("version %d (%s) at %s: %s", incompatibility.Version,
+		strings.ToLower(string(incompatibility.Direction)), incompatibility.Path, incompatibility.Message)
+}
+
+// Result : The result of a check.
+type Result struct {
+	// The mode that was checked.
+	Mode Mode
+
+	// The versions that were checked, in the order they were checked.
+	Checked []int64
+
+	// The reasons the new schema breaks the rule. Empty if the new schema is compatible.
+	Incompatibilities []Incompatibility
+}
+
+// Compatible returns true if the new schema satisfies the rule.
+func (result *Result) Compatible() bool {
+	return len(result.Incompatibilities) == 0
+}
+
+// String returns a description of the result, with one line for each incompatibility.
+func (result *Result) String() string {
+	if result.Compatible() {
+		return fmt.Sprintf("compatible (%s)\n", result.Mode)
+	}
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "not compatible (%s):\n", result.Mode)
+	for _, incompatibility := range result.Incompatibilities {
+		fmt.Fprintf(&buf, "  %s\n", incompatibility)
+	}
+	return buf.String()
+}
+
+// Check : checks a candidate schema against the existing versions of a schema, oldest first, under a COMPATIBILITY
+// rule configuration. An error is returned if the mode is not valid or a schema cannot be parsed; otherwise the
+// Result lists every incompatibility found. NONE and an empty list of versions always pass.
+func Check(mode Mode, candidate map[string]interface{}, existing []Version) (*Result, error) {
+	if !mode.Valid() {
+		return nil, fmt.Errorf("compat: %q is not a COMPATIBILITY rule configuration", mode)
+	}
+	proposed, err := parseSchema(candidate)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{Mode: mode}
+	if mode == ModeNone || len(existing) == 0 {
+		return result, nil
+	}
+
+	checked := existing[len(existing)-1:]
+	if mode.Transitive() {
+		checked = existing
+	}
+	for i := len(checked) - 1; i >= 0; i-- {
+		version := checked[i]
+		previous, err := parseSchema(version.Schema)
+		if err != nil {
+			if schemaError, ok := err.(*SchemaError); ok {
+				schemaError.Version = version.Version
+			}
+			return nil, err
+		}
+		result.Checked = append(result.Checked, version.Version)
+		if mode.Backward() {
+			r := newResolver(DirectionBackward, version.Version)
+			r.resolve(proposed, previous, rootPath(proposed))
+			result.Incompatibilities = append(result.Incompatibilities, r.incompatibilities...)
+		}
+		if mode.Forward() {
+			r := newResolver(DirectionForward, version.Version)
+			r.resolve(previous, proposed, rootPath(previous))
+			result.Incompatibilities = append(result.Incompatibilities, r.incompatibilities...)
+		}
+	}
+	return result, nil
+}
+
+// rootPath returns the path of the top level of a schema.
+func rootPath(s *avro.Schema) string {
+	if s.Type.IsNamed() {
+		return shortName(s.FullName())
+	}
+	return "(root)"
+}
@@ -0,0 +1,238 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compat_test
+
+import (
+	"encoding/json"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/compat"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// decode decodes a JSON schema document.
+func decode(document string) map[string]interface{} {
+	var result map[string]interface{}
+	Expect(json.Unmarshal([]byte(document), &result)).To(Succeed())
+	return result
+}
+
+// history returns the documents as versions numbered from 1.
+func history(documents ...string) []compat.Version {
+	versions := make([]compat.Version, len(documents))
+	for i, document := range documents {
+		versions[i] = compat.Version{Version: int64(i + 1), Schema: decode(document)}
+	}
+	return versions
+}
+
+// types returns the types of the incompatibilities in a result.
+func types(result *compat.Result) []compat.IncompatibilityType {
+	var list []compat.IncompatibilityType
+	for _, incompatibility := range result.Incompatibilities {
+		list = append(list, incompatibility.Type)
+	}
+	return list
+}
+
+const (
+	v1 = `{"type": "record", "name": "Citizen", "fields": [{"name": "firstName", "type": "string"}]}`
+	v2 = `{"type": "record", "name": "Citizen", "fields": [{"name": "firstName", "type": "string"}, {"name": "age", "type": "int", "default": 0}]}`
+	// v3 drops firstName, which has no default in v1 or v2.
+	v3 = `{"type": "record", "name": "Citizen", "fields": [{"name": "age", "type": "int", "default": 0}]}`
+)
+
+var _ = Describe(`Check`, func() {
+	Describe(`Modes`, func() {
+		It(`Pass anything under NONE`, func() {
+			result, err := compat.Check(compat.ModeNone, decode(`{"type": "string"}`), history(v1))
+			Expect(err).To(BeNil())
+			Expect(result.Compatible()).To(BeTrue())
+			Expect(result.Checked).To(BeEmpty())
+		})
+		It(`Pass the first version of a schema`, func() {
+			result, err := compat.Check(compat.ModeFullTransitive, decode(v1), nil)
+			Expect(err).To(BeNil())
+			Expect(result.Compatible()).To(BeTrue())
+		})
+		It(`Check BACKWARD against the latest version only`, func() {
+			// The new schema adds a field without a default, which v2 cannot fill.
+			candidate := decode(`{"type": "record", "name": "Citizen", "fields": [{"name": "age", "type": "int", "default": 0}, {"name": "city", "type": "string"}]}`)
+			result, err := compat.Check(compat.ModeBackward, candidate, history(v1, v2))
+			Expect(err).To(BeNil())
+			Expect(result.Checked).To(Equal([]int64{2}))
+			Expect(result.Incompatibilities).To(HaveLen(1))
+			incompatibility := result.Incompatibilities[0]
+			Expect(incompatibility.Type).To(Equal(compat.IncompatibilityMissingDefault))
+			Expect(incompatibility.Direction).To(Equal(compat.DirectionBackward))
+			Expect(incompatibility.Version).To(Equal(int64(2)))
+			Expect(incompatibility.Path).To(Equal("Citizen.city"))
+			Expect(incompatibility.Message).To(Equal("field city was added without a default, so the new schema cannot read data written with version 2"))
+		})
+		It(`Check BACKWARD_TRANSITIVE against every version`, func() {
+			result, err := compat.Check(compat.ModeBackward, decode(v3), history(v1, v2))
+			Expect(err).To(BeNil())
+			Expect(result.Compatible()).To(BeTrue())
+
+			result, err = compat.Check(compat.ModeBackwardTransitive, decode(v3), history(v1, v2))
+			Expect(err).To(BeNil())
+			Expect(result.Compatible()).To(BeTrue())
+			Expect(result.Checked).To(Equal([]int64{2, 1}))
+		})
+		It(`Check FORWARD in the other direction`, func() {
+			result, err := compat.Check(compat.ModeForward, decode(v3), history(v1, v2))
+			Expect(err).To(BeNil())
+			Expect(types(result)).To(Equal([]compat.IncompatibilityType{compat.IncompatibilityMissingDefault}))
+			Expect(result.Incompatibilities[0].Direction).To(Equal(compat.DirectionForward))
+			Expect(result.Incompatibilities[0].Message).To(Equal("field firstName was removed but has no default in version 2, so version 2 cannot read data written with the new schema"))
+
+			result, err = compat.Check(compat.ModeForward, decode(v2), history(v1))
+			Expect(err).To(BeNil())
+			Expect(result.Compatible()).To(BeTrue())
+		})
+		It(`Check FORWARD_TRANSITIVE against every version`, func() {
+			v2WithDefault := `{"type": "record", "name": "Citizen", "fields": [{"name": "firstName", "type": "string", "default": ""}, {"name": "age", "type": "int", "default": 0}]}`
+			result, err := compat.Check(compat.ModeForward, decode(v3), history(v1, v2WithDefault))
+			Expect(err).To(BeNil())
+			Expect(result.Compatible()).To(BeTrue())
+
+			result, err = compat.Check(compat.ModeForwardTransitive, decode(v3), history(v1, v2WithDefault))
+			Expect(err).To(BeNil())
+			Expect(result.Incompatibilities).To(HaveLen(1))
+			Expect(result.Incompatibilities[0].Version).To(Equal(int64(1)))
+		})
+		It(`Check FULL in both directions`, func() {
+			candidate := decode(`{"type": "record", "name": "Citizen", "fields": [{"name": "city", "type": "string"}]}`)
+			result, err := compat.Check(compat.ModeFull, candidate, history(v1))
+			Expect(err).To(BeNil())
+			Expect(result.Incompatibilities).To(HaveLen(2))
+			Expect(result.Incompatibilities[0].Direction).To(Equal(compat.DirectionBackward))
+			Expect(result.Incompatibilities[0].Path).To(Equal("Citizen.city"))
+			Expect(result.Incompatibilities[1].Direction).To(Equal(compat.DirectionForward))
+			Expect(result.Incompatibilities[1].Path).To(Equal("Citizen.firstName"))
+			Expect(result.String()).To(Equal("not compatible (FULL):\n" +
+				"  version 1 (backward) at Citizen.city: field city was added without a default, so the new schema cannot read data written with version 1\n" +
+				"  version 1 (forward) at Citizen.firstName: field firstName was removed but has no default in version 1, so version 1 cannot read data written with the new schema\n"))
+
+			result, err = compat.Check(compat.ModeFull, decode(v2), history(v1))
+			Expect(err).To(BeNil())
+			Expect(result.String()).To(Equal("compatible (FULL)\n"))
+		})
+		It(`Check FULL_TRANSITIVE against every version in both directions`, func() {
+			result, err := compat.Check(compat.ModeFullTransitive, decode(v2), history(v1, v2))
+			Expect(err).To(BeNil())
+			Expect(result.Compatible()).To(BeTrue())
+			Expect(result.Checked).To(Equal([]int64{2, 1}))
+
+			result, err = compat.Check(compat.ModeFullTransitive, decode(v3), history(v1, v2))
+			Expect(err).To(BeNil())
+			Expect(result.Incompatibilities).To(HaveLen(2))
+		})
+		It(`Reject an unknown mode`, func() {
+			_, err := compat.Check("SIDEWAYS", decode(v1), history(v1))
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("SIDEWAYS"))
+		})
+	})
+
+	Describe(`Schema resolution`, func() {
+		// check returns the result of checking the candidate field type against the existing field type.
+		check := func(mode compat.Mode, existing string, candidate string) *compat.Result {
+			wrap := func(fieldType string) string {
+				return `{"type": "record", "name": "R", "fields": [{"name": "f", "type": ` + fieldType + `}]}`
+			}
+			result, err := compat.Check(mode, decode(wrap(candidate)), history(wrap(existing)))
+			Expect(err).To(BeNil())
+			return result
+		}
+
+		It(`Allow promotions and refuse demotions`, func() {
+			Expect(check(compat.ModeBackward, `"int"`, `"long"`).Compatible()).To(BeTrue())
+			Expect(check(compat.ModeBackward, `"long"`, `"double"`).Compatible()).To(BeTrue())
+			Expect(check(compat.ModeBackward, `"bytes"`, `"string"`).Compatible()).To(BeTrue())
+
+			result := check(compat.ModeBackward, `"long"`, `"int"`)
+			Expect(types(result)).To(Equal([]compat.IncompatibilityType{compat.IncompatibilityTypeMismatch}))
+			Expect(result.Incompatibilities[0].Path).To(Equal("R.f"))
+			Expect(result.Incompatibilities[0].Message).To(Equal("version 1 writes long, which the new schema cannot read as int"))
+
+			Expect(types(check(compat.ModeForward, `"int"`, `"long"`))).To(Equal([]compat.IncompatibilityType{compat.IncompatibilityTypeMismatch}))
+		})
+		It(`Resolve unions`, func() {
+			Expect(check(compat.ModeBackward, `"string"`, `["null", "string"]`).Compatible()).To(BeTrue())
+			Expect(check(compat.ModeBackward, `["null", "int"]`, `["null", "long", "string"]`).Compatible()).To(BeTrue())
+
+			result := check(compat.ModeBackward, `["null", "string"]`, `"string"`)
+			Expect(types(result)).To(Equal([]compat.IncompatibilityType{compat.IncompatibilityMissingUnionBranch}))
+			Expect(result.Incompatibilities[0].Message).To(Equal("version 1 writes null in a union, which the new schema cannot read as string"))
+
+			result = check(compat.ModeBackward, `["null", "string", "boolean"]`, `["null", "string"]`)
+			Expect(types(result)).To(Equal([]compat.IncompatibilityType{compat.IncompatibilityMissingUnionBranch}))
+			Expect(result.Incompatibilities[0].Message).To(Equal("version 1 writes boolean, which is not a branch of the union union[null, string] in the new schema"))
+		})
+		It(`Resolve enums`, func() {
+			suit := func(symbols string, extra string) string {
+				return `{"type": "enum", "name": "Suit", "symbols": ` + symbols + extra + `}`
+			}
+			Expect(check(compat.ModeBackward, suit(`["HEARTS"]`, ``), suit(`["HEARTS", "SPADES"]`, ``)).Compatible()).To(BeTrue())
+
+			result := check(compat.ModeForward, suit(`["HEARTS"]`, ``), suit(`["HEARTS", "SPADES", "CLUBS"]`, ``))
+			Expect(types(result)).To(Equal([]compat.IncompatibilityType{compat.IncompatibilityMissingEnumSymbols}))
+			Expect(result.Incompatibilities[0].Message).To(Equal("the new schema writes the enum symbols SPADES, CLUBS, which the enum Suit in version 1 does not have and has no default for"))
+
+			Expect(check(compat.ModeForward, suit(`["HEARTS", "UNKNOWN"]`, `, "default": "UNKNOWN"`), suit(`["HEARTS", "SPADES"]`, ``)).Compatible()).To(BeTrue())
+		})
+		It(`Resolve fixed, arrays and maps`, func() {
+			result := check(compat.ModeBackward, `{"type": "fixed", "name": "Hash", "size": 16}`, `{"type": "fixed", "name": "Hash", "size": 32}`)
+			Expect(types(result)).To(Equal([]compat.IncompatibilityType{compat.IncompatibilityFixedSizeMismatch}))
+
+			Expect(check(compat.ModeBackward, `{"type": "array", "items": "int"}`, `{"type": "array", "items": "long"}`).Compatible()).To(BeTrue())
+			result = check(compat.ModeBackward, `{"type": "map", "values": "long"}`, `{"type": "map", "values": "int"}`)
+			Expect(result.Incompatibilities).To(HaveLen(1))
+			Expect(result.Incompatibilities[0].Path).To(Equal("R.f{}"))
+
+			result = check(compat.ModeBackward, `{"type": "array", "items": "int"}`, `{"type": "map", "values": "int"}`)
+			Expect(result.Incompatibilities[0].Message).To(Equal("version 1 writes array<int>, which the new schema cannot read as map<int>"))
+		})
+		It(`Match renamed types and fields through aliases`, func() {
+			result, err := compat.Check(compat.ModeBackward,
+				decode(`{"type": "record", "name": "Person", "fields": [{"name": "givenName", "type": "string"}]}`),
+				history(v1))
+			Expect(err).To(BeNil())
+			Expect(types(result)).To(Equal([]compat.IncompatibilityType{compat.IncompatibilityNameMismatch}))
+
+			result, err = compat.Check(compat.ModeBackward,
+				decode(`{"type": "record", "name": "Person", "aliases": ["Citizen"], "fields": [{"name": "givenName", "aliases": ["firstName"], "type": "string"}]}`),
+				history(v1))
+			Expect(err).To(BeNil())
+			Expect(result.Compatible()).To(BeTrue())
+		})
+		It(`Resolve nested and recursive records`, func() {
+			existing := `{"type": "record", "name": "Node", "namespace": "com.example", "fields": [
+				{"name": "value", "type": "int"},
+				{"name": "next", "type": ["null", "Node"], "default": null}]}`
+			candidate := `{"type": "record", "name": "Node", "namespace": "com.example", "fields": [
+				{"name": "value", "type": "long"},
+				{"name": "label", "type": "string"},
+				{"name": "next", "type": ["null", "com.example.Node"], "default": null}]}`
+			result, err := compat.Check(compat.ModeBackward, decode(candidate), history(existing))
+			Expect(err).To(BeNil())
+			Expect(result.Incompatibilities).To(HaveLen(1))
+			Expect(result.Incompatibilities[0].Path).To(Equal("Node.label"))
+		})
+	})
+})
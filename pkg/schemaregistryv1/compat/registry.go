@@ -0,0 +1,82 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compat
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+)
+
+// FetchVersions : returns the versions of a schema held by the registry, oldest first, using ListVersions and
+// GetVersion. A schema that does not exist has no versions.
+func FetchVersions(ctx context.Context, client *schemaregistryv1.SchemaregistryV1, id string) ([]Version, error) {
+	numbers, response, err := client.ListVersionsWithContext(ctx, client.NewListVersionsOptions(id))
+	if err != nil && response != nil && response.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing the versions of schema %s: %w", id, err)
+	}
+	versions := make([]Version, 0, len(numbers))
+	for _, number := range numbers {
+		document, _, err := client.GetVersionWithContext(ctx, client.NewGetVersionOptions(id, number))
+		if err != nil {
+			return nil, fmt.Errorf("getting version %d of schema %s: %w", number, id, err)
+		}
+		versions = append(versions, Version{Version: number, Schema: document})
+	}
+	return versions, nil
+}
+
+// EffectiveMode : returns the COMPATIBILITY rule configuration the registry applies to a schema: the schema's own rule
+// if it has one, otherwise the global rule.
+func EffectiveMode(ctx context.Context, client *schemaregistryv1.SchemaregistryV1, id string) (Mode, error) {
+	rule, response, err := client.GetSchemaRuleWithContext(ctx,
+		client.NewGetSchemaRuleOptions(id, schemaregistryv1.GetSchemaRuleOptionsRuleCompatibilityConst))
+	if err == nil {
+		return Mode(*rule.Config), nil
+	}
+	if response == nil || response.StatusCode != http.StatusNotFound {
+		return "", fmt.Errorf("getting the rule of schema %s: %w", id, err)
+	}
+	rule, _, err = client.GetGlobalRuleWithContext(ctx,
+		client.NewGetGlobalRuleOptions(schemaregistryv1.GetGlobalRuleOptionsRuleCompatibilityConst))
+	if err != nil {
+		return "", fmt.Errorf("getting the global rule: %w", err)
+	}
+	return Mode(*rule.Config), nil
+}
+
+// CheckRegistry : checks a candidate schema against the versions of a schema held by the registry under the rule the
+// registry applies to it, as CreateVersion would. If mode is empty, the rule is found with EffectiveMode.
+func CheckRegistry(ctx context.Context, client *schemaregistryv1.SchemaregistryV1, id string, candidate map[string]interface{}, mode Mode) (*Result, error) {
+	if mode == "" {
+		var err error
+		mode, err = EffectiveMode(ctx, client, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	versions, err := FetchVersions(ctx, client, id)
+	if err != nil {
+		return nil, err
+	}
+	return Check(mode, candidate, versions)
+}
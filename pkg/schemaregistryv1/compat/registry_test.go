@@ -0,0 +1,85 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compat_test
+
+import (
+	"context"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/compat"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/fake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Registry`, func() {
+	var server *fake.Server
+	var client *schemaregistryv1.SchemaregistryV1
+
+	BeforeEach(func() {
+		server = fake.NewServer(&fake.ServerOptions{GlobalCompatibility: schemaregistryv1.RuleConfigBackwardConst})
+		client, _ = server.NewClient()
+		_, _, err := client.CreateSchema(client.NewCreateSchemaOptions().SetID("citizen").SetSchema(decode(v1)))
+		Expect(err).To(BeNil())
+		_, _, err = client.CreateVersion(client.NewCreateVersionOptions("citizen").SetSchema(decode(v2)))
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Fetch the versions of a schema`, func() {
+		versions, err := compat.FetchVersions(context.Background(), client, "citizen")
+		Expect(err).To(BeNil())
+		Expect(versions).To(HaveLen(2))
+		Expect(versions[0].Version).To(Equal(int64(1)))
+		Expect(versions[1].Schema["fields"]).To(HaveLen(2))
+
+		versions, err = compat.FetchVersions(context.Background(), client, "missing")
+		Expect(err).To(BeNil())
+		Expect(versions).To(BeEmpty())
+	})
+	It(`Find the rule that applies to a schema`, func() {
+		mode, err := compat.EffectiveMode(context.Background(), client, "citizen")
+		Expect(err).To(BeNil())
+		Expect(mode).To(Equal(compat.ModeBackward))
+
+		_, _, err = client.CreateSchemaRule(client.NewCreateSchemaRuleOptions("citizen",
+			schemaregistryv1.CreateSchemaRuleOptionsTypeCompatibilityConst,
+			schemaregistryv1.CreateSchemaRuleOptionsConfigForwardConst))
+		Expect(err).To(BeNil())
+		mode, err = compat.EffectiveMode(context.Background(), client, "citizen")
+		Expect(err).To(BeNil())
+		Expect(mode).To(Equal(compat.ModeForward))
+	})
+	It(`Agree with the registry`, func() {
+		candidates := []string{v3, v1, `{"type": "record", "name": "Citizen", "fields": [{"name": "city", "type": "string"}]}`}
+		for _, mode := range []compat.Mode{compat.ModeBackward, compat.ModeForward, compat.ModeFull, compat.ModeFullTransitive} {
+			server.SetGlobalRule(string(mode))
+			for _, candidate := range candidates {
+				result, err := compat.CheckRegistry(context.Background(), client, "citizen", decode(candidate), "")
+				Expect(err).To(BeNil())
+				_, response, _ := client.CreateVersion(client.NewCreateVersionOptions("citizen").SetSchema(decode(candidate)))
+				if result.Compatible() {
+					Expect(response.StatusCode).To(Equal(200), "%s %s", mode, candidate)
+				} else {
+					Expect(response.StatusCode).To(Equal(409), "%s %s", mode, candidate)
+				}
+			}
+		}
+	})
+})
@@ -0,0 +1,255 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/avro"
+)
+
+// promotions lists the writer types that a reader of each primitive type can also read.
+var promotions = map[avro.Type]map[avro.Type]bool{
+	avro.TypeLong:   {avro.TypeInt: true},
+	avro.TypeFloat:  {avro.TypeInt: true, avro.TypeLong: true},
+	avro.TypeDouble: {avro.TypeInt: true, avro.TypeLong: true, avro.TypeFloat: true},
+	avro.TypeString: {avro.TypeBytes: true},
+	avro.TypeBytes:  {avro.TypeString: true},
+}
+
+// resolver applies the Avro schema resolution rules to a reader and a writer schema, collecting the reasons data
+// written with the writer cannot be read with the reader.
+type resolver struct {
+	direction         Direction
+	version           int64
+	incompatibilities []Incompatibility
+
+	// The pairs of named schemas being resolved, which stops recursive types from being resolved forever.
+	visiting map[[2]*avro.Schema]bool
+}
+
+// newResolver returns a resolver for the direction against an existing version.
+func newResolver(direction Direction, version int64) *resolver {
+	return &resolver{
+		direction: direction,
+		version:   version,
+		visiting:  make(map[[2]*avro.Schema]bool),
+	}
+}
+
+// readerLabel describes the reader schema in messages.
+func (r *resolver) readerLabel() string {
+	if r.direction == DirectionBackward {
+		return "the new schema"
+	}
+	return fmt.Sprintf("version %d", r.version)
+}
+
+// writerLabel describes the writer schema in messages.
+func (r *resolver) writerLabel() string {
+	if r.direction == DirectionBackward {
+		return fmt.Sprintf("version %d", r.version)
+	}
+	return "the new schema"
+}
+
+// report records an incompatibility.
+func (r *resolver) report(incompatibilityType IncompatibilityType, path string, format string, args ...interface{}) {
+	r.incompatibilities = append(r.incompatibilities, Incompatibility{
+		Type:      incompatibilityType,
+		Direction: r.direction,
+		Version:   r.version,
+		Path:      path,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+// resolve checks that data written with the writer schema can be read with the reader schema.
+func (r *resolver) resolve(reader *avro.Schema, writer *avro.Schema, path string) {
+	if writer.Type == avro.TypeUnion {
+		// Any branch of the writer's union may have been written, so each one must be readable.
+		for _, branch := range writer.Branches {
+			if reader.Type != avro.TypeUnion && !matches(reader, branch) && !promotable(reader, branch) {
+				r.report(IncompatibilityMissingUnionBranch, path,
+					"%s writes %s in a union, which %s cannot read as %s",
+					r.writerLabel(), typeName(branch), r.readerLabel(), typeName(reader))
+				continue
+			}
+			r.resolve(reader, branch, path)
+		}
+		return
+	}
+	if reader.Type == avro.TypeUnion {
+		branch := selectBranch(reader, writer)
+		if branch == nil {
+			r.report(IncompatibilityMissingUnionBranch, path,
+				"%s writes %s, which is not a branch of the union %s in %s",
+				r.writerLabel(), typeName(writer), typeName(reader), r.readerLabel())
+			return
+		}
+		r.resolve(branch, writer, path)
+		return
+	}
+
+	if reader.Type != writer.Type {
+		if !promotable(reader, writer) {
+			r.report(IncompatibilityTypeMismatch, path, "%s writes %s, which %s cannot read as %s",
+				r.writerLabel(), typeName(writer), r.readerLabel(), typeName(reader))
+		}
+		return
+	}
+
+	switch reader.Type {
+	case avro.TypeRecord:
+		r.resolveRecord(reader, writer, path)
+	case avro.TypeEnum:
+		if !r.checkName(reader, writer, path) {
+			return
+		}
+		var missing []string
+		for _, symbol := range writer.Symbols {
+			if !contains(reader.Symbols, symbol) {
+				missing = append(missing, symbol)
+			}
+		}
+		if len(missing) > 0 && reader.EnumDefault == "" {
+			r.report(IncompatibilityMissingEnumSymbols, path,
+				"%s writes the enum symbols %s, which the enum %s in %s does not have and has no default for",
+				r.writerLabel(), strings.Join(missing, ", "), reader.FullName(), r.readerLabel())
+		}
+	case avro.TypeFixed:
+		if !r.checkName(reader, writer, path) {
+			return
+		}
+		if reader.Size != writer.Size {
+			r.report(IncompatibilityFixedSizeMismatch, path, "%s writes %s with size %d, which %s reads with size %d",
+				r.writerLabel(), writer.FullName(), writer.Size, r.readerLabel(), reader.Size)
+		}
+	case avro.TypeArray:
+		r.resolve(reader.Items, writer.Items, path+"[]")
+	case avro.TypeMap:
+		r.resolve(reader.Values, writer.Values, path+"{}")
+	}
+}
+
+// resolveRecord checks that every field of the reader record can be filled from the writer record.
+func (r *resolver) resolveRecord(reader *avro.Schema, writer *avro.Schema, path string) {
+	key := [2]*avro.Schema{reader, writer}
+	if r.visiting[key] {
+		return
+	}
+	r.visiting[key] = true
+	defer delete(r.visiting, key)
+
+	if !r.checkName(reader, writer, path) {
+		return
+	}
+	for _, readerField := range reader.Fields {
+		fieldPath := path + "." + readerField.Name
+		writerField := findField(writer, readerField)
+		if writerField != nil {
+			r.resolve(readerField.Type, writerField.Type, fieldPath)
+			continue
+		}
+		if readerField.HasDefault {
+			continue
+		}
+		if r.direction == DirectionBackward {
+			r.report(IncompatibilityMissingDefault, fieldPath,
+				"field %s was added without a default, so the new schema cannot read data written with version %d",
+				readerField.Name, r.version)
+		} else {
+			r.report(IncompatibilityMissingDefault, fieldPath,
+				"field %s was removed but has no default in version %d, so version %d cannot read data written with the new schema",
+				readerField.Name, r.version, r.version)
+		}
+	}
+}
+
+// checkName reports an incompatibility and returns false if the names of two named schemas do not match.
+func (r *resolver) checkName(reader *avro.Schema, writer *avro.Schema, path string) bool {
+	if namesMatch(reader, writer) {
+		return true
+	}
+	r.report(IncompatibilityNameMismatch, path, "%s writes %s %s, which %s cannot read as %s without an alias",
+		r.writerLabel(), writer.Type, writer.FullName(), r.readerLabel(), reader.FullName())
+	return false
+}
+
+// findField returns the writer field that fills the reader field, matching its name or one of its aliases.
+func findField(writer *avro.Schema, readerField *avro.Field) *avro.Field {
+	for _, writerField := range writer.Fields {
+		if writerField.Name == readerField.Name || contains(readerField.Aliases, writerField.Name) {
+			return writerField
+		}
+	}
+	return nil
+}
+
+// namesMatch returns true if the reader can read the writer's named type: their unqualified names are the same, or
+// one of the reader's aliases names the writer's type.
+func namesMatch(reader *avro.Schema, writer *avro.Schema) bool {
+	if reader.FullName() == writer.FullName() || shortName(reader.FullName()) == shortName(writer.FullName()) {
+		return true
+	}
+	return contains(qualifiedAliases(reader), writer.FullName())
+}
+
+// matches returns true if the reader schema is the same kind of schema as the writer, with a matching name for named
+// types.
+func matches(reader *avro.Schema, writer *avro.Schema) bool {
+	if reader.Type != writer.Type {
+		return false
+	}
+	switch reader.Type {
+	case avro.TypeRecord, avro.TypeEnum, avro.TypeFixed:
+		return namesMatch(reader, writer)
+	}
+	return true
+}
+
+// promotable returns true if a reader of a primitive type can read the writer's primitive type.
+func promotable(reader *avro.Schema, writer *avro.Schema) bool {
+	return promotions[reader.Type][writer.Type]
+}
+
+// selectBranch returns the first branch of the reader's union that matches the writer, or failing that the first
+// branch the writer's type can be promoted to, or nil if there is none.
+func selectBranch(reader *avro.Schema, writer *avro.Schema) *avro.Schema {
+	for _, branch := range reader.Branches {
+		if matches(branch, writer) {
+			return branch
+		}
+	}
+	for _, branch := range reader.Branches {
+		if promotable(branch, writer) {
+			return branch
+		}
+	}
+	return nil
+}
+
+// contains returns true if the list contains the value.
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
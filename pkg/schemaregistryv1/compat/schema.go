@@ -0,0 +1,101 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/avro"
+)
+
+// SchemaError : An error in an Avro schema that prevents it from being checked.
+type SchemaError struct {
+	// The existing version that contains the error, or 0 if the error is in the candidate schema.
+	Version int64
+
+	// The location of the error in the schema.
+	Path string
+
+	// A description of the error.
+	Message string
+}
+
+// Error returns a description of the error.
+func (schemaError *SchemaError) Error() string {
+	location := "candidate schema"
+	if schemaError.Version != 0 {
+		location = fmt.Sprintf("version %d", schemaError.Version)
+	}
+	if schemaError.Path != "" {
+		location += " at " + schemaError.Path
+	}
+	return fmt.Sprintf("invalid Avro schema in %s: %s", location, schemaError.Message)
+}
+
+// parseSchema parses a JSON-decoded Avro schema, as returned by GetVersion or passed to CreateVersion.
+func parseSchema(document map[string]interface{}) (*avro.Schema, error) {
+	parsed, err := avro.ParseMap(document)
+	if err != nil {
+		if schemaError, ok := err.(*avro.SchemaError); ok {
+			return nil, &SchemaError{Path: schemaError.Path, Message: schemaError.Message}
+		}
+		return nil, err
+	}
+	return parsed, nil
+}
+
+// qualifiedAliases returns the full names of the aliases of a named schema, which are relative to its namespace.
+func qualifiedAliases(s *avro.Schema) []string {
+	namespace := ""
+	if i := strings.LastIndex(s.FullName(), "."); i >= 0 {
+		namespace = s.FullName()[:i]
+	}
+	aliases := make([]string, len(s.Aliases))
+	for i, alias := range s.Aliases {
+		if strings.Contains(alias, ".") || namespace == "" {
+			aliases[i] = alias
+		} else {
+			aliases[i] = namespace + "." + alias
+		}
+	}
+	return aliases
+}
+
+// shortName returns the unqualified part of a full name.
+func shortName(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// typeName returns a short description of a schema for messages.
+func typeName(s *avro.Schema) string {
+	switch s.Type {
+	case avro.TypeRecord, avro.TypeEnum, avro.TypeFixed:
+		return s.FullName()
+	case avro.TypeArray:
+		return "array<" + typeName(s.Items) + ">"
+	case avro.TypeMap:
+		return "map<" + typeName(s.Values) + ">"
+	case avro.TypeUnion:
+		names := make([]string, len(s.Branches))
+		for i, branch := range s.Branches {
+			names[i] = typeName(branch)
+		}
+		return "union[" + strings.Join(names, ", ") + "]"
+	}
+	return string(s.Type)
+}
@@ -0,0 +1,61 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compat_test
+
+import (
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/compat"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Schema parsing`, func() {
+	// parseError returns the error from checking the candidate schema.
+	parseError := func(candidate string) error {
+		_, err := compat.Check(compat.ModeNone, decode(candidate), nil)
+		return err
+	}
+
+	It(`Accept logical types and named references`, func() {
+		Expect(parseError(`{"type": "record", "name": "Payment", "namespace": "com.example", "fields": [
+			{"name": "amount", "type": {"type": "bytes", "logicalType": "decimal", "precision": 9, "scale": 2}},
+			{"name": "at", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+			{"name": "currency", "type": {"type": "enum", "name": "Currency", "symbols": ["EUR", "USD"]}},
+			{"name": "previous", "type": ["null", "Currency"]}]}`)).To(Succeed())
+	})
+	It(`Report where a schema is invalid`, func() {
+		err := parseError(`{"type": "record", "name": "Payment", "fields": [{"name": "amount", "type": "money"}]}`)
+		Expect(err).ToNot(BeNil())
+		schemaError, ok := err.(*compat.SchemaError)
+		Expect(ok).To(BeTrue())
+		Expect(schemaError.Path).To(Equal("Payment.amount"))
+		Expect(err.Error()).To(Equal(`invalid Avro schema in candidate schema at Payment.amount: unknown type "money"`))
+
+		Expect(parseError(`{"type": "record", "name": "Payment"}`).Error()).To(ContainSubstring(`"fields" array`))
+		Expect(parseError(`{"type": "record", "name": "P", "fields": [{"name": "a", "type": ["null", ["string"]]}]}`).Error()).To(
+			ContainSubstring("a union may not immediately contain another union"))
+		Expect(parseError(`{"type": "record", "name": "P", "fields": [{"name": "a", "type": ["null", "null"]}]}`).Error()).To(
+			ContainSubstring("a union may not contain null more than once"))
+		Expect(parseError(`{"type": "enum", "name": "E", "symbols": ["A", "A"]}`)).ToNot(BeNil())
+		Expect(parseError(`{"type": "fixed", "name": "F", "size": 1.5}`)).ToNot(BeNil())
+	})
+	It(`Report which existing version is invalid`, func() {
+		_, err := compat.Check(compat.ModeBackward, decode(v1), history(v1, `{"type": "record", "name": "Citizen", "fields": [{"name": "a"}]}`))
+		Expect(err).ToNot(BeNil())
+		Expect(err.(*compat.SchemaError).Version).To(Equal(int64(2)))
+		Expect(err.Error()).To(HavePrefix("invalid Avro schema in version 2 at Citizen.a"))
+	})
+})
@@ -0,0 +1,332 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fake
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/compat"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// headerNameArtifactID is the header that carries the ID of a schema when it is created.
+const headerNameArtifactID = "X-Registry-ArtifactId"
+
+// schemaTypeAvro is the type reported for every schema.
+const schemaTypeAvro = "AVRO"
+
+// SchemaVersion : A version of a schema held by a fake Server.
+type SchemaVersion struct {
+	// The version number, unique within the schema.
+	Version int64
+
+	// The global ID, unique across all versions of all schemas.
+	GlobalID int64
+
+	// The schema document.
+	Schema map[string]interface{}
+}
+
+// schema is a schema and its version history.
+type schema struct {
+	id          string
+	createdOn   int64
+	modifiedOn  int64
+	versions    []*SchemaVersion
+	nextVersion int64
+
+	// The per-schema COMPATIBILITY rule, or empty if the global rule applies.
+	rule string
+}
+
+// latest returns the latest version of the schema.
+func (s *schema) latest() *SchemaVersion {
+	return s.versions[len(s.versions)-1]
+}
+
+// SchemaIDs returns the IDs of the schemas held by the server in lexical order.
+func (server *Server) SchemaIDs() []string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return server.sortedSchemaIDs()
+}
+
+// Versions returns the versions of the schema in the order they were created, or nil if the schema does not exist.
+func (server *Server) Versions(id string) []SchemaVersion {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	s, ok := server.schemas[id]
+	if !ok {
+		return nil
+	}
+	versions := make([]SchemaVersion, len(s.versions))
+	for i, version := range s.versions {
+		versions[i] = *version
+	}
+	return versions
+}
+
+// sortedSchemaIDs returns the IDs of the schemas in lexical order. The caller must hold the server mutex.
+func (server *Server) sortedSchemaIDs() []string {
+	ids := make([]string, 0, len(server.schemas))
+	for id := range server.schemas {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// now returns the current time in milliseconds since the UNIX epoch.
+func (server *Server) now() int64 {
+	return server.options.Now().UnixNano() / 1e6
+}
+
+// newSchemaID returns a random UUID for a schema created without an ID.
+func newSchemaID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// addVersion validates the document, checks it against the COMPATIBILITY rule that applies to the schema and appends
+// it as a new version, writing the metadata of the version as the response. The caller must hold the server mutex.
+func (server *Server) addVersion(res http.ResponseWriter, s *schema, document map[string]interface{}) {
+	rule := s.rule
+	if rule == "" {
+		rule = server.globalRule
+	}
+	existing := make([]compat.Version, len(s.versions))
+	for i, version := range s.versions {
+		existing[i] = compat.Version{Version: version.Version, Schema: version.Schema}
+	}
+	result, err := compat.Check(compat.Mode(rule), document, existing)
+	if err != nil {
+		server.writeError(res, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !result.Compatible() {
+		messages := make([]string, len(result.Incompatibilities))
+		for i, incompatibility := range result.Incompatibilities {
+			messages[i] = incompatibility.String()
+		}
+		server.writeError(res, http.StatusConflict, fmt.Sprintf("Schema is not %s compatible with schema %s: %s",
+			rule, s.id, strings.Join(messages, "; ")))
+		return
+	}
+
+	if s.nextVersion == 0 {
+		s.nextVersion = 1
+	}
+	version := &SchemaVersion{
+		Version:  s.nextVersion,
+		GlobalID: server.nextGlobalID,
+		Schema:   document,
+	}
+	s.nextVersion++
+	server.nextGlobalID++
+	s.modifiedOn = server.now()
+	s.versions = append(s.versions, version)
+	server.schemas[s.id] = s
+
+	writeMetadata(res, s, version)
+}
+
+// writeMetadata writes the metadata of a version of a schema as the response.
+func writeMetadata(res http.ResponseWriter, s *schema, version *SchemaVersion) {
+	writeJSON(res, http.StatusOK, &schemaregistryv1.SchemaMetadata{
+		CreatedOn:  &s.createdOn,
+		GlobalID:   &version.GlobalID,
+		ID:         &s.id,
+		ModifiedOn: &s.modifiedOn,
+		Type:       core.StringPtr(schemaTypeAvro),
+		Version:    &version.Version,
+	})
+}
+
+// listSchemas serves ListSchemas.
+func (server *Server) listSchemas(res http.ResponseWriter, req *http.Request, _ string, _ string) {
+	writeJSON(res, http.StatusOK, server.sortedSchemaIDs())
+}
+
+// createSchema serves CreateSchema.
+func (server *Server) createSchema(res http.ResponseWriter, req *http.Request, _ string, _ string) {
+	var document map[string]interface{}
+	if !server.decodeBody(res, req, &document) {
+		return
+	}
+	id := req.Header.Get(headerNameArtifactID)
+	if id == "" {
+		id = newSchemaID()
+	}
+	if _, exists := server.schemas[id]; exists {
+		server.writeError(res, http.StatusConflict, fmt.Sprintf("A schema with ID '%s' already exists.", id))
+		return
+	}
+	server.addVersion(res, &schema{id: id, createdOn: server.now()}, document)
+}
+
+// getLatestSchema serves GetLatestSchema.
+func (server *Server) getLatestSchema(res http.ResponseWriter, req *http.Request, id string, _ string) {
+	s, ok := server.findSchema(res, id)
+	if !ok {
+		return
+	}
+	writeJSON(res, http.StatusOK, s.latest().Schema)
+}
+
+// updateSchema serves UpdateSchema, which adds a new version to an existing schema.
+func (server *Server) updateSchema(res http.ResponseWriter, req *http.Request, id string, _ string) {
+	server.createVersion(res, req, id, "")
+}
+
+// deleteSchema serves DeleteSchema.
+func (server *Server) deleteSchema(res http.ResponseWriter, req *http.Request, id string, _ string) {
+	if _, ok := server.findSchema(res, id); !ok {
+		return
+	}
+	delete(server.schemas, id)
+	res.WriteHeader(http.StatusNoContent)
+}
+
+// listVersions serves ListVersions.
+func (server *Server) listVersions(res http.ResponseWriter, req *http.Request, id string, _ string) {
+	s, ok := server.findSchema(res, id)
+	if !ok {
+		return
+	}
+	numbers := make([]int64, len(s.versions))
+	for i, version := range s.versions {
+		numbers[i] = version.Version
+	}
+	writeJSON(res, http.StatusOK, numbers)
+}
+
+// createVersion serves CreateVersion.
+func (server *Server) createVersion(res http.ResponseWriter, req *http.Request, id string, _ string) {
+	s, ok := server.findSchema(res, id)
+	if !ok {
+		return
+	}
+	var document map[string]interface{}
+	if !server.decodeBody(res, req, &document) {
+		return
+	}
+	server.addVersion(res, s, document)
+}
+
+// getVersion serves GetVersion.
+func (server *Server) getVersion(res http.ResponseWriter, req *http.Request, id string, versionParam string) {
+	s, index, ok := server.findVersion(res, id, versionParam)
+	if !ok {
+		return
+	}
+	writeJSON(res, http.StatusOK, s.versions[index].Schema)
+}
+
+// deleteVersion serves DeleteVersion. Deleting the only version of a schema deletes the schema.
+func (server *Server) deleteVersion(res http.ResponseWriter, req *http.Request, id string, versionParam string) {
+	s, index, ok := server.findVersion(res, id, versionParam)
+	if !ok {
+		return
+	}
+	s.versions = append(s.versions[:index], s.versions[index+1:]...)
+	if len(s.versions) == 0 {
+		delete(server.schemas, id)
+	}
+	res.WriteHeader(http.StatusNoContent)
+}
+
+// findSchema returns the schema, writing a 404 response and returning false if it does not exist.
+func (server *Server) findSchema(res http.ResponseWriter, id string) (*schema, bool) {
+	s, ok := server.schemas[id]
+	if !ok {
+		server.writeError(res, http.StatusNotFound, fmt.Sprintf("No schema with ID '%s' was found.", id))
+		return nil, false
+	}
+	return s, true
+}
+
+// findVersion returns the schema and the index of the version in its history, writing a 400 or 404 response and
+// returning false if the version is not valid or does not exist.
+func (server *Server) findVersion(res http.ResponseWriter, id string, versionParam string) (*schema, int, bool) {
+	s, ok := server.findSchema(res, id)
+	if !ok {
+		return nil, 0, false
+	}
+	number, err := strconv.ParseInt(versionParam, 10, 64)
+	if err != nil {
+		server.writeError(res, http.StatusBadRequest, fmt.Sprintf("The version '%s' is not a number.", versionParam))
+		return nil, 0, false
+	}
+	for i, version := range s.versions {
+		if version.Version == number {
+			return s, i, true
+		}
+	}
+	server.writeError(res, http.StatusNotFound, fmt.Sprintf("No version %d of schema '%s' was found.", number, id))
+	return nil, 0, false
+}
+
+// getSchemaByGlobalID serves GetSchemaByGlobalID.
+func (server *Server) getSchemaByGlobalID(res http.ResponseWriter, req *http.Request, _ string, globalIDParam string) {
+	globalID, err := strconv.ParseInt(globalIDParam, 10, 64)
+	if err != nil {
+		server.writeError(res, http.StatusBadRequest, fmt.Sprintf("The global ID '%s' is not a number.", globalIDParam))
+		return
+	}
+	for _, s := range server.schemas {
+		for _, version := range s.versions {
+			if version.GlobalID == globalID {
+				writeJSON(res, http.StatusOK, version.Schema)
+				return
+			}
+		}
+	}
+	server.writeError(res, http.StatusNotFound, fmt.Sprintf("No schema with global ID %d was found.", globalID))
+}
+
+// getVersionMetadataByContent serves GetVersionMetadataByContent, matching the latest version whose JSON is the same as
+// the request body once object keys are sorted.
+func (server *Server) getVersionMetadataByContent(res http.ResponseWriter, req *http.Request, id string, _ string) {
+	s, ok := server.findSchema(res, id)
+	if !ok {
+		return
+	}
+	var document map[string]interface{}
+	if !server.decodeBody(res, req, &document) {
+		return
+	}
+	content, _ := json.Marshal(document)
+	for i := len(s.versions) - 1; i >= 0; i-- {
+		versionContent, _ := json.Marshal(s.versions[i].Schema)
+		if bytes.Equal(content, versionContent) {
+			writeMetadata(res, s, s.versions[i])
+			return
+		}
+	}
+	server.writeError(res, http.StatusNotFound, fmt.Sprintf("No version of schema '%s' matches the content.", id))
+}
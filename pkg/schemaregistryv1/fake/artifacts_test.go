@@ -0,0 +1,202 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fake
+
+import (
+	"time"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// citizen returns a version of the Citizen record schema with the given fields.
+func citizen(fields ...map[string]interface{}) map[string]interface{} {
+	list := make([]interface{}, len(fields))
+	for i, field := range fields {
+		list[i] = field
+	}
+	return map[string]interface{}{"type": "record", "name": "Citizen", "fields": list}
+}
+
+// field returns a record field, with a default value if one is given.
+func field(name string, fieldType interface{}, defaultValue ...interface{}) map[string]interface{} {
+	result := map[string]interface{}{"name": name, "type": fieldType}
+	if len(defaultValue) > 0 {
+		result["default"] = defaultValue[0]
+	}
+	return result
+}
+
+var _ = Describe(`Artifacts`, func() {
+	var server *Server
+	var client *schemaregistryv1.SchemaregistryV1
+
+	BeforeEach(func() {
+		server = NewServer(&ServerOptions{
+			FirstGlobalID: 451,
+			Now:           func() time.Time { return time.Unix(1631518689, 408000000) },
+		})
+		client, _ = server.NewClient()
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Create a schema and add versions`, func() {
+		metadata, response, err := client.CreateSchema(client.NewCreateSchemaOptions().
+			SetID("citizen").SetSchema(citizen(field("firstName", "string"))))
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(200))
+		Expect(*metadata.ID).To(Equal("citizen"))
+		Expect(*metadata.Type).To(Equal("AVRO"))
+		Expect(*metadata.Version).To(Equal(int64(1)))
+		Expect(*metadata.GlobalID).To(Equal(int64(451)))
+		Expect(*metadata.CreatedOn).To(Equal(int64(1631518689408)))
+
+		metadata, _, err = client.CreateVersion(client.NewCreateVersionOptions("citizen").
+			SetSchema(citizen(field("firstName", "string"), field("lastName", "string"))))
+		Expect(err).To(BeNil())
+		Expect(*metadata.Version).To(Equal(int64(2)))
+		Expect(*metadata.GlobalID).To(Equal(int64(452)))
+
+		metadata, _, err = client.UpdateSchema(client.NewUpdateSchemaOptions("citizen").
+			SetSchema(citizen(field("lastName", "string"))))
+		Expect(err).To(BeNil())
+		Expect(*metadata.Version).To(Equal(int64(3)))
+		Expect(*metadata.GlobalID).To(Equal(int64(453)))
+
+		versions, _, err := client.ListVersions(client.NewListVersionsOptions("citizen"))
+		Expect(err).To(BeNil())
+		Expect(versions).To(Equal([]int64{1, 2, 3}))
+
+		latest, _, err := client.GetLatestSchema(client.NewGetLatestSchemaOptions("citizen"))
+		Expect(err).To(BeNil())
+		Expect(latest["fields"]).To(HaveLen(1))
+
+		version, _, err := client.GetVersion(client.NewGetVersionOptions("citizen", 2))
+		Expect(err).To(BeNil())
+		Expect(version["fields"]).To(HaveLen(2))
+
+		history := server.Versions("citizen")
+		Expect(history).To(HaveLen(3))
+		Expect(history[1].GlobalID).To(Equal(int64(452)))
+		Expect(server.SchemaIDs()).To(Equal([]string{"citizen"}))
+	})
+	It(`Generate an ID when none is given`, func() {
+		metadata, _, err := client.CreateSchema(client.NewCreateSchemaOptions().
+			SetSchema(citizen(field("firstName", "string"))))
+		Expect(err).To(BeNil())
+		Expect(*metadata.ID).To(MatchRegexp(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`))
+
+		ids, _, err := client.ListSchemas(client.NewListSchemasOptions())
+		Expect(err).To(BeNil())
+		Expect(ids).To(Equal([]string{*metadata.ID}))
+	})
+	It(`Reject invalid requests`, func() {
+		_, response, err := client.CreateSchema(client.NewCreateSchemaOptions().
+			SetID("citizen").SetSchema(map[string]interface{}{"name": "Citizen"}))
+		Expect(err).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(400))
+
+		_, response, err = client.CreateSchema(client.NewCreateSchemaOptions().
+			SetID("citizen").SetSchema(map[string]interface{}{"type": "record", "name": "Citizen"}))
+		Expect(err).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(400))
+		Expect(server.SchemaIDs()).To(BeEmpty())
+
+		_, _, err = client.CreateSchema(client.NewCreateSchemaOptions().
+			SetID("citizen").SetSchema(citizen(field("firstName", "string"))))
+		Expect(err).To(BeNil())
+		_, response, err = client.CreateSchema(client.NewCreateSchemaOptions().
+			SetID("citizen").SetSchema(citizen(field("firstName", "string"))))
+		Expect(err).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(409))
+
+		_, response, err = client.CreateVersion(client.NewCreateVersionOptions("missing").
+			SetSchema(citizen(field("firstName", "string"))))
+		Expect(err).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(404))
+
+		_, response, err = client.GetVersion(client.NewGetVersionOptions("citizen", 7))
+		Expect(err).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(404))
+	})
+	It(`Delete versions and schemas`, func() {
+		_, _, err := client.CreateSchema(client.NewCreateSchemaOptions().
+			SetID("citizen").SetSchema(citizen(field("firstName", "string"))))
+		Expect(err).To(BeNil())
+		_, _, err = client.CreateVersion(client.NewCreateVersionOptions("citizen").
+			SetSchema(citizen(field("lastName", "string"))))
+		Expect(err).To(BeNil())
+
+		response, err := client.DeleteVersion(client.NewDeleteVersionOptions("citizen", 1))
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(204))
+
+		metadata, _, err := client.CreateVersion(client.NewCreateVersionOptions("citizen").
+			SetSchema(citizen(field("firstName", "string"))))
+		Expect(err).To(BeNil())
+		Expect(*metadata.Version).To(Equal(int64(3)))
+
+		versions, _, err := client.ListVersions(client.NewListVersionsOptions("citizen"))
+		Expect(err).To(BeNil())
+		Expect(versions).To(Equal([]int64{2, 3}))
+
+		response, err = client.DeleteSchema(client.NewDeleteSchemaOptions("citizen"))
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(204))
+		Expect(server.Versions("citizen")).To(BeNil())
+
+		response, err = client.DeleteSchema(client.NewDeleteSchemaOptions("citizen"))
+		Expect(err).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(404))
+	})
+	It(`Delete a schema with its last version`, func() {
+		_, _, err := client.CreateSchema(client.NewCreateSchemaOptions().
+			SetID("citizen").SetSchema(citizen(field("firstName", "string"))))
+		Expect(err).To(BeNil())
+		_, err = client.DeleteVersion(client.NewDeleteVersionOptions("citizen", 1))
+		Expect(err).To(BeNil())
+		Expect(server.SchemaIDs()).To(BeEmpty())
+	})
+	It(`Look up versions by global ID and by content`, func() {
+		_, _, err := client.CreateSchema(client.NewCreateSchemaOptions().
+			SetID("citizen").SetSchema(citizen(field("firstName", "string"))))
+		Expect(err).To(BeNil())
+		_, _, err = client.CreateVersion(client.NewCreateVersionOptions("citizen").
+			SetSchema(citizen(field("lastName", "string"))))
+		Expect(err).To(BeNil())
+
+		document, _, err := client.GetSchemaByGlobalID(client.NewGetSchemaByGlobalIDOptions(452))
+		Expect(err).To(BeNil())
+		Expect(document).To(Equal(citizen(field("lastName", "string"))))
+		_, response, err := client.GetSchemaByGlobalID(client.NewGetSchemaByGlobalIDOptions(999))
+		Expect(err).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(404))
+
+		metadata, _, err := client.GetVersionMetadataByContent(client.NewGetVersionMetadataByContentOptions("citizen").
+			SetSchema(citizen(field("firstName", "string"))))
+		Expect(err).To(BeNil())
+		Expect(*metadata.Version).To(Equal(int64(1)))
+		Expect(*metadata.GlobalID).To(Equal(int64(451)))
+		_, response, err = client.GetVersionMetadataByContent(client.NewGetVersionMetadataByContentOptions("citizen").
+			SetSchema(citizen(field("age", "int"))))
+		Expect(err).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(404))
+	})
+})
@@ -0,0 +1,189 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fake
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+)
+
+// compatibilityConfigs are the valid configurations of the COMPATIBILITY rule.
+var compatibilityConfigs = map[string]bool{
+	schemaregistryv1.RuleConfigBackwardConst:           true,
+	schemaregistryv1.RuleConfigBackwardTransitiveConst: true,
+	schemaregistryv1.RuleConfigForwardConst:            true,
+	schemaregistryv1.RuleConfigForwardTransitiveConst:  true,
+	schemaregistryv1.RuleConfigFullConst:               true,
+	schemaregistryv1.RuleConfigFullTransitiveConst:     true,
+	schemaregistryv1.RuleConfigNoneConst:               true,
+}
+
+// ruleBody is the JSON body of a rule request or response.
+type ruleBody struct {
+	Type   string `json:"type"`
+	Config string `json:"config"`
+}
+
+// GlobalRule returns the configuration of the global COMPATIBILITY rule.
+func (server *Server) GlobalRule() string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return server.globalRule
+}
+
+// SetGlobalRule sets the configuration of the global COMPATIBILITY rule.
+func (server *Server) SetGlobalRule(config string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.globalRule = config
+}
+
+// SchemaRule returns the configuration of the COMPATIBILITY rule of the schema, or false if the schema does not exist
+// or has no rule of its own.
+func (server *Server) SchemaRule(id string) (string, bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	s, ok := server.schemas[id]
+	if !ok || s.rule == "" {
+		return "", false
+	}
+	return s.rule, true
+}
+
+// checkRuleName writes a 400 response and returns false if the rule in the path is not COMPATIBILITY.
+func (server *Server) checkRuleName(res http.ResponseWriter, rule string) bool {
+	if rule != schemaregistryv1.RuleTypeCompatibilityConst {
+		server.writeError(res, http.StatusBadRequest, fmt.Sprintf("The rule type '%s' is not supported.", rule))
+		return false
+	}
+	return true
+}
+
+// decodeRule decodes a rule from the request body, writing a 400 response and returning false if it is not a valid
+// COMPATIBILITY rule.
+func (server *Server) decodeRule(res http.ResponseWriter, req *http.Request) (string, bool) {
+	var body ruleBody
+	if !server.decodeBody(res, req, &body) {
+		return "", false
+	}
+	if !server.checkRuleName(res, body.Type) {
+		return "", false
+	}
+	if !compatibilityConfigs[body.Config] {
+		server.writeError(res, http.StatusBadRequest, fmt.Sprintf("The configuration '%s' is not valid for the %s rule.",
+			body.Config, body.Type))
+		return "", false
+	}
+	return body.Config, true
+}
+
+// writeRule writes a COMPATIBILITY rule as the response.
+func writeRule(res http.ResponseWriter, config string) {
+	writeJSON(res, http.StatusOK, ruleBody{Type: schemaregistryv1.RuleTypeCompatibilityConst, Config: config})
+}
+
+// getGlobalRule serves GetGlobalRule.
+func (server *Server) getGlobalRule(res http.ResponseWriter, req *http.Request, _ string, rule string) {
+	if !server.checkRuleName(res, rule) {
+		return
+	}
+	writeRule(res, server.globalRule)
+}
+
+// updateGlobalRule serves UpdateGlobalRule.
+func (server *Server) updateGlobalRule(res http.ResponseWriter, req *http.Request, _ string, rule string) {
+	if !server.checkRuleName(res, rule) {
+		return
+	}
+	config, ok := server.decodeRule(res, req)
+	if !ok {
+		return
+	}
+	server.globalRule = config
+	writeRule(res, config)
+}
+
+// createSchemaRule serves CreateSchemaRule.
+func (server *Server) createSchemaRule(res http.ResponseWriter, req *http.Request, id string, _ string) {
+	s, ok := server.findSchema(res, id)
+	if !ok {
+		return
+	}
+	config, ok := server.decodeRule(res, req)
+	if !ok {
+		return
+	}
+	if s.rule != "" {
+		server.writeError(res, http.StatusConflict, fmt.Sprintf("Schema '%s' already has a %s rule.",
+			id, schemaregistryv1.RuleTypeCompatibilityConst))
+		return
+	}
+	s.rule = config
+	writeRule(res, config)
+}
+
+// getSchemaRule serves GetSchemaRule.
+func (server *Server) getSchemaRule(res http.ResponseWriter, req *http.Request, id string, rule string) {
+	s, ok := server.findSchemaRule(res, id, rule)
+	if !ok {
+		return
+	}
+	writeRule(res, s.rule)
+}
+
+// updateSchemaRule serves UpdateSchemaRule.
+func (server *Server) updateSchemaRule(res http.ResponseWriter, req *http.Request, id string, rule string) {
+	s, ok := server.findSchemaRule(res, id, rule)
+	if !ok {
+		return
+	}
+	config, ok := server.decodeRule(res, req)
+	if !ok {
+		return
+	}
+	s.rule = config
+	writeRule(res, config)
+}
+
+// deleteSchemaRule serves DeleteSchemaRule.
+func (server *Server) deleteSchemaRule(res http.ResponseWriter, req *http.Request, id string, rule string) {
+	s, ok := server.findSchemaRule(res, id, rule)
+	if !ok {
+		return
+	}
+	s.rule = ""
+	res.WriteHeader(http.StatusNoContent)
+}
+
+// findSchemaRule returns the schema, writing a 400 or 404 response and returning false if the rule is not
+// COMPATIBILITY, or the schema does not exist or has no rule of its own.
+func (server *Server) findSchemaRule(res http.ResponseWriter, id string, rule string) (*schema, bool) {
+	if !server.checkRuleName(res, rule) {
+		return nil, false
+	}
+	s, ok := server.findSchema(res, id)
+	if !ok {
+		return nil, false
+	}
+	if s.rule == "" {
+		server.writeError(res, http.StatusNotFound, fmt.Sprintf("Schema '%s' has no %s rule.", id, rule))
+		return nil, false
+	}
+	return s, true
+}
@@ -0,0 +1,140 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fake
+
+import (
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Rules`, func() {
+	var server *Server
+	var client *schemaregistryv1.SchemaregistryV1
+
+	// createVersion adds a version of the citizen schema and returns the HTTP status code.
+	createVersion := func(document map[string]interface{}) int {
+		_, response, _ := client.CreateVersion(client.NewCreateVersionOptions("citizen").SetSchema(document))
+		return response.StatusCode
+	}
+
+	BeforeEach(func() {
+		server = NewServer(nil)
+		client, _ = server.NewClient()
+		_, _, err := client.CreateSchema(client.NewCreateSchemaOptions().
+			SetID("citizen").SetSchema(citizen(field("firstName", "string"))))
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Get and update the global rule`, func() {
+		rule, _, err := client.GetGlobalRule(client.NewGetGlobalRuleOptions(
+			schemaregistryv1.GetGlobalRuleOptionsRuleCompatibilityConst))
+		Expect(err).To(BeNil())
+		Expect(*rule.Config).To(Equal("NONE"))
+
+		rule, _, err = client.UpdateGlobalRule(client.NewUpdateGlobalRuleOptions(
+			schemaregistryv1.UpdateGlobalRuleOptionsRuleCompatibilityConst,
+			schemaregistryv1.UpdateGlobalRuleOptionsTypeCompatibilityConst,
+			schemaregistryv1.UpdateGlobalRuleOptionsConfigBackwardConst))
+		Expect(err).To(BeNil())
+		Expect(*rule.Config).To(Equal("BACKWARD"))
+		Expect(server.GlobalRule()).To(Equal("BACKWARD"))
+
+		_, response, err := client.UpdateGlobalRule(client.NewUpdateGlobalRuleOptions(
+			schemaregistryv1.UpdateGlobalRuleOptionsRuleCompatibilityConst,
+			schemaregistryv1.UpdateGlobalRuleOptionsTypeCompatibilityConst, "SIDEWAYS"))
+		Expect(err).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(400))
+	})
+	It(`Create, get, update and delete a schema rule`, func() {
+		_, response, err := client.GetSchemaRule(client.NewGetSchemaRuleOptions("citizen",
+			schemaregistryv1.GetSchemaRuleOptionsRuleCompatibilityConst))
+		Expect(err).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(404))
+
+		rule, _, err := client.CreateSchemaRule(client.NewCreateSchemaRuleOptions("citizen",
+			schemaregistryv1.CreateSchemaRuleOptionsTypeCompatibilityConst,
+			schemaregistryv1.CreateSchemaRuleOptionsConfigForwardConst))
+		Expect(err).To(BeNil())
+		Expect(*rule.Config).To(Equal("FORWARD"))
+
+		_, response, err = client.CreateSchemaRule(client.NewCreateSchemaRuleOptions("citizen",
+			schemaregistryv1.CreateSchemaRuleOptionsTypeCompatibilityConst,
+			schemaregistryv1.CreateSchemaRuleOptionsConfigFullConst))
+		Expect(err).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(409))
+
+		rule, _, err = client.UpdateSchemaRule(client.NewUpdateSchemaRuleOptions("citizen",
+			schemaregistryv1.UpdateSchemaRuleOptionsRuleCompatibilityConst,
+			schemaregistryv1.UpdateSchemaRuleOptionsTypeCompatibilityConst,
+			schemaregistryv1.UpdateSchemaRuleOptionsConfigFullConst))
+		Expect(err).To(BeNil())
+		Expect(*rule.Config).To(Equal("FULL"))
+		config, ok := server.SchemaRule("citizen")
+		Expect(ok).To(BeTrue())
+		Expect(config).To(Equal("FULL"))
+
+		response, err = client.DeleteSchemaRule(client.NewDeleteSchemaRuleOptions("citizen",
+			schemaregistryv1.DeleteSchemaRuleOptionsRuleCompatibilityConst))
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(204))
+		_, ok = server.SchemaRule("citizen")
+		Expect(ok).To(BeFalse())
+	})
+	It(`Enforce the global rule`, func() {
+		server.SetGlobalRule(schemaregistryv1.RuleConfigBackwardConst)
+		Expect(createVersion(citizen(field("firstName", "string"), field("lastName", "string")))).To(Equal(409))
+		Expect(createVersion(citizen(field("firstName", "string"), field("lastName", "string", "")))).To(Equal(200))
+		Expect(createVersion(citizen(field("firstName", "int"), field("lastName", "string", "")))).To(Equal(409))
+
+		_, response, err := client.CreateVersion(client.NewCreateVersionOptions("citizen").
+			SetSchema(citizen(field("age", "int"))))
+		Expect(err).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(409))
+		body := response.Result.(map[string]interface{})
+		Expect(body["error_code"]).To(BeEquivalentTo(409))
+		Expect(body["message"]).To(ContainSubstring("field age was added without a default"))
+
+		Expect(server.Versions("citizen")).To(HaveLen(2))
+	})
+	It(`Let the schema rule override the global rule`, func() {
+		server.SetGlobalRule(schemaregistryv1.RuleConfigFullConst)
+		_, _, err := client.CreateSchemaRule(client.NewCreateSchemaRuleOptions("citizen",
+			schemaregistryv1.CreateSchemaRuleOptionsTypeCompatibilityConst,
+			schemaregistryv1.CreateSchemaRuleOptionsConfigForwardConst))
+		Expect(err).To(BeNil())
+
+		// Adding a field without a default is forward compatible but not backward compatible.
+		Expect(createVersion(citizen(field("firstName", "string"), field("lastName", "string")))).To(Equal(200))
+		// Removing a field without a default is backward compatible but not forward compatible.
+		Expect(createVersion(citizen(field("lastName", "string")))).To(Equal(409))
+	})
+	It(`Check every version with a transitive rule`, func() {
+		Expect(createVersion(citizen(field("firstName", "string"), field("lastName", "string", "")))).To(Equal(200))
+		Expect(createVersion(citizen(field("lastName", "string", "")))).To(Equal(200))
+
+		server.SetGlobalRule(schemaregistryv1.RuleConfigForwardConst)
+		Expect(createVersion(citizen(field("lastName", "string", "")))).To(Equal(200))
+
+		server.SetGlobalRule(schemaregistryv1.RuleConfigForwardTransitiveConst)
+		Expect(createVersion(citizen(field("lastName", "string", "")))).To(Equal(409))
+		Expect(server.Versions("citizen")).To(HaveLen(4))
+	})
+})
@@ -0,0 +1,267 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fake : An in-memory Event Streams schema registry for tests
+//
+// A Server implements the /artifacts, /artifacts/{id}/versions, /artifacts/{id}/meta, /artifacts/{id}/rules, /ids and
+// /rules endpoints used by SchemaregistryV1 on top of an httptest.Server. It assigns global IDs and version numbers,
+// returns SchemaMetadata for new versions, and rejects versions that break the global or per-schema COMPATIBILITY
+// rule, which it evaluates with the compat package.
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Operation names, matching the operation names used by the schemaregistryv1 package.
+const (
+	OperationGetGlobalRule    = "GetGlobalRule"
+	OperationUpdateGlobalRule = "UpdateGlobalRule"
+	OperationCreateSchemaRule = "CreateSchemaRule"
+	OperationGetSchemaRule    = "GetSchemaRule"
+	OperationUpdateSchemaRule = "UpdateSchemaRule"
+	OperationDeleteSchemaRule = "DeleteSchemaRule"
+	OperationListVersions     = "ListVersions"
+	OperationCreateVersion    = "CreateVersion"
+	OperationGetVersion       = "GetVersion"
+	OperationDeleteVersion    = "DeleteVersion"
+	OperationListSchemas      = "ListSchemas"
+	OperationCreateSchema     = "CreateSchema"
+	OperationGetLatestSchema  = "GetLatestSchema"
+	OperationDeleteSchema     = "DeleteSchema"
+	OperationUpdateSchema     = "UpdateSchema"
+
+	OperationGetSchemaByGlobalID         = "GetSchemaByGlobalID"
+	OperationGetVersionMetadataByContent = "GetVersionMetadataByContent"
+)
+
+// ServerOptions : The options of a fake Server.
+type ServerOptions struct {
+	// The initial value of the global COMPATIBILITY rule. Defaults to NONE.
+	GlobalCompatibility string
+
+	// The first global ID assigned to a schema version. Defaults to 1.
+	FirstGlobalID int64
+
+	// Returns the current time, used for the createdOn and modifiedOn timestamps. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Server : An in-memory implementation of the Event Streams schema registry.
+type Server struct {
+	// The base URL of the server, suitable for SchemaregistryV1Options.URL.
+	URL string
+
+	httpServer    *httptest.Server
+	options       ServerOptions
+	mutex         sync.Mutex
+	schemas       map[string]*schema
+	globalRule    string
+	nextGlobalID  int64
+	nextSchemaID  int64
+	requestCounts map[string]int
+	nextIncident  int64
+}
+
+// NewServer : starts a new fake Server. The caller should call Close when finished.
+func NewServer(options *ServerOptions) *Server {
+	server := &Server{
+		schemas:       make(map[string]*schema),
+		requestCounts: make(map[string]int),
+	}
+	if options != nil {
+		server.options = *options
+	}
+	server.globalRule = server.options.GlobalCompatibility
+	if server.globalRule == "" {
+		server.globalRule = schemaregistryv1.RuleConfigNoneConst
+	}
+	server.nextGlobalID = server.options.FirstGlobalID
+	if server.nextGlobalID == 0 {
+		server.nextGlobalID = 1
+	}
+	if server.options.Now == nil {
+		server.options.Now = time.Now
+	}
+	server.httpServer = httptest.NewServer(server)
+	server.URL = server.httpServer.URL
+	return server
+}
+
+// Close shuts down the server.
+func (server *Server) Close() {
+	server.httpServer.Close()
+}
+
+// NewClient returns a SchemaregistryV1 client configured to use the server.
+func (server *Server) NewClient() (*schemaregistryv1.SchemaregistryV1, error) {
+	return schemaregistryv1.NewSchemaregistryV1(&schemaregistryv1.SchemaregistryV1Options{
+		URL:           server.URL,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+}
+
+// RequestCount returns the number of requests received for the operation, including requests that failed.
+func (server *Server) RequestCount(operation string) int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return server.requestCounts[operation]
+}
+
+// route identifies the operation addressed by a request, along with the schema ID and the version or rule in its path.
+type route struct {
+	operation string
+	id        string
+	param     string
+	handler   func(server *Server, res http.ResponseWriter, req *http.Request, id string, param string)
+}
+
+// findRoute returns the route for the request, or nil if the path and method are not part of the API.
+func findRoute(req *http.Request) *route {
+	// Split the escaped path, so that a schema ID containing an escaped '/' stays in one segment.
+	segments := strings.Split(strings.Trim(req.URL.EscapedPath(), "/"), "/")
+	for i, segment := range segments {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil || unescaped == "" {
+			return nil
+		}
+		segments[i] = unescaped
+	}
+	switch {
+	case len(segments) == 1 && segments[0] == "artifacts":
+		switch req.Method {
+		case http.MethodGet:
+			return &route{operation: OperationListSchemas, handler: (*Server).listSchemas}
+		case http.MethodPost:
+			return &route{operation: OperationCreateSchema, handler: (*Server).createSchema}
+		}
+	case len(segments) == 2 && segments[0] == "artifacts":
+		id := segments[1]
+		switch req.Method {
+		case http.MethodGet:
+			return &route{operation: OperationGetLatestSchema, id: id, handler: (*Server).getLatestSchema}
+		case http.MethodPut:
+			return &route{operation: OperationUpdateSchema, id: id, handler: (*Server).updateSchema}
+		case http.MethodDelete:
+			return &route{operation: OperationDeleteSchema, id: id, handler: (*Server).deleteSchema}
+		}
+	case len(segments) == 3 && segments[0] == "artifacts" && segments[2] == "versions":
+		id := segments[1]
+		switch req.Method {
+		case http.MethodGet:
+			return &route{operation: OperationListVersions, id: id, handler: (*Server).listVersions}
+		case http.MethodPost:
+			return &route{operation: OperationCreateVersion, id: id, handler: (*Server).createVersion}
+		}
+	case len(segments) == 4 && segments[0] == "artifacts" && segments[2] == "versions":
+		id, version := segments[1], segments[3]
+		switch req.Method {
+		case http.MethodGet:
+			return &route{operation: OperationGetVersion, id: id, param: version, handler: (*Server).getVersion}
+		case http.MethodDelete:
+			return &route{operation: OperationDeleteVersion, id: id, param: version, handler: (*Server).deleteVersion}
+		}
+	case len(segments) == 3 && segments[0] == "artifacts" && segments[2] == "rules":
+		if req.Method == http.MethodPost {
+			return &route{operation: OperationCreateSchemaRule, id: segments[1], handler: (*Server).createSchemaRule}
+		}
+	case len(segments) == 3 && segments[0] == "artifacts" && segments[2] == "meta":
+		if req.Method == http.MethodPost {
+			return &route{operation: OperationGetVersionMetadataByContent, id: segments[1], handler: (*Server).getVersionMetadataByContent}
+		}
+	case len(segments) == 2 && segments[0] == "ids":
+		if req.Method == http.MethodGet {
+			return &route{operation: OperationGetSchemaByGlobalID, param: segments[1], handler: (*Server).getSchemaByGlobalID}
+		}
+	case len(segments) == 4 && segments[0] == "artifacts" && segments[2] == "rules":
+		id, rule := segments[1], segments[3]
+		switch req.Method {
+		case http.MethodGet:
+			return &route{operation: OperationGetSchemaRule, id: id, param: rule, handler: (*Server).getSchemaRule}
+		case http.MethodPut:
+			return &route{operation: OperationUpdateSchemaRule, id: id, param: rule, handler: (*Server).updateSchemaRule}
+		case http.MethodDelete:
+			return &route{operation: OperationDeleteSchemaRule, id: id, param: rule, handler: (*Server).deleteSchemaRule}
+		}
+	case len(segments) == 2 && segments[0] == "rules":
+		switch req.Method {
+		case http.MethodGet:
+			return &route{operation: OperationGetGlobalRule, param: segments[1], handler: (*Server).getGlobalRule}
+		case http.MethodPut:
+			return &route{operation: OperationUpdateGlobalRule, param: segments[1], handler: (*Server).updateGlobalRule}
+		}
+	}
+	return nil
+}
+
+// ServeHTTP implements http.Handler, so the server can also be mounted in another http.Server.
+func (server *Server) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	matched := findRoute(req)
+	if matched == nil {
+		server.writeError(res, http.StatusNotFound, "The requested resource was not found.")
+		return
+	}
+	server.requestCounts[matched.operation]++
+	matched.handler(server, res, req, matched.id, matched.param)
+}
+
+// errorBody is the JSON body of an error response, in the format of the schemaregistryv1 Error model.
+type errorBody struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+	Incident  string `json:"incident"`
+}
+
+// writeError writes an error response. The caller must hold the server mutex.
+func (server *Server) writeError(res http.ResponseWriter, statusCode int, message string) {
+	server.nextIncident++
+	writeJSON(res, statusCode, errorBody{
+		ErrorCode: statusCode,
+		Message:   message,
+		Incident:  fmt.Sprintf("fake-%08d", server.nextIncident),
+	})
+}
+
+// writeJSON writes a JSON response.
+func writeJSON(res http.ResponseWriter, statusCode int, body interface{}) {
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(statusCode)
+	_ = json.NewEncoder(res).Encode(body)
+}
+
+// decodeBody decodes the JSON request body into body, writing a 400 response and returning false if it is not valid.
+// The caller must hold the server mutex.
+func (server *Server) decodeBody(res http.ResponseWriter, req *http.Request, body interface{}) bool {
+	err := json.NewDecoder(req.Body).Decode(body)
+	if err != nil {
+		server.writeError(res, http.StatusBadRequest, "The request body was invalid JSON.")
+		return false
+	}
+	return true
+}
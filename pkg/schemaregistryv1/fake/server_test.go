@@ -0,0 +1,64 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fake
+
+import (
+	"net/http"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Server`, func() {
+	var server *Server
+	var client *schemaregistryv1.SchemaregistryV1
+
+	BeforeEach(func() {
+		server = NewServer(nil)
+		client, _ = server.NewClient()
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Count requests per operation`, func() {
+		_, _, err := client.ListSchemas(client.NewListSchemasOptions())
+		Expect(err).To(BeNil())
+		_, _, err = client.GetLatestSchema(client.NewGetLatestSchemaOptions("missing"))
+		Expect(err).ToNot(BeNil())
+		Expect(server.RequestCount(OperationListSchemas)).To(Equal(1))
+		Expect(server.RequestCount(OperationGetLatestSchema)).To(Equal(1))
+		Expect(server.RequestCount(OperationCreateSchema)).To(Equal(0))
+	})
+	It(`Respond with the error model`, func() {
+		_, response, err := client.GetLatestSchema(client.NewGetLatestSchemaOptions("missing"))
+		Expect(err).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(404))
+		body, ok := response.Result.(map[string]interface{})
+		Expect(ok).To(BeTrue())
+		Expect(body["error_code"]).To(BeEquivalentTo(404))
+		Expect(body["message"]).To(ContainSubstring("missing"))
+		Expect(body["incident"]).ToNot(BeEmpty())
+	})
+	It(`Respond with not found for unknown paths`, func() {
+		response, err := http.Get(server.URL + "/artifacts/id/unknown")
+		Expect(err).To(BeNil())
+		defer response.Body.Close()
+		Expect(response.StatusCode).To(Equal(404))
+	})
+})
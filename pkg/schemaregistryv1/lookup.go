@@ -0,0 +1,215 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schemaregistryv1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	common "github.com/IBM/eventstreams-go-sdk/pkg/common"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// The operations in this file look up schema versions by global ID and by content. They are served by the registry
+// alongside the operations of the API definition, and are needed to map between the global IDs that identify schemas
+// in serialized messages and the schemas themselves.
+
+// GetSchemaByGlobalID : Get a version of a schema by its global ID
+// Retrieves the version of a schema that was assigned the specified global ID.
+func (schemaregistry *SchemaregistryV1) GetSchemaByGlobalID(getSchemaByGlobalIDOptions *GetSchemaByGlobalIDOptions) (result map[string]interface{}, response *core.DetailedResponse, err error) {
+	return schemaregistry.GetSchemaByGlobalIDWithContext(context.Background(), getSchemaByGlobalIDOptions)
+}
+
+// GetSchemaByGlobalIDWithContext is an alternate form of the GetSchemaByGlobalID method which supports a Context parameter
+func (schemaregistry *SchemaregistryV1) GetSchemaByGlobalIDWithContext(ctx context.Context, getSchemaByGlobalIDOptions *GetSchemaByGlobalIDOptions) (result map[string]interface{}, response *core.DetailedResponse, err error) {
+	err = core.ValidateNotNil(getSchemaByGlobalIDOptions, "getSchemaByGlobalIDOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(getSchemaByGlobalIDOptions, "getSchemaByGlobalIDOptions")
+	if err != nil {
+		return
+	}
+
+	pathParamsMap := map[string]string{
+		"globalId": fmt.Sprint(*getSchemaByGlobalIDOptions.GlobalID),
+	}
+
+	builder := core.NewRequestBuilder(core.GET)
+	builder = builder.WithContext(ctx)
+	builder.EnableGzipCompression = schemaregistry.GetEnableGzipCompression()
+	_, err = builder.ResolveRequestURL(schemaregistry.Service.Options.URL, `/ids/{globalId}`, pathParamsMap)
+	if err != nil {
+		return
+	}
+
+	for headerName, headerValue := range getSchemaByGlobalIDOptions.Headers {
+		builder.AddHeader(headerName, headerValue)
+	}
+
+	sdkHeaders := common.GetSdkHeaders("schemaregistry", "V1", "GetSchemaByGlobalID")
+	for headerName, headerValue := range sdkHeaders {
+		builder.AddHeader(headerName, headerValue)
+	}
+	builder.AddHeader("Accept", "application/json")
+
+	request, err := builder.Build()
+	if err != nil {
+		return
+	}
+
+	response, err = schemaregistry.request("GetSchemaByGlobalID", request, &result)
+
+	return
+}
+
+// GetVersionMetadataByContent : Find the version of a schema that matches a schema document
+// Retrieves the metadata of the version of the specified schema whose content matches the schema in the request body.
+// Responds with HTTP status code 404 if no version matches.
+func (schemaregistry *SchemaregistryV1) GetVersionMetadataByContent(getVersionMetadataByContentOptions *GetVersionMetadataByContentOptions) (result *SchemaMetadata, response *core.DetailedResponse, err error) {
+	return schemaregistry.GetVersionMetadataByContentWithContext(context.Background(), getVersionMetadataByContentOptions)
+}
+
+// GetVersionMetadataByContentWithContext is an alternate form of the GetVersionMetadataByContent method which supports a Context parameter
+func (schemaregistry *SchemaregistryV1) GetVersionMetadataByContentWithContext(ctx context.Context, getVersionMetadataByContentOptions *GetVersionMetadataByContentOptions) (result *SchemaMetadata, response *core.DetailedResponse, err error) {
+	err = core.ValidateNotNil(getVersionMetadataByContentOptions, "getVersionMetadataByContentOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(getVersionMetadataByContentOptions, "getVersionMetadataByContentOptions")
+	if err != nil {
+		return
+	}
+
+	pathParamsMap := map[string]string{
+		"id": *getVersionMetadataByContentOptions.ID,
+	}
+
+	builder := core.NewRequestBuilder(core.POST)
+	builder = builder.WithContext(ctx)
+	builder.EnableGzipCompression = schemaregistry.GetEnableGzipCompression()
+	_, err = builder.ResolveRequestURL(schemaregistry.Service.Options.URL, `/artifacts/{id}/meta`, pathParamsMap)
+	if err != nil {
+		return
+	}
+
+	for headerName, headerValue := range getVersionMetadataByContentOptions.Headers {
+		builder.AddHeader(headerName, headerValue)
+	}
+
+	sdkHeaders := common.GetSdkHeaders("schemaregistry", "V1", "GetVersionMetadataByContent")
+	for headerName, headerValue := range sdkHeaders {
+		builder.AddHeader(headerName, headerValue)
+	}
+	builder.AddHeader("Accept", "application/json")
+	builder.AddHeader("Content-Type", "application/json")
+
+	body := make(map[string]interface{})
+	if getVersionMetadataByContentOptions.Schema != nil {
+		body = getVersionMetadataByContentOptions.Schema
+	}
+	_, err = builder.SetBodyContentJSON(body)
+	if err != nil {
+		return
+	}
+
+	request, err := builder.Build()
+	if err != nil {
+		return
+	}
+
+	var rawResponse map[string]json.RawMessage
+	response, err = schemaregistry.request("GetVersionMetadataByContent", request, &rawResponse)
+	if err != nil {
+		return
+	}
+	if rawResponse != nil {
+		err = core.UnmarshalModel(rawResponse, "", &result, UnmarshalSchemaMetadata)
+		if err != nil {
+			return
+		}
+		response.Result = result
+	}
+
+	return
+}
+
+// GetSchemaByGlobalIDOptions : The GetSchemaByGlobalID options.
+type GetSchemaByGlobalIDOptions struct {
+	// The global ID of the schema version to return.
+	GlobalID *int64 `json:"-" validate:"required"`
+
+	// Allows users to set headers on API requests
+	Headers map[string]string
+}
+
+// NewGetSchemaByGlobalIDOptions : Instantiate GetSchemaByGlobalIDOptions
+func (*SchemaregistryV1) NewGetSchemaByGlobalIDOptions(globalID int64) *GetSchemaByGlobalIDOptions {
+	return &GetSchemaByGlobalIDOptions{
+		GlobalID: core.Int64Ptr(globalID),
+	}
+}
+
+// SetGlobalID : Allow user to set GlobalID
+func (_options *GetSchemaByGlobalIDOptions) SetGlobalID(globalID int64) *GetSchemaByGlobalIDOptions {
+	_options.GlobalID = core.Int64Ptr(globalID)
+	return _options
+}
+
+// SetHeaders : Allow user to set Headers
+func (options *GetSchemaByGlobalIDOptions) SetHeaders(param map[string]string) *GetSchemaByGlobalIDOptions {
+	options.Headers = param
+	return options
+}
+
+// GetVersionMetadataByContentOptions : The GetVersionMetadataByContent options.
+type GetVersionMetadataByContentOptions struct {
+	// The ID of the schema to search.
+	ID *string `json:"-" validate:"required,ne="`
+
+	// The AVRO schema to match.
+	Schema map[string]interface{} `json:"schema,omitempty"`
+
+	// Allows users to set headers on API requests
+	Headers map[string]string
+}
+
+// NewGetVersionMetadataByContentOptions : Instantiate GetVersionMetadataByContentOptions
+func (*SchemaregistryV1) NewGetVersionMetadataByContentOptions(id string) *GetVersionMetadataByContentOptions {
+	return &GetVersionMetadataByContentOptions{
+		ID: core.StringPtr(id),
+	}
+}
+
+// SetID : Allow user to set ID
+func (_options *GetVersionMetadataByContentOptions) SetID(id string) *GetVersionMetadataByContentOptions {
+	_options.ID = core.StringPtr(id)
+	return _options
+}
+
+// SetSchema : Allow user to set Schema
+func (_options *GetVersionMetadataByContentOptions) SetSchema(schema map[string]interface{}) *GetVersionMetadataByContentOptions {
+	_options.Schema = schema
+	return _options
+}
+
+// SetHeaders : Allow user to set Headers
+func (options *GetVersionMetadataByContentOptions) SetHeaders(param map[string]string) *GetVersionMetadataByContentOptions {
+	options.Headers = param
+	return options
+}
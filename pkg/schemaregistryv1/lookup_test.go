@@ -0,0 +1,127 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schemaregistryv1_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`SchemaregistryV1 lookups`, func() {
+	var testServer *httptest.Server
+	var schemaregistryService *schemaregistryv1.SchemaregistryV1
+
+	AfterEach(func() {
+		testServer.Close()
+	})
+	newService := func(handler http.HandlerFunc) {
+		testServer = httptest.NewServer(handler)
+		var serviceErr error
+		schemaregistryService, serviceErr = schemaregistryv1.NewSchemaregistryV1(&schemaregistryv1.SchemaregistryV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(serviceErr).To(BeNil())
+	}
+
+	Describe(`GetSchemaByGlobalID(getSchemaByGlobalIDOptions *GetSchemaByGlobalIDOptions)`, func() {
+		It(`Invoke GetSchemaByGlobalID successfully`, func() {
+			newService(func(res http.ResponseWriter, req *http.Request) {
+				defer GinkgoRecover()
+				Expect(req.URL.EscapedPath()).To(Equal("/ids/451"))
+				Expect(req.Method).To(Equal("GET"))
+				Expect(req.Header["X-Custom-Header"]).To(Equal([]string{"x-custom-value"}))
+				res.Header().Set("Content-type", "application/json")
+				res.WriteHeader(200)
+				fmt.Fprintf(res, "%s", `{"type": "string"}`)
+			})
+			options := schemaregistryService.NewGetSchemaByGlobalIDOptions(0).
+				SetGlobalID(451).
+				SetHeaders(map[string]string{"x-custom-header": "x-custom-value"})
+			result, response, operationErr := schemaregistryService.GetSchemaByGlobalID(options)
+			Expect(operationErr).To(BeNil())
+			Expect(response.StatusCode).To(Equal(200))
+			Expect(result).To(Equal(map[string]interface{}{"type": "string"}))
+		})
+		It(`Invoke GetSchemaByGlobalID with error: Operation validation and request error`, func() {
+			newService(func(res http.ResponseWriter, req *http.Request) {})
+			result, response, operationErr := schemaregistryService.GetSchemaByGlobalID(nil)
+			Expect(operationErr).ToNot(BeNil())
+			Expect(response).To(BeNil())
+			Expect(result).To(BeNil())
+
+			result, response, operationErr = schemaregistryService.GetSchemaByGlobalID(new(schemaregistryv1.GetSchemaByGlobalIDOptions))
+			Expect(operationErr).ToNot(BeNil())
+			Expect(response).To(BeNil())
+			Expect(result).To(BeNil())
+		})
+	})
+
+	Describe(`GetVersionMetadataByContent(getVersionMetadataByContentOptions *GetVersionMetadataByContentOptions)`, func() {
+		It(`Invoke GetVersionMetadataByContent successfully`, func() {
+			newService(func(res http.ResponseWriter, req *http.Request) {
+				defer GinkgoRecover()
+				Expect(req.URL.EscapedPath()).To(Equal("/artifacts/testString/meta"))
+				Expect(req.Method).To(Equal("POST"))
+				var body map[string]interface{}
+				Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+				Expect(body).To(Equal(map[string]interface{}{"type": "string"}))
+				res.Header().Set("Content-type", "application/json")
+				res.WriteHeader(200)
+				fmt.Fprintf(res, "%s", `{"createdOn": 1, "globalId": 451, "id": "testString", "modifiedOn": 2, "type": "AVRO", "version": 3}`)
+			})
+			options := schemaregistryService.NewGetVersionMetadataByContentOptions("other").
+				SetID("testString").
+				SetSchema(map[string]interface{}{"type": "string"}).
+				SetHeaders(map[string]string{"x-custom-header": "x-custom-value"})
+			result, response, operationErr := schemaregistryService.GetVersionMetadataByContent(options)
+			Expect(operationErr).To(BeNil())
+			Expect(response.Result).To(Equal(result))
+			Expect(*result.GlobalID).To(Equal(int64(451)))
+			Expect(*result.Version).To(Equal(int64(3)))
+		})
+		It(`Invoke GetVersionMetadataByContent with error: Operation validation and request error`, func() {
+			newService(func(res http.ResponseWriter, req *http.Request) {
+				res.Header().Set("Content-type", "application/json")
+				res.WriteHeader(200)
+				fmt.Fprintf(res, `} this is not valid json {`)
+			})
+			result, response, operationErr := schemaregistryService.GetVersionMetadataByContent(nil)
+			Expect(operationErr).ToNot(BeNil())
+			Expect(response).To(BeNil())
+			Expect(result).To(BeNil())
+
+			result, response, operationErr = schemaregistryService.GetVersionMetadataByContent(new(schemaregistryv1.GetVersionMetadataByContentOptions))
+			Expect(operationErr).ToNot(BeNil())
+			Expect(response).To(BeNil())
+			Expect(result).To(BeNil())
+
+			result, response, operationErr = schemaregistryService.GetVersionMetadataByContent(
+				schemaregistryService.NewGetVersionMetadataByContentOptions("testString"))
+			Expect(operationErr).ToNot(BeNil())
+			Expect(response).ToNot(BeNil())
+			Expect(result).To(BeNil())
+		})
+	})
+})
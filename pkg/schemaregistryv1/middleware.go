@@ -0,0 +1,41 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schemaregistryv1
+
+import (
+	"net/http"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/middleware"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Use adds middlewares to the end of the chain that every request of the client runs through. Clones made before the
+// call are not affected.
+func (schemaregistry *SchemaregistryV1) Use(middlewares ...middleware.Middleware) {
+	schemaregistry.middlewares = middleware.Append(schemaregistry.middlewares, middlewares...)
+}
+
+// request sends the request through the middlewares of the client and decodes the response body into result.
+func (schemaregistry *SchemaregistryV1) request(operation string, request *http.Request, result interface{}) (*core.DetailedResponse, error) {
+	send := func(call *middleware.Call) (*core.DetailedResponse, error) {
+		return middleware.Send(call, func(request *http.Request) (*core.DetailedResponse, error) {
+			return schemaregistry.Service.Request(request, result)
+		})
+	}
+	call := &middleware.Call{Service: DefaultServiceName, Operation: operation, Request: request}
+	return middleware.Chain(send, schemaregistry.middlewares...)(call)
+}
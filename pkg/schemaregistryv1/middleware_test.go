@@ -0,0 +1,98 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schemaregistryv1_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/middleware"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`SchemaregistryV1 middlewares`, func() {
+	var testServer *httptest.Server
+	var calls []string
+	var signatures []string
+
+	recording := func(next middleware.Handler) middleware.Handler {
+		return func(call *middleware.Call) (*core.DetailedResponse, error) {
+			response, err := next(call)
+			status := 0
+			if response != nil {
+				status = response.StatusCode
+			}
+			calls = append(calls, call.Service+" "+call.Operation+" "+http.StatusText(status))
+			return response, err
+		}
+	}
+	signing := middleware.RequestHeader("X-Signature", func(call *middleware.Call) string {
+		return call.Request.Method + " " + call.Request.URL.Path
+	})
+
+	BeforeEach(func() {
+		calls = nil
+		signatures = nil
+		testServer = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			signatures = append(signatures, req.Header.Get("X-Signature"))
+			res.Header().Set("Content-Type", "application/json")
+			switch req.URL.Path {
+			case "/artifacts":
+				res.WriteHeader(http.StatusOK)
+				_, _ = res.Write([]byte(`["orders-value"]`))
+			case "/ids/7":
+				res.WriteHeader(http.StatusOK)
+				_, _ = res.Write([]byte(`{"type":"string"}`))
+			default:
+				res.WriteHeader(http.StatusNotFound)
+				_, _ = res.Write([]byte(`{"error_code":404,"message":"Not found"}`))
+			}
+		}))
+	})
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	It(`Run every request through the middlewares`, func() {
+		service, err := schemaregistryv1.NewSchemaregistryV1(&schemaregistryv1.SchemaregistryV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+			Middlewares:   []middleware.Middleware{recording},
+		})
+		Expect(err).To(BeNil())
+		service.Use(signing)
+
+		ids, _, err := service.ListSchemas(service.NewListSchemasOptions())
+		Expect(err).To(BeNil())
+		Expect(ids).To(Equal([]string{"orders-value"}))
+		schema, _, err := service.GetSchemaByGlobalID(service.NewGetSchemaByGlobalIDOptions(7))
+		Expect(err).To(BeNil())
+		Expect(schema).To(Equal(map[string]interface{}{"type": "string"}))
+		_, err = service.DeleteSchema(service.NewDeleteSchemaOptions("missing"))
+		Expect(err).ToNot(BeNil())
+
+		Expect(calls).To(Equal([]string{
+			"schemaregistry ListSchemas OK",
+			"schemaregistry GetSchemaByGlobalID OK",
+			"schemaregistry DeleteSchema Not Found",
+		}))
+		Expect(signatures).To(Equal([]string{"GET /artifacts", "GET /ids/7", "DELETE /artifacts/missing"}))
+	})
+})
@@ -0,0 +1,643 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Code generated by internal/mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/avro"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/stretchr/testify/mock"
+)
+
+// API : A mock implementation of schemaregistryv1.API.
+type API struct {
+	mock.Mock
+}
+
+// API must implement every method of schemaregistryv1.API.
+var _ schemaregistryv1.API = (*API)(nil)
+
+// NewAPI : constructs a mock API that fails the test on unexpected calls and asserts the expected calls were made
+// when the test finishes.
+func NewAPI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *API {
+	api := &API{}
+	api.Mock.Test(t)
+	t.Cleanup(func() { api.AssertExpectations(t) })
+	return api
+}
+
+// ListSchemasWithContext provides a mock function with given fields: ctx, listSchemasOptions
+func (_m *API) ListSchemasWithContext(ctx context.Context, listSchemasOptions *schemaregistryv1.ListSchemasOptions) ([]string, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, listSchemasOptions)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.ListSchemasOptions) []string); ok {
+		r0 = rf(ctx, listSchemasOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]string)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.ListSchemasOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, listSchemasOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *schemaregistryv1.ListSchemasOptions) error); ok {
+		r2 = rf(ctx, listSchemasOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateSchemaWithContext provides a mock function with given fields: ctx, createSchemaOptions
+func (_m *API) CreateSchemaWithContext(ctx context.Context, createSchemaOptions *schemaregistryv1.CreateSchemaOptions) (*schemaregistryv1.SchemaMetadata, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, createSchemaOptions)
+
+	var r0 *schemaregistryv1.SchemaMetadata
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.CreateSchemaOptions) *schemaregistryv1.SchemaMetadata); ok {
+		r0 = rf(ctx, createSchemaOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*schemaregistryv1.SchemaMetadata)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.CreateSchemaOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, createSchemaOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *schemaregistryv1.CreateSchemaOptions) error); ok {
+		r2 = rf(ctx, createSchemaOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// GetLatestSchemaWithContext provides a mock function with given fields: ctx, getLatestSchemaOptions
+func (_m *API) GetLatestSchemaWithContext(ctx context.Context, getLatestSchemaOptions *schemaregistryv1.GetLatestSchemaOptions) (map[string]interface{}, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, getLatestSchemaOptions)
+
+	var r0 map[string]interface{}
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.GetLatestSchemaOptions) map[string]interface{}); ok {
+		r0 = rf(ctx, getLatestSchemaOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(map[string]interface{})
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.GetLatestSchemaOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, getLatestSchemaOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *schemaregistryv1.GetLatestSchemaOptions) error); ok {
+		r2 = rf(ctx, getLatestSchemaOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// UpdateSchemaWithContext provides a mock function with given fields: ctx, updateSchemaOptions
+func (_m *API) UpdateSchemaWithContext(ctx context.Context, updateSchemaOptions *schemaregistryv1.UpdateSchemaOptions) (*schemaregistryv1.SchemaMetadata, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, updateSchemaOptions)
+
+	var r0 *schemaregistryv1.SchemaMetadata
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.UpdateSchemaOptions) *schemaregistryv1.SchemaMetadata); ok {
+		r0 = rf(ctx, updateSchemaOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*schemaregistryv1.SchemaMetadata)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.UpdateSchemaOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, updateSchemaOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *schemaregistryv1.UpdateSchemaOptions) error); ok {
+		r2 = rf(ctx, updateSchemaOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// DeleteSchemaWithContext provides a mock function with given fields: ctx, deleteSchemaOptions
+func (_m *API) DeleteSchemaWithContext(ctx context.Context, deleteSchemaOptions *schemaregistryv1.DeleteSchemaOptions) (*core.DetailedResponse, error) {
+	ret := _m.Called(ctx, deleteSchemaOptions)
+
+	var r0 *core.DetailedResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.DeleteSchemaOptions) *core.DetailedResponse); ok {
+		r0 = rf(ctx, deleteSchemaOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*core.DetailedResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.DeleteSchemaOptions) error); ok {
+		r1 = rf(ctx, deleteSchemaOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(error)
+	}
+
+	return r0, r1
+}
+
+// GetSchemaByGlobalIDWithContext provides a mock function with given fields: ctx, getSchemaByGlobalIDOptions
+func (_m *API) GetSchemaByGlobalIDWithContext(ctx context.Context, getSchemaByGlobalIDOptions *schemaregistryv1.GetSchemaByGlobalIDOptions) (map[string]interface{}, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, getSchemaByGlobalIDOptions)
+
+	var r0 map[string]interface{}
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.GetSchemaByGlobalIDOptions) map[string]interface{}); ok {
+		r0 = rf(ctx, getSchemaByGlobalIDOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(map[string]interface{})
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.GetSchemaByGlobalIDOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, getSchemaByGlobalIDOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *schemaregistryv1.GetSchemaByGlobalIDOptions) error); ok {
+		r2 = rf(ctx, getSchemaByGlobalIDOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// GetVersionMetadataByContentWithContext provides a mock function with given fields: ctx, getVersionMetadataByContentOptions
+func (_m *API) GetVersionMetadataByContentWithContext(ctx context.Context, getVersionMetadataByContentOptions *schemaregistryv1.GetVersionMetadataByContentOptions) (*schemaregistryv1.SchemaMetadata, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, getVersionMetadataByContentOptions)
+
+	var r0 *schemaregistryv1.SchemaMetadata
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.GetVersionMetadataByContentOptions) *schemaregistryv1.SchemaMetadata); ok {
+		r0 = rf(ctx, getVersionMetadataByContentOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*schemaregistryv1.SchemaMetadata)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.GetVersionMetadataByContentOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, getVersionMetadataByContentOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *schemaregistryv1.GetVersionMetadataByContentOptions) error); ok {
+		r2 = rf(ctx, getVersionMetadataByContentOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// ListVersionsWithContext provides a mock function with given fields: ctx, listVersionsOptions
+func (_m *API) ListVersionsWithContext(ctx context.Context, listVersionsOptions *schemaregistryv1.ListVersionsOptions) ([]int64, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, listVersionsOptions)
+
+	var r0 []int64
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.ListVersionsOptions) []int64); ok {
+		r0 = rf(ctx, listVersionsOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]int64)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.ListVersionsOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, listVersionsOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *schemaregistryv1.ListVersionsOptions) error); ok {
+		r2 = rf(ctx, listVersionsOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateVersionWithContext provides a mock function with given fields: ctx, createVersionOptions
+func (_m *API) CreateVersionWithContext(ctx context.Context, createVersionOptions *schemaregistryv1.CreateVersionOptions) (*schemaregistryv1.SchemaMetadata, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, createVersionOptions)
+
+	var r0 *schemaregistryv1.SchemaMetadata
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.CreateVersionOptions) *schemaregistryv1.SchemaMetadata); ok {
+		r0 = rf(ctx, createVersionOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*schemaregistryv1.SchemaMetadata)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.CreateVersionOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, createVersionOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *schemaregistryv1.CreateVersionOptions) error); ok {
+		r2 = rf(ctx, createVersionOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// GetVersionWithContext provides a mock function with given fields: ctx, getVersionOptions
+func (_m *API) GetVersionWithContext(ctx context.Context, getVersionOptions *schemaregistryv1.GetVersionOptions) (map[string]interface{}, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, getVersionOptions)
+
+	var r0 map[string]interface{}
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.GetVersionOptions) map[string]interface{}); ok {
+		r0 = rf(ctx, getVersionOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(map[string]interface{})
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.GetVersionOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, getVersionOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *schemaregistryv1.GetVersionOptions) error); ok {
+		r2 = rf(ctx, getVersionOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// DeleteVersionWithContext provides a mock function with given fields: ctx, deleteVersionOptions
+func (_m *API) DeleteVersionWithContext(ctx context.Context, deleteVersionOptions *schemaregistryv1.DeleteVersionOptions) (*core.DetailedResponse, error) {
+	ret := _m.Called(ctx, deleteVersionOptions)
+
+	var r0 *core.DetailedResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.DeleteVersionOptions) *core.DetailedResponse); ok {
+		r0 = rf(ctx, deleteVersionOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*core.DetailedResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.DeleteVersionOptions) error); ok {
+		r1 = rf(ctx, deleteVersionOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(error)
+	}
+
+	return r0, r1
+}
+
+// GetGlobalRuleWithContext provides a mock function with given fields: ctx, getGlobalRuleOptions
+func (_m *API) GetGlobalRuleWithContext(ctx context.Context, getGlobalRuleOptions *schemaregistryv1.GetGlobalRuleOptions) (*schemaregistryv1.Rule, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, getGlobalRuleOptions)
+
+	var r0 *schemaregistryv1.Rule
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.GetGlobalRuleOptions) *schemaregistryv1.Rule); ok {
+		r0 = rf(ctx, getGlobalRuleOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*schemaregistryv1.Rule)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.GetGlobalRuleOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, getGlobalRuleOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *schemaregistryv1.GetGlobalRuleOptions) error); ok {
+		r2 = rf(ctx, getGlobalRuleOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// UpdateGlobalRuleWithContext provides a mock function with given fields: ctx, updateGlobalRuleOptions
+func (_m *API) UpdateGlobalRuleWithContext(ctx context.Context, updateGlobalRuleOptions *schemaregistryv1.UpdateGlobalRuleOptions) (*schemaregistryv1.Rule, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, updateGlobalRuleOptions)
+
+	var r0 *schemaregistryv1.Rule
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.UpdateGlobalRuleOptions) *schemaregistryv1.Rule); ok {
+		r0 = rf(ctx, updateGlobalRuleOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*schemaregistryv1.Rule)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.UpdateGlobalRuleOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, updateGlobalRuleOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *schemaregistryv1.UpdateGlobalRuleOptions) error); ok {
+		r2 = rf(ctx, updateGlobalRuleOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateSchemaRuleWithContext provides a mock function with given fields: ctx, createSchemaRuleOptions
+func (_m *API) CreateSchemaRuleWithContext(ctx context.Context, createSchemaRuleOptions *schemaregistryv1.CreateSchemaRuleOptions) (*schemaregistryv1.Rule, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, createSchemaRuleOptions)
+
+	var r0 *schemaregistryv1.Rule
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.CreateSchemaRuleOptions) *schemaregistryv1.Rule); ok {
+		r0 = rf(ctx, createSchemaRuleOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*schemaregistryv1.Rule)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.CreateSchemaRuleOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, createSchemaRuleOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *schemaregistryv1.CreateSchemaRuleOptions) error); ok {
+		r2 = rf(ctx, createSchemaRuleOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// GetSchemaRuleWithContext provides a mock function with given fields: ctx, getSchemaRuleOptions
+func (_m *API) GetSchemaRuleWithContext(ctx context.Context, getSchemaRuleOptions *schemaregistryv1.GetSchemaRuleOptions) (*schemaregistryv1.Rule, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, getSchemaRuleOptions)
+
+	var r0 *schemaregistryv1.Rule
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.GetSchemaRuleOptions) *schemaregistryv1.Rule); ok {
+		r0 = rf(ctx, getSchemaRuleOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*schemaregistryv1.Rule)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.GetSchemaRuleOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, getSchemaRuleOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *schemaregistryv1.GetSchemaRuleOptions) error); ok {
+		r2 = rf(ctx, getSchemaRuleOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// UpdateSchemaRuleWithContext provides a mock function with given fields: ctx, updateSchemaRuleOptions
+func (_m *API) UpdateSchemaRuleWithContext(ctx context.Context, updateSchemaRuleOptions *schemaregistryv1.UpdateSchemaRuleOptions) (*schemaregistryv1.Rule, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, updateSchemaRuleOptions)
+
+	var r0 *schemaregistryv1.Rule
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.UpdateSchemaRuleOptions) *schemaregistryv1.Rule); ok {
+		r0 = rf(ctx, updateSchemaRuleOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*schemaregistryv1.Rule)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.UpdateSchemaRuleOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, updateSchemaRuleOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *schemaregistryv1.UpdateSchemaRuleOptions) error); ok {
+		r2 = rf(ctx, updateSchemaRuleOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// DeleteSchemaRuleWithContext provides a mock function with given fields: ctx, deleteSchemaRuleOptions
+func (_m *API) DeleteSchemaRuleWithContext(ctx context.Context, deleteSchemaRuleOptions *schemaregistryv1.DeleteSchemaRuleOptions) (*core.DetailedResponse, error) {
+	ret := _m.Called(ctx, deleteSchemaRuleOptions)
+
+	var r0 *core.DetailedResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.DeleteSchemaRuleOptions) *core.DetailedResponse); ok {
+		r0 = rf(ctx, deleteSchemaRuleOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*core.DetailedResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.DeleteSchemaRuleOptions) error); ok {
+		r1 = rf(ctx, deleteSchemaRuleOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(error)
+	}
+
+	return r0, r1
+}
+
+// CreateAvroSchemaWithContext provides a mock function with given fields: ctx, createAvroSchemaOptions
+func (_m *API) CreateAvroSchemaWithContext(ctx context.Context, createAvroSchemaOptions *schemaregistryv1.CreateAvroSchemaOptions) (*schemaregistryv1.SchemaMetadata, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, createAvroSchemaOptions)
+
+	var r0 *schemaregistryv1.SchemaMetadata
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.CreateAvroSchemaOptions) *schemaregistryv1.SchemaMetadata); ok {
+		r0 = rf(ctx, createAvroSchemaOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*schemaregistryv1.SchemaMetadata)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.CreateAvroSchemaOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, createAvroSchemaOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *schemaregistryv1.CreateAvroSchemaOptions) error); ok {
+		r2 = rf(ctx, createAvroSchemaOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateAvroVersionWithContext provides a mock function with given fields: ctx, createAvroVersionOptions
+func (_m *API) CreateAvroVersionWithContext(ctx context.Context, createAvroVersionOptions *schemaregistryv1.CreateAvroVersionOptions) (*schemaregistryv1.SchemaMetadata, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, createAvroVersionOptions)
+
+	var r0 *schemaregistryv1.SchemaMetadata
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.CreateAvroVersionOptions) *schemaregistryv1.SchemaMetadata); ok {
+		r0 = rf(ctx, createAvroVersionOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*schemaregistryv1.SchemaMetadata)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.CreateAvroVersionOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, createAvroVersionOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *schemaregistryv1.CreateAvroVersionOptions) error); ok {
+		r2 = rf(ctx, createAvroVersionOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// UpdateAvroSchemaWithContext provides a mock function with given fields: ctx, updateAvroSchemaOptions
+func (_m *API) UpdateAvroSchemaWithContext(ctx context.Context, updateAvroSchemaOptions *schemaregistryv1.UpdateAvroSchemaOptions) (*schemaregistryv1.SchemaMetadata, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, updateAvroSchemaOptions)
+
+	var r0 *schemaregistryv1.SchemaMetadata
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.UpdateAvroSchemaOptions) *schemaregistryv1.SchemaMetadata); ok {
+		r0 = rf(ctx, updateAvroSchemaOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*schemaregistryv1.SchemaMetadata)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.UpdateAvroSchemaOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, updateAvroSchemaOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *schemaregistryv1.UpdateAvroSchemaOptions) error); ok {
+		r2 = rf(ctx, updateAvroSchemaOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// GetLatestAvroSchemaWithContext provides a mock function with given fields: ctx, getLatestSchemaOptions
+func (_m *API) GetLatestAvroSchemaWithContext(ctx context.Context, getLatestSchemaOptions *schemaregistryv1.GetLatestSchemaOptions) (*avro.Schema, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, getLatestSchemaOptions)
+
+	var r0 *avro.Schema
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.GetLatestSchemaOptions) *avro.Schema); ok {
+		r0 = rf(ctx, getLatestSchemaOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*avro.Schema)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.GetLatestSchemaOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, getLatestSchemaOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *schemaregistryv1.GetLatestSchemaOptions) error); ok {
+		r2 = rf(ctx, getLatestSchemaOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
+
+// GetAvroVersionWithContext provides a mock function with given fields: ctx, getVersionOptions
+func (_m *API) GetAvroVersionWithContext(ctx context.Context, getVersionOptions *schemaregistryv1.GetVersionOptions) (*avro.Schema, *core.DetailedResponse, error) {
+	ret := _m.Called(ctx, getVersionOptions)
+
+	var r0 *avro.Schema
+	if rf, ok := ret.Get(0).(func(context.Context, *schemaregistryv1.GetVersionOptions) *avro.Schema); ok {
+		r0 = rf(ctx, getVersionOptions)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*avro.Schema)
+	}
+
+	var r1 *core.DetailedResponse
+	if rf, ok := ret.Get(1).(func(context.Context, *schemaregistryv1.GetVersionOptions) *core.DetailedResponse); ok {
+		r1 = rf(ctx, getVersionOptions)
+	} else if ret.Get(1) != nil {
+		r1 = ret.Get(1).(*core.DetailedResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *schemaregistryv1.GetVersionOptions) error); ok {
+		r2 = rf(ctx, getVersionOptions)
+	} else if ret.Get(2) != nil {
+		r2 = ret.Get(2).(error)
+	}
+
+	return r0, r1, r2
+}
@@ -0,0 +1,73 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mocks_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/mocks"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+// schemaType returns the type of the latest version of a schema through the API, as application code would.
+func schemaType(ctx context.Context, api schemaregistryv1.API, id string) (string, error) {
+	schema, _, err := api.GetLatestSchemaWithContext(ctx, &schemaregistryv1.GetLatestSchemaOptions{ID: core.StringPtr(id)})
+	if err != nil {
+		return "", err
+	}
+	return schema["type"].(string), nil
+}
+
+var _ = Describe(`API`, func() {
+	It(`Return the values given to Return for matching arguments`, func() {
+		api := &mocks.API{}
+		forID := func(id string) interface{} {
+			return mock.MatchedBy(func(options *schemaregistryv1.GetLatestSchemaOptions) bool { return *options.ID == id })
+		}
+		api.On("GetLatestSchemaWithContext", mock.Anything, forID("orders-value")).
+			Return(map[string]interface{}{"type": "record"}, &core.DetailedResponse{StatusCode: 200}, nil)
+		api.On("GetLatestSchemaWithContext", mock.Anything, forID("missing")).
+			Return(nil, &core.DetailedResponse{StatusCode: 404}, errors.New("Not Found"))
+
+		kind, err := schemaType(context.Background(), api, "orders-value")
+		Expect(err).To(BeNil())
+		Expect(kind).To(Equal("record"))
+		_, err = schemaType(context.Background(), api, "missing")
+		Expect(err).To(MatchError("Not Found"))
+		api.AssertExpectations(GinkgoT())
+	})
+	It(`Compute return values with functions`, func() {
+		api := &mocks.API{}
+		api.On("ListVersionsWithContext", mock.Anything, mock.Anything).Return(
+			func(ctx context.Context, options *schemaregistryv1.ListVersionsOptions) []int64 { return []int64{1, 2} },
+			nil,
+			func(ctx context.Context, options *schemaregistryv1.ListVersionsOptions) error { return ctx.Err() })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		versions, _, err := api.ListVersionsWithContext(ctx, &schemaregistryv1.ListVersionsOptions{})
+		Expect(err).To(BeNil())
+		Expect(versions).To(Equal([]int64{1, 2}))
+		cancel()
+		_, _, err = api.ListVersionsWithContext(ctx, &schemaregistryv1.ListVersionsOptions{})
+		Expect(err).To(Equal(context.Canceled))
+	})
+})
@@ -30,6 +30,7 @@ import (
 	"time"
 
 	"github.com/IBM/eventstreams-go-sdk/pkg/common"
+	"github.com/IBM/eventstreams-go-sdk/pkg/middleware"
 	"github.com/IBM/go-sdk-core/v5/core"
 )
 
@@ -38,6 +39,8 @@ import (
 // Version: 1.0.0
 type SchemaregistryV1 struct {
 	Service *core.BaseService
+
+	middlewares []middleware.Middleware
 }
 
 // DefaultServiceName is the default key used to find external configuration information.
@@ -48,6 +51,9 @@ type SchemaregistryV1Options struct {
 	ServiceName   string
 	URL           string
 	Authenticator core.Authenticator
+
+	// The middlewares that every request runs through, in order.
+	Middlewares []middleware.Middleware
 }
 
 // NewSchemaregistryV1UsingExternalConfig : constructs an instance of SchemaregistryV1 with passed in options and external configuration.
@@ -98,7 +104,8 @@ func NewSchemaregistryV1(options *SchemaregistryV1Options) (service *Schemaregis
 	}
 
 	service = &SchemaregistryV1{
-		Service: baseService,
+		Service:     baseService,
+		middlewares: middleware.Append(nil, options.Middlewares...),
 	}
 
 	return
@@ -146,8 +153,10 @@ func (schemaregistry *SchemaregistryV1) GetEnableGzipCompression() bool {
 
 // EnableRetries enables automatic retries for requests invoked for this service instance.
 // If either parameter is specified as 0, then a default value is used instead.
+// The attempts made for each request are reported to middlewares in Call.Attempts.
 func (schemaregistry *SchemaregistryV1) EnableRetries(maxRetries int, maxRetryInterval time.Duration) {
 	schemaregistry.Service.EnableRetries(maxRetries, maxRetryInterval)
+	middleware.CountAttempts(schemaregistry.Service.Client)
 }
 
 // DisableRetries disables automatic retries for requests invoked for this service instance.
@@ -201,7 +210,7 @@ func (schemaregistry *SchemaregistryV1) GetGlobalRuleWithContext(ctx context.Con
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = schemaregistry.Service.Request(request, &rawResponse)
+	response, err = schemaregistry.request("GetGlobalRule", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -275,7 +284,7 @@ func (schemaregistry *SchemaregistryV1) UpdateGlobalRuleWithContext(ctx context.
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = schemaregistry.Service.Request(request, &rawResponse)
+	response, err = schemaregistry.request("UpdateGlobalRule", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -349,7 +358,7 @@ func (schemaregistry *SchemaregistryV1) CreateSchemaRuleWithContext(ctx context.
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = schemaregistry.Service.Request(request, &rawResponse)
+	response, err = schemaregistry.request("CreateSchemaRule", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -411,7 +420,7 @@ func (schemaregistry *SchemaregistryV1) GetSchemaRuleWithContext(ctx context.Con
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = schemaregistry.Service.Request(request, &rawResponse)
+	response, err = schemaregistry.request("GetSchemaRule", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -486,7 +495,7 @@ func (schemaregistry *SchemaregistryV1) UpdateSchemaRuleWithContext(ctx context.
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = schemaregistry.Service.Request(request, &rawResponse)
+	response, err = schemaregistry.request("UpdateSchemaRule", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -546,7 +555,7 @@ func (schemaregistry *SchemaregistryV1) DeleteSchemaRuleWithContext(ctx context.
 		return
 	}
 
-	response, err = schemaregistry.Service.Request(request, nil)
+	response, err = schemaregistry.request("DeleteSchemaRule", request, nil)
 
 	return
 }
@@ -595,7 +604,7 @@ func (schemaregistry *SchemaregistryV1) ListVersionsWithContext(ctx context.Cont
 		return
 	}
 
-	response, err = schemaregistry.Service.Request(request, &result)
+	response, err = schemaregistry.request("ListVersions", request, &result)
 
 	return
 }
@@ -655,7 +664,7 @@ func (schemaregistry *SchemaregistryV1) CreateVersionWithContext(ctx context.Con
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = schemaregistry.Service.Request(request, &rawResponse)
+	response, err = schemaregistry.request("CreateVersion", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -715,7 +724,7 @@ func (schemaregistry *SchemaregistryV1) GetVersionWithContext(ctx context.Contex
 		return
 	}
 
-	response, err = schemaregistry.Service.Request(request, &result)
+	response, err = schemaregistry.request("GetVersion", request, &result)
 
 	return
 }
@@ -764,7 +773,7 @@ func (schemaregistry *SchemaregistryV1) DeleteVersionWithContext(ctx context.Con
 		return
 	}
 
-	response, err = schemaregistry.Service.Request(request, nil)
+	response, err = schemaregistry.request("DeleteVersion", request, nil)
 
 	return
 }
@@ -805,7 +814,7 @@ func (schemaregistry *SchemaregistryV1) ListSchemasWithContext(ctx context.Conte
 		return
 	}
 
-	response, err = schemaregistry.Service.Request(request, &result)
+	response, err = schemaregistry.request("ListSchemas", request, &result)
 
 	return
 }
@@ -864,7 +873,7 @@ func (schemaregistry *SchemaregistryV1) CreateSchemaWithContext(ctx context.Cont
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = schemaregistry.Service.Request(request, &rawResponse)
+	response, err = schemaregistry.request("CreateSchema", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -923,7 +932,7 @@ func (schemaregistry *SchemaregistryV1) GetLatestSchemaWithContext(ctx context.C
 		return
 	}
 
-	response, err = schemaregistry.Service.Request(request, &result)
+	response, err = schemaregistry.request("GetLatestSchema", request, &result)
 	return
 }
 
@@ -970,7 +979,7 @@ func (schemaregistry *SchemaregistryV1) DeleteSchemaWithContext(ctx context.Cont
 		return
 	}
 
-	response, err = schemaregistry.Service.Request(request, nil)
+	response, err = schemaregistry.request("DeleteSchema", request, nil)
 
 	return
 }
@@ -1030,7 +1039,7 @@ func (schemaregistry *SchemaregistryV1) UpdateSchemaWithContext(ctx context.Cont
 	}
 
 	var rawResponse map[string]json.RawMessage
-	response, err = schemaregistry.Service.Request(request, &rawResponse)
+	response, err = schemaregistry.request("UpdateSchema", request, &rawResponse)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,489 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package serde
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/avro"
+)
+
+// node is a compiled Avro schema, holding what the binary encoding needs.
+type node struct {
+	kind string
+
+	// The full name of a record, enum or fixed schema.
+	name string
+
+	fields   []*nodeField
+	symbols  []string
+	items    *node
+	values   *node
+	branches []*node
+	size     int
+}
+
+// nodeField is a field of a record schema.
+type nodeField struct {
+	name         string
+	node         *node
+	hasDefault   bool
+	defaultValue interface{}
+}
+
+// compile parses and validates a schema document and compiles it.
+func compile(document map[string]interface{}) (*node, error) {
+	schema, err := avro.ParseMap(document)
+	if err != nil {
+		return nil, fmt.Errorf("serde: %w", err)
+	}
+	return compileSchema(schema, make(map[*avro.Schema]*node)), nil
+}
+
+// compileSchema compiles a parsed schema. Each named type is compiled once, so recursive types compile to a cycle.
+func compileSchema(schema *avro.Schema, compiled map[*avro.Schema]*node) *node {
+	if n, ok := compiled[schema]; ok {
+		return n
+	}
+	n := &node{kind: string(schema.Type), symbols: schema.Symbols, size: schema.Size}
+	if schema.Type.IsNamed() {
+		n.name = schema.FullName()
+		compiled[schema] = n
+	}
+	switch schema.Type {
+	case avro.TypeRecord:
+		for _, field := range schema.Fields {
+			n.fields = append(n.fields, &nodeField{
+				name:         field.Name,
+				node:         compileSchema(field.Type, compiled),
+				hasDefault:   field.HasDefault,
+				defaultValue: field.Default,
+			})
+		}
+	case avro.TypeArray:
+		n.items = compileSchema(schema.Items, compiled)
+	case avro.TypeMap:
+		n.values = compileSchema(schema.Values, compiled)
+	case avro.TypeUnion:
+		for _, branch := range schema.Branches {
+			n.branches = append(n.branches, compileSchema(branch, compiled))
+		}
+	}
+	return n
+}
+
+// typeName returns the name of the type of a schema, for messages.
+func (n *node) typeName() string {
+	if n.name != "" {
+		return n.name
+	}
+	return n.kind
+}
+
+// encode appends the Avro binary encoding of the value to dst.
+func encode(dst []byte, n *node, value interface{}, path string) ([]byte, error) {
+	mismatch := func() ([]byte, error) {
+		return nil, fmt.Errorf("serde: %s: cannot encode %T as %s", pathOrRoot(path), value, n.typeName())
+	}
+	switch n.kind {
+	case "null":
+		if value != nil {
+			return mismatch()
+		}
+		return dst, nil
+	case "boolean":
+		b, ok := value.(bool)
+		if !ok {
+			return mismatch()
+		}
+		if b {
+			return append(dst, 1), nil
+		}
+		return append(dst, 0), nil
+	case "int", "long":
+		i, ok := toInt64(value)
+		if !ok || (n.kind == "int" && (i < math.MinInt32 || i > math.MaxInt32)) {
+			return mismatch()
+		}
+		return appendLong(dst, i), nil
+	case "float":
+		f, ok := toFloat64(value)
+		if !ok {
+			return mismatch()
+		}
+		var buf [4]byte
+		binary.LittleEndian.PutUint32(buf[:], math.Float32bits(float32(f)))
+		return append(dst, buf[:]...), nil
+	case "double":
+		f, ok := toFloat64(value)
+		if !ok {
+			return mismatch()
+		}
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(f))
+		return append(dst, buf[:]...), nil
+	case "bytes", "string":
+		var b []byte
+		switch v := value.(type) {
+		case []byte:
+			b = v
+		case string:
+			b = []byte(v)
+		default:
+			return mismatch()
+		}
+		dst = appendLong(dst, int64(len(b)))
+		return append(dst, b...), nil
+	case "fixed":
+		var b []byte
+		switch v := value.(type) {
+		case []byte:
+			b = v
+		case string:
+			b = []byte(v)
+		default:
+			return mismatch()
+		}
+		if len(b) != n.size {
+			return nil, fmt.Errorf("serde: %s: %s needs %d bytes, not %d", pathOrRoot(path), n.name, n.size, len(b))
+		}
+		return append(dst, b...), nil
+	case "enum":
+		symbol, ok := value.(string)
+		if !ok {
+			return mismatch()
+		}
+		for i, candidate := range n.symbols {
+			if candidate == symbol {
+				return appendLong(dst, int64(i)), nil
+			}
+		}
+		return nil, fmt.Errorf("serde: %s: %q is not a symbol of %s", pathOrRoot(path), symbol, n.name)
+	case "record":
+		record, ok := value.(map[string]interface{})
+		if !ok {
+			return mismatch()
+		}
+		if path == "" {
+			path = n.name[strings.LastIndex(n.name, ".")+1:]
+		}
+		var err error
+		for _, f := range n.fields {
+			fieldValue, present := record[f.name]
+			if !present {
+				if !f.hasDefault {
+					return nil, fmt.Errorf("serde: %s.%s: the field has no value and no default", path, f.name)
+				}
+				fieldValue = f.defaultValue
+				if f.node.kind == "union" {
+					// The default of a union field is a value of its first branch.
+					dst = appendLong(dst, 0)
+					dst, err = encode(dst, f.node.branches[0], fieldValue, path+"."+f.name)
+					if err != nil {
+						return nil, err
+					}
+					continue
+				}
+			}
+			dst, err = encode(dst, f.node, fieldValue, path+"."+f.name)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return dst, nil
+	case "array":
+		items := reflect.ValueOf(value)
+		if value == nil || (items.Kind() != reflect.Slice && items.Kind() != reflect.Array) {
+			return mismatch()
+		}
+		if items.Len() > 0 {
+			dst = appendLong(dst, int64(items.Len()))
+			var err error
+			for i := 0; i < items.Len(); i++ {
+				dst, err = encode(dst, n.items, items.Index(i).Interface(), fmt.Sprintf("%s[%d]", path, i))
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		return appendLong(dst, 0), nil
+	case "map":
+		entries := reflect.ValueOf(value)
+		if value == nil || entries.Kind() != reflect.Map || entries.Type().Key().Kind() != reflect.String {
+			return mismatch()
+		}
+		if entries.Len() > 0 {
+			dst = appendLong(dst, int64(entries.Len()))
+			iterator := entries.MapRange()
+			var err error
+			for iterator.Next() {
+				key := iterator.Key().String()
+				dst = appendLong(dst, int64(len(key)))
+				dst = append(dst, key...)
+				dst, err = encode(dst, n.values, iterator.Value().Interface(), path+"{"+key+"}")
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		return appendLong(dst, 0), nil
+	case "union":
+		// The value is written with the first branch that can encode it.
+		for i, branch := range n.branches {
+			encoded, err := encode(appendLong(nil, int64(i)), branch, value, path)
+			if err == nil {
+				return append(dst, encoded...), nil
+			}
+		}
+		names := make([]string, len(n.branches))
+		for i, branch := range n.branches {
+			names[i] = branch.typeName()
+		}
+		return nil, fmt.Errorf("serde: %s: %T does not match any of %s", pathOrRoot(path), value, strings.Join(names, ", "))
+	}
+	return nil, fmt.Errorf("serde: %s: unsupported schema type %s", pathOrRoot(path), n.kind)
+}
+
+// decoder reads values in Avro's binary encoding.
+type decoder struct {
+	data []byte
+	pos  int
+}
+
+// errTruncated is returned when the data ends before a value is complete.
+var errTruncated = fmt.Errorf("serde: unexpected end of data")
+
+// readLong reads a zig-zag encoded variable-length integer.
+func (d *decoder) readLong() (int64, error) {
+	value, n := binary.Varint(d.data[d.pos:])
+	if n <= 0 {
+		return 0, errTruncated
+	}
+	d.pos += n
+	return value, nil
+}
+
+// readBytes reads n bytes.
+func (d *decoder) readBytes(n int64) ([]byte, error) {
+	if n < 0 || int64(len(d.data)-d.pos) < n {
+		return nil, errTruncated
+	}
+	b := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return b, nil
+}
+
+// readBlockCount reads the item count of an array or map block, skipping the byte size that follows a negative count.
+func (d *decoder) readBlockCount() (int64, error) {
+	count, err := d.readLong()
+	if err != nil || count >= 0 {
+		return count, err
+	}
+	if _, err = d.readLong(); err != nil {
+		return 0, err
+	}
+	return -count, nil
+}
+
+// decode reads a value of the schema. Values are returned as nil, bool, int32, int64, float32, float64, []byte,
+// string, map[string]interface{} for records and maps, []interface{} for arrays, and string for enum symbols.
+func (d *decoder) decode(n *node) (interface{}, error) {
+	switch n.kind {
+	case "null":
+		return nil, nil
+	case "boolean":
+		b, err := d.readBytes(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0] != 0, nil
+	case "int":
+		value, err := d.readLong()
+		return int32(value), err
+	case "long":
+		return d.readLong()
+	case "float":
+		b, err := d.readBytes(4)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float32frombits(binary.LittleEndian.Uint32(b)), nil
+	case "double":
+		b, err := d.readBytes(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	case "bytes", "string":
+		length, err := d.readLong()
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.readBytes(length)
+		if err != nil {
+			return nil, err
+		}
+		if n.kind == "string" {
+			return string(b), nil
+		}
+		return append([]byte(nil), b...), nil
+	case "fixed":
+		b, err := d.readBytes(int64(n.size))
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+	case "enum":
+		index, err := d.readLong()
+		if err != nil {
+			return nil, err
+		}
+		if index < 0 || index >= int64(len(n.symbols)) {
+			return nil, fmt.Errorf("serde: enum index %d is out of range for %s", index, n.name)
+		}
+		return n.symbols[index], nil
+	case "record":
+		record := make(map[string]interface{}, len(n.fields))
+		for _, f := range n.fields {
+			value, err := d.decode(f.node)
+			if err != nil {
+				return nil, err
+			}
+			record[f.name] = value
+		}
+		return record, nil
+	case "array":
+		items := []interface{}{}
+		for {
+			count, err := d.readBlockCount()
+			if err != nil {
+				return nil, err
+			}
+			if count == 0 {
+				return items, nil
+			}
+			for ; count > 0; count-- {
+				item, err := d.decode(n.items)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+			}
+		}
+	case "map":
+		entries := map[string]interface{}{}
+		for {
+			count, err := d.readBlockCount()
+			if err != nil {
+				return nil, err
+			}
+			if count == 0 {
+				return entries, nil
+			}
+			for ; count > 0; count-- {
+				length, err := d.readLong()
+				if err != nil {
+					return nil, err
+				}
+				key, err := d.readBytes(length)
+				if err != nil {
+					return nil, err
+				}
+				value, err := d.decode(n.values)
+				if err != nil {
+					return nil, err
+				}
+				entries[string(key)] = value
+			}
+		}
+	case "union":
+		index, err := d.readLong()
+		if err != nil {
+			return nil, err
+		}
+		if index < 0 || index >= int64(len(n.branches)) {
+			return nil, fmt.Errorf("serde: union index %d is out of range", index)
+		}
+		return d.decode(n.branches[index])
+	}
+	return nil, fmt.Errorf("serde: unsupported schema type %s", n.kind)
+}
+
+// appendLong appends a zig-zag encoded variable-length integer.
+func appendLong(dst []byte, value int64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buf[:], value)
+	return append(dst, buf[:n]...)
+}
+
+// toInt64 converts an integer, or a floating point or JSON number with an integral value, to int64.
+func toInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case float64:
+		if v == math.Trunc(v) && v >= math.MinInt64 && v <= math.MaxInt64 {
+			return int64(v), true
+		}
+	case json.Number:
+		i, err := v.Int64()
+		return i, err == nil
+	}
+	return 0, false
+}
+
+// toFloat64 converts a number to float64.
+func toFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	i, ok := toInt64(value)
+	return float64(i), ok
+}
+
+// pathOrRoot returns the path, or a name for the top level of the value if the path is empty.
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
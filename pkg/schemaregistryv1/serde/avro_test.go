@@ -0,0 +1,153 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package serde_test
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/fake"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/serde"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// decode decodes a JSON schema document.
+func decode(document string) map[string]interface{} {
+	var result map[string]interface{}
+	Expect(json.Unmarshal([]byte(document), &result)).To(Succeed())
+	return result
+}
+
+var _ = Describe(`Avro binary encoding`, func() {
+	var server *fake.Server
+	var serializer *serde.Serializer
+	var deserializer *serde.Deserializer
+
+	BeforeEach(func() {
+		server = fake.NewServer(nil)
+		client, _ := server.NewClient()
+		registry := serde.NewRegistry(client)
+		serializer = serde.NewSerializer(registry, &serde.SerializerOptions{AutoRegister: true})
+		deserializer = serde.NewDeserializer(serde.NewRegistry(client))
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	// payload serializes the value with the schema and returns the encoded value without its framing.
+	payload := func(topic string, schema string, value interface{}) []byte {
+		message, err := serializer.Serialize(context.Background(), topic, decode(schema), value)
+		Expect(err).To(BeNil())
+		return message[5:]
+	}
+	// roundTrip serializes and deserializes the value.
+	roundTrip := func(topic string, schema string, value interface{}) interface{} {
+		message, err := serializer.Serialize(context.Background(), topic, decode(schema), value)
+		Expect(err).To(BeNil())
+		result, err := deserializer.Deserialize(context.Background(), message)
+		Expect(err).To(BeNil())
+		return result
+	}
+
+	It(`Encode the examples from the Avro specification`, func() {
+		schema := `{"type": "record", "name": "test", "fields": [{"name": "a", "type": "long"}, {"name": "b", "type": "string"}]}`
+		Expect(payload("spec", schema, map[string]interface{}{"a": 27, "b": "foo"})).To(Equal([]byte{0x36, 0x06, 0x66, 0x6f, 0x6f}))
+
+		array := `{"type": "record", "name": "a", "fields": [{"name": "v", "type": {"type": "array", "items": "long"}}]}`
+		Expect(payload("array", array, map[string]interface{}{"v": []int64{3, 27}})).To(Equal([]byte{0x04, 0x06, 0x36, 0x00}))
+
+		union := `{"type": "record", "name": "u", "fields": [{"name": "v", "type": ["null", "string"]}]}`
+		Expect(payload("union", union, map[string]interface{}{"v": nil})).To(Equal([]byte{0x00}))
+		Expect(payload("union", union, map[string]interface{}{"v": "a"})).To(Equal([]byte{0x02, 0x02, 0x61}))
+
+		ints := `{"type": "record", "name": "i", "fields": [{"name": "v", "type": "int"}]}`
+		Expect(payload("ints", ints, map[string]interface{}{"v": -64})).To(Equal([]byte{0x7f}))
+		Expect(payload("ints", ints, map[string]interface{}{"v": 64})).To(Equal([]byte{0x80, 0x01}))
+	})
+	It(`Round trip every type`, func() {
+		schema := `{"type": "record", "name": "Everything", "namespace": "com.example", "fields": [
+			{"name": "n", "type": "null"},
+			{"name": "b", "type": "boolean"},
+			{"name": "i", "type": "int"},
+			{"name": "l", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+			{"name": "f", "type": "float"},
+			{"name": "d", "type": "double"},
+			{"name": "by", "type": "bytes"},
+			{"name": "s", "type": "string"},
+			{"name": "e", "type": {"type": "enum", "name": "Suit", "symbols": ["HEARTS", "SPADES"]}},
+			{"name": "fx", "type": {"type": "fixed", "name": "Pair", "size": 2}},
+			{"name": "a", "type": {"type": "array", "items": "Suit"}},
+			{"name": "m", "type": {"type": "map", "values": ["null", "long"]}},
+			{"name": "r", "type": ["null", "Everything"]},
+			{"name": "withDefault", "type": "string", "default": "hello"},
+			{"name": "unionDefault", "type": ["null", "string"], "default": null}]}`
+		value := map[string]interface{}{
+			"n": nil, "b": true, "i": int32(-7), "l": int64(1631518689408), "f": float32(1.5), "d": 2.25,
+			"by": []byte{1, 2}, "s": "text", "e": "SPADES", "fx": []byte{9, 8},
+			"a": []interface{}{"HEARTS", "SPADES"},
+			"m": map[string]interface{}{"x": int64(1), "y": nil},
+			"r": map[string]interface{}{
+				"n": nil, "b": false, "i": 1, "l": 2, "f": 3, "d": 4, "by": []byte{}, "s": "", "e": "HEARTS",
+				"fx": []byte{0, 0}, "a": []string{}, "m": map[string]int64{}, "r": nil,
+			},
+		}
+		result := roundTrip("everything", schema, value).(map[string]interface{})
+		Expect(result["i"]).To(Equal(int32(-7)))
+		Expect(result["l"]).To(Equal(int64(1631518689408)))
+		Expect(result["f"]).To(Equal(float32(1.5)))
+		Expect(result["by"]).To(Equal([]byte{1, 2}))
+		Expect(result["a"]).To(Equal([]interface{}{"HEARTS", "SPADES"}))
+		Expect(result["m"]).To(Equal(map[string]interface{}{"x": int64(1), "y": nil}))
+		Expect(result["withDefault"]).To(Equal("hello"))
+		Expect(result["unionDefault"]).To(BeNil())
+		nested := result["r"].(map[string]interface{})
+		Expect(nested["i"]).To(Equal(int32(1)))
+		Expect(nested["r"]).To(BeNil())
+		Expect(nested["a"]).To(Equal([]interface{}{}))
+	})
+	It(`Report values that do not match the schema`, func() {
+		schema := decode(`{"type": "record", "name": "Citizen", "fields": [
+			{"name": "age", "type": "int"},
+			{"name": "suit", "type": {"type": "enum", "name": "Suit", "symbols": ["HEARTS"]}}]}`)
+		_, err := serializer.Serialize(context.Background(), "citizens", schema, map[string]interface{}{"age": "old", "suit": "HEARTS"})
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal("serde: Citizen.age: cannot encode string as int"))
+
+		_, err = serializer.Serialize(context.Background(), "citizens", schema, map[string]interface{}{"age": 1 << 40, "suit": "HEARTS"})
+		Expect(err).ToNot(BeNil())
+
+		_, err = serializer.Serialize(context.Background(), "citizens", schema, map[string]interface{}{"age": 1, "suit": "CLUBS"})
+		Expect(err.Error()).To(Equal(`serde: Citizen.suit: "CLUBS" is not a symbol of Suit`))
+
+		_, err = serializer.Serialize(context.Background(), "citizens", schema, map[string]interface{}{"suit": "HEARTS"})
+		Expect(err.Error()).To(Equal("serde: Citizen.age: the field has no value and no default"))
+	})
+	It(`Report truncated and oversized payloads`, func() {
+		schema := `{"type": "record", "name": "test", "fields": [{"name": "b", "type": "string"}]}`
+		message, err := serializer.Serialize(context.Background(), "spec", decode(schema), map[string]interface{}{"b": "foo"})
+		Expect(err).To(BeNil())
+
+		_, err = deserializer.Deserialize(context.Background(), message[:len(message)-1])
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("unexpected end of data"))
+
+		_, err = deserializer.Deserialize(context.Background(), append(message, 0))
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("1 bytes after its value"))
+	})
+})
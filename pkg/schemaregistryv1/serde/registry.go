@@ -0,0 +1,173 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package serde
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Schema : A version of a schema, compiled for encoding and decoding.
+type Schema struct {
+	// The global ID of the version.
+	GlobalID int64
+
+	// The schema document.
+	Document map[string]interface{}
+
+	compiled *node
+}
+
+// Registry : A cache of the schema versions held by the schema registry, keyed by global ID and by content.
+type Registry struct {
+	client *schemaregistryv1.SchemaregistryV1
+
+	mutex      sync.Mutex
+	byGlobalID map[int64]*Schema
+	byContent  map[string]map[string]*Schema
+}
+
+// NewRegistry : returns a Registry that fetches and registers schemas with the client.
+func NewRegistry(client *schemaregistryv1.SchemaregistryV1) *Registry {
+	return &Registry{
+		client:     client,
+		byGlobalID: make(map[int64]*Schema),
+		byContent:  make(map[string]map[string]*Schema),
+	}
+}
+
+// newSchema compiles a schema document.
+func newSchema(globalID int64, document map[string]interface{}) (*Schema, error) {
+	compiled, err := compile(document)
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{GlobalID: globalID, Document: document, compiled: compiled}, nil
+}
+
+// Lookup : returns the schema version with the global ID, fetching it with GetSchemaByGlobalID the first time it is
+// needed.
+func (registry *Registry) Lookup(ctx context.Context, globalID int64) (*Schema, error) {
+	registry.mutex.Lock()
+	schema, ok := registry.byGlobalID[globalID]
+	registry.mutex.Unlock()
+	if ok {
+		return schema, nil
+	}
+
+	document, _, err := registry.client.GetSchemaByGlobalIDWithContext(ctx,
+		registry.client.NewGetSchemaByGlobalIDOptions(globalID))
+	if err != nil {
+		return nil, fmt.Errorf("serde: getting the schema with global ID %d: %w", globalID, err)
+	}
+	schema, err = newSchema(globalID, document)
+	if err != nil {
+		return nil, err
+	}
+	registry.mutex.Lock()
+	registry.byGlobalID[globalID] = schema
+	registry.mutex.Unlock()
+	return schema, nil
+}
+
+// Register : returns the version of the schema with the ID whose content is the document. The version is found with
+// GetVersionMetadataByContent the first time it is needed. If there is no such version and autoRegister is true, the
+// document is added as a new version with CreateVersion, or with CreateSchema if the schema does not exist;
+// otherwise an error wrapping ErrSchemaNotRegistered is returned.
+func (registry *Registry) Register(ctx context.Context, id string, document map[string]interface{}, autoRegister bool) (*Schema, error) {
+	content, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("serde: encoding schema %s: %w", id, err)
+	}
+	registry.mutex.Lock()
+	schema, ok := registry.byContent[id][string(content)]
+	registry.mutex.Unlock()
+	if ok {
+		return schema, nil
+	}
+
+	schema, err = newSchema(0, document)
+	if err != nil {
+		return nil, err
+	}
+	globalID, err := registry.findOrCreate(ctx, id, document, autoRegister)
+	if err != nil {
+		return nil, err
+	}
+	schema.GlobalID = globalID
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	if registry.byContent[id] == nil {
+		registry.byContent[id] = make(map[string]*Schema)
+	}
+	registry.byContent[id][string(content)] = schema
+	registry.byGlobalID[globalID] = schema
+	return schema, nil
+}
+
+// findOrCreate returns the global ID of the version of the schema whose content is the document, creating it if
+// autoRegister is true.
+func (registry *Registry) findOrCreate(ctx context.Context, id string, document map[string]interface{}, autoRegister bool) (int64, error) {
+	client := registry.client
+	metadata, response, err := client.GetVersionMetadataByContentWithContext(ctx,
+		client.NewGetVersionMetadataByContentOptions(id).SetSchema(document))
+	if err == nil {
+		return metadataGlobalID(id, metadata)
+	}
+	if !isNotFound(response) {
+		return 0, fmt.Errorf("serde: finding schema %s: %w", id, err)
+	}
+	if !autoRegister {
+		return 0, fmt.Errorf("%w: no version of schema %s matches", ErrSchemaNotRegistered, id)
+	}
+
+	metadata, response, err = client.CreateVersionWithContext(ctx,
+		client.NewCreateVersionOptions(id).SetSchema(document))
+	if isNotFound(response) {
+		metadata, response, err = client.CreateSchemaWithContext(ctx,
+			client.NewCreateSchemaOptions().SetID(id).SetSchema(document))
+		if response != nil && response.StatusCode == http.StatusConflict {
+			// Another client created the schema first.
+			metadata, _, err = client.CreateVersionWithContext(ctx,
+				client.NewCreateVersionOptions(id).SetSchema(document))
+		}
+	}
+	if err != nil {
+		return 0, fmt.Errorf("serde: registering schema %s: %w", id, err)
+	}
+	return metadataGlobalID(id, metadata)
+}
+
+// metadataGlobalID returns the global ID in the metadata of a version of the schema, or an error if it has none.
+func metadataGlobalID(id string, metadata *schemaregistryv1.SchemaMetadata) (int64, error) {
+	if metadata == nil || metadata.GlobalID == nil {
+		return 0, fmt.Errorf("serde: the registry returned the metadata of schema %s without a global ID", id)
+	}
+	return *metadata.GlobalID, nil
+}
+
+// isNotFound returns true if the response has HTTP status code 404.
+func isNotFound(response *core.DetailedResponse) bool {
+	return response != nil && response.StatusCode == http.StatusNotFound
+}
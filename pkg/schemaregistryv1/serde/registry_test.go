@@ -0,0 +1,122 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package serde_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/fake"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/serde"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Registry`, func() {
+	var server *fake.Server
+	var client *schemaregistryv1.SchemaregistryV1
+	var registry *serde.Registry
+
+	citizen := `{"type": "record", "name": "Citizen", "fields": [{"name": "firstName", "type": "string"}]}`
+	citizenV2 := `{"type": "record", "name": "Citizen", "fields": [{"name": "firstName", "type": "string"}, {"name": "age", "type": "int", "default": 0}]}`
+
+	BeforeEach(func() {
+		server = fake.NewServer(&fake.ServerOptions{FirstGlobalID: 451})
+		client, _ = server.NewClient()
+		registry = serde.NewRegistry(client)
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Find registered versions and cache them`, func() {
+		_, _, err := client.CreateSchema(client.NewCreateSchemaOptions().SetID("citizens-value").SetSchema(decode(citizen)))
+		Expect(err).To(BeNil())
+
+		schema, err := registry.Register(context.Background(), "citizens-value", decode(citizen), false)
+		Expect(err).To(BeNil())
+		Expect(schema.GlobalID).To(Equal(int64(451)))
+		schema, err = registry.Register(context.Background(), "citizens-value", decode(citizen), false)
+		Expect(err).To(BeNil())
+		Expect(schema.GlobalID).To(Equal(int64(451)))
+		Expect(server.RequestCount(fake.OperationGetVersionMetadataByContent)).To(Equal(1))
+
+		looked, err := registry.Lookup(context.Background(), 451)
+		Expect(err).To(BeNil())
+		Expect(looked).To(BeIdenticalTo(schema))
+		Expect(server.RequestCount(fake.OperationGetSchemaByGlobalID)).To(Equal(0))
+	})
+	It(`Register new schemas and versions when allowed`, func() {
+		_, err := registry.Register(context.Background(), "citizens-value", decode(citizen), false)
+		Expect(errors.Is(err, serde.ErrSchemaNotRegistered)).To(BeTrue())
+		Expect(server.SchemaIDs()).To(BeEmpty())
+
+		schema, err := registry.Register(context.Background(), "citizens-value", decode(citizen), true)
+		Expect(err).To(BeNil())
+		Expect(schema.GlobalID).To(Equal(int64(451)))
+		schema, err = registry.Register(context.Background(), "citizens-value", decode(citizenV2), true)
+		Expect(err).To(BeNil())
+		Expect(schema.GlobalID).To(Equal(int64(452)))
+
+		Expect(server.RequestCount(fake.OperationCreateSchema)).To(Equal(1))
+		Expect(server.RequestCount(fake.OperationCreateVersion)).To(Equal(2))
+		Expect(server.Versions("citizens-value")).To(HaveLen(2))
+	})
+	It(`Surface compatibility rejections`, func() {
+		server.SetGlobalRule(schemaregistryv1.RuleConfigBackwardConst)
+		_, err := registry.Register(context.Background(), "citizens-value", decode(citizen), true)
+		Expect(err).To(BeNil())
+		_, err = registry.Register(context.Background(), "citizens-value",
+			decode(`{"type": "record", "name": "Citizen", "fields": [{"name": "age", "type": "int"}]}`), true)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("registering schema citizens-value"))
+		Expect(err.Error()).To(ContainSubstring("field age was added without a default"))
+	})
+	It(`Return an error for metadata without a global ID`, func() {
+		metadataServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.Header().Set("Content-Type", "application/json")
+			_, _ = res.Write([]byte(`{"id":"citizens-value","version":1}`))
+		}))
+		defer metadataServer.Close()
+		metadataClient, err := schemaregistryv1.NewSchemaregistryV1(&schemaregistryv1.SchemaregistryV1Options{
+			URL:           metadataServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+
+		_, err = serde.NewRegistry(metadataClient).Register(context.Background(), "citizens-value", decode(citizen), false)
+		Expect(err).To(MatchError("serde: the registry returned the metadata of schema citizens-value without a global ID"))
+	})
+	It(`Fetch unknown global IDs once`, func() {
+		_, _, err := client.CreateSchema(client.NewCreateSchemaOptions().SetID("citizens-value").SetSchema(decode(citizen)))
+		Expect(err).To(BeNil())
+		for i := 0; i < 3; i++ {
+			schema, err := registry.Lookup(context.Background(), 451)
+			Expect(err).To(BeNil())
+			Expect(schema.Document).To(Equal(decode(citizen)))
+		}
+		Expect(server.RequestCount(fake.OperationGetSchemaByGlobalID)).To(Equal(1))
+
+		_, err = registry.Lookup(context.Background(), 999)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("global ID 999"))
+	})
+})
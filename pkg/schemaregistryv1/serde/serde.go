@@ -0,0 +1,71 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package serde : Serialization of Kafka messages with schemas held in the Event Streams schema registry
+//
+// Messages are framed the same way as Confluent-compatible serializers frame them: a magic byte of 0, the global ID of
+// the schema version as a 4-byte big-endian integer, and then the value encoded with Avro's binary encoding. A
+// Serializer finds the global ID of a schema with the Registry, registering the schema through CreateSchema or
+// CreateVersion if it is configured to. A Deserializer fetches the schema for the global ID in a message. Both cache
+// what they learn from the registry, so each schema is fetched or registered once.
+package serde
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// MagicByte is the first byte of a framed message.
+const MagicByte byte = 0
+
+// headerLength is the length of the magic byte and the global ID that precede the encoded value.
+const headerLength = 5
+
+// Sentinel errors, for use with errors.Is.
+var (
+	// ErrMessageTooShort is returned for a message that is too short to hold the magic byte and a global ID.
+	ErrMessageTooShort = errors.New("serde: message is too short to hold a schema ID")
+
+	// ErrUnknownMagicByte is returned for a message that does not start with MagicByte.
+	ErrUnknownMagicByte = errors.New("serde: message does not start with the magic byte")
+
+	// ErrSchemaNotRegistered is returned when a schema is not in the registry and automatic registration is disabled.
+	ErrSchemaNotRegistered = errors.New("serde: schema is not registered")
+)
+
+// Frame : returns the payload preceded by the magic byte and the global ID.
+func Frame(globalID int64, payload []byte) ([]byte, error) {
+	if globalID < 0 || globalID > math.MaxInt32 {
+		return nil, fmt.Errorf("serde: global ID %d does not fit in 4 bytes", globalID)
+	}
+	message := make([]byte, headerLength, headerLength+len(payload))
+	message[0] = MagicByte
+	binary.BigEndian.PutUint32(message[1:headerLength], uint32(globalID))
+	return append(message, payload...), nil
+}
+
+// Unframe : returns the global ID in a framed message and the payload that follows it.
+func Unframe(message []byte) (globalID int64, payload []byte, err error) {
+	if len(message) < headerLength {
+		return 0, nil, ErrMessageTooShort
+	}
+	if message[0] != MagicByte {
+		return 0, nil, fmt.Errorf("%w: found 0x%02x", ErrUnknownMagicByte, message[0])
+	}
+	return int64(binary.BigEndian.Uint32(message[1:headerLength])), message[headerLength:], nil
+}
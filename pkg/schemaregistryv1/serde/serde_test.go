@@ -0,0 +1,52 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package serde_test
+
+import (
+	"errors"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/serde"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Framing`, func() {
+	It(`Frame and unframe a payload`, func() {
+		message, err := serde.Frame(451, []byte{0x06, 'f', 'o', 'o'})
+		Expect(err).To(BeNil())
+		Expect(message).To(Equal([]byte{0x00, 0x00, 0x00, 0x01, 0xc3, 0x06, 'f', 'o', 'o'}))
+
+		globalID, payload, err := serde.Unframe(message)
+		Expect(err).To(BeNil())
+		Expect(globalID).To(Equal(int64(451)))
+		Expect(payload).To(Equal([]byte{0x06, 'f', 'o', 'o'}))
+	})
+	It(`Refuse global IDs that do not fit`, func() {
+		_, err := serde.Frame(1<<31, nil)
+		Expect(err).ToNot(BeNil())
+		_, err = serde.Frame(-1, nil)
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Refuse messages that are not framed`, func() {
+		_, _, err := serde.Unframe([]byte{0x00, 0x00})
+		Expect(errors.Is(err, serde.ErrMessageTooShort)).To(BeTrue())
+
+		_, _, err = serde.Unframe([]byte{0x7b, 0x22, 0x61, 0x22, 0x3a, 0x31, 0x7d})
+		Expect(errors.Is(err, serde.ErrUnknownMagicByte)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("0x7b"))
+	})
+})
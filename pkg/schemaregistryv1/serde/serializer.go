@@ -0,0 +1,153 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package serde
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SchemaIDStrategy : Chooses the ID of the schema a message is registered under.
+type SchemaIDStrategy func(topic string, isKey bool, document map[string]interface{}) (string, error)
+
+// TopicNameStrategy : uses the topic name followed by -key or -value, so each topic has one schema for its keys and
+// one for its values.
+func TopicNameStrategy(topic string, isKey bool, _ map[string]interface{}) (string, error) {
+	if isKey {
+		return topic + "-key", nil
+	}
+	return topic + "-value", nil
+}
+
+// RecordNameStrategy : uses the full name of the record, so a record type has one schema across all topics.
+func RecordNameStrategy(_ string, _ bool, document map[string]interface{}) (string, error) {
+	return recordName(document)
+}
+
+// TopicRecordNameStrategy : uses the topic name followed by the full name of the record, so a topic can hold several
+// record types.
+func TopicRecordNameStrategy(topic string, _ bool, document map[string]interface{}) (string, error) {
+	name, err := recordName(document)
+	if err != nil {
+		return "", err
+	}
+	return topic + "-" + name, nil
+}
+
+// recordName returns the full name of a record schema.
+func recordName(document map[string]interface{}) (string, error) {
+	name, ok := document["name"].(string)
+	if !ok || name == "" || (document["type"] != "record" && document["type"] != "error") {
+		return "", fmt.Errorf("serde: the schema is not a named record")
+	}
+	if namespace, ok := document["namespace"].(string); ok && namespace != "" && !strings.Contains(name, ".") {
+		return namespace + "." + name, nil
+	}
+	return name, nil
+}
+
+// SerializerOptions : The options of a Serializer.
+type SerializerOptions struct {
+	// When true, schemas that are not in the registry are registered. When false, serializing a value with such a
+	// schema fails with ErrSchemaNotRegistered.
+	AutoRegister bool
+
+	// When true, the serializer is used for message keys rather than values.
+	IsKey bool
+
+	// Chooses the ID of the schema. Defaults to TopicNameStrategy.
+	SchemaIDStrategy SchemaIDStrategy
+}
+
+// Serializer : Encodes values as framed messages.
+type Serializer struct {
+	registry *Registry
+	options  SerializerOptions
+}
+
+// NewSerializer : returns a Serializer that finds and registers schemas with the registry. The options may be nil.
+func NewSerializer(registry *Registry, options *SerializerOptions) *Serializer {
+	serializer := &Serializer{registry: registry}
+	if options != nil {
+		serializer.options = *options
+	}
+	if serializer.options.SchemaIDStrategy == nil {
+		serializer.options.SchemaIDStrategy = TopicNameStrategy
+	}
+	return serializer
+}
+
+// Serialize : encodes a value for the topic with the schema document, and frames it with the global ID of the schema.
+// Records are map[string]interface{} keyed by field name, arrays are slices, maps are maps with string keys, enum
+// symbols are strings and fixed values are []byte. A field that is missing from a record takes its default.
+func (serializer *Serializer) Serialize(ctx context.Context, topic string, document map[string]interface{}, value interface{}) ([]byte, error) {
+	id, err := serializer.options.SchemaIDStrategy(topic, serializer.options.IsKey, document)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := serializer.registry.Register(ctx, id, document, serializer.options.AutoRegister)
+	if err != nil {
+		return nil, err
+	}
+	message, err := Frame(schema.GlobalID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return encode(message, schema.compiled, value, "")
+}
+
+// Deserializer : Decodes framed messages.
+type Deserializer struct {
+	registry *Registry
+}
+
+// NewDeserializer : returns a Deserializer that fetches schemas from the registry.
+func NewDeserializer(registry *Registry) *Deserializer {
+	return &Deserializer{registry: registry}
+}
+
+// Deserialize : decodes a framed message with the schema its global ID identifies. Values are returned as nil, bool,
+// int32, int64, float32, float64, []byte, string, map[string]interface{} for records and maps, []interface{} for
+// arrays and string for enum symbols.
+func (deserializer *Deserializer) Deserialize(ctx context.Context, message []byte) (interface{}, error) {
+	value, _, err := deserializer.DeserializeWithSchema(ctx, message)
+	return value, err
+}
+
+// DeserializeWithSchema : is an alternate form of Deserialize that also returns the schema the message was written
+// with.
+func (deserializer *Deserializer) DeserializeWithSchema(ctx context.Context, message []byte) (interface{}, *Schema, error) {
+	globalID, payload, err := Unframe(message)
+	if err != nil {
+		return nil, nil, err
+	}
+	schema, err := deserializer.registry.Lookup(ctx, globalID)
+	if err != nil {
+		return nil, nil, err
+	}
+	d := &decoder{data: payload}
+	value, err := d.decode(schema.compiled)
+	if err != nil {
+		return nil, nil, fmt.Errorf("serde: decoding a message written with global ID %d: %w", globalID, err)
+	}
+	if d.pos != len(payload) {
+		return nil, nil, fmt.Errorf("serde: a message written with global ID %d has %d bytes after its value",
+			globalID, len(payload)-d.pos)
+	}
+	return value, schema, nil
+}
@@ -0,0 +1,83 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package serde_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/fake"
+	"github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/serde"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Serializer`, func() {
+	var server *fake.Server
+	var registry *serde.Registry
+
+	payment := `{"type": "record", "name": "Payment", "namespace": "com.example", "fields": [{"name": "amount", "type": "long"}]}`
+
+	BeforeEach(func() {
+		server = fake.NewServer(nil)
+		client, _ := server.NewClient()
+		registry = serde.NewRegistry(client)
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Choose schema IDs with a strategy`, func() {
+		id, err := serde.TopicNameStrategy("payments", false, decode(payment))
+		Expect(err).To(BeNil())
+		Expect(id).To(Equal("payments-value"))
+		id, _ = serde.TopicNameStrategy("payments", true, decode(payment))
+		Expect(id).To(Equal("payments-key"))
+
+		id, err = serde.RecordNameStrategy("payments", false, decode(payment))
+		Expect(err).To(BeNil())
+		Expect(id).To(Equal("com.example.Payment"))
+		id, err = serde.TopicRecordNameStrategy("payments", false, decode(payment))
+		Expect(err).To(BeNil())
+		Expect(id).To(Equal("payments-com.example.Payment"))
+
+		_, err = serde.RecordNameStrategy("payments", false, decode(`{"type": "string"}`))
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Serialize with one team and deserialize with another`, func() {
+		serializer := serde.NewSerializer(registry, &serde.SerializerOptions{
+			AutoRegister:     true,
+			SchemaIDStrategy: serde.TopicRecordNameStrategy,
+		})
+		message, err := serializer.Serialize(context.Background(), "payments", decode(payment), map[string]interface{}{"amount": 1250})
+		Expect(err).To(BeNil())
+		Expect(message[0]).To(Equal(serde.MagicByte))
+		Expect(server.SchemaIDs()).To(Equal([]string{"payments-com.example.Payment"}))
+
+		client, _ := server.NewClient()
+		value, schema, err := serde.NewDeserializer(serde.NewRegistry(client)).DeserializeWithSchema(context.Background(), message)
+		Expect(err).To(BeNil())
+		Expect(value).To(Equal(map[string]interface{}{"amount": int64(1250)}))
+		Expect(schema.GlobalID).To(Equal(int64(1)))
+		Expect(schema.Document).To(Equal(decode(payment)))
+	})
+	It(`Refuse unregistered schemas without automatic registration`, func() {
+		_, err := serde.NewSerializer(registry, nil).Serialize(context.Background(), "payments", decode(payment),
+			map[string]interface{}{"amount": 1})
+		Expect(errors.Is(err, serde.ErrSchemaNotRegistered)).To(BeTrue())
+	})
+})
@@ -0,0 +1,212 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tracing : OpenTelemetry tracing for the Event Streams service clients
+//
+// Middleware returns a middleware.Middleware that starts a client span for every request made by an AdminrestV1 or
+// SchemaregistryV1 client. The span is named after the operation, such as CreateTopic or GetLatestSchema, and is a
+// child of the span in the Context given to the ...WithContext method. It carries the topic name, quota entity,
+// schema ID and version addressed by the request, the HTTP status code and, for the Admin REST API, the Kafka error
+// code, and the W3C trace context of the span is sent in the request headers.
+//
+//	serviceAPI.Use(tracing.Middleware(nil))
+//
+// This package is a separate module, so that applications that do not use OpenTelemetry do not depend on it.
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/eventstreams-go-sdk/pkg/common"
+	"github.com/IBM/eventstreams-go-sdk/pkg/middleware"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the tracer that creates the spans.
+const InstrumentationName = "github.com/IBM/eventstreams-go-sdk/pkg/tracing"
+
+// The attributes of a span, in addition to the HTTP semantic conventions.
+const (
+	// The service of the client: adminrest or schemaregistry.
+	ServiceKey = attribute.Key("eventstreams.service")
+
+	// The name of the topic addressed by an Admin REST API request.
+	TopicKey = attribute.Key("eventstreams.topic")
+
+	// The entity whose quotas are addressed by an Admin REST API request.
+	QuotaEntityKey = attribute.Key("eventstreams.quota_entity")
+
+	// The Kafka error code of an unsuccessful Admin REST API request.
+	KafkaErrorCodeKey = attribute.Key("eventstreams.kafka_error_code")
+
+	// The ID of the schema addressed by a schema registry request.
+	SchemaIDKey = attribute.Key("eventstreams.schema_id")
+
+	// The version of the schema addressed by or created by a schema registry request, as an Int64.
+	SchemaVersionKey = attribute.Key("eventstreams.schema_version")
+)
+
+// Options : The options of the tracing middleware.
+type Options struct {
+	// The provider of the tracer. Defaults to the global TracerProvider.
+	TracerProvider trace.TracerProvider
+
+	// The propagator that writes the trace context into the request headers. Defaults to the W3C trace context and
+	// baggage propagators.
+	Propagator propagation.TextMapPropagator
+}
+
+// Middleware : returns a middleware that traces each request of a service client.
+func Middleware(options *Options) middleware.Middleware {
+	var tracerProvider trace.TracerProvider
+	var propagator propagation.TextMapPropagator
+	if options != nil {
+		tracerProvider = options.TracerProvider
+		propagator = options.Propagator
+	}
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	if propagator == nil {
+		propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	}
+	tracer := tracerProvider.Tracer(InstrumentationName, trace.WithInstrumentationVersion(common.Version))
+
+	return func(next middleware.Handler) middleware.Handler {
+		return func(call *middleware.Call) (*core.DetailedResponse, error) {
+			request := call.Request
+			attributes := append([]attribute.KeyValue{
+				ServiceKey.String(call.Service),
+				semconv.HTTPMethod(request.Method),
+				semconv.HTTPURL(request.URL.String()),
+			}, resourceAttributes(call)...)
+			ctx, span := tracer.Start(request.Context(), call.Operation,
+				trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+			defer span.End()
+
+			call.Request = request.WithContext(ctx)
+			propagator.Inject(ctx, propagation.HeaderCarrier(call.Request.Header))
+
+			response, err := next(call)
+			if response != nil {
+				span.SetAttributes(semconv.HTTPStatusCode(response.StatusCode))
+				if version, ok := createdVersion(response); ok {
+					span.SetAttributes(SchemaVersionKey.Int64(version))
+				}
+			}
+			var apiError *adminrestv1.APIError
+			if errors.As(err, &apiError) && apiError.KafkaErrorCode != adminrestv1.KafkaErrorNone {
+				span.SetAttributes(KafkaErrorCodeKey.Int(int(apiError.KafkaErrorCode)))
+			}
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return response, err
+		}
+	}
+}
+
+// resourceAttributes returns the attributes that identify the topic, quota entity, schema or version addressed by a
+// request, which are taken from its path, or for CreateTopic and CreateSchema from its body and headers.
+func resourceAttributes(call *middleware.Call) []attribute.KeyValue {
+	segments := strings.Split(strings.Trim(call.Request.URL.Path, "/"), "/")
+	var attributes []attribute.KeyValue
+	switch {
+	case len(segments) >= 3 && segments[0] == "admin" && segments[1] == "topics":
+		attributes = append(attributes, TopicKey.String(segments[2]))
+	case len(segments) >= 3 && segments[0] == "admin" && segments[1] == "quotas":
+		attributes = append(attributes, QuotaEntityKey.String(segments[2]))
+	case call.Operation == "CreateTopic":
+		if name := bodyName(call.Request); name != "" {
+			attributes = append(attributes, TopicKey.String(name))
+		}
+	case len(segments) >= 2 && segments[0] == "artifacts":
+		attributes = append(attributes, SchemaIDKey.String(segments[1]))
+		if len(segments) >= 4 && segments[2] == "versions" {
+			// Paths such as /artifacts/{id}/versions/latest do not name a version.
+			if version, err := strconv.ParseInt(segments[3], 10, 64); err == nil {
+				attributes = append(attributes, SchemaVersionKey.Int64(version))
+			}
+		}
+	case call.Operation == "CreateSchema":
+		if id := headerValue(call.Request.Header, "X-Registry-ArtifactId"); id != "" {
+			attributes = append(attributes, SchemaIDKey.String(id))
+		}
+	}
+	return attributes
+}
+
+// headerValue returns the first value of a header, which core.RequestBuilder may have stored under a name that is not
+// in canonical form.
+func headerValue(header http.Header, name string) string {
+	for key, values := range header {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// bodyName returns the name field of the JSON body of a request, read from a copy of the body so that the request can
+// still be sent.
+func bodyName(request *http.Request) string {
+	if request.GetBody == nil {
+		return ""
+	}
+	body, err := request.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	var decoded struct {
+		Name string `json:"name"`
+	}
+	if json.NewDecoder(io.LimitReader(body, 1<<20)).Decode(&decoded) != nil {
+		return ""
+	}
+	return decoded.Name
+}
+
+// createdVersion returns the version in a schema registry response that describes a new schema or version.
+func createdVersion(response *core.DetailedResponse) (int64, bool) {
+	raw, ok := response.Result.(map[string]json.RawMessage)
+	if !ok {
+		return 0, false
+	}
+	buf, ok := raw["version"]
+	if !ok {
+		return 0, false
+	}
+	var version int64
+	if json.NewDecoder(bytes.NewReader(buf)).Decode(&version) != nil {
+		return 0, false
+	}
+	return version, true
+}
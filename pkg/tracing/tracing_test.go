@@ -0,0 +1,172 @@
+/**
+ * (C) Copyright IBM Corp. 2023.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	adminfake "github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1/fake"
+	"github.com/IBM/eventstreams-go-sdk/pkg/middleware"
+	schemafake "github.com/IBM/eventstreams-go-sdk/pkg/schemaregistryv1/fake"
+	"github.com/IBM/eventstreams-go-sdk/pkg/tracing"
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// attributes returns the attributes of a span as a map.
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	values := make(map[attribute.Key]attribute.Value)
+	for _, keyValue := range span.Attributes() {
+		values[keyValue.Key] = keyValue.Value
+	}
+	return values
+}
+
+var _ = Describe(`Tracing middleware`, func() {
+	var recorder *tracetest.SpanRecorder
+	var options *tracing.Options
+
+	BeforeEach(func() {
+		recorder = tracetest.NewSpanRecorder()
+		options = &tracing.Options{TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))}
+	})
+
+	It(`Trace Admin REST API requests`, func() {
+		server := adminfake.NewServer(nil)
+		defer server.Close()
+		server.AddTopic(adminfake.Topic{Name: "orders"})
+		serviceAPI, err := server.NewClient()
+		Expect(err).To(BeNil())
+		serviceAPI.Use(tracing.Middleware(options))
+
+		_, err = serviceAPI.CreateTopic(serviceAPI.NewCreateTopicOptions().SetName("payments"))
+		Expect(err).To(BeNil())
+		_, _, err = serviceAPI.GetTopic(serviceAPI.NewGetTopicOptions("missing"))
+		Expect(err).ToNot(BeNil())
+		_, err = serviceAPI.DeleteQuota(serviceAPI.NewDeleteQuotaOptions("default"))
+		Expect(err).ToNot(BeNil())
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(3))
+		Expect(spans[0].Name()).To(Equal("CreateTopic"))
+		Expect(spans[0].SpanKind()).To(Equal(trace.SpanKindClient))
+		Expect(spans[0].Status().Code).To(Equal(codes.Unset))
+		Expect(spans[0].InstrumentationScope().Name).To(Equal(tracing.InstrumentationName))
+		created := attributes(spans[0])
+		Expect(created[tracing.ServiceKey].AsString()).To(Equal("adminrest"))
+		Expect(created[tracing.TopicKey].AsString()).To(Equal("payments"))
+		Expect(created["http.method"].AsString()).To(Equal("POST"))
+		Expect(created["http.status_code"].AsInt64()).To(Equal(int64(http.StatusAccepted)))
+
+		Expect(spans[1].Name()).To(Equal("GetTopic"))
+		Expect(spans[1].Status().Code).To(Equal(codes.Error))
+		missing := attributes(spans[1])
+		Expect(missing[tracing.TopicKey].AsString()).To(Equal("missing"))
+		Expect(missing["http.status_code"].AsInt64()).To(Equal(int64(http.StatusNotFound)))
+		Expect(missing[tracing.KafkaErrorCodeKey].AsInt64()).To(Equal(int64(adminrestv1.KafkaErrorUnknownTopicOrPartition)))
+		Expect(spans[1].Events()).To(HaveLen(1))
+		Expect(spans[1].Events()[0].Name).To(Equal("exception"))
+
+		Expect(attributes(spans[2])[tracing.QuotaEntityKey].AsString()).To(Equal("default"))
+		Expect(server.TopicNames()).To(ContainElement("payments"))
+	})
+	It(`Trace schema registry requests`, func() {
+		server := schemafake.NewServer(nil)
+		defer server.Close()
+		schemaAPI, err := server.NewClient()
+		Expect(err).To(BeNil())
+		schemaAPI.Use(tracing.Middleware(options))
+
+		schema := map[string]interface{}{"type": "string"}
+		_, _, err = schemaAPI.CreateSchema(schemaAPI.NewCreateSchemaOptions().SetID("orders-value").SetSchema(schema))
+		Expect(err).To(BeNil())
+		_, _, err = schemaAPI.CreateVersion(schemaAPI.NewCreateVersionOptions("orders-value").SetSchema(schema))
+		Expect(err).To(BeNil())
+		_, _, err = schemaAPI.GetVersion(schemaAPI.NewGetVersionOptions("orders-value", 1))
+		Expect(err).To(BeNil())
+		_, _, err = schemaAPI.GetLatestSchema(schemaAPI.NewGetLatestSchemaOptions("missing"))
+		Expect(err).ToNot(BeNil())
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(4))
+		Expect(spans[0].Name()).To(Equal("CreateSchema"))
+		Expect(attributes(spans[0])[tracing.SchemaIDKey].AsString()).To(Equal("orders-value"))
+		Expect(attributes(spans[0])[tracing.SchemaVersionKey].AsInt64()).To(Equal(int64(1)))
+		Expect(spans[1].Name()).To(Equal("CreateVersion"))
+		Expect(attributes(spans[1])[tracing.SchemaVersionKey].AsInt64()).To(Equal(int64(2)))
+		Expect(spans[2].Name()).To(Equal("GetVersion"))
+		Expect(attributes(spans[2])[tracing.SchemaVersionKey].AsInt64()).To(Equal(int64(1)))
+		Expect(spans[3].Name()).To(Equal("GetLatestSchema"))
+		Expect(spans[3].Status().Code).To(Equal(codes.Error))
+		Expect(attributes(spans[3])[tracing.ServiceKey].AsString()).To(Equal("schemaregistry"))
+		Expect(attributes(spans[3])["http.status_code"].AsInt64()).To(Equal(int64(http.StatusNotFound)))
+	})
+	It(`Only record numeric schema versions`, func() {
+		handler := middleware.Chain(func(call *middleware.Call) (*core.DetailedResponse, error) {
+			return &core.DetailedResponse{StatusCode: http.StatusOK}, nil
+		}, tracing.Middleware(options))
+		for _, version := range []string{"3", "latest"} {
+			request, err := http.NewRequest(http.MethodGet, "https://registry.example.com/artifacts/orders-value/versions/"+version, nil)
+			Expect(err).To(BeNil())
+			_, err = handler(&middleware.Call{Service: "schemaregistry", Operation: "GetVersion", Request: request})
+			Expect(err).To(BeNil())
+		}
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(2))
+		Expect(attributes(spans[0])[tracing.SchemaVersionKey].AsInt64()).To(Equal(int64(3)))
+		Expect(attributes(spans[1])).ToNot(HaveKey(tracing.SchemaVersionKey))
+		Expect(attributes(spans[1])[tracing.SchemaIDKey].AsString()).To(Equal("orders-value"))
+	})
+	It(`Start spans as children of the caller's span and propagate the trace context`, func() {
+		var traceparent string
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			traceparent = req.Header.Get("traceparent")
+			res.Header().Set("Content-Type", "application/json")
+			_, _ = res.Write([]byte(`[]`))
+		}))
+		defer testServer.Close()
+		serviceAPI, err := adminrestv1.NewAdminrestV1(&adminrestv1.AdminrestV1Options{
+			URL:           testServer.URL,
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+		serviceAPI.Use(tracing.Middleware(options))
+
+		ctx, parent := options.TracerProvider.Tracer("test").Start(context.Background(), "reconcile")
+		_, _, err = serviceAPI.ListTopicsWithContext(ctx, serviceAPI.NewListTopicsOptions())
+		Expect(err).To(BeNil())
+		parent.End()
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(2))
+		child := spans[0]
+		Expect(child.Name()).To(Equal("ListTopics"))
+		Expect(child.Parent().SpanID()).To(Equal(parent.SpanContext().SpanID()))
+		Expect(child.SpanContext().TraceID()).To(Equal(parent.SpanContext().TraceID()))
+		Expect(traceparent).To(Equal("00-" + child.SpanContext().TraceID().String() + "-" + child.SpanContext().SpanID().String() + "-01"))
+	})
+})